@@ -28,7 +28,7 @@ func TestLivePush_AccessControl(t *testing.T) {
 		nil, nil, nil, nil,
 		&usagestats.UsageStatsMock{T: t},
 		featuremgmt.WithFeatures(),
-		&dashboards.FakeDashboardService{}, nil)
+		&dashboards.FakeDashboardService{}, nil, nil)
 	require.NoError(t, err)
 	gateway := pushhttp.ProvideService(cfg, gLive)
 
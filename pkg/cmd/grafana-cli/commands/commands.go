@@ -10,6 +10,7 @@ import (
 	"github.com/grafana/grafana/pkg/cmd/grafana-cli/commands/datamigrations"
 	"github.com/grafana/grafana/pkg/cmd/grafana-cli/commands/secretsconsolidation"
 	"github.com/grafana/grafana/pkg/cmd/grafana-cli/commands/secretsmigrations"
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/commands/statehistory"
 	"github.com/grafana/grafana/pkg/cmd/grafana-cli/logger"
 	"github.com/grafana/grafana/pkg/cmd/grafana-cli/utils"
 	"github.com/grafana/grafana/pkg/infra/db"
@@ -84,6 +85,31 @@ func runPluginCommand(command func(commandLine utils.CommandLine) error) func(co
 	}
 }
 
+var stateHistoryFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "url",
+		Usage: "URL of the Grafana instance",
+		Value: "http://localhost:3000",
+	},
+	&cli.StringFlag{
+		Name:    "token",
+		Usage:   "Service account token of an organization admin",
+		EnvVars: []string{"GF_STATE_HISTORY_TOKEN"},
+	},
+	&cli.StringFlag{
+		Name:  "from",
+		Usage: "Start of the time range, as RFC3339 or unix milliseconds",
+	},
+	&cli.StringFlag{
+		Name:  "to",
+		Usage: "End of the time range, as RFC3339 or unix milliseconds. Defaults to now.",
+	},
+	&cli.StringSliceFlag{
+		Name:  "rule-uid",
+		Usage: "Only include the history of these rules",
+	},
+}
+
 var pluginCommands = []*cli.Command{
 	{
 		Name:   "install",
@@ -224,6 +250,54 @@ var adminCommands = []*cli.Command{
 			},
 		},
 	},
+	{
+		Name:  "state-history",
+		Usage: "Migrates or exports alert state history using the API of a running Grafana instance",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "migrate",
+				Usage:  "Starts a migration job that reads the state history from one backend and writes it to another in batches, and waits for it to finish. A failed migration is resumed from the checkpoint file.",
+				Action: runPluginCommand(statehistory.Migrate),
+				Flags: append(stateHistoryFlags,
+					&cli.StringFlag{
+						Name:     "source",
+						Usage:    "Backend to read the state history from: annotations, loki or prometheus",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "destination",
+						Usage:    "Backend to write the state history to: annotations, loki or prometheus",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:  "limit",
+						Usage: "Maximum number of entries that are read from the source in a single batch. Defaults to 1000.",
+					},
+					&cli.StringFlag{
+						Name:  "checkpoint-file",
+						Usage: "File to save the progress of the migration to",
+						Value: "state-history-migration.json",
+					},
+				),
+			},
+			{
+				Name:   "export",
+				Usage:  "Exports the state history of a backend as newline-delimited JSON",
+				Action: runPluginCommand(statehistory.Export),
+				Flags: append(stateHistoryFlags,
+					&cli.StringFlag{
+						Name:     "backend",
+						Usage:    "Backend to read the state history from: annotations, loki or prometheus",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "File to write the history to. Defaults to stdout.",
+					},
+				),
+			},
+		},
+	},
 	{
 		Name:   "flush-rbac-seed-assignment",
 		Usage:  "Clears RBAC seeding to force re-seeding on next startup. Use after running an Enterprise build, then an OSS build, then an Enterprise build again.",
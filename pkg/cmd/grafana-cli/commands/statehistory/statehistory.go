@@ -0,0 +1,248 @@
+package statehistory
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/logger"
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/utils"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+const (
+	migratePath = "/api/v1/rules/history/migrate"
+	exportPath  = "/api/v1/rules/history/export"
+)
+
+// migrationPollInterval is how often the status of a running migration is checked.
+var migrationPollInterval = 2 * time.Second
+
+// Migrate replays the alert state history from one backend into another by starting a migration job in a running
+// Grafana and waiting for it to finish. The checkpoint reported by the server is written to the checkpoint file while
+// the job runs, so that a migration that stopped with an error continues where it stopped when the command is run again.
+func Migrate(c utils.CommandLine) error {
+	from, err := parseTime(c.String("from"))
+	if err != nil {
+		return fmt.Errorf("invalid from: %w", err)
+	}
+	if from.IsZero() {
+		return errors.New("from is required")
+	}
+	to, err := parseTime(c.String("to"))
+	if err != nil {
+		return fmt.Errorf("invalid to: %w", err)
+	}
+
+	cfg := apimodels.StateHistoryMigrationConfig{
+		Source:      c.String("source"),
+		Destination: c.String("destination"),
+		From:        from,
+		To:          to,
+		RuleUIDs:    c.StringSlice("rule-uid"),
+		Limit:       c.Int("limit"),
+	}
+
+	checkpointFile := c.String("checkpoint-file")
+	if checkpointFile != "" {
+		checkpoint, err := readCheckpoint(checkpointFile)
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			logger.Infof("Resuming migration of rule %s at %s\n", checkpoint.RuleUID, checkpoint.Time.Format(time.RFC3339))
+			cfg.Resume = checkpoint
+		}
+	}
+
+	client := newClient(c)
+	body, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	status, err := client.migrationStatus(http.MethodPost, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	var reported apimodels.StateHistoryMigrationProgress
+	for {
+		if status.Progress != reported {
+			reported = status.Progress
+			logger.Infof("Migrated %d entries in %d batches, %d of %d rules completed\n", reported.Entries, reported.Batches, reported.RulesCompleted, reported.RulesTotal)
+			if checkpointFile != "" && !reported.Checkpoint.Time.IsZero() {
+				if err := writeCheckpoint(checkpointFile, reported.Checkpoint); err != nil {
+					return err
+				}
+			}
+		}
+		if !status.Running {
+			break
+		}
+		time.Sleep(migrationPollInterval)
+		status, err = client.migrationStatus(http.MethodGet, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if status.Error != "" {
+		return fmt.Errorf("state history migration stopped at rule %s, time %s: %s", status.Progress.Checkpoint.RuleUID, status.Progress.Checkpoint.Time.Format(time.RFC3339), status.Error)
+	}
+	if checkpointFile != "" {
+		if err := os.Remove(checkpointFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove checkpoint file: %w", err)
+		}
+	}
+	logger.Info("State history migration completed.\n")
+	return nil
+}
+
+// Export writes the alert state history of a backend as newline-delimited JSON to the output file, or to stdout.
+func Export(c utils.CommandLine) error {
+	query, err := exportQuery(c.String("backend"), c.String("from"), c.String("to"), c.StringSlice("rule-uid"))
+	if err != nil {
+		return err
+	}
+
+	resp, err := newClient(c).do(http.MethodGet, exportPath, query, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Close() }()
+
+	out := io.Writer(os.Stdout)
+	if path := c.String("output"); path != "" {
+		// nolint:gosec
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+	if _, err := io.Copy(out, resp); err != nil {
+		return fmt.Errorf("failed to write state history: %w", err)
+	}
+	return nil
+}
+
+// exportQuery builds the query of the export API. The API expects timestamps in unix seconds.
+func exportQuery(backend, from, to string, ruleUIDs []string) (url.Values, error) {
+	query := url.Values{}
+	query.Set("backend", backend)
+	for flag, value := range map[string]string{"from": from, "to": to} {
+		ts, err := parseTime(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", flag, err)
+		}
+		if !ts.IsZero() {
+			query.Set(flag, strconv.FormatInt(ts.Unix(), 10))
+		}
+	}
+	for _, uid := range ruleUIDs {
+		query.Add("ruleUID", uid)
+	}
+	return query, nil
+}
+
+type client struct {
+	url   string
+	token string
+	http  http.Client
+}
+
+func newClient(c utils.CommandLine) *client {
+	return &client{
+		url:   strings.TrimSuffix(c.String("url"), "/"),
+		token: c.String("token"),
+	}
+}
+
+func (c *client) do(method, path string, query url.Values, body io.Reader) (io.ReadCloser, error) {
+	u := c.url + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer func() { _ = resp.Body.Close() }()
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp.Body, nil
+}
+
+// migrationStatus starts a migration with POST or gets the status of the running one with GET.
+func (c *client) migrationStatus(method string, body io.Reader) (apimodels.StateHistoryMigrationStatus, error) {
+	resp, err := c.do(method, migratePath, nil, body)
+	if err != nil {
+		return apimodels.StateHistoryMigrationStatus{}, err
+	}
+	defer func() { _ = resp.Close() }()
+	var status apimodels.StateHistoryMigrationStatus
+	if err := json.NewDecoder(resp).Decode(&status); err != nil {
+		return apimodels.StateHistoryMigrationStatus{}, fmt.Errorf("failed to decode migration status: %w", err)
+	}
+	return status, nil
+}
+
+// parseTime parses either an RFC3339 timestamp or a unix timestamp in milliseconds.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func readCheckpoint(path string) (*apimodels.StateHistoryMigrationCheckpoint, error) {
+	// nolint:gosec
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+	var checkpoint apimodels.StateHistoryMigrationCheckpoint
+	if err := json.Unmarshal(b, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint file: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+func writeCheckpoint(path string, checkpoint apimodels.StateHistoryMigrationCheckpoint) error {
+	b, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+	return nil
+}
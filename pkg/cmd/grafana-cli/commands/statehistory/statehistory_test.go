@@ -0,0 +1,26 @@
+package statehistory
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func TestExportQueryRoundTrip(t *testing.T) {
+	from := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	query, err := exportQuery("loki", from.Format(time.RFC3339), strconv.FormatInt(to.UnixMilli(), 10), []string{"a", "b"})
+	require.NoError(t, err)
+
+	opts, err := api.ParseHistoryExportQuery(1, &user.SignedInUser{OrgID: 1}, query, time.Now())
+	require.NoError(t, err)
+	require.True(t, from.Equal(opts.From), "expected from %s, got %s", from, opts.From)
+	require.True(t, to.Equal(opts.To), "expected to %s, got %s", to, opts.To)
+	require.Equal(t, []string{"a", "b"}, opts.RuleUIDs)
+}
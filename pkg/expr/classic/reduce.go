@@ -15,6 +15,8 @@ func (cr reducer) ValidReduceFunc() bool {
 		return true
 	case "diff", "diff_abs", "percent_diff", "percent_diff_abs", "count_non_null":
 		return true
+	case "first", "p90", "p95", "p99", "stddev", "variance", "range":
+		return true
 	}
 	return false
 }
@@ -144,6 +146,14 @@ func (cr reducer) Reduce(series mathexp.Series) mathexp.Number {
 		if value > 0 {
 			allNull = false
 		}
+	case "first", "p90", "p95", "p99", "stddev", "variance", "range":
+		// These reducers are shared with the Reduce expression, and non-numbers are dropped
+		// the same way as its "dropNN" mode does.
+		n, err := series.Reduce("", mathexp.ReducerID(cr), mathexp.DropNonNumber{})
+		if v := n.GetFloat64Value(); err == nil && v != nil && !math.IsNaN(*v) {
+			value = *v
+			allNull = false
+		}
 	}
 
 	if allNull {
@@ -115,6 +115,42 @@ func TestReducer(t *testing.T) {
 			inputSeries:    newSeries(nil, nil),
 			expectedNumber: newNumber(nil),
 		},
+		{
+			name:           "first should ignore null values",
+			reducer:        reducer("first"),
+			inputSeries:    newSeries(nil, util.Pointer(2.0), util.Pointer(3.0)),
+			expectedNumber: newNumber(util.Pointer(2.0)),
+		},
+		{
+			name:           "p90",
+			reducer:        reducer("p90"),
+			inputSeries:    newSeries(util.Pointer(1.0), util.Pointer(2.0)),
+			expectedNumber: newNumber(util.Pointer(1.9)),
+		},
+		{
+			name:           "p99 with only nulls",
+			reducer:        reducer("p99"),
+			inputSeries:    newSeries(nil, nil),
+			expectedNumber: newNumber(nil),
+		},
+		{
+			name:           "variance should ignore null values",
+			reducer:        reducer("variance"),
+			inputSeries:    newSeries(util.Pointer(1.0), nil, util.Pointer(2.0)),
+			expectedNumber: newNumber(util.Pointer(0.25)),
+		},
+		{
+			name:           "stddev",
+			reducer:        reducer("stddev"),
+			inputSeries:    newSeries(util.Pointer(1.0), util.Pointer(2.0)),
+			expectedNumber: newNumber(util.Pointer(0.5)),
+		},
+		{
+			name:           "range",
+			reducer:        reducer("range"),
+			inputSeries:    newSeries(util.Pointer(3.0), util.Pointer(1.0), util.Pointer(7.0)),
+			expectedNumber: newNumber(util.Pointer(6.0)),
+		},
 	}
 
 	for _, tt := range tests {
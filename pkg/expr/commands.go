@@ -345,6 +345,8 @@ const (
 	TypeThreshold
 	// TypeSQL is the CMDType for running SQL expressions
 	TypeSQL
+	// TypeForecast is the CMDType for predicting future values of a timeseries.
+	TypeForecast
 )
 
 func (gt CommandType) String() string {
@@ -361,6 +363,8 @@ func (gt CommandType) String() string {
 		return "threshold"
 	case TypeSQL:
 		return "sql"
+	case TypeForecast:
+		return "forecast"
 	default:
 		return "unknown"
 	}
@@ -381,6 +385,8 @@ func ParseCommandType(s string) (CommandType, error) {
 		return TypeThreshold, nil
 	case "sql":
 		return TypeSQL, nil
+	case "forecast":
+		return TypeForecast, nil
 	default:
 		return TypeUnknown, fmt.Errorf("'%v' is not a recognized expression type", s)
 	}
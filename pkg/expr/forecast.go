@@ -0,0 +1,143 @@
+package expr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/metrics"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+const (
+	defaultForecastConfidenceLevel = 0.95
+	defaultForecastAlpha           = 0.5
+	defaultForecastBeta            = 0.1
+)
+
+// ForecastCommand is an expression command that predicts the future values of time series,
+// together with a confidence band around the predicted values.
+type ForecastCommand struct {
+	VarToForecast string
+	Options       mathexp.ForecastOptions
+	refID         string
+}
+
+// NewForecastCommand creates a new ForecastCommand.
+func NewForecastCommand(refID, varToForecast string, options mathexp.ForecastOptions) (*ForecastCommand, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	return &ForecastCommand{
+		VarToForecast: varToForecast,
+		Options:       options,
+		refID:         refID,
+	}, nil
+}
+
+// UnmarshalForecastCommand creates a ForecastCommand from Grafana's frontend query.
+func UnmarshalForecastCommand(rn *rawNode) (*ForecastCommand, error) {
+	rawVar, ok := rn.Query["expression"]
+	if !ok {
+		return nil, errors.New("no expression ID to forecast. must be a reference to an existing query or expression")
+	}
+	varToForecast, ok := rawVar.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected forecast input variable to be type string, but got type %T", rawVar)
+	}
+	varToForecast = strings.TrimPrefix(varToForecast, "$")
+
+	rawMethod, ok := rn.Query["method"]
+	if !ok {
+		return nil, errors.New("no method specified in forecast command")
+	}
+	method, ok := rawMethod.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected forecast method to be a string, got type %T", rawMethod)
+	}
+
+	rawHorizon, ok := rn.Query["horizon"]
+	if !ok {
+		return nil, errors.New("no time duration specified for the horizon in forecast command")
+	}
+	horizonStr, ok := rawHorizon.(string)
+	if !ok {
+		return nil, fmt.Errorf("forecast horizon is expected to be a string, got %T", rawHorizon)
+	}
+	horizon, err := gtime.ParseDuration(horizonStr)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to parse forecast "horizon" duration field %q: %w`, horizonStr, err)
+	}
+
+	options := mathexp.ForecastOptions{
+		Method:          mathexp.ForecastMethod(method),
+		Horizon:         horizon,
+		ConfidenceLevel: defaultForecastConfidenceLevel,
+		Alpha:           defaultForecastAlpha,
+		Beta:            defaultForecastBeta,
+	}
+	for _, setting := range []struct {
+		key   string
+		value *float64
+	}{
+		{key: "confidenceLevel", value: &options.ConfidenceLevel},
+		{key: "alpha", value: &options.Alpha},
+		{key: "beta", value: &options.Beta},
+	} {
+		raw, ok := rn.Query[setting.key]
+		if !ok || raw == nil {
+			continue
+		}
+		f, ok := raw.(float64)
+		if !ok {
+			return nil, fmt.Errorf("forecast %s is expected to be a number, got %T", setting.key, raw)
+		}
+		*setting.value = f
+	}
+
+	return NewForecastCommand(rn.RefID, varToForecast, options)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (fc *ForecastCommand) NeedsVars() []string {
+	return []string{fc.VarToForecast}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (fc *ForecastCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer, _ *metrics.ExprMetrics) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteForecast")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("method", string(fc.Options.Method)))
+
+	newRes := mathexp.Results{}
+	for _, val := range vars[fc.VarToForecast].Values {
+		switch v := val.(type) {
+		case mathexp.Series:
+			forecast, err := v.Forecast(fc.refID, fc.Options)
+			if err != nil {
+				return newRes, err
+			}
+			for _, s := range forecast {
+				newRes.Values = append(newRes.Values, s)
+			}
+		case mathexp.NoData:
+			newRes.Values = append(newRes.Values, v.New())
+		default:
+			return newRes, fmt.Errorf("can only forecast type series, got type %v", val.Type())
+		}
+	}
+	return newRes, nil
+}
+
+func (fc *ForecastCommand) Type() string {
+	return TypeForecast.String()
+}
@@ -0,0 +1,135 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestUnmarshalForecastCommand(t *testing.T) {
+	var tests = []struct {
+		name     string
+		query    string
+		expected mathexp.ForecastOptions
+		errMsg   string
+	}{
+		{
+			name:  "uses defaults for optional settings",
+			query: `{"expression": "$A", "method": "holt", "horizon": "4h"}`,
+			expected: mathexp.ForecastOptions{
+				Method:          mathexp.ForecastMethodHolt,
+				Horizon:         4 * time.Hour,
+				ConfidenceLevel: 0.95,
+				Alpha:           0.5,
+				Beta:            0.1,
+			},
+		},
+		{
+			name:  "reads optional settings",
+			query: `{"expression": "A", "method": "linear", "horizon": "1d", "confidenceLevel": 0.8, "alpha": 0.3, "beta": 0.2}`,
+			expected: mathexp.ForecastOptions{
+				Method:          mathexp.ForecastMethodLinear,
+				Horizon:         24 * time.Hour,
+				ConfidenceLevel: 0.8,
+				Alpha:           0.3,
+				Beta:            0.2,
+			},
+		},
+		{
+			name:   "fails without horizon",
+			query:  `{"expression": "$A", "method": "linear"}`,
+			errMsg: "no time duration specified for the horizon",
+		},
+		{
+			name:   "fails with invalid horizon",
+			query:  `{"expression": "$A", "method": "linear", "horizon": "soon"}`,
+			errMsg: `failed to parse forecast "horizon" duration field "soon"`,
+		},
+		{
+			name:   "fails with unknown method",
+			query:  `{"expression": "$A", "method": "prophet", "horizon": "1h"}`,
+			errMsg: `forecast method "prophet" is not supported`,
+		},
+		{
+			name:   "fails if a setting is not a number",
+			query:  `{"expression": "$A", "method": "linear", "horizon": "1h", "confidenceLevel": "high"}`,
+			errMsg: "forecast confidenceLevel is expected to be a number, got string",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := map[string]any{}
+			require.NoError(t, json.Unmarshal([]byte(test.query), &q))
+			cmd, err := UnmarshalForecastCommand(&rawNode{RefID: "B", Query: q})
+			if test.errMsg != "" {
+				require.ErrorContains(t, err, test.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "A", cmd.VarToForecast)
+			require.Equal(t, test.expected, cmd.Options)
+			require.Equal(t, []string{"A"}, cmd.NeedsVars())
+		})
+	}
+}
+
+func TestForecastCommand_Execute(t *testing.T) {
+	varToForecast := util.GenerateShortUID()
+	cmd, err := NewForecastCommand(util.GenerateShortUID(), varToForecast, mathexp.ForecastOptions{
+		Method:          mathexp.ForecastMethodLinear,
+		Horizon:         time.Minute,
+		ConfidenceLevel: 0.95,
+	})
+	require.NoError(t, err)
+
+	series := mathexp.NewSeries(varToForecast, nil, 2)
+	series.SetPoint(0, time.Unix(0, 0), util.Pointer(1.0))
+	series.SetPoint(1, time.Unix(10, 0), util.Pointer(2.0))
+
+	var tests = []struct {
+		name          string
+		vals          mathexp.Value
+		isError       bool
+		expectedTypes []parse.ReturnType
+	}{
+		{
+			name:          "should return predicted values and confidence band when input Series",
+			vals:          series,
+			expectedTypes: []parse.ReturnType{parse.TypeSeriesSet, parse.TypeSeriesSet, parse.TypeSeriesSet},
+		},
+		{
+			name:          "should return NoData when input NoData",
+			vals:          mathexp.NoData{},
+			expectedTypes: []parse.ReturnType{parse.TypeNoData},
+		}, {
+			name:    "should return error when input Number",
+			vals:    mathexp.NewNumber("test", nil),
+			isError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+				varToForecast: mathexp.Results{Values: mathexp.Values{test.vals}},
+			}, tracing.InitializeTracerForTest(), nil)
+			if test.isError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Values, len(test.expectedTypes))
+			for i, res := range result.Values {
+				require.Equal(t, test.expectedTypes[i], res.Type())
+			}
+		})
+	}
+}
@@ -137,7 +137,11 @@ func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (m
 			return vars, makeUnexpectedNodeTypeError(node.RefID(), node.NodeType().String())
 		}
 
+		start := time.Now()
 		res, err := execNode.Execute(c, now, vars, s)
+		if node.NodeType() == TypeDatasourceNode {
+			recordNodeDuration(c, node.RefID(), time.Since(start))
+		}
 		if err != nil {
 			res.Error = err
 		}
@@ -147,6 +151,22 @@ func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (m
 	return vars, nil
 }
 
+type nodeDurationsKey struct{}
+
+// WithNodeDurations returns a context that makes the pipeline record the execution time of its data source queries
+// in the returned map by RefID. The time of a request that executes several queries is recorded once,
+// for the first query of the request.
+func WithNodeDurations(ctx context.Context) (context.Context, map[string]time.Duration) {
+	durations := make(map[string]time.Duration)
+	return context.WithValue(ctx, nodeDurationsKey{}, durations), durations
+}
+
+func recordNodeDuration(ctx context.Context, refID string, d time.Duration) {
+	if durations, ok := ctx.Value(nodeDurationsKey{}).(map[string]time.Duration); ok {
+		durations[refID] = d
+	}
+}
+
 // GetDatasourceTypes returns an unique list of data source types used in the query. Machine learning node is encoded as `ml_<type>`, e.g. ml_outlier
 func (dp *DataPipeline) GetDatasourceTypes() []string {
 	if dp == nil {
@@ -214,28 +214,8 @@ func (e *State) union(aResults, bResults Results, biNode *parse.BinaryNode) []*U
 	aMatched := make([]bool, len(aResults.Values))
 	bMatched := make([]bool, len(bResults.Values))
 	collectDrops := func() {
-		check := func(v string, matchArray []bool, r *Results) {
-			for i, b := range matchArray {
-				if b {
-					continue
-				}
-				if e.Drops == nil {
-					e.Drops = make(map[string]map[string][]data.Labels)
-				}
-				if e.Drops[biNode.String()] == nil {
-					e.Drops[biNode.String()] = make(map[string][]data.Labels)
-				}
-
-				if r.Values[i].Type() == parse.TypeNoData {
-					continue
-				}
-
-				e.DropCount++
-				e.Drops[biNode.String()][v] = append(e.Drops[biNode.String()][v], r.Values[i].GetLabels())
-			}
-		}
-		check(aVar, aMatched, &aResults)
-		check(bVar, bMatched, &bResults)
+		e.collectDrops(biNode, aVar, aMatched, &aResults)
+		e.collectDrops(biNode, bVar, bMatched, &bResults)
 	}
 
 	aValueLen := len(aResults.Values)
@@ -306,6 +286,166 @@ func (e *State) union(aResults, bResults Results, biNode *parse.BinaryNode) []*U
 	return unions
 }
 
+// collectDrops records the items of r that were not matched in the binary operation,
+// so they can be reported by addDropNotices.
+func (e *State) collectDrops(biNode *parse.BinaryNode, v string, matched []bool, r *Results) {
+	for i, b := range matched {
+		if b {
+			continue
+		}
+		if e.Drops == nil {
+			e.Drops = make(map[string]map[string][]data.Labels)
+		}
+		if e.Drops[biNode.String()] == nil {
+			e.Drops[biNode.String()] = make(map[string][]data.Labels)
+		}
+
+		if r.Values[i].Type() == parse.TypeNoData {
+			continue
+		}
+
+		e.DropCount++
+		e.Drops[biNode.String()][v] = append(e.Drops[biNode.String()][v], r.Values[i].GetLabels())
+	}
+}
+
+// matchUnion creates Union objects like union does, but pairs the items of each side
+// according to the label matching modifiers of the binary node, e.g. $A + on(instance) $B.
+// Items are matched when their labels are equal after only keeping the labels listed
+// in on(..), or after removing the labels listed in ignoring(..).
+// It returns an error if an item matches more than one item on a side that must be unique
+// for the cardinality of the operation, or if no item of either side found a match.
+func (e *State) matchUnion(aResults, bResults Results, biNode *parse.BinaryNode) ([]*Union, error) {
+	unions := []*Union{}
+	if len(aResults.Values) == 0 || len(bResults.Values) == 0 {
+		return unions, nil
+	}
+	if (len(aResults.Values) == 1 && aResults.Values[0].Type() == parse.TypeNoData) ||
+		(len(bResults.Values) == 1 && bResults.Values[0].Type() == parse.TypeNoData) {
+		return e.union(aResults, bResults, biNode), nil
+	}
+
+	m := biNode.Matching
+	aVar := biNode.Args[0].String()
+	bVar := biNode.Args[1].String()
+	aSigs, aBySig := matchSignatures(aResults, m)
+	bSigs, bBySig := matchSignatures(bResults, m)
+
+	// The "one" side of the operation must have at most one item per signature.
+	checkUnique := func(v string, sigs []string, bySig, other map[string][]int) error {
+		for _, sig := range sigs {
+			if len(bySig[sig]) > 1 && len(other[sig]) > 0 {
+				return fmt.Errorf("%d items of %s in %q match the labels {%s}, use group_left or group_right for many-to-one matching", len(bySig[sig]), v, biNode, sig)
+			}
+		}
+		return nil
+	}
+	if m.Card != parse.CardManyToOne {
+		if err := checkUnique(aVar, aSigs, aBySig, bBySig); err != nil {
+			return nil, err
+		}
+	}
+	if m.Card != parse.CardOneToMany {
+		if err := checkUnique(bVar, bSigs, bBySig, aBySig); err != nil {
+			return nil, err
+		}
+	}
+
+	aMatched := make([]bool, len(aResults.Values))
+	bMatched := make([]bool, len(bResults.Values))
+	for iA, a := range aResults.Values {
+		for _, iB := range bBySig[aSigs[iA]] {
+			b := bResults.Values[iB]
+			unions = append(unions, &Union{
+				Labels: matchLabels(a.GetLabels(), b.GetLabels(), m),
+				A:      a,
+				B:      b,
+			})
+			aMatched[iA] = true
+			bMatched[iB] = true
+		}
+	}
+
+	if len(unions) == 0 {
+		return nil, fmt.Errorf("no items matched in %q: %s has {%s}, %s has {%s}", biNode,
+			aVar, strings.Join(limitStrings(aSigs, 5), "} {"),
+			bVar, strings.Join(limitStrings(bSigs, 5), "} {"))
+	}
+
+	e.collectDrops(biNode, aVar, aMatched, &aResults)
+	e.collectDrops(biNode, bVar, bMatched, &bResults)
+	return unions, nil
+}
+
+// matchSignatures returns the labels used for matching of each item in r as string, and the
+// indices of the items per signature.
+func matchSignatures(r Results, m *parse.VectorMatching) ([]string, map[string][]int) {
+	sigs := make([]string, len(r.Values))
+	bySig := make(map[string][]int, len(r.Values))
+	for i, v := range r.Values {
+		sigs[i] = matchingLabels(v.GetLabels(), m).String()
+		bySig[sigs[i]] = append(bySig[sigs[i]], i)
+	}
+	return sigs, bySig
+}
+
+// matchingLabels returns the subset of labels that is compared by the matching modifier.
+func matchingLabels(labels data.Labels, m *parse.VectorMatching) data.Labels {
+	result := data.Labels{}
+	if m.On {
+		for _, name := range m.Labels {
+			if v, ok := labels[name]; ok {
+				result[name] = v
+			}
+		}
+		return result
+	}
+	for name, v := range labels {
+		result[name] = v
+	}
+	for _, name := range m.Labels {
+		delete(result, name)
+	}
+	return result
+}
+
+// matchLabels returns the labels of the result of a matched pair of items. A one-to-one
+// operation keeps the matching labels only, while a many-to-one or one-to-many operation
+// keeps the labels of the "many" side and the included labels of the "one" side.
+func matchLabels(aLabels, bLabels data.Labels, m *parse.VectorMatching) data.Labels {
+	var many, one data.Labels
+	switch m.Card {
+	case parse.CardManyToOne:
+		many, one = aLabels, bLabels
+	case parse.CardOneToMany:
+		many, one = bLabels, aLabels
+	default:
+		return matchingLabels(aLabels, m)
+	}
+
+	labels := data.Labels{}
+	for name, v := range many {
+		labels[name] = v
+	}
+	for _, name := range m.Include {
+		if v, ok := one[name]; ok {
+			labels[name] = v
+		} else {
+			delete(labels, name)
+		}
+	}
+	return labels
+}
+
+// limitStrings returns the first n items of s, and an item with the number of remaining items
+// if there are more than n items.
+func limitStrings(s []string, n int) []string {
+	if len(s) <= n {
+		return s
+	}
+	return append(s[:n:n], fmt.Sprintf("...%v more...", len(s)-n))
+}
+
 func (e *State) walkBinary(node *parse.BinaryNode) (Results, error) {
 	res := Results{Values: Values{}}
 	ar, err := e.walk(node.Args[0])
@@ -316,7 +456,15 @@ func (e *State) walkBinary(node *parse.BinaryNode) (Results, error) {
 	if err != nil {
 		return res, err
 	}
-	unions := e.union(ar, br, node)
+	var unions []*Union
+	if node.Matching != nil {
+		unions, err = e.matchUnion(ar, br, node)
+		if err != nil {
+			return res, err
+		}
+	} else {
+		unions = e.union(ar, br, node)
+	}
 	for _, uni := range unions {
 		var value Value
 		switch at := uni.A.(type) {
@@ -569,6 +717,8 @@ func (e *State) walkFunc(node *parse.FuncNode) (Results, error) {
 			v = e.Vars[t.Name]
 		case *parse.ScalarNode:
 			v = NewScalarResults(e.RefID, &t.Float64)
+		case *parse.DurationNode:
+			v = t.Duration
 		case *parse.FuncNode:
 			v, err = e.walkFunc(t)
 		case *parse.UnaryNode:
@@ -0,0 +1,247 @@
+package mathexp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// The forecast method
+// +enum
+type ForecastMethod string
+
+const (
+	// Fit a straight line through the points (least squares)
+	ForecastMethodLinear ForecastMethod = "linear"
+
+	// Holt's linear trend method (double exponential smoothing)
+	ForecastMethodHolt ForecastMethod = "holt"
+)
+
+const (
+	// ForecastLabel is the label added to the series returned by Forecast to tell them apart.
+	ForecastLabel = "forecast"
+	// ForecastPredicted is the value of ForecastLabel for the series of predicted values.
+	ForecastPredicted = "predicted"
+	// ForecastLower is the value of ForecastLabel for the series of the lower confidence band.
+	ForecastLower = "lower"
+	// ForecastUpper is the value of ForecastLabel for the series of the upper confidence band.
+	ForecastUpper = "upper"
+
+	// maxForecastPoints is the maximum number of points that are predicted per series.
+	maxForecastPoints = 10000
+)
+
+// ForecastOptions configures Series.Forecast.
+type ForecastOptions struct {
+	Method ForecastMethod
+	// Horizon is how far ahead of the last point of the series values are predicted.
+	Horizon time.Duration
+	// ConfidenceLevel is the probability, between 0 and 1, that a value falls within the confidence bands.
+	ConfidenceLevel float64
+	// Alpha and Beta are the smoothing factors of the level and the trend of the holt method.
+	Alpha float64
+	Beta  float64
+}
+
+// Validate returns an error if the options can not be used to forecast.
+func (o ForecastOptions) Validate() error {
+	switch o.Method {
+	case ForecastMethodLinear, ForecastMethodHolt:
+	default:
+		return fmt.Errorf("forecast method %q is not supported. Supported only: [%s,%s]", o.Method, ForecastMethodLinear, ForecastMethodHolt)
+	}
+	if o.Horizon <= 0 {
+		return fmt.Errorf("forecast horizon must be greater than zero, got %s", o.Horizon)
+	}
+	if o.ConfidenceLevel <= 0 || o.ConfidenceLevel >= 1 {
+		return fmt.Errorf("forecast confidence level must be between 0 and 1, got %v", o.ConfidenceLevel)
+	}
+	if o.Method == ForecastMethodHolt {
+		if o.Alpha <= 0 || o.Alpha > 1 {
+			return fmt.Errorf("forecast alpha must be greater than 0 and at most 1, got %v", o.Alpha)
+		}
+		if o.Beta <= 0 || o.Beta > 1 {
+			return fmt.Errorf("forecast beta must be greater than 0 and at most 1, got %v", o.Beta)
+		}
+	}
+	return nil
+}
+
+// Forecast predicts the values of the Series from its last point until the horizon, at the median
+// interval between its points. It returns three series with the labels of the Series and the
+// ForecastLabel: the predicted values, and the lower and upper bound of the confidence band.
+// Null, NaN and infinite values are ignored. If there are fewer than two points left the returned
+// series are empty.
+func (s Series) Forecast(refID string, opts ForecastOptions) ([]Series, error) {
+	type point struct {
+		t time.Time
+		v float64
+	}
+	points := make([]point, 0, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		t, v := s.GetPoint(i)
+		if v == nil || math.IsNaN(*v) || math.IsInf(*v, 0) {
+			continue
+		}
+		points = append(points, point{t: t, v: *v})
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].t.Before(points[j].t)
+	})
+
+	newSeries := func(forecast string, size int) Series {
+		labels := data.Labels{}
+		for k, v := range s.GetLabels() {
+			labels[k] = v
+		}
+		labels[ForecastLabel] = forecast
+		return NewSeries(refID, labels, size)
+	}
+
+	times := make([]time.Time, len(points))
+	for i, p := range points {
+		times[i] = p.t
+	}
+	step := medianInterval(times)
+	if len(points) < 2 || step <= 0 {
+		return []Series{newSeries(ForecastPredicted, 0), newSeries(ForecastLower, 0), newSeries(ForecastUpper, 0)}, nil
+	}
+	steps := int(opts.Horizon / step)
+	if steps > maxForecastPoints {
+		return nil, fmt.Errorf("forecast horizon %s is too long for the interval %s between the points of the series, at most %d points can be predicted", opts.Horizon, step, maxForecastPoints)
+	}
+	if steps < 1 {
+		steps = 1
+	}
+
+	// x is the position of the point in number of steps since the first point.
+	x := make([]float64, len(points))
+	y := make([]float64, len(points))
+	for i, p := range points {
+		x[i] = float64(p.t.Sub(points[0].t)) / float64(step)
+		y[i] = p.v
+	}
+
+	var predict func(k int) (value, stdErr float64)
+	switch opts.Method {
+	case ForecastMethodLinear:
+		predict = forecastLinear(x, y)
+	case ForecastMethodHolt:
+		predict = forecastHolt(x, y, opts.Alpha, opts.Beta)
+	default:
+		return nil, fmt.Errorf("forecast method %q is not supported", opts.Method)
+	}
+
+	z := math.Sqrt2 * math.Erfinv(opts.ConfidenceLevel)
+	predicted, lower, upper := newSeries(ForecastPredicted, steps), newSeries(ForecastLower, steps), newSeries(ForecastUpper, steps)
+	last := points[len(points)-1].t
+	for k := 1; k <= steps; k++ {
+		t := last.Add(time.Duration(k) * step)
+		value, stdErr := predict(k)
+		lo, hi := value-z*stdErr, value+z*stdErr
+		predicted.SetPoint(k-1, t, &value)
+		lower.SetPoint(k-1, t, &lo)
+		upper.SetPoint(k-1, t, &hi)
+	}
+	return []Series{predicted, lower, upper}, nil
+}
+
+// forecastLinear fits a straight line through the points with least squares. The returned function
+// predicts the value k steps after the last point, and the standard error of the prediction.
+func forecastLinear(x, y []float64) func(k int) (float64, float64) {
+	n := float64(len(x))
+	var xMean, yMean float64
+	for i := range x {
+		xMean += x[i]
+		yMean += y[i]
+	}
+	xMean /= n
+	yMean /= n
+
+	var sxx, sxy float64
+	for i := range x {
+		sxx += (x[i] - xMean) * (x[i] - xMean)
+		sxy += (x[i] - xMean) * (y[i] - yMean)
+	}
+	var slope float64
+	if sxx > 0 {
+		slope = sxy / sxx
+	}
+	intercept := yMean - slope*xMean
+
+	var residual float64
+	if len(x) > 2 {
+		var sse float64
+		for i := range x {
+			e := y[i] - (intercept + slope*x[i])
+			sse += e * e
+		}
+		residual = math.Sqrt(sse / (n - 2))
+	}
+
+	xLast := x[len(x)-1]
+	return func(k int) (float64, float64) {
+		xk := xLast + float64(k)
+		stdErr := residual
+		if sxx > 0 {
+			stdErr = residual * math.Sqrt(1+1/n+(xk-xMean)*(xk-xMean)/sxx)
+		}
+		return intercept + slope*xk, stdErr
+	}
+}
+
+// forecastHolt smooths the level and the trend of the points with the smoothing factors alpha
+// and beta. The returned function predicts the value k steps after the last point, and the
+// standard error of the prediction based on the errors of the one-step-ahead predictions.
+func forecastHolt(x, y []float64, alpha, beta float64) func(k int) (float64, float64) {
+	level := y[0]
+	var trend float64
+	if dx := x[1] - x[0]; dx > 0 {
+		trend = (y[1] - y[0]) / dx
+	}
+
+	var sse float64
+	for i := 1; i < len(x); i++ {
+		dx := x[i] - x[i-1]
+		if dx <= 0 {
+			continue
+		}
+		expected := level + trend*dx
+		e := y[i] - expected
+		sse += e * e
+
+		newLevel := alpha*y[i] + (1-alpha)*expected
+		trend = beta*(newLevel-level)/dx + (1-beta)*trend
+		level = newLevel
+	}
+	sigma := math.Sqrt(sse / float64(len(x)-1))
+
+	return func(k int) (float64, float64) {
+		// The variance is 1 + sum of (alpha*(1+j*beta))^2 for j in [1, k), expanded
+		// with the sums of j and j^2 so that each prediction takes constant time.
+		n := float64(k - 1)
+		sumJ := n * (n + 1) / 2
+		sumJ2 := n * (n + 1) * (2*n + 1) / 6
+		variance := 1 + alpha*alpha*(n+2*beta*sumJ+beta*beta*sumJ2)
+		return level + trend*float64(k), sigma * math.Sqrt(variance)
+	}
+}
+
+// medianInterval returns the median duration between consecutive sorted times.
+func medianInterval(times []time.Time) time.Duration {
+	if len(times) < 2 {
+		return 0
+	}
+	intervals := make([]time.Duration, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		intervals = append(intervals, times[i].Sub(times[i-1]))
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+	return intervals[len(intervals)/2]
+}
@@ -0,0 +1,125 @@
+package mathexp
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesForecast(t *testing.T) {
+	linear := makeSeries("A", data.Labels{"host": "a"},
+		tp{time.Unix(60, 0), float64Pointer(0)},
+		tp{time.Unix(180, 0), float64Pointer(20)},
+		tp{time.Unix(120, 0), float64Pointer(10)},
+		tp{time.Unix(200, 0), nil},
+		tp{time.Unix(240, 0), float64Pointer(30)},
+	)
+
+	for _, method := range []ForecastMethod{ForecastMethodLinear, ForecastMethodHolt} {
+		t.Run(string(method)+" predicts a linear trend", func(t *testing.T) {
+			opts := ForecastOptions{Method: method, Horizon: 3 * time.Minute, ConfidenceLevel: 0.95, Alpha: 0.5, Beta: 0.1}
+			res, err := linear.Forecast("B", opts)
+			require.NoError(t, err)
+			require.Len(t, res, 3)
+
+			for i, forecast := range []string{ForecastPredicted, ForecastLower, ForecastUpper} {
+				s := res[i]
+				require.Equal(t, data.Labels{"host": "a", ForecastLabel: forecast}, s.GetLabels())
+				require.Equal(t, 3, s.Len())
+				for k, expected := range []float64{40, 50, 60} {
+					ts, v := s.GetPoint(k)
+					require.Equal(t, time.Unix(int64(240+60*(k+1)), 0).UTC(), ts.UTC())
+					require.InDelta(t, expected, *v, 1e-9)
+				}
+			}
+		})
+	}
+
+	t.Run("confidence band widens with the distance from the last point", func(t *testing.T) {
+		noisy := makeSeries("A", nil,
+			tp{time.Unix(0, 0), float64Pointer(1)},
+			tp{time.Unix(10, 0), float64Pointer(12)},
+			tp{time.Unix(20, 0), float64Pointer(19)},
+			tp{time.Unix(30, 0), float64Pointer(33)},
+			tp{time.Unix(40, 0), float64Pointer(38)},
+		)
+		for _, method := range []ForecastMethod{ForecastMethodLinear, ForecastMethodHolt} {
+			res, err := noisy.Forecast("B", ForecastOptions{Method: method, Horizon: 30 * time.Second, ConfidenceLevel: 0.9, Alpha: 0.5, Beta: 0.1})
+			require.NoError(t, err)
+			predicted, lower, upper := res[0], res[1], res[2]
+			previousWidth := 0.0
+			for k := 0; k < predicted.Len(); k++ {
+				_, p := predicted.GetPoint(k)
+				_, lo := lower.GetPoint(k)
+				_, hi := upper.GetPoint(k)
+				require.Less(t, *lo, *p)
+				require.Greater(t, *hi, *p)
+				require.InDelta(t, *p-*lo, *hi-*p, 1e-9)
+				require.Greater(t, *hi-*lo, previousWidth)
+				previousWidth = *hi - *lo
+			}
+		}
+	})
+
+	t.Run("returns empty series if there are fewer than two points", func(t *testing.T) {
+		s := makeSeries("A", nil, tp{time.Unix(0, 0), float64Pointer(1)}, tp{time.Unix(10, 0), nil})
+		res, err := s.Forecast("B", ForecastOptions{Method: ForecastMethodLinear, Horizon: time.Hour, ConfidenceLevel: 0.95})
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+		for _, s := range res {
+			require.Equal(t, 0, s.Len())
+		}
+	})
+
+	t.Run("fails if the horizon has too many points", func(t *testing.T) {
+		_, err := linear.Forecast("B", ForecastOptions{Method: ForecastMethodLinear, Horizon: 365 * 24 * time.Hour, ConfidenceLevel: 0.95})
+		require.ErrorContains(t, err, "forecast horizon 8760h0m0s is too long")
+	})
+}
+
+func TestForecastOptionsValidate(t *testing.T) {
+	valid := ForecastOptions{Method: ForecastMethodHolt, Horizon: time.Hour, ConfidenceLevel: 0.95, Alpha: 0.5, Beta: 0.1}
+	require.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(o *ForecastOptions)
+		errMsg string
+	}{
+		{name: "unknown method", modify: func(o *ForecastOptions) { o.Method = "arima" }, errMsg: `forecast method "arima" is not supported`},
+		{name: "zero horizon", modify: func(o *ForecastOptions) { o.Horizon = 0 }, errMsg: "horizon must be greater than zero"},
+		{name: "confidence level of 1", modify: func(o *ForecastOptions) { o.ConfidenceLevel = 1 }, errMsg: "confidence level must be between 0 and 1"},
+		{name: "zero alpha", modify: func(o *ForecastOptions) { o.Alpha = 0 }, errMsg: "alpha must be greater than 0"},
+		{name: "beta above 1", modify: func(o *ForecastOptions) { o.Beta = 1.5 }, errMsg: "beta must be greater than 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := valid
+			tt.modify(&o)
+			require.ErrorContains(t, o.Validate(), tt.errMsg)
+		})
+	}
+
+	linear := ForecastOptions{Method: ForecastMethodLinear, Horizon: time.Hour, ConfidenceLevel: 0.95}
+	require.NoError(t, linear.Validate(), "smoothing factors are only required by the holt method")
+}
+
+func TestForecastHoltStdErr(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4, 5}
+	y := []float64{1, 3, 2, 5, 4, 6}
+	alpha, beta := 0.5, 0.3
+	predict := forecastHolt(x, y, alpha, beta)
+	_, stdErr1 := predict(1)
+	for _, k := range []int{1, 2, 10, 1000} {
+		variance := 1.0
+		for j := 1; j < k; j++ {
+			c := alpha * (1 + float64(j)*beta)
+			variance += c * c
+		}
+		_, stdErr := predict(k)
+		require.InDelta(t, stdErr1*math.Sqrt(variance), stdErr, 1e-9*stdErr)
+	}
+}
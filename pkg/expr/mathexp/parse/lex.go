@@ -56,8 +56,9 @@ const (
 	itemRightParen
 	itemString
 	itemFunc
-	itemVar // e.g. $A
-	itemPow // '**'
+	itemVar      // e.g. $A
+	itemPow      // '**'
+	itemDuration // e.g. 5m or 1h30m
 )
 
 const eof = -1
@@ -232,10 +233,19 @@ Loop:
 // isn't a perfect number scanner - for instance it accepts "." and "0x0.2"
 // and "089" - but when it's wrong the input is invalid and the parser (via
 // strconv) will notice.
+// A number that is directly followed by a letter is scanned as a duration,
+// e.g. 5m or 1h30m, and is validated by the parser as well.
 func lexNumber(l *lexer) stateFn {
 	if !l.scanNumber() {
 		return l.errorf("bad number syntax: %q", l.input[l.start:l.pos])
 	}
+	if r := l.peek(); unicode.IsLetter(r) {
+		for r = l.next(); unicode.IsLetter(r) || unicode.IsDigit(r); r = l.next() {
+		}
+		l.backup()
+		l.emit(itemDuration)
+		return lexItem
+	}
 	l.emit(itemNumber)
 	return lexItem
 }
@@ -299,10 +309,12 @@ func lexSymbol(l *lexer) stateFn {
 	return lexItem
 }
 
+// lexFunc scans a name, which is a function name or a keyword such as "on", or
+// a label name in a matching modifier.
 func lexFunc(l *lexer) stateFn {
 	for {
 		switch r := l.next(); {
-		case unicode.IsLetter(r) || r == '_':
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
 			// absorb
 		default:
 			l.backup()
@@ -9,6 +9,9 @@ package parse
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
+	"unicode"
 )
 
 // A Node is an element in the parse tree. The interface is trivial.
@@ -62,6 +65,8 @@ const (
 	NodeNumber
 	// NodeVar is variable: $A
 	NodeVar
+	// NodeDuration is a duration constant: 5m
+	NodeDuration
 )
 
 // String returns the string representation of the NodeType
@@ -79,6 +84,8 @@ func (t NodeType) String() string {
 		return "NodeNumber"
 	case NodeVar:
 		return "NodeVar"
+	case NodeDuration:
+		return "NodeDuration"
 	default:
 		return "NodeUnknown"
 	}
@@ -293,6 +300,83 @@ func (s *StringNode) Return() ReturnType {
 	return TypeString
 }
 
+// DurationNode holds a duration constant, e.g. 5m or 1h30m.
+type DurationNode struct {
+	NodeType
+	Pos
+	Duration time.Duration // The parsed duration.
+	Text     string        // The original textual representation from the input.
+}
+
+func newDuration(pos Pos, text string) (*DurationNode, error) {
+	d, err := parseDuration(text)
+	if err != nil {
+		return nil, err
+	}
+	return &DurationNode{NodeType: NodeDuration, Pos: pos, Duration: d, Text: text}, nil
+}
+
+// durationUnits are the units supported in duration constants.
+var durationUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+// parseDuration parses a duration such as 5m or 1h30m. Unlike time.ParseDuration it supports
+// days and weeks, and it does not support fractions or negative durations.
+func parseDuration(text string) (time.Duration, error) {
+	var d time.Duration
+	rest := text
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		j := i
+		for j < len(rest) && unicode.IsLetter(rune(rest[j])) {
+			j++
+		}
+		unit, ok := durationUnits[rest[i:j]]
+		if i == 0 || !ok {
+			return 0, fmt.Errorf("illegal duration syntax: %q", text)
+		}
+		n, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("illegal duration syntax: %q", text)
+		}
+		d += time.Duration(n) * unit
+		rest = rest[j:]
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be greater than zero: %q", text)
+	}
+	return d, nil
+}
+
+// String returns the string representation of the DurationNode so it fulfills the Node interface.
+func (n *DurationNode) String() string {
+	return n.Text
+}
+
+// StringAST returns the string representation of abstract syntax tree of the DurationNode so it fulfills the Node interface.
+func (n *DurationNode) StringAST() string {
+	return n.String()
+}
+
+// Check performs parse time checking on the DurationNode so it fulfills the Node interface.
+func (n *DurationNode) Check(*Tree) error {
+	return nil
+}
+
+// Return returns the result type of the DurationNode so it fulfills the Node interface.
+func (n *DurationNode) Return() ReturnType {
+	return TypeDuration
+}
+
 // BinaryNode holds two arguments and an operator.
 type BinaryNode struct {
 	NodeType
@@ -300,6 +384,9 @@ type BinaryNode struct {
 	Args     [2]Node
 	Operator item
 	OpStr    string
+	// Matching holds the label matching modifiers of the operation, e.g. on(instance).
+	// It is nil when the arguments are matched by the default union logic.
+	Matching *VectorMatching
 }
 
 func newBinary(operator item, arg1, arg2 Node) *BinaryNode {
@@ -308,11 +395,17 @@ func newBinary(operator item, arg1, arg2 Node) *BinaryNode {
 
 // String returns the string representation of the BinaryNode so it fulfills the Node interface.
 func (b *BinaryNode) String() string {
+	if b.Matching != nil {
+		return fmt.Sprintf("%s %s %s %s", b.Args[0], b.Operator.val, b.Matching, b.Args[1])
+	}
 	return fmt.Sprintf("%s %s %s", b.Args[0], b.Operator.val, b.Args[1])
 }
 
 // StringAST returns the string representation of abstract syntax tree of the BinaryNode so it fulfills the Node interface.
 func (b *BinaryNode) StringAST() string {
+	if b.Matching != nil {
+		return fmt.Sprintf("%s %s(%s, %s)", b.Operator.val, b.Matching, b.Args[0], b.Args[1])
+	}
 	return fmt.Sprintf("%s(%s, %s)", b.Operator.val, b.Args[0], b.Args[1])
 }
 
@@ -331,6 +424,52 @@ func (b *BinaryNode) Return() ReturnType {
 	return t0
 }
 
+// MatchCardinality describes how many items on each side of a binary operation may be matched to each other.
+type MatchCardinality int
+
+const (
+	// CardOneToOne requires every item to match at most one item on the other side.
+	CardOneToOne MatchCardinality = iota
+	// CardManyToOne allows many items on the left side to match one item on the right side (group_left).
+	CardManyToOne
+	// CardOneToMany allows one item on the left side to match many items on the right side (group_right).
+	CardOneToMany
+)
+
+// VectorMatching holds the label matching modifiers of a binary operation,
+// e.g. on(instance) group_left(team).
+type VectorMatching struct {
+	Card MatchCardinality
+	// On is true if Labels are the labels to match on (on), and false if Labels
+	// are the labels to ignore when matching (ignoring).
+	On     bool
+	Labels []string
+	// Include are the labels copied from the "one" side to the result of a
+	// many-to-one or one-to-many operation.
+	Include []string
+}
+
+func (m *VectorMatching) String() string {
+	s := "ignoring"
+	if m.On {
+		s = "on"
+	}
+	s = fmt.Sprintf("%s(%s)", s, strings.Join(m.Labels, ", "))
+
+	switch m.Card {
+	case CardManyToOne:
+		s += " group_left"
+	case CardOneToMany:
+		s += " group_right"
+	default:
+		return s
+	}
+	if len(m.Include) > 0 {
+		s += fmt.Sprintf("(%s)", strings.Join(m.Include, ", "))
+	}
+	return s
+}
+
 // UnaryNode holds one argument and an operator.
 type UnaryNode struct {
 	NodeType
@@ -380,7 +519,7 @@ func Walk(n Node, f func(Node)) {
 		for _, a := range n.Args {
 			Walk(a, f)
 		}
-	case *ScalarNode, *StringNode:
+	case *ScalarNode, *StringNode, *DurationNode:
 		// Ignore since these node types have no sub nodes.
 	case *UnaryNode:
 		Walk(n.Arg, f)
@@ -407,6 +546,8 @@ const (
 	TypeNoData
 	// TypeTableData is a tabular data response.
 	TypeTableData
+	// TypeDuration is a duration constant, only valid as a function argument.
+	TypeDuration
 )
 
 // String returns a string representation of the ReturnType.
@@ -426,6 +567,8 @@ func (f ReturnType) String() string {
 		return "noData"
 	case TypeTableData:
 		return "tableData"
+	case TypeDuration:
+		return "duration"
 	default:
 		return "unknown"
 	}
@@ -168,16 +168,18 @@ func (t *Tree) parse() {
 }
 
 /* Grammar:
-O -> A {"||" A}
-A -> C {"&&" C}
-C -> P {( "==" | "!=" | ">" | ">=" | "<" | "<=") P}
-P -> M {( "+" | "-" ) M}
-M -> E {( "*" | "/" ) F}
-E -> F {( "**" ) F}
+O -> A {"||" [matching] A}
+A -> C {"&&" [matching] C}
+C -> P {( "==" | "!=" | ">" | ">=" | "<" | "<=") [matching] P}
+P -> M {( "+" | "-" ) [matching] M}
+M -> E {( "*" | "/" ) [matching] F}
+E -> F {( "**" ) [matching] F}
 F -> v | "(" O ")" | "!" O | "-" O
 v -> number | func(..) | queryVar
-Func -> name "(" param {"," param} ")"
-param -> number | "string" | queryVar
+Func -> name "(" [param {"," param}] ")"
+param -> number | "string" | duration | queryVar
+matching -> ( "on" | "ignoring" ) labels [( "group_left" | "group_right" ) [labels]]
+labels -> "(" [label {"," label}] ")"
 */
 
 // expr:
@@ -188,7 +190,7 @@ func (t *Tree) O() Node {
 	for {
 		switch t.peek().typ {
 		case itemOr:
-			n = newBinary(t.next(), n, t.A())
+			n = t.binary(t.next(), n, t.A)
 		default:
 			return n
 		}
@@ -201,7 +203,7 @@ func (t *Tree) A() Node {
 	for {
 		switch t.peek().typ {
 		case itemAnd:
-			n = newBinary(t.next(), n, t.C())
+			n = t.binary(t.next(), n, t.C)
 		default:
 			return n
 		}
@@ -214,7 +216,7 @@ func (t *Tree) C() Node {
 	for {
 		switch t.peek().typ {
 		case itemEq, itemNotEq, itemGreater, itemGreaterEq, itemLess, itemLessEq:
-			n = newBinary(t.next(), n, t.P())
+			n = t.binary(t.next(), n, t.P)
 		default:
 			return n
 		}
@@ -227,7 +229,7 @@ func (t *Tree) P() Node {
 	for {
 		switch t.peek().typ {
 		case itemPlus, itemMinus:
-			n = newBinary(t.next(), n, t.M())
+			n = t.binary(t.next(), n, t.M)
 		default:
 			return n
 		}
@@ -240,7 +242,7 @@ func (t *Tree) M() Node {
 	for {
 		switch t.peek().typ {
 		case itemMult, itemDiv, itemMod:
-			n = newBinary(t.next(), n, t.E())
+			n = t.binary(t.next(), n, t.E)
 		default:
 			return n
 		}
@@ -253,7 +255,7 @@ func (t *Tree) E() Node {
 	for {
 		switch t.peek().typ {
 		case itemPow:
-			n = newBinary(t.next(), n, t.F())
+			n = t.binary(t.next(), n, t.F)
 		default:
 			return n
 		}
@@ -278,6 +280,73 @@ func (t *Tree) F() Node {
 	return nil
 }
 
+// binary parses the optional matching modifiers that follow the operator, and the
+// right hand side argument with rhs, and returns the BinaryNode for lhs operator rhs.
+func (t *Tree) binary(operator item, lhs Node, rhs func() Node) Node {
+	m := t.matching()
+	b := newBinary(operator, lhs, rhs())
+	if m == nil {
+		return b
+	}
+	for _, arg := range b.Args {
+		if arg.Return() == TypeScalar {
+			t.errorf("matching modifier %s can not be used with scalar %s", m, arg)
+		}
+	}
+	b.Matching = m
+	return b
+}
+
+// matching is ( "on" | "ignoring" ) labels [( "group_left" | "group_right" ) [labels]] in the grammar.
+// It returns nil if the next token does not start a matching modifier.
+func (t *Tree) matching() *VectorMatching {
+	token := t.peek()
+	if token.typ != itemFunc || (token.val != "on" && token.val != "ignoring") {
+		return nil
+	}
+	t.next()
+	m := &VectorMatching{On: token.val == "on", Labels: t.labels()}
+
+	token = t.peek()
+	if token.typ != itemFunc {
+		return m
+	}
+	switch token.val {
+	case "group_left":
+		m.Card = CardManyToOne
+	case "group_right":
+		m.Card = CardOneToMany
+	default:
+		return m
+	}
+	t.next()
+	if t.peek().typ == itemLeftParen {
+		m.Include = t.labels()
+	}
+	return m
+}
+
+// labels is "(" [label {"," label}] ")" in the grammar.
+func (t *Tree) labels() []string {
+	t.expect(itemLeftParen, "label list")
+	labels := []string{}
+	if t.peek().typ == itemRightParen {
+		t.next()
+		return labels
+	}
+	for {
+		labels = append(labels, t.expect(itemFunc, "label list").val)
+		switch token := t.next(); token.typ {
+		case itemComma:
+			// next label
+		case itemRightParen:
+			return labels
+		default:
+			t.unexpected(token, "label list")
+		}
+	}
+}
+
 // V is number | func(..) | queryVar in the grammar.
 func (t *Tree) v() Node {
 	switch token := t.next(); token.typ {
@@ -317,23 +386,44 @@ func (t *Tree) Func() (f *FuncNode) {
 	}
 	f = newFunc(token.pos, token.val, funcv)
 	t.expect(itemLeftParen, "func")
+	if t.peek().typ == itemRightParen {
+		t.next()
+		return
+	}
 	for {
+		t.param(f)
 		switch token = t.next(); token.typ {
-		default:
-			t.backup()
-			node := t.O()
-			f.append(node)
-			if len(f.Args) == 1 && f.F.VariantReturn {
-				f.F.Return = node.Return()
-			}
-		case itemString:
-			s, err := strconv.Unquote(token.val)
-			if err != nil {
-				t.errorf("Unquoting error: %s", err)
-			}
-			f.append(newString(token.pos, token.val, s))
+		case itemComma:
+			// next parameter
 		case itemRightParen:
 			return
+		default:
+			t.unexpected(token, "func")
+		}
+	}
+}
+
+// param is number | "string" | duration | queryVar in the grammar, and appends it to the arguments of f.
+func (t *Tree) param(f *FuncNode) {
+	switch token := t.next(); token.typ {
+	case itemString:
+		s, err := strconv.Unquote(token.val)
+		if err != nil {
+			t.errorf("Unquoting error: %s", err)
+		}
+		f.append(newString(token.pos, token.val, s))
+	case itemDuration:
+		n, err := newDuration(token.pos, token.val)
+		if err != nil {
+			t.error(err)
+		}
+		f.append(n)
+	default:
+		t.backup()
+		node := t.O()
+		f.append(node)
+		if len(f.Args) == 1 && f.F.VariantReturn {
+			f.F.Return = node.Return()
 		}
 	}
 }
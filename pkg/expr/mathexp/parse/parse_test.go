@@ -0,0 +1,202 @@
+package parse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testFuncs = map[string]Func{
+	"abs": {
+		Args:          []ReturnType{TypeVariantSet},
+		VariantReturn: true,
+	},
+	"shift": {
+		Args:   []ReturnType{TypeSeriesSet, TypeDuration},
+		Return: TypeSeriesSet,
+	},
+	"now": {
+		Return: TypeScalar,
+	},
+}
+
+func TestParseFuncArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+		errMsg   string
+	}{
+		{
+			name:     "single argument",
+			expr:     "abs($A)",
+			expected: "abs($A)",
+		},
+		{
+			name:     "no arguments",
+			expr:     "now()",
+			expected: "now()",
+		},
+		{
+			name:     "duration argument",
+			expr:     "shift($A, 1h30m)",
+			expected: "shift($A, 1h30m)",
+		},
+		{
+			name:     "nested call with duration argument",
+			expr:     "shift(abs($A), 1d) + 1",
+			expected: "shift(abs($A), 1d) + 1",
+		},
+		{
+			name:   "duration where a number is expected",
+			expr:   "$A + 5m",
+			errMsg: `unexpected "5m"`,
+		},
+		{
+			name:   "invalid duration unit",
+			expr:   "shift($A, 5x)",
+			errMsg: `illegal duration syntax: "5x"`,
+		},
+		{
+			name:   "number instead of duration",
+			expr:   "shift($A, 5)",
+			errMsg: "expected duration",
+		},
+		{
+			name:   "missing argument",
+			expr:   "shift($A)",
+			errMsg: "not enough arguments for shift",
+		},
+		{
+			name:   "missing comma",
+			expr:   "shift($A 5m)",
+			errMsg: `unexpected "5m" in func`,
+		},
+		{
+			name:   "trailing comma",
+			expr:   "abs($A,)",
+			errMsg: `unexpected ")"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := Parse(tt.expr, testFuncs)
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tree.String())
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected time.Duration
+		err      bool
+	}{
+		{text: "100ms", expected: 100 * time.Millisecond},
+		{text: "30s", expected: 30 * time.Second},
+		{text: "5m", expected: 5 * time.Minute},
+		{text: "1h30m", expected: 90 * time.Minute},
+		{text: "1d", expected: 24 * time.Hour},
+		{text: "2w", expected: 14 * 24 * time.Hour},
+		{text: "0m", err: true},
+		{text: "m", err: true},
+		{text: "5", err: true},
+		{text: "5y", err: true},
+		{text: "1.5h", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			d, err := parseDuration(tt.text)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestParseMatching(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+		matching *VectorMatching
+		errMsg   string
+	}{
+		{
+			name:     "no matching modifier",
+			expr:     "$A + $B",
+			expected: "$A + $B",
+		},
+		{
+			name:     "on",
+			expr:     "$A + on(instance) $B",
+			expected: "$A + on(instance) $B",
+			matching: &VectorMatching{On: true, Labels: []string{"instance"}},
+		},
+		{
+			name:     "ignoring with several labels",
+			expr:     "$A > ignoring(job, k8s_pod) $B",
+			expected: "$A > ignoring(job, k8s_pod) $B",
+			matching: &VectorMatching{Labels: []string{"job", "k8s_pod"}},
+		},
+		{
+			name:     "on with empty label list",
+			expr:     "$A * on() $B",
+			expected: "$A * on() $B",
+			matching: &VectorMatching{On: true, Labels: []string{}},
+		},
+		{
+			name:     "group_left without labels",
+			expr:     "$A / on(instance) group_left $B",
+			expected: "$A / on(instance) group_left $B",
+			matching: &VectorMatching{Card: CardManyToOne, On: true, Labels: []string{"instance"}},
+		},
+		{
+			name:     "group_right with labels followed by a function",
+			expr:     "$A - ignoring(job) group_right(team) abs($B)",
+			expected: "$A - ignoring(job) group_right(team) abs($B)",
+			matching: &VectorMatching{Card: CardOneToMany, Labels: []string{"job"}, Include: []string{"team"}},
+		},
+		{
+			name:   "matching with a scalar",
+			expr:   "$A + on(instance) 1",
+			errMsg: "can not be used with scalar 1",
+		},
+		{
+			name:   "missing label list",
+			expr:   "$A + on $B",
+			errMsg: `unexpected "$B" in label list`,
+		},
+		{
+			name:   "group_left without on or ignoring",
+			expr:   "$A + group_left $B",
+			errMsg: "non existent function group_left",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := Parse(tt.expr, testFuncs)
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tree.String())
+			b, ok := tree.Root.(*BinaryNode)
+			require.True(t, ok)
+			require.Equal(t, tt.matching, b.Matching)
+		})
+	}
+}
@@ -15,18 +15,30 @@ type ReducerFunc = func(fv *Float64Field) *float64
 type ReducerID string
 
 const (
-	ReducerSum    ReducerID = "sum"
-	ReducerMean   ReducerID = "mean"
-	ReducerMin    ReducerID = "min"
-	ReducerMax    ReducerID = "max"
-	ReducerCount  ReducerID = "count"
-	ReducerLast   ReducerID = "last"
-	ReducerMedian ReducerID = "median"
+	ReducerSum         ReducerID = "sum"
+	ReducerMean        ReducerID = "mean"
+	ReducerMin         ReducerID = "min"
+	ReducerMax         ReducerID = "max"
+	ReducerCount       ReducerID = "count"
+	ReducerLast        ReducerID = "last"
+	ReducerMedian      ReducerID = "median"
+	ReducerFirst       ReducerID = "first"
+	ReducerP90         ReducerID = "p90"
+	ReducerP95         ReducerID = "p95"
+	ReducerP99         ReducerID = "p99"
+	ReducerStdDev      ReducerID = "stddev"
+	ReducerVariance    ReducerID = "variance"
+	ReducerRange       ReducerID = "range"
+	ReducerDiff        ReducerID = "diff"
+	ReducerPercentDiff ReducerID = "percent_diff"
 )
 
 // GetSupportedReduceFuncs returns collection of supported function names
 func GetSupportedReduceFuncs() []ReducerID {
-	return []ReducerID{ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian}
+	return []ReducerID{
+		ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian,
+		ReducerFirst, ReducerP90, ReducerP95, ReducerP99, ReducerStdDev, ReducerVariance, ReducerRange, ReducerDiff, ReducerPercentDiff,
+	}
 }
 
 func Sum(fv *Float64Field) *float64 {
@@ -126,6 +138,111 @@ func Median(fv *Float64Field) *float64 {
 	}
 }
 
+func First(fv *Float64Field) *float64 {
+	var f float64
+	if fv.Len() == 0 {
+		f = math.NaN()
+		return &f
+	}
+	return fv.GetValue(0)
+}
+
+// Percentile returns a reducer that calculates the p-th percentile of the values,
+// using linear interpolation between the closest ranks.
+func Percentile(p float64) ReducerFunc {
+	return func(fv *Float64Field) *float64 {
+		values, ok := numericValues(fv)
+		if !ok || len(values) == 0 {
+			nan := math.NaN()
+			return &nan
+		}
+
+		sort.Float64s(values)
+		rank := p / 100 * float64(len(values)-1)
+		lower := int(math.Floor(rank))
+		upper := int(math.Ceil(rank))
+		v := values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+		return &v
+	}
+}
+
+// Variance returns the population variance of the values.
+func Variance(fv *Float64Field) *float64 {
+	values, ok := numericValues(fv)
+	if !ok || len(values) == 0 {
+		nan := math.NaN()
+		return &nan
+	}
+
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+	return &variance
+}
+
+// StdDev returns the population standard deviation of the values.
+func StdDev(fv *Float64Field) *float64 {
+	v := Variance(fv)
+	f := math.Sqrt(*v)
+	return &f
+}
+
+// Range returns the difference between the max and min value.
+func Range(fv *Float64Field) *float64 {
+	f := *Max(fv) - *Min(fv)
+	return &f
+}
+
+// Diff returns the difference between the last and first value.
+func Diff(fv *Float64Field) *float64 {
+	return firstLast(fv, func(first, last float64) float64 {
+		return last - first
+	})
+}
+
+// PercentDiff returns the difference between the last and first value as percent of the first value.
+func PercentDiff(fv *Float64Field) *float64 {
+	return firstLast(fv, func(first, last float64) float64 {
+		return (last - first) / math.Abs(first) * 100
+	})
+}
+
+// firstLast calls fn with the first and last value. It returns NaN if there are no values,
+// or if the first or last value is null or NaN.
+func firstLast(fv *Float64Field, fn func(first, last float64) float64) *float64 {
+	nan := math.NaN()
+	if fv.Len() == 0 {
+		return &nan
+	}
+	first, last := fv.GetValue(0), fv.GetValue(fv.Len()-1)
+	if first == nil || last == nil || math.IsNaN(*first) || math.IsNaN(*last) {
+		return &nan
+	}
+	f := fn(*first, *last)
+	return &f
+}
+
+// numericValues returns a copy of the values. It returns false if any of the values is null or NaN.
+func numericValues(fv *Float64Field) ([]float64, bool) {
+	values := make([]float64, 0, fv.Len())
+	for i := 0; i < fv.Len(); i++ {
+		v := fv.GetValue(i)
+		if v == nil || math.IsNaN(*v) {
+			return nil, false
+		}
+		values = append(values, *v)
+	}
+	return values, true
+}
+
 func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 	switch rFunc {
 	case ReducerSum:
@@ -142,6 +259,24 @@ func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 		return Last, nil
 	case ReducerMedian:
 		return Median, nil
+	case ReducerFirst:
+		return First, nil
+	case ReducerP90:
+		return Percentile(90), nil
+	case ReducerP95:
+		return Percentile(95), nil
+	case ReducerP99:
+		return Percentile(99), nil
+	case ReducerStdDev:
+		return StdDev, nil
+	case ReducerVariance:
+		return Variance, nil
+	case ReducerRange:
+		return Range, nil
+	case ReducerDiff:
+		return Diff, nil
+	case ReducerPercentDiff:
+		return PercentDiff, nil
 	default:
 		return nil, fmt.Errorf("reduction %v not implemented", rFunc)
 	}
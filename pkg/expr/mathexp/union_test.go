@@ -6,6 +6,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_union(t *testing.T) {
@@ -300,3 +301,132 @@ func Test_union(t *testing.T) {
 		})
 	}
 }
+
+func Test_matchUnion(t *testing.T) {
+	var tests = []struct {
+		name     string
+		matching *parse.VectorMatching
+		aResults Results
+		bResults Results
+		unions   []*Union
+		drops    int64
+		errMsg   string
+	}{
+		{
+			name:     "on matches on the listed labels only",
+			matching: &parse.VectorMatching{On: true, Labels: []string{"instance"}},
+			aResults: Results{Values: Values{
+				makeNumber("a", data.Labels{"instance": "a", "job": "node"}, nil),
+				makeNumber("b", data.Labels{"instance": "b", "job": "node"}, nil),
+			}},
+			bResults: Results{Values: Values{
+				makeNumber("c", data.Labels{"instance": "a", "db": "inventory"}, nil),
+			}},
+			unions: []*Union{
+				{
+					Labels: data.Labels{"instance": "a"},
+					A:      makeNumber("a", data.Labels{"instance": "a", "job": "node"}, nil),
+					B:      makeNumber("c", data.Labels{"instance": "a", "db": "inventory"}, nil),
+				},
+			},
+			drops: 1,
+		},
+		{
+			name:     "ignoring removes the listed labels before matching",
+			matching: &parse.VectorMatching{Labels: []string{"job"}},
+			aResults: Results{Values: Values{
+				makeNumber("a", data.Labels{"instance": "a", "job": "node"}, nil),
+			}},
+			bResults: Results{Values: Values{
+				makeNumber("b", data.Labels{"instance": "a", "job": "sql"}, nil),
+			}},
+			unions: []*Union{
+				{
+					Labels: data.Labels{"instance": "a"},
+					A:      makeNumber("a", data.Labels{"instance": "a", "job": "node"}, nil),
+					B:      makeNumber("b", data.Labels{"instance": "a", "job": "sql"}, nil),
+				},
+			},
+		},
+		{
+			name:     "group_left keeps the labels of the left side and includes labels of the right side",
+			matching: &parse.VectorMatching{Card: parse.CardManyToOne, On: true, Labels: []string{"instance"}, Include: []string{"team"}},
+			aResults: Results{Values: Values{
+				makeNumber("a", data.Labels{"instance": "a", "cpu": "0"}, nil),
+				makeNumber("b", data.Labels{"instance": "a", "cpu": "1"}, nil),
+			}},
+			bResults: Results{Values: Values{
+				makeNumber("c", data.Labels{"instance": "a", "team": "db"}, nil),
+			}},
+			unions: []*Union{
+				{
+					Labels: data.Labels{"instance": "a", "cpu": "0", "team": "db"},
+					A:      makeNumber("a", data.Labels{"instance": "a", "cpu": "0"}, nil),
+					B:      makeNumber("c", data.Labels{"instance": "a", "team": "db"}, nil),
+				},
+				{
+					Labels: data.Labels{"instance": "a", "cpu": "1", "team": "db"},
+					A:      makeNumber("b", data.Labels{"instance": "a", "cpu": "1"}, nil),
+					B:      makeNumber("c", data.Labels{"instance": "a", "team": "db"}, nil),
+				},
+			},
+		},
+		{
+			name:     "group_right keeps the labels of the right side",
+			matching: &parse.VectorMatching{Card: parse.CardOneToMany, On: true, Labels: []string{"instance"}},
+			aResults: Results{Values: Values{
+				makeNumber("a", data.Labels{"instance": "a", "team": "db"}, nil),
+			}},
+			bResults: Results{Values: Values{
+				makeNumber("b", data.Labels{"instance": "a", "cpu": "0"}, nil),
+			}},
+			unions: []*Union{
+				{
+					Labels: data.Labels{"instance": "a", "cpu": "0"},
+					A:      makeNumber("a", data.Labels{"instance": "a", "team": "db"}, nil),
+					B:      makeNumber("b", data.Labels{"instance": "a", "cpu": "0"}, nil),
+				},
+			},
+		},
+		{
+			name:     "many-to-one without group_left is an error",
+			matching: &parse.VectorMatching{On: true, Labels: []string{"instance"}},
+			aResults: Results{Values: Values{
+				makeNumber("a", data.Labels{"instance": "a", "cpu": "0"}, nil),
+				makeNumber("b", data.Labels{"instance": "a", "cpu": "1"}, nil),
+			}},
+			bResults: Results{Values: Values{
+				makeNumber("c", data.Labels{"instance": "a"}, nil),
+			}},
+			errMsg: "2 items of $A in \"$A + on(instance) $B\" match the labels {instance=a}, use group_left or group_right",
+		},
+		{
+			name:     "no matches at all is an error listing the labels of both sides",
+			matching: &parse.VectorMatching{On: true, Labels: []string{"instance"}},
+			aResults: Results{Values: Values{
+				makeNumber("a", data.Labels{"instance": "a"}, nil),
+			}},
+			bResults: Results{Values: Values{
+				makeNumber("b", data.Labels{"host": "a"}, nil),
+			}},
+			errMsg: "no items matched in \"$A + on(instance) $B\": $A has {instance=a}, $B has {}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := parse.Parse("$A + $B")
+			require.NoError(t, err)
+			node := tree.Root.(*parse.BinaryNode)
+			node.Matching = tt.matching
+			s := &State{}
+			unions, err := s.matchUnion(tt.aResults, tt.bResults, node)
+			if tt.errMsg != "" {
+				assert.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.unions, unions)
+			assert.Equal(t, tt.drops, s.DropCount)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package mathexp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// rate returns the per-second rate of increase between consecutive points of each series.
+// A decrease of the value is treated as a counter reset. The first point of each series is dropped.
+func rate(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "rate", varSet, func(points []seriesPoint) []seriesPoint {
+		out := make([]seriesPoint, 0, len(points))
+		for i := 1; i < len(points); i++ {
+			prev, cur := points[i-1], points[i]
+			dt := cur.t.Sub(prev.t).Seconds()
+			if dt <= 0 {
+				continue
+			}
+			if prev.v == nil || cur.v == nil {
+				out = append(out, seriesPoint{t: cur.t})
+				continue
+			}
+			inc := *cur.v - *prev.v
+			if inc < 0 {
+				inc = *cur.v
+			}
+			r := inc / dt
+			out = append(out, seriesPoint{t: cur.t, v: &r})
+		}
+		return out
+	})
+}
+
+// delta returns the difference between consecutive points of each series.
+// The first point of each series is dropped.
+func delta(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "delta", varSet, func(points []seriesPoint) []seriesPoint {
+		out := make([]seriesPoint, 0, len(points))
+		for i := 1; i < len(points); i++ {
+			prev, cur := points[i-1], points[i]
+			if prev.v == nil || cur.v == nil {
+				out = append(out, seriesPoint{t: cur.t})
+				continue
+			}
+			d := *cur.v - *prev.v
+			out = append(out, seriesPoint{t: cur.t, v: &d})
+		}
+		return out
+	})
+}
+
+// shift moves every point of each series forward in time by d, so that the series
+// can be compared with itself at an earlier time, e.g. $A / shift($A, 1d).
+func shift(e *State, varSet Results, d time.Duration) (Results, error) {
+	return perSeries(e, "shift", varSet, func(points []seriesPoint) []seriesPoint {
+		out := make([]seriesPoint, len(points))
+		for i, p := range points {
+			out[i] = seriesPoint{t: p.t.Add(d), v: p.v}
+		}
+		return out
+	})
+}
+
+// movingAvg returns for every point of each series the average of the points within the window ending at that point.
+// Null and NaN values are ignored, and null is returned when there are no values in the window.
+func movingAvg(e *State, varSet Results, window time.Duration) (Results, error) {
+	return perSeries(e, "moving_avg", varSet, func(points []seriesPoint) []seriesPoint {
+		out := make([]seriesPoint, len(points))
+		sum, count, start := 0.0, 0, 0
+		for i, p := range points {
+			if isWindowValue(p.v) {
+				sum += *p.v
+				count++
+			}
+			for ; !points[start].t.After(p.t.Add(-window)); start++ {
+				if isWindowValue(points[start].v) {
+					sum -= *points[start].v
+					count--
+				}
+			}
+			out[i] = seriesPoint{t: p.t}
+			if count > 0 {
+				avg := sum / float64(count)
+				out[i].v = &avg
+			}
+		}
+		return out
+	})
+}
+
+// cumsum returns the cumulative sum of each series.
+// Null and NaN values are returned unchanged and do not contribute to the sum.
+func cumsum(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "cumsum", varSet, func(points []seriesPoint) []seriesPoint {
+		out := make([]seriesPoint, len(points))
+		sum := 0.0
+		for i, p := range points {
+			out[i] = seriesPoint{t: p.t, v: p.v}
+			if isWindowValue(p.v) {
+				sum += *p.v
+				s := sum
+				out[i].v = &s
+			}
+		}
+		return out
+	})
+}
+
+type seriesPoint struct {
+	t time.Time
+	v *float64
+}
+
+// perSeries passes the points of each series in varSet, sorted by time, to seriesF and
+// returns the resulting points as a new series with the same labels.
+// NoData is passed through, while numbers and scalars are an error since they have no time dimension.
+func perSeries(e *State, name string, varSet Results, seriesF func(points []seriesPoint) []seriesPoint) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		switch v := res.(type) {
+		case Series:
+			points := make([]seriesPoint, v.Len())
+			for i := range points {
+				points[i].t, points[i].v = v.GetPoint(i)
+			}
+			sort.SliceStable(points, func(i, j int) bool {
+				return points[i].t.Before(points[j].t)
+			})
+			newPoints := seriesF(points)
+			newSeries := NewSeries(e.RefID, v.GetLabels(), len(newPoints))
+			for i, p := range newPoints {
+				newSeries.SetPoint(i, p.t, p.v)
+			}
+			newRes.Values = append(newRes.Values, newSeries)
+		case NoData:
+			newRes.Values = append(newRes.Values, NewNoData())
+		default:
+			return newRes, fmt.Errorf("%s can only be applied to a series, got %s", name, res.Type())
+		}
+	}
+	return newRes, nil
+}
+
+func isWindowValue(f *float64) bool {
+	return f != nil && !math.IsNaN(*f)
+}
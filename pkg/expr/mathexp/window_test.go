@@ -0,0 +1,174 @@
+package mathexp
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+func TestWindowFuncs(t *testing.T) {
+	counter := Vars{
+		"A": resultValuesNoErr(
+			makeSeries("", data.Labels{"host": "a"},
+				tp{time.Unix(20, 0), float64Pointer(30)},
+				tp{time.Unix(0, 0), float64Pointer(0)},
+				tp{time.Unix(10, 0), float64Pointer(10)},
+				tp{time.Unix(30, 0), float64Pointer(5)},
+			),
+		),
+	}
+
+	var tests = []struct {
+		name      string
+		expr      string
+		vars      Vars
+		execErrIs require.ErrorAssertionFunc
+		results   Results
+	}{
+		{
+			name:      "rate handles unsorted input and counter resets",
+			expr:      "rate($A)",
+			vars:      counter,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(10, 0), float64Pointer(1)},
+					tp{time.Unix(20, 0), float64Pointer(2)},
+					tp{time.Unix(30, 0), float64Pointer(0.5)},
+				),
+			),
+		},
+		{
+			name:      "delta",
+			expr:      "delta($A)",
+			vars:      counter,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(10, 0), float64Pointer(10)},
+					tp{time.Unix(20, 0), float64Pointer(20)},
+					tp{time.Unix(30, 0), float64Pointer(-25)},
+				),
+			),
+		},
+		{
+			name:      "shift",
+			expr:      "shift($A, 1h)",
+			vars:      counter,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(3600, 0), float64Pointer(0)},
+					tp{time.Unix(3610, 0), float64Pointer(10)},
+					tp{time.Unix(3620, 0), float64Pointer(30)},
+					tp{time.Unix(3630, 0), float64Pointer(5)},
+				),
+			),
+		},
+		{
+			name:      "moving_avg",
+			expr:      "moving_avg($A, 20s)",
+			vars:      counter,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(0, 0), float64Pointer(0)},
+					tp{time.Unix(10, 0), float64Pointer(5)},
+					tp{time.Unix(20, 0), float64Pointer(20)},
+					tp{time.Unix(30, 0), float64Pointer(17.5)},
+				),
+			),
+		},
+		{
+			name:      "cumsum",
+			expr:      "cumsum($A)",
+			vars:      counter,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(0, 0), float64Pointer(0)},
+					tp{time.Unix(10, 0), float64Pointer(10)},
+					tp{time.Unix(20, 0), float64Pointer(40)},
+					tp{time.Unix(30, 0), float64Pointer(45)},
+				),
+			),
+		},
+		{
+			name: "null values",
+			expr: "cumsum(delta($A))",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(0, 0), float64Pointer(1)},
+						tp{time.Unix(10, 0), nil},
+						tp{time.Unix(20, 0), float64Pointer(3)},
+						tp{time.Unix(30, 0), float64Pointer(6)},
+					),
+				),
+			},
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(10, 0), nil},
+					tp{time.Unix(20, 0), nil},
+					tp{time.Unix(30, 0), float64Pointer(3)},
+				),
+			),
+		},
+		{
+			name:      "compare with the past keeps the order of the left side",
+			expr:      "$A / shift($A, 10s)",
+			vars:      counter,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(20, 0), float64Pointer(3)},
+					tp{time.Unix(10, 0), float64Pointer(math.Inf(1))},
+					tp{time.Unix(30, 0), float64Pointer(5.0 / 30)},
+				),
+			),
+		},
+		{
+			name: "no data is passed through",
+			expr: "rate($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(NewNoData()),
+			},
+			execErrIs: require.NoError,
+			results:   resultValuesNoErr(NewNoData()),
+		},
+		{
+			name: "numbers are an error",
+			expr: "rate($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+			},
+			execErrIs: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			require.NoError(t, err)
+			res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+			tt.execErrIs(t, err)
+			if tt.results.Values != nil {
+				require.Equal(t, tt.results, res)
+			}
+		})
+	}
+}
+
+func TestWindowFuncsParse(t *testing.T) {
+	for _, expr := range []string{"shift(1, 1h)", "moving_avg($A)", "rate($A, 5m)", `shift($A, "1h")`} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := New(expr)
+			require.Error(t, err)
+		})
+	}
+}
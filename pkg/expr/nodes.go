@@ -151,6 +151,8 @@ func buildCMDNode(ctx context.Context, rn *rawNode, toggles featuremgmt.FeatureT
 		node.Command, err = UnmarshalThresholdCommand(rn)
 	case TypeSQL:
 		node.Command, err = UnmarshalSQLCommand(ctx, rn, cfg)
+	case TypeForecast:
+		node.Command, err = UnmarshalForecastCommand(rn)
 	default:
 		return nil, fmt.Errorf("expression command type '%v' in expression '%v' not implemented", commandType, rn.RefID)
 	}
@@ -262,6 +264,11 @@ func executeDSNodesGrouped(ctx context.Context, now time.Time, vars mathexp.Vars
 			defer span.End()
 
 			firstNode := nodeGroup[0]
+			start := time.Now()
+			defer func() {
+				recordNodeDuration(ctx, firstNode.refID, time.Since(start))
+			}()
+
 			logger := logger.FromContext(ctx).New("datasourceType", firstNode.datasource.Type,
 				"queryRefId", firstNode.refID,
 				"datasourceUid", firstNode.datasource.UID,
@@ -29,6 +29,9 @@ const (
 
 	// SQL query
 	QueryTypeSQL QueryType = "sql"
+
+	// Forecast query results
+	QueryTypeForecast QueryType = "forecast"
 )
 
 type MathQuery struct {
@@ -62,6 +65,26 @@ type ResampleQuery struct {
 	Upsampler mathexp.Upsampler `json:"upsampler"`
 }
 
+type ForecastQuery struct {
+	// Reference to single query result
+	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
+
+	// The forecast method
+	Method mathexp.ForecastMethod `json:"method"`
+
+	// How far after the last point to predict values
+	Horizon string `json:"horizon" jsonschema:"minLength=1,example=4h,example=1d"`
+
+	// The probability that a value falls within the confidence band (default 0.95)
+	ConfidenceLevel *float64 `json:"confidenceLevel,omitempty"`
+
+	// Smoothing factor of the level for the holt method (default 0.5)
+	Alpha *float64 `json:"alpha,omitempty"`
+
+	// Smoothing factor of the trend for the holt method (default 0.1)
+	Beta *float64 `json:"beta,omitempty"`
+}
+
 type ThresholdQuery struct {
 	// Reference to single query result
 	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
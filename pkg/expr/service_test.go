@@ -150,6 +150,45 @@ func TestDSQueryError(t *testing.T) {
 	require.Equal(t, fp(42), res.Responses["C"].Frames[0].Fields[0].At(0))
 }
 
+func TestExecutePipelineWithNodeDurations(t *testing.T) {
+	resp := map[string]backend.DataResponse{
+		"A": {Frames: data.Frames{data.NewFrame("test",
+			data.NewField("time", nil, []time.Time{time.Unix(1, 0)}),
+			data.NewField("value", nil, []*float64{fp(2)}),
+		)}},
+	}
+
+	queries := []Query{
+		{
+			RefID: "A",
+			DataSource: &datasources.DataSource{
+				OrgID: 1,
+				UID:   "test",
+				Type:  "test",
+			},
+			JSON: json.RawMessage(`{ "datasource": { "uid": "1" }, "intervalMs": 1000, "maxDataPoints": 1000 }`),
+		},
+		{
+			RefID:      "B",
+			DataSource: dataSourceModel(),
+			JSON:       json.RawMessage(`{ "datasource": { "uid": "__expr__", "type": "__expr__"}, "type": "math", "expression": "$A * 2" }`),
+		},
+	}
+
+	s, req := newMockQueryService(resp, queries)
+
+	pl, err := s.BuildPipeline(t.Context(), req)
+	require.NoError(t, err)
+
+	ctx, durations := WithNodeDurations(context.Background())
+	_, err = s.ExecutePipeline(ctx, time.Now(), pl)
+	require.NoError(t, err)
+
+	// Only data source queries are recorded.
+	require.Len(t, durations, 1)
+	require.Contains(t, durations, "A")
+}
+
 func TestParseError(t *testing.T) {
 	resp := map[string]backend.DataResponse{}
 
@@ -338,6 +338,10 @@ var wireBasicSet = wire.NewSet(
 	wire.Bind(new(secrets.Store), new(*secretsDatabase.SecretsStoreImpl)),
 	secretsgarbagecollectionworker.ProvideWorker,
 	grafanads.ProvideService,
+	ngstore.ProvideRecordedSamplesReader,
+	wire.Bind(new(grafanads.RecordedSamplesReader), new(*ngstore.RecordedSamplesReader)),
+	live.ProvideChannelHistory,
+	wire.Bind(new(grafanads.LiveHistoryReader), new(*live.ChannelHistory)),
 	wire.Bind(new(dashboardsnapshots.Store), new(*dashsnapstore.DashboardSnapshotStore)),
 	dashsnapstore.ProvideStore,
 	wire.Bind(new(dashboardsnapshots.Service), new(*dashsnapsvc.ServiceImpl)),
@@ -42,6 +42,7 @@ import (
 
 type AlertRuleService interface {
 	CleanUpDeletedAlertRules(ctx context.Context) (int64, error)
+	CleanUpRecordedSamples(ctx context.Context) (int64, error)
 }
 
 type CleanUpService struct {
@@ -147,6 +148,10 @@ func (srv *CleanUpService) clean(ctx context.Context) {
 		cleanupJobs = append(cleanupJobs, cleanUpJob{"cleanup trash alert rules", srv.cleanUpTrashAlertRules})
 	}
 
+	if srv.Cfg.UnifiedAlerting.RecordingRules.LocalStorageEnabled && srv.Cfg.UnifiedAlerting.RecordingRules.LocalStorageRetention > 0 {
+		cleanupJobs = append(cleanupJobs, cleanUpJob{"delete expired recorded samples", srv.deleteExpiredRecordedSamples})
+	}
+
 	logger := srv.log.FromContext(ctx)
 	logger.Debug("Starting cleanup jobs", "jobs", fmt.Sprintf("%v", cleanupJobs))
 
@@ -651,3 +656,13 @@ func (srv *CleanUpService) updateDataSourceLBACRules(ctx context.Context, ds *da
 	_, err := srv.dataSourceService.UpdateDataSource(ctx, updateCmd)
 	return err
 }
+
+func (srv *CleanUpService) deleteExpiredRecordedSamples(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	affected, err := srv.alertRuleService.CleanUpRecordedSamples(ctx)
+	if err != nil {
+		logger.Error("Problem deleting expired recorded samples", "error", err)
+	} else {
+		logger.Debug("Deleted expired recorded samples", "rows affected", affected)
+	}
+}
@@ -5,35 +5,78 @@ import (
 	"fmt"
 
 	"github.com/grafana/grafana/pkg/services/live/telemetry"
+	"github.com/grafana/grafana/pkg/services/live/telemetry/otlp"
+	"github.com/grafana/grafana/pkg/services/live/telemetry/remotewrite"
 	"github.com/grafana/grafana/pkg/services/live/telemetry/telegraf"
 )
 
+// Supported push input formats.
+const (
+	InputFormatInflux      = "influx"
+	InputFormatOTLP        = "otlp"
+	InputFormatRemoteWrite = "prometheus_remote_write"
+)
+
+type frameConverters struct {
+	wide         telemetry.Converter
+	labelsColumn telemetry.Converter
+}
+
 type Converter struct {
-	telegrafConverterWide         *telegraf.Converter
-	telegrafConverterLabelsColumn *telegraf.Converter
+	converters map[string]frameConverters
 }
 
 func NewConverter() *Converter {
 	return &Converter{
-		telegrafConverterWide: telegraf.NewConverter(
-			telegraf.WithFloat64Numbers(true),
-		),
-		telegrafConverterLabelsColumn: telegraf.NewConverter(
-			telegraf.WithUseLabelsColumn(true),
-			telegraf.WithFloat64Numbers(true),
-		),
+		converters: map[string]frameConverters{
+			InputFormatInflux: {
+				wide: telegraf.NewConverter(
+					telegraf.WithFloat64Numbers(true),
+				),
+				labelsColumn: telegraf.NewConverter(
+					telegraf.WithUseLabelsColumn(true),
+					telegraf.WithFloat64Numbers(true),
+				),
+			},
+			InputFormatOTLP: {
+				wide: otlp.NewConverter(
+					telegraf.WithFloat64Numbers(true),
+				),
+				labelsColumn: otlp.NewConverter(
+					telegraf.WithUseLabelsColumn(true),
+					telegraf.WithFloat64Numbers(true),
+				),
+			},
+			InputFormatRemoteWrite: {
+				wide: remotewrite.NewConverter(
+					telegraf.WithFloat64Numbers(true),
+				),
+				labelsColumn: remotewrite.NewConverter(
+					telegraf.WithUseLabelsColumn(true),
+					telegraf.WithFloat64Numbers(true),
+				),
+			},
+		},
 	}
 }
 
-var ErrUnsupportedFrameFormat = errors.New("unsupported frame format")
+var (
+	ErrUnsupportedInputFormat = errors.New("unsupported input format")
+	ErrUnsupportedFrameFormat = errors.New("unsupported frame format")
+)
+
+func (c *Converter) Convert(data []byte, inputFormat string, frameFormat string) ([]telemetry.FrameWrapper, error) {
+	converters, ok := c.converters[inputFormat]
+	if !ok {
+		return nil, ErrUnsupportedInputFormat
+	}
 
-func (c *Converter) Convert(data []byte, frameFormat string) ([]telemetry.FrameWrapper, error) {
 	var converter telemetry.Converter
 	switch frameFormat {
 	case "wide":
-		converter = c.telegrafConverterWide
+		converter = converters.wide
 	case "labels_column":
-		converter = c.telegrafConverterLabelsColumn
+		converter = converters.labelsColumn
 	default:
 		return nil, ErrUnsupportedFrameFormat
 	}
@@ -0,0 +1,99 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana-plugin-sdk-go/live"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/live/model"
+)
+
+var (
+	ErrHistoryNotAvailable     = errors.New("live channel history is not available")
+	ErrHistoryNotStreamChannel = errors.New("history is only available for stream channels")
+	ErrHistoryPermissionDenied = errors.New("not allowed to subscribe to channel")
+)
+
+// ChannelHistory reads the history of managed stream channels for services that
+// Live itself depends on, such as the Grafana data source. GrafanaLive attaches
+// itself when it is created.
+type ChannelHistory struct {
+	mu   sync.RWMutex
+	live *GrafanaLive
+}
+
+func ProvideChannelHistory() *ChannelHistory {
+	return &ChannelHistory{}
+}
+
+func (h *ChannelHistory) setLive(g *GrafanaLive) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.live = g
+}
+
+// GetChannelHistory returns the frames kept for a stream channel since the given
+// time merged into a single frame. The user must be allowed to subscribe to the
+// channel. Returns false if the channel has no history.
+func (h *ChannelHistory) GetChannelHistory(ctx context.Context, user identity.Requester, channel string, since time.Time) (*data.Frame, bool, error) {
+	h.mu.RLock()
+	g := h.live
+	h.mu.RUnlock()
+	if g == nil || g.ManagedStreamRunner == nil {
+		return nil, false, ErrHistoryNotAvailable
+	}
+
+	addr, err := live.ParseChannel(channel)
+	if err != nil {
+		return nil, false, err
+	}
+	if addr.Scope != live.ScopeStream {
+		return nil, false, ErrHistoryNotStreamChannel
+	}
+
+	ok, err := g.canSubscribe(ctx, user, channel)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, ErrHistoryPermissionDenied
+	}
+	return g.ManagedStreamRunner.GetHistoryFrame(ctx, user.GetNamespace(), channel, since)
+}
+
+// canSubscribe checks if the user is allowed to subscribe to the channel the
+// same way as handleOnSubscribe: with the subscribe auth of a matching channel
+// rule, or with the channel handler otherwise.
+func (g *GrafanaLive) canSubscribe(ctx context.Context, user identity.Requester, channel string) (bool, error) {
+	if g.Pipeline != nil {
+		rule, ok, err := g.Pipeline.Get(user.GetNamespace(), channel)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			if rule.SubscribeAuth == nil {
+				return true, nil
+			}
+			return rule.SubscribeAuth.CanSubscribe(ctx, user)
+		}
+	}
+
+	handler, addr, err := g.GetChannelHandler(ctx, user, channel)
+	if err != nil {
+		return false, err
+	}
+	_, status, err := handler.OnSubscribe(ctx, user, model.SubscribeEvent{
+		Channel: channel,
+		Path:    addr.Path,
+	})
+	if err != nil {
+		return false, err
+	}
+	return status == backend.SubscribeStreamStatusOK, nil
+}
@@ -45,6 +45,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/live/orgchannel"
 	"github.com/grafana/grafana/pkg/services/live/pipeline"
 	"github.com/grafana/grafana/pkg/services/live/pushws"
+	"github.com/grafana/grafana/pkg/services/live/ratelimit"
 	"github.com/grafana/grafana/pkg/services/live/runstream"
 	"github.com/grafana/grafana/pkg/services/live/survey"
 	"github.com/grafana/grafana/pkg/services/org"
@@ -73,7 +74,7 @@ func ProvideService(cfg *setting.Cfg, routeRegister routing.RouteRegister, plugC
 	pluginStore pluginstore.Store, pluginClient plugins.Client, dataSourceCache datasources.CacheService,
 	usageStatsService usagestats.Service, toggles featuremgmt.FeatureToggles,
 	dashboardService dashboards.DashboardAccessService,
-	configProvider apiserver.RestConfigProvider) (*GrafanaLive, error) {
+	configProvider apiserver.RestConfigProvider, channelHistory *ChannelHistory) (*GrafanaLive, error) {
 	g := &GrafanaLive{
 		Cfg:                   cfg,
 		Features:              toggles,
@@ -82,6 +83,7 @@ func ProvideService(cfg *setting.Cfg, routeRegister routing.RouteRegister, plugC
 		pluginClient:          pluginClient,
 		DataSourceCache:       dataSourceCache,
 		channels:              make(map[string]model.ChannelHandler),
+		ruleSubscriptions:     make(map[ruleSubscriptionKey]ruleSubscription),
 		GrafanaScope: CoreGrafanaScope{
 			Features: make(map[string]model.ChannelHandlerFactory),
 		},
@@ -145,22 +147,38 @@ func ProvideService(cfg *setting.Cfg, routeRegister routing.RouteRegister, plugC
 		}
 	}
 
+	historyConfig := managedstream.HistoryConfig{
+		MaxFrames: g.Cfg.LiveManagedStreamHistoryMaxFrames,
+		MaxAge:    g.Cfg.LiveManagedStreamHistoryMaxAge,
+	}
 	if redisClient != nil {
 		managedStreamRunner = managedstream.NewRunner(
 			g.Publish,
 			channelLocalPublisher,
-			managedstream.NewRedisFrameCache(redisClient, g.keyPrefix),
+			managedstream.NewRedisFrameCache(redisClient, g.keyPrefix, historyConfig),
 		)
 	} else {
 		managedStreamRunner = managedstream.NewRunner(
 			g.Publish,
 			channelLocalPublisher,
-			managedstream.NewMemoryFrameCache(),
+			managedstream.NewMemoryFrameCache(historyConfig),
 		)
 	}
 
 	g.ManagedStreamRunner = managedStreamRunner
 
+	g.PublishLimiter = ratelimit.NewPublishLimiter(ratelimit.Limits{
+		MessagesPerSecond: g.Cfg.LiveOrgPublishMessagesPerSecond,
+		BytesPerSecond:    g.Cfg.LiveOrgPublishBytesPerSecond,
+		Policy:            ratelimit.Policy(g.Cfg.LiveOrgPublishRateLimitPolicy),
+	})
+	if g.Pipeline != nil {
+		g.Pipeline.SetPublishLimiter(g.PublishLimiter)
+	}
+	// The Live pipeline is not constructed at the moment, so the pool is only
+	// used by rules built for the convert dry run.
+	g.brokerPool = pipeline.NewBrokerPool(g.IsHA())
+
 	g.contextGetter = liveplugin.NewContextGetter(g.PluginContextProvider, g.DataSourceCache)
 	pipelinedChannelLocalPublisher := liveplugin.NewChannelLocalPublisher(node, g.Pipeline)
 	numLocalSubscribersGetter := liveplugin.NewNumLocalSubscribersGetter(node)
@@ -298,14 +316,14 @@ func ProvideService(cfg *setting.Cfg, routeRegister routing.RouteRegister, plugC
 			}
 		})
 
-		// We don't need to do anything on unsubscribe, but we create tracing span with channel name.
 		client.OnUnsubscribe(func(e centrifuge.UnsubscribeEvent) {
-			_, span := tracer.Start(client.Context(), "live.OnUnsubscribe")
+			ctx, span := tracer.Start(client.Context(), "live.OnUnsubscribe")
 			defer span.End()
 
 			span.SetAttributes(
 				attribute.String("channel", e.Channel),
 			)
+			g.handleOnUnsubscribe(ctx, client, e)
 		})
 
 		client.OnDisconnect(func(e centrifuge.DisconnectEvent) {
@@ -343,7 +361,7 @@ func ProvideService(cfg *setting.Cfg, routeRegister routing.RouteRegister, plugC
 	// Use a pure websocket transport.
 	wsHandler := centrifuge.NewWebsocketHandler(node, wsCfg)
 
-	pushWSHandler := pushws.NewHandler(g.ManagedStreamRunner, pushws.Config{
+	pushWSHandler := pushws.NewHandler(g.ManagedStreamRunner, g.PublishLimiter, pushws.Config{
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
 		CheckOrigin:     checkOrigin,
@@ -395,6 +413,10 @@ func ProvideService(cfg *setting.Cfg, routeRegister routing.RouteRegister, plugC
 
 	g.registerUsageMetrics()
 
+	if channelHistory != nil {
+		channelHistory.setLive(g)
+	}
+
 	return g, nil
 }
 
@@ -470,7 +492,14 @@ type GrafanaLive struct {
 
 	ManagedStreamRunner *managedstream.Runner
 	Pipeline            *pipeline.Pipeline
+	PublishLimiter      *ratelimit.PublishLimiter
 	pipelineStorage     pipeline.Storage
+	brokerPool          *pipeline.BrokerPool
+
+	// Channel rule subscribers that clients subscribed with, so that exactly
+	// those are unsubscribed even if the rule changes in the meantime.
+	ruleSubscriptions   map[ruleSubscriptionKey]ruleSubscription
+	ruleSubscriptionsMu sync.Mutex
 
 	contextGetter    *liveplugin.ContextGetter
 	runStreamManager *runstream.Manager
@@ -714,19 +743,25 @@ func (g *GrafanaLive) handleOnSubscribe(clientContextWithSpan context.Context, c
 			}
 			if len(rule.Subscribers) > 0 {
 				var err error
-				for _, sub := range rule.Subscribers {
-					reply, status, err = sub.Subscribe(clientContextWithSpan, pipeline.Vars{
-						NS:      ns,
-						Channel: channel,
-					}, e.Data)
+				vars := pipelineVars(ns, channel)
+				for i, sub := range rule.Subscribers {
+					reply, status, err = sub.Subscribe(clientContextWithSpan, vars, e.Data)
 					if err != nil {
 						logger.Error("Error channel rule subscribe", "user", client.UserID(), "client", client.ID(), "channel", e.Channel, "error", err)
+						// The client is not subscribed, so it will not unsubscribe.
+						unsubscribeRule(clientContextWithSpan, ruleSubscription{vars: vars, subscribers: rule.Subscribers[:i]})
 						return centrifuge.SubscribeReply{}, centrifuge.ErrorInternal
 					}
 					if status != backend.SubscribeStreamStatusOK {
+						unsubscribeRule(clientContextWithSpan, ruleSubscription{vars: vars, subscribers: rule.Subscribers[:i]})
 						break
 					}
 				}
+				if status == backend.SubscribeStreamStatusOK {
+					g.ruleSubscriptionsMu.Lock()
+					g.ruleSubscriptions[ruleSubscriptionKey{client: client.ID(), channel: e.Channel}] = ruleSubscription{vars: vars, subscribers: rule.Subscribers}
+					g.ruleSubscriptionsMu.Unlock()
+				}
 			}
 		}
 	}
@@ -771,6 +806,53 @@ func (g *GrafanaLive) handleOnSubscribe(clientContextWithSpan context.Context, c
 	}, nil
 }
 
+type ruleSubscriptionKey struct {
+	client  string
+	channel string
+}
+
+// ruleSubscription is the state of a channel rule a client subscribed with.
+type ruleSubscription struct {
+	vars        pipeline.Vars
+	subscribers []pipeline.Subscriber
+}
+
+// handleOnUnsubscribe lets the channel rule subscribers the client subscribed
+// with release resources taken on subscribe, for example message broker
+// subscriptions.
+func (g *GrafanaLive) handleOnUnsubscribe(ctx context.Context, client *centrifuge.Client, e centrifuge.UnsubscribeEvent) {
+	key := ruleSubscriptionKey{client: client.ID(), channel: e.Channel}
+	g.ruleSubscriptionsMu.Lock()
+	sub, ok := g.ruleSubscriptions[key]
+	delete(g.ruleSubscriptions, key)
+	g.ruleSubscriptionsMu.Unlock()
+	if ok {
+		unsubscribeRule(ctx, sub)
+	}
+}
+
+func unsubscribeRule(ctx context.Context, sub ruleSubscription) {
+	for _, s := range sub.subscribers {
+		if u, ok := s.(pipeline.Unsubscriber); ok {
+			u.Unsubscribe(ctx, sub.vars)
+		}
+	}
+}
+
+// pipelineVars returns variables of a channel rule for the channel.
+func pipelineVars(ns string, channel string) pipeline.Vars {
+	vars := pipeline.Vars{
+		NS:      ns,
+		Channel: channel,
+	}
+	if addr, err := live.ParseChannel(channel); err == nil {
+		vars.Scope = addr.Scope
+		vars.Stream = addr.Namespace
+		vars.Path = addr.Path
+	}
+	return vars
+}
+
 func (g *GrafanaLive) handleOnPublish(clientCtxWithSpan context.Context, client *centrifuge.Client, e centrifuge.PublishEvent) (centrifuge.PublishReply, error) {
 	logger.Debug("Client wants to publish", "user", client.UserID(), "client", client.ID(), "channel", e.Channel)
 
@@ -824,6 +906,9 @@ func (g *GrafanaLive) handleOnPublish(clientCtxWithSpan context.Context, client
 			}
 			_, err := g.Pipeline.ProcessInput(clientCtxWithSpan, ns.Value, channel, e.Data)
 			if err != nil {
+				if errors.Is(err, ratelimit.ErrRateLimited) {
+					return centrifuge.PublishReply{}, &centrifuge.Error{Code: uint32(http.StatusTooManyRequests), Message: http.StatusText(http.StatusTooManyRequests)}
+				}
 				logger.Error("Error processing input", "user", client.UserID(), "client", client.ID(), "channel", e.Channel, "error", err)
 				return centrifuge.PublishReply{}, centrifuge.ErrorInternal
 			}
@@ -842,6 +927,16 @@ func (g *GrafanaLive) handleOnPublish(clientCtxWithSpan context.Context, client
 		logger.Error("Error getting channel handler", "user", client.UserID(), "client", client.ID(), "channel", e.Channel, "error", err)
 		return centrifuge.PublishReply{}, centrifuge.ErrorInternal
 	}
+	switch g.PublishLimiter.Check(ns.Value, channel, ratelimit.Limits{}, len(e.Data), nil) {
+	case ratelimit.Rejected:
+		logger.Debug("Publish rate limit exceeded", "user", client.UserID(), "client", client.ID(), "channel", e.Channel)
+		return centrifuge.PublishReply{}, &centrifuge.Error{Code: uint32(http.StatusTooManyRequests), Message: http.StatusText(http.StatusTooManyRequests)}
+	case ratelimit.Dropped, ratelimit.Deferred:
+		// Non-nil result prevents Centrifuge from publishing.
+		return centrifuge.PublishReply{
+			Result: &centrifuge.PublishResult{},
+		}, nil
+	}
 	reply, status, err := handler.OnPublish(client.Context(), user, model.PublishEvent{
 		Channel: channel,
 		Path:    addr.Path,
@@ -1075,6 +1170,9 @@ func (g *GrafanaLive) HandleHTTPPublish(ctx *contextmodel.ReqContext) response.R
 			}
 			_, err := g.Pipeline.ProcessInput(ctx.Req.Context(), ns, channel, cmd.Data)
 			if err != nil {
+				if errors.Is(err, ratelimit.ErrRateLimited) {
+					return response.Error(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests), err)
+				}
 				logger.Error("Error processing input", "user", user, "channel", channel, "error", err)
 				return response.Error(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil)
 			}
@@ -1088,6 +1186,13 @@ func (g *GrafanaLive) HandleHTTPPublish(ctx *contextmodel.ReqContext) response.R
 		return response.Error(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil)
 	}
 
+	switch g.PublishLimiter.Check(ns, channel, ratelimit.Limits{}, len(cmd.Data), nil) {
+	case ratelimit.Rejected:
+		return response.Error(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests), ratelimit.ErrRateLimited)
+	case ratelimit.Dropped, ratelimit.Deferred:
+		return response.JSON(http.StatusOK, model.LivePublishResponse{})
+	}
+
 	reply, status, err := channelHandler.OnPublish(ctx.Req.Context(), ctx.SignedInUser, model.PublishEvent{Channel: cmd.Channel, Path: addr.Path, Data: cmd.Data})
 	if err != nil {
 		logger.Error("Error calling OnPublish", "error", err, "channel", cmd.Channel)
@@ -1219,12 +1324,14 @@ func (g *GrafanaLive) HandlePipelineConvertTestHTTP(c *contextmodel.ReqContext)
 		FrameStorage:         pipeline.NewFrameStorage(),
 		Storage:              storage,
 		ChannelHandlerGetter: g,
+		Brokers:              g.brokerPool,
 	}
 	channelRuleGetter := pipeline.NewCacheSegmentedTree(builder)
 	pipe, err := pipeline.New(channelRuleGetter)
 	if err != nil {
 		return response.Error(http.StatusInternalServerError, "Error creating pipeline", err)
 	}
+	builder.InputProcessor = pipe
 	rule, ok, err := channelRuleGetter.Get(c.GetNamespace(), req.Channel)
 	if err != nil {
 		return response.Error(http.StatusInternalServerError, "Error getting channel rule", err)
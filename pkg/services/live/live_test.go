@@ -346,7 +346,7 @@ func setupLiveService(cfg *setting.Cfg, t *testing.T) (*GrafanaLive, error) {
 		&usagestats.UsageStatsMock{T: t},
 		featuremgmt.WithFeatures(),
 		&dashboards.FakeDashboardService{},
-		nil)
+		nil, nil)
 }
 
 type dummyTransport struct {
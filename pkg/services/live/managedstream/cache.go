@@ -3,6 +3,7 @@ package managedstream
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
@@ -13,6 +14,9 @@ type FrameCache interface {
 	GetActiveChannels(ns string) (map[string]json.RawMessage, error)
 	// GetFrame returns full JSON frame for a channel in org.
 	GetFrame(ctx context.Context, ns string, channel string) (json.RawMessage, bool, error)
+	// GetHistory returns JSON frames pushed to a channel in org since the given time, oldest first.
+	// Returns nothing when history is disabled.
+	GetHistory(ctx context.Context, ns string, channel string, since time.Time) ([]json.RawMessage, error)
 	// Update updates frame cache and returns true if schema changed.
 	Update(ctx context.Context, ns string, channel string, frameJson data.FrameJSONCache) (bool, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
@@ -12,16 +13,20 @@ import (
 
 // MemoryFrameCache ...
 type MemoryFrameCache struct {
-	mu     sync.RWMutex
-	frames map[string]map[string]data.FrameJSONCache
-	log    log.Logger
+	mu      sync.RWMutex
+	frames  map[string]map[string]data.FrameJSONCache
+	history map[string]map[string]*frameRing
+	config  HistoryConfig
+	log     log.Logger
 }
 
 // NewMemoryFrameCache ...
-func NewMemoryFrameCache() *MemoryFrameCache {
+func NewMemoryFrameCache(config HistoryConfig) *MemoryFrameCache {
 	return &MemoryFrameCache{
-		frames: map[string]map[string]data.FrameJSONCache{},
-		log:    log.New("live.memoryframecache"),
+		frames:  map[string]map[string]data.FrameJSONCache{},
+		history: map[string]map[string]*frameRing{},
+		config:  config,
+		log:     log.New("live.memoryframecache"),
 	}
 }
 
@@ -52,6 +57,16 @@ func (c *MemoryFrameCache) GetFrame(ctx context.Context, ns string, channel stri
 	return raw, ok, nil
 }
 
+func (c *MemoryFrameCache) GetHistory(_ context.Context, ns string, channel string, since time.Time) ([]json.RawMessage, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ring, ok := c.history[ns][channel]
+	if !ok {
+		return nil, nil
+	}
+	return ring.since(c.config.minTime(time.Now(), since)), nil
+}
+
 func (c *MemoryFrameCache) Update(ctx context.Context, ns string, channel string, jsonFrame data.FrameJSONCache) (bool, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -61,6 +76,17 @@ func (c *MemoryFrameCache) Update(ctx context.Context, ns string, channel string
 	cachedJsonFrame, exists := c.frames[ns][channel]
 	schemaUpdated := !exists || !cachedJsonFrame.SameSchema(&jsonFrame)
 	c.frames[ns][channel] = jsonFrame
+	if c.config.Enabled() {
+		if _, ok := c.history[ns]; !ok {
+			c.history[ns] = map[string]*frameRing{}
+		}
+		ring, ok := c.history[ns][channel]
+		if !ok {
+			ring = newFrameRing(c.config.maxFrames())
+			c.history[ns][channel] = ring
+		}
+		ring.push(time.Now(), jsonFrame.Bytes(data.IncludeAll))
+	}
 	c.log.Debug("Cache update",
 		"ns", ns,
 		"channel", channel,
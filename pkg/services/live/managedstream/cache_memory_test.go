@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -63,8 +64,52 @@ func testFrameCache(t *testing.T, c FrameCache) {
 	require.NotEqual(t, string(channels["test"]), string(schema))
 }
 
+func testFrameCacheHistory(t *testing.T, c FrameCache) {
+	for i := 0; i < 3; i++ {
+		frame := data.NewFrame("hello", data.NewField("value", nil, []int64{int64(i)}))
+		frameJsonCache, err := data.FrameToJSONCache(frame)
+		require.NoError(t, err)
+		_, err = c.Update(context.Background(), "default", "history", frameJsonCache)
+		require.NoError(t, err)
+	}
+
+	// Only the last 2 frames are kept, oldest first.
+	frames, err := c.GetHistory(context.Background(), "default", "history", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+
+	merged, err := mergeFrames(frames)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, []int64{merged.Fields[0].At(0).(int64), merged.Fields[0].At(1).(int64)})
+
+	// Frames are returned only since the requested time.
+	frames, err = c.GetHistory(context.Background(), "default", "history", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, frames)
+
+	// Other orgs do not see the history.
+	frames, err = c.GetHistory(context.Background(), "org-2", "history", time.Time{})
+	require.NoError(t, err)
+	require.Empty(t, frames)
+}
+
 func TestMemoryFrameCache(t *testing.T) {
-	c := NewMemoryFrameCache()
+	c := NewMemoryFrameCache(HistoryConfig{})
 	require.NotNil(t, c)
 	testFrameCache(t, c)
 }
+
+func TestMemoryFrameCacheHistory(t *testing.T) {
+	c := NewMemoryFrameCache(HistoryConfig{MaxFrames: 2})
+	testFrameCacheHistory(t, c)
+
+	// History is not kept when disabled.
+	c = NewMemoryFrameCache(HistoryConfig{})
+	frameJsonCache, err := data.FrameToJSONCache(data.NewFrame("hello"))
+	require.NoError(t, err)
+	_, err = c.Update(context.Background(), "default", "history", frameJsonCache)
+	require.NoError(t, err)
+	frames, err := c.GetHistory(context.Background(), "default", "history", time.Time{})
+	require.NoError(t, err)
+	require.Empty(t, frames)
+}
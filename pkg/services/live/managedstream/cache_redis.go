@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,14 +23,16 @@ type RedisFrameCache struct {
 	redisClient *redis.Client
 	frames      map[string]map[string]data.FrameJSONCache
 	keyPrefix   string
+	config      HistoryConfig
 }
 
 // NewRedisFrameCache ...
-func NewRedisFrameCache(redisClient *redis.Client, keyPrefix string) *RedisFrameCache {
+func NewRedisFrameCache(redisClient *redis.Client, keyPrefix string, config HistoryConfig) *RedisFrameCache {
 	return &RedisFrameCache{
 		keyPrefix:   keyPrefix,
 		frames:      map[string]map[string]data.FrameJSONCache{},
 		redisClient: redisClient,
+		config:      config,
 	}
 }
 
@@ -59,6 +63,32 @@ func (c *RedisFrameCache) GetFrame(ctx context.Context, ns string, channel strin
 	return json.RawMessage(result["frame"]), true, nil
 }
 
+// GetHistory reads frames from a sorted set scored by push time in milliseconds.
+func (c *RedisFrameCache) GetHistory(ctx context.Context, ns string, channel string, since time.Time) ([]json.RawMessage, error) {
+	if !c.config.Enabled() {
+		return nil, nil
+	}
+	key := c.getHistoryKey(orgchannel.PrependK8sNamespace(ns, channel))
+	minTime := c.config.minTime(time.Now(), since)
+	members, err := c.redisClient.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min: strconv.FormatInt(minTime.UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	frames := make([]json.RawMessage, 0, len(members))
+	for _, member := range members {
+		// Members are prefixed with push time to keep equal frames unique.
+		_, frame, ok := strings.Cut(member, " ")
+		if !ok {
+			continue
+		}
+		frames = append(frames, json.RawMessage(frame))
+	}
+	return frames, nil
+}
+
 const (
 	frameCacheTTL = 7 * 24 * time.Hour
 )
@@ -73,16 +103,21 @@ func (c *RedisFrameCache) Update(ctx context.Context, ns string, channel string,
 
 	stringSchema := string(jsonFrame.Bytes(data.IncludeSchemaOnly))
 
-	key := c.getCacheKey(orgchannel.PrependK8sNamespace(ns, channel))
+	channelID := orgchannel.PrependK8sNamespace(ns, channel)
+	key := c.getCacheKey(channelID)
+	frameData := string(jsonFrame.Bytes(data.IncludeAll))
 
 	var mapReply *redis.MapStringStringCmd
 	replies, err := c.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
 		mapReply = pipe.HGetAll(ctx, key)
 		pipe.HMSet(ctx, key, map[string]string{
 			"schema": stringSchema,
-			"frame":  string(jsonFrame.Bytes(data.IncludeAll)),
+			"frame":  frameData,
 		})
 		pipe.Expire(ctx, key, frameCacheTTL)
+		if c.config.Enabled() {
+			c.pushHistory(ctx, pipe, c.getHistoryKey(channelID), frameData)
+		}
 		return nil
 	})
 	if err != nil {
@@ -105,6 +140,24 @@ func (c *RedisFrameCache) Update(ctx context.Context, ns string, channel string,
 	return result["schema"] != stringSchema, nil
 }
 
+func (c *RedisFrameCache) pushHistory(ctx context.Context, pipe redis.Pipeliner, key string, frameData string) {
+	now := time.Now()
+	pipe.ZAdd(ctx, key, redis.Z{
+		Score:  float64(now.UnixMilli()),
+		Member: strconv.FormatInt(now.UnixNano(), 10) + " " + frameData,
+	})
+	if c.config.MaxAge > 0 {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", "("+strconv.FormatInt(now.Add(-c.config.MaxAge).UnixMilli(), 10))
+	}
+	// Keep only the last maxFrames members.
+	pipe.ZRemRangeByRank(ctx, key, 0, int64(-c.config.maxFrames()-1))
+	pipe.Expire(ctx, key, frameCacheTTL)
+}
+
+func (c *RedisFrameCache) getHistoryKey(channelID string) string {
+	return c.getCacheKey(channelID) + ".history"
+}
+
 func (c *RedisFrameCache) getCacheKey(channelID string) string {
 	return c.keyPrefix + ".managed_stream." + channelID
 }
@@ -36,9 +36,10 @@ func TestIntegrationRedisCacheStorage(t *testing.T) {
 
 	t.Cleanup(redisCleanup(t, redisClient, prefix))
 
-	c := NewRedisFrameCache(redisClient, prefix)
+	c := NewRedisFrameCache(redisClient, prefix, HistoryConfig{MaxFrames: 2})
 	require.NotNil(t, c)
 	testFrameCache(t, c)
+	testFrameCacheHistory(t, c)
 
 	keys, err := redisClient.Keys(t.Context(), "*").Result()
 	if err != nil {
@@ -0,0 +1,137 @@
+package managedstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// defaultHistoryMaxFrames bounds history which is configured by age only,
+// so a chatty channel can't grow it without limit.
+const defaultHistoryMaxFrames = 1000
+
+// HistoryConfig bounds the history of frames kept per managed stream channel.
+// History is disabled when both limits are zero, in which case only the
+// latest frame is kept.
+type HistoryConfig struct {
+	// MaxFrames is a maximum number of frames kept per channel.
+	MaxFrames int
+	// MaxAge is a maximum age of frames kept per channel.
+	MaxAge time.Duration
+}
+
+// Enabled returns true if frames history should be kept.
+func (c HistoryConfig) Enabled() bool {
+	return c.MaxFrames > 0 || c.MaxAge > 0
+}
+
+func (c HistoryConfig) maxFrames() int {
+	if c.MaxFrames > 0 {
+		return c.MaxFrames
+	}
+	return defaultHistoryMaxFrames
+}
+
+// minTime returns the oldest time of a frame which should be returned from
+// history when frames since the given time are requested.
+func (c HistoryConfig) minTime(now time.Time, since time.Time) time.Time {
+	if c.MaxAge > 0 && since.Before(now.Add(-c.MaxAge)) {
+		return now.Add(-c.MaxAge)
+	}
+	return since
+}
+
+type historyEntry struct {
+	time  time.Time
+	frame json.RawMessage
+}
+
+// frameRing is a fixed size ring buffer of frames pushed into a channel.
+type frameRing struct {
+	entries []historyEntry
+	next    int
+	size    int
+}
+
+func newFrameRing(capacity int) *frameRing {
+	return &frameRing{
+		entries: make([]historyEntry, capacity),
+	}
+}
+
+// push adds a frame to the buffer overwriting the oldest one when the buffer is full.
+func (r *frameRing) push(t time.Time, frame json.RawMessage) {
+	r.entries[r.next] = historyEntry{time: t, frame: frame}
+	r.next = (r.next + 1) % len(r.entries)
+	if r.size < len(r.entries) {
+		r.size++
+	}
+}
+
+// since returns frames pushed not before the given time, oldest first.
+func (r *frameRing) since(t time.Time) []json.RawMessage {
+	var frames []json.RawMessage
+	start := (r.next - r.size + len(r.entries)) % len(r.entries)
+	for i := 0; i < r.size; i++ {
+		e := r.entries[(start+i)%len(r.entries)]
+		if e.time.Before(t) {
+			continue
+		}
+		frames = append(frames, e.frame)
+	}
+	return frames
+}
+
+// mergeFrames combines JSON frames from history, oldest first, into a single
+// frame. Only the latest frames with the same schema as the last one are merged,
+// older frames were pushed before the schema changed and can't be combined.
+func mergeFrames(frames []json.RawMessage) (*data.Frame, error) {
+	if len(frames) == 0 {
+		return nil, nil
+	}
+	decoded := make([]*data.Frame, 0, len(frames))
+	for i := len(frames) - 1; i >= 0; i-- {
+		var f data.Frame
+		if err := json.Unmarshal(frames[i], &f); err != nil {
+			return nil, fmt.Errorf("error decoding frame from history: %w", err)
+		}
+		if len(decoded) > 0 && !sameSchema(decoded[0], &f) {
+			break
+		}
+		decoded = append(decoded, &f)
+	}
+
+	merged := decoded[0].EmptyCopy()
+	// EmptyCopy does not keep frame metadata and field configs.
+	merged.Meta = decoded[0].Meta
+	for i, field := range decoded[0].Fields {
+		merged.Fields[i].Config = field.Config
+	}
+	for i := len(decoded) - 1; i >= 0; i-- {
+		f := decoded[i]
+		rows, err := f.RowLen()
+		if err != nil {
+			return nil, err
+		}
+		for row := 0; row < rows; row++ {
+			merged.AppendRow(f.RowCopy(row)...)
+		}
+	}
+	return merged, nil
+}
+
+func sameSchema(a, b *data.Frame) bool {
+	if a.Name != b.Name || len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for i := range a.Fields {
+		if a.Fields[i].Name != b.Fields[i].Name ||
+			a.Fields[i].Type() != b.Fields[i].Type() ||
+			a.Fields[i].Labels.String() != b.Fields[i].Labels.String() {
+			return false
+		}
+	}
+	return true
+}
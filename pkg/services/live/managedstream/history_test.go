@@ -0,0 +1,70 @@
+package managedstream
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameRing(t *testing.T) {
+	now := time.Now()
+	r := newFrameRing(3)
+	require.Empty(t, r.since(time.Time{}))
+
+	for i := 0; i < 5; i++ {
+		r.push(now.Add(time.Duration(i)*time.Second), json.RawMessage{byte('0' + i)})
+	}
+	require.Equal(t, []json.RawMessage{{'2'}, {'3'}, {'4'}}, r.since(time.Time{}))
+	require.Equal(t, []json.RawMessage{{'3'}, {'4'}}, r.since(now.Add(3*time.Second)))
+}
+
+func TestHistoryConfig(t *testing.T) {
+	require.False(t, HistoryConfig{}.Enabled())
+	require.True(t, HistoryConfig{MaxFrames: 10}.Enabled())
+	require.True(t, HistoryConfig{MaxAge: time.Minute}.Enabled())
+	require.Equal(t, defaultHistoryMaxFrames, HistoryConfig{MaxAge: time.Minute}.maxFrames())
+
+	now := time.Now()
+	c := HistoryConfig{MaxAge: time.Minute}
+	require.Equal(t, now.Add(-time.Minute), c.minTime(now, time.Time{}))
+	require.Equal(t, now.Add(-time.Second), c.minTime(now, now.Add(-time.Second)))
+}
+
+func TestMergeFrames(t *testing.T) {
+	frameJSON := func(t *testing.T, f *data.Frame) json.RawMessage {
+		t.Helper()
+		b, err := data.FrameToJSON(f, data.IncludeAll)
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("empty history", func(t *testing.T) {
+		merged, err := mergeFrames(nil)
+		require.NoError(t, err)
+		require.Nil(t, merged)
+	})
+
+	t.Run("frames with the same schema are merged", func(t *testing.T) {
+		merged, err := mergeFrames([]json.RawMessage{
+			frameJSON(t, data.NewFrame("cpu", data.NewField("value", nil, []float64{1, 2}))),
+			frameJSON(t, data.NewFrame("cpu", data.NewField("value", nil, []float64{3}))),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, merged.Fields[0].Len())
+		require.Equal(t, 3.0, merged.Fields[0].At(2))
+	})
+
+	t.Run("frames before schema change are dropped", func(t *testing.T) {
+		merged, err := mergeFrames([]json.RawMessage{
+			frameJSON(t, data.NewFrame("cpu", data.NewField("value", nil, []float64{1}))),
+			frameJSON(t, data.NewFrame("cpu", data.NewField("value", data.Labels{"host": "a"}, []float64{2}))),
+			frameJSON(t, data.NewFrame("cpu", data.NewField("value", data.Labels{"host": "a"}, []float64{3}))),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, merged.Fields[0].Len())
+		require.Equal(t, 2.0, merged.Fields[0].At(0))
+	})
+}
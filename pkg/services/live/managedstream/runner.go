@@ -113,6 +113,20 @@ func (r *Runner) GetManagedChannels(ns string) ([]*ManagedChannel, error) {
 	return channels, nil
 }
 
+// GetHistoryFrame returns frames pushed to a managed stream channel since the given
+// time merged into a single frame. Returns false if channel has no history.
+func (r *Runner) GetHistoryFrame(ctx context.Context, ns string, channel string, since time.Time) (*data.Frame, bool, error) {
+	frames, err := r.frameCache.GetHistory(ctx, ns, channel, since)
+	if err != nil {
+		return nil, false, fmt.Errorf("error getting managed stream history: %w", err)
+	}
+	frame, err := mergeFrames(frames)
+	if err != nil {
+		return nil, false, err
+	}
+	return frame, frame != nil, nil
+}
+
 // GetOrCreateStream -- for now this will create new manager for each key.
 // Eventually, the stream behavior will need to be configured explicitly
 func (r *Runner) GetOrCreateStream(ns string, scope string, stream string) (*Stream, error) {
@@ -241,6 +255,23 @@ func (s *Stream) GetHandlerForPath(_ string) (model.ChannelHandler, error) {
 
 func (s *Stream) OnSubscribe(ctx context.Context, u identity.Requester, e model.SubscribeEvent) (model.SubscribeReply, backend.SubscribeStreamStatus, error) {
 	reply := model.SubscribeReply{}
+	// Late subscribers get frames from history so graphs are not empty.
+	historyFrames, err := s.frameCache.GetHistory(ctx, u.GetNamespace(), e.Channel, time.Time{})
+	if err != nil {
+		return reply, 0, err
+	}
+	if len(historyFrames) > 0 {
+		frame, err := mergeFrames(historyFrames)
+		if err != nil {
+			return reply, 0, err
+		}
+		frameJSON, err := data.FrameToJSON(frame, data.IncludeAll)
+		if err != nil {
+			return reply, 0, err
+		}
+		reply.Data = frameJSON
+		return reply, backend.SubscribeStreamStatusOK, nil
+	}
 	frameJSON, ok, err := s.frameCache.GetFrame(ctx, u.GetNamespace(), e.Channel)
 	if err != nil {
 		return reply, 0, err
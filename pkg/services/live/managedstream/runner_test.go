@@ -20,13 +20,13 @@ func (p *testPublisher) publish(_ string, _ string, _ []byte) error {
 
 func TestNewManagedStream(t *testing.T) {
 	publisher := &testPublisher{t: t}
-	c := NewStream("default", "stream", "a", publisher.publish, nil, NewMemoryFrameCache())
+	c := NewStream("default", "stream", "a", publisher.publish, nil, NewMemoryFrameCache(HistoryConfig{}))
 	require.NotNil(t, c)
 }
 
 func TestManagedStreamMinuteRate(t *testing.T) {
 	publisher := &testPublisher{t: t}
-	c := NewStream("default", "stream", "a", publisher.publish, nil, NewMemoryFrameCache())
+	c := NewStream("default", "stream", "a", publisher.publish, nil, NewMemoryFrameCache(HistoryConfig{}))
 	require.NotNil(t, c)
 
 	c.incRate("test1", time.Now().Unix())
@@ -48,7 +48,7 @@ func TestManagedStreamMinuteRate(t *testing.T) {
 
 func TestGetManagedStreams(t *testing.T) {
 	publisher := &testPublisher{t: t}
-	frameCache := NewMemoryFrameCache()
+	frameCache := NewMemoryFrameCache(HistoryConfig{})
 	runner := NewRunner(publisher.publish, nil, frameCache)
 	s1, err := runner.GetOrCreateStream("default", "stream", "test1")
 	require.NoError(t, err)
@@ -84,3 +84,24 @@ func TestGetManagedStreams(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, managedChannels, 7) // Not affected by other org.
 }
+
+func TestGetHistoryFrame(t *testing.T) {
+	publisher := &testPublisher{t: t}
+	runner := NewRunner(publisher.publish, nil, NewMemoryFrameCache(HistoryConfig{MaxFrames: 10}))
+	s, err := runner.GetOrCreateStream("default", "stream", "test1")
+	require.NoError(t, err)
+
+	_, ok, err := runner.GetHistoryFrame(context.Background(), "default", "stream/test1/cpu", time.Time{})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	for i := 0; i < 3; i++ {
+		err = s.Push(context.Background(), "cpu", data.NewFrame("cpu", data.NewField("value", nil, []float64{float64(i)})))
+		require.NoError(t, err)
+	}
+
+	frame, ok, err := runner.GetHistoryFrame(context.Background(), "default", "stream/test1/cpu", time.Time{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 3, frame.Fields[0].Len())
+}
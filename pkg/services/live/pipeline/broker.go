@@ -0,0 +1,200 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// brokerTimeout limits connecting to a message broker.
+const brokerTimeout = 5 * time.Second
+
+// brokerClient publishes messages to message broker topics and subscribes to them.
+type brokerClient interface {
+	Publish(ctx context.Context, topic string, data []byte) error
+	// Subscribe subscribes handler to a topic and returns a function to unsubscribe it.
+	Subscribe(ctx context.Context, topic string, handler func(data []byte)) (func() error, error)
+}
+
+// BrokerPool keeps connections to message brokers (MQTT or NATS) shared by
+// pipeline rules, so rebuilding rules does not open new connections.
+type BrokerPool struct {
+	mu            sync.Mutex
+	clients       map[string]*brokerConn
+	subscriptions map[string]*brokerSubscription
+	shared        bool
+	dial          func(ctx context.Context, endpoint string, basicAuth *BasicAuth, shared bool) (brokerClient, error)
+}
+
+// brokerConn is a connection to a message broker, ready is closed once dialing is done.
+type brokerConn struct {
+	ready  chan struct{}
+	client brokerClient
+	err    error
+}
+
+// brokerSubscription is a subscription to a topic shared by Live subscribers of a channel.
+type brokerSubscription struct {
+	refs        int
+	unsubscribe func() error
+	// cancelled is set when the last subscriber leaves before the subscription is made.
+	cancelled bool
+}
+
+// NewBrokerPool creates new BrokerPool. With shared subscriptions each message
+// is delivered to a single Grafana instance subscribed to a topic, which is
+// required to process messages once when running several instances. MQTT
+// brokers must support $share subscriptions in this case.
+func NewBrokerPool(shared bool) *BrokerPool {
+	return &BrokerPool{
+		clients:       map[string]*brokerConn{},
+		subscriptions: map[string]*brokerSubscription{},
+		shared:        shared,
+		dial:          dialBroker,
+	}
+}
+
+func brokerKey(endpoint string, basicAuth *BasicAuth) string {
+	if basicAuth == nil {
+		return endpoint
+	}
+	return endpoint + "\x00" + basicAuth.User + "\x00" + basicAuth.Password
+}
+
+// getClient returns a connection to a broker, dialing it if needed. Concurrent
+// calls for the same broker wait for a single dial without blocking others.
+func (p *BrokerPool) getClient(ctx context.Context, endpoint string, basicAuth *BasicAuth) (brokerClient, error) {
+	key := brokerKey(endpoint, basicAuth)
+	p.mu.Lock()
+	conn, ok := p.clients[key]
+	if !ok {
+		conn = &brokerConn{ready: make(chan struct{})}
+		p.clients[key] = conn
+	}
+	p.mu.Unlock()
+
+	if ok {
+		select {
+		case <-conn.ready:
+			return conn.client, conn.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, brokerTimeout)
+	defer cancel()
+	conn.client, conn.err = p.dial(dialCtx, endpoint, basicAuth, p.shared)
+	if conn.err != nil {
+		// Failed connections are not kept, so the next call dials again.
+		p.mu.Lock()
+		delete(p.clients, key)
+		p.mu.Unlock()
+	}
+	close(conn.ready)
+	return conn.client, conn.err
+}
+
+// Publish publishes data to a topic.
+func (p *BrokerPool) Publish(ctx context.Context, endpoint string, basicAuth *BasicAuth, topic string, data []byte) error {
+	client, err := p.getClient(ctx, endpoint, basicAuth)
+	if err != nil {
+		return err
+	}
+	return client.Publish(ctx, topic, data)
+}
+
+func subscriptionKey(endpoint string, basicAuth *BasicAuth, topic string, key string) string {
+	return brokerKey(endpoint, basicAuth) + "\x00" + topic + "\x00" + key
+}
+
+// Subscribe subscribes handler to a topic. Only the first subscription with the
+// same key to the same topic is made, subsequent calls only count subscribers.
+// The subscription is kept until all of them call Unsubscribe.
+func (p *BrokerPool) Subscribe(ctx context.Context, endpoint string, basicAuth *BasicAuth, topic string, key string, handler func(data []byte)) error {
+	subKey := subscriptionKey(endpoint, basicAuth, topic, key)
+	p.mu.Lock()
+	if sub, ok := p.subscriptions[subKey]; ok {
+		sub.refs++
+		p.mu.Unlock()
+		return nil
+	}
+	sub := &brokerSubscription{refs: 1}
+	p.subscriptions[subKey] = sub
+	p.mu.Unlock()
+
+	client, err := p.getClient(ctx, endpoint, basicAuth)
+	var unsubscribe func() error
+	if err == nil {
+		unsubscribe, err = client.Subscribe(ctx, topic, handler)
+	}
+
+	p.mu.Lock()
+	if err != nil {
+		if p.subscriptions[subKey] == sub {
+			delete(p.subscriptions, subKey)
+		}
+		p.mu.Unlock()
+		return err
+	}
+	sub.unsubscribe = unsubscribe
+	cancelled := sub.cancelled
+	p.mu.Unlock()
+
+	if cancelled {
+		return unsubscribe()
+	}
+	return nil
+}
+
+// Unsubscribe removes a subscriber added by Subscribe. The subscription to a
+// topic is removed when the last subscriber leaves.
+func (p *BrokerPool) Unsubscribe(endpoint string, basicAuth *BasicAuth, topic string, key string) error {
+	subKey := subscriptionKey(endpoint, basicAuth, topic, key)
+	p.mu.Lock()
+	sub, ok := p.subscriptions[subKey]
+	if !ok {
+		p.mu.Unlock()
+		return nil
+	}
+	sub.refs--
+	if sub.refs > 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	delete(p.subscriptions, subKey)
+	unsubscribe := sub.unsubscribe
+	if unsubscribe == nil {
+		// Subscribe is still in progress, it unsubscribes when done.
+		sub.cancelled = true
+	}
+	p.mu.Unlock()
+
+	if unsubscribe != nil {
+		return unsubscribe()
+	}
+	return nil
+}
+
+func dialBroker(ctx context.Context, endpoint string, basicAuth *BasicAuth, shared bool) (brokerClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing broker endpoint: %w", err)
+	}
+	switch u.Scheme {
+	case "mqtt", "mqtts", "tcp", "ssl", "tls", "ws", "wss":
+		return newMQTTBrokerClient(ctx, endpoint, basicAuth, shared)
+	case "nats":
+		return newNATSBrokerClient(endpoint, basicAuth, shared)
+	default:
+		return nil, fmt.Errorf("unsupported broker endpoint scheme: %s", u.Scheme)
+	}
+}
+
+// brokerTopic replaces {stream} and {path} placeholders in topic with channel values.
+func brokerTopic(topic string, vars Vars) string {
+	return strings.NewReplacer("{stream}", vars.Stream, "{path}", vars.Path).Replace(topic)
+}
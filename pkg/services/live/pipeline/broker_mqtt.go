@@ -0,0 +1,129 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/at-wat/mqtt-go"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type mqttHandler struct {
+	id      int
+	filter  string
+	handler func(data []byte)
+}
+
+// mqttBrokerClient is a brokerClient for MQTT 3.1.1 brokers.
+type mqttBrokerClient struct {
+	client mqtt.ReconnectClient
+	// shared subscriptions use $share topic filters.
+	shared bool
+
+	mu       sync.Mutex
+	handlers []mqttHandler
+	nextID   int
+}
+
+func newMQTTBrokerClient(ctx context.Context, endpoint string, basicAuth *BasicAuth, shared bool) (*mqttBrokerClient, error) {
+	client, err := mqtt.NewReconnectClient(
+		&mqtt.URLDialer{URL: endpoint},
+		mqtt.WithTimeout(brokerTimeout),
+		mqtt.WithPingInterval(10*time.Second),
+		mqtt.WithReconnectWait(time.Second, 15*time.Second),
+		mqtt.WithAlwaysResubscribe(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var opts []mqtt.ConnectOption
+	if basicAuth != nil {
+		opts = append(opts, mqtt.WithUserNamePassword(basicAuth.User, basicAuth.Password))
+	}
+	_, err = client.Connect(ctx, "grafana-live-"+util.GenerateShortUID(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &mqttBrokerClient{client: client, shared: shared}, nil
+}
+
+func (c *mqttBrokerClient) Publish(ctx context.Context, topic string, data []byte) error {
+	return c.client.Publish(ctx, &mqtt.Message{
+		Topic:   topic,
+		QoS:     mqtt.QoS0,
+		Payload: data,
+	})
+}
+
+func (c *mqttBrokerClient) Subscribe(ctx context.Context, topic string, handler func(data []byte)) (func() error, error) {
+	c.mu.Lock()
+	c.nextID++
+	id := c.nextID
+	handlers := append(c.handlers, mqttHandler{id: id, filter: topic, handler: handler})
+	if err := c.setHandlers(handlers); err != nil {
+		c.mu.Unlock()
+		return nil, err
+	}
+	c.mu.Unlock()
+
+	_, err := c.client.Subscribe(ctx, mqtt.Subscription{Topic: c.subscriptionTopic(topic), QoS: mqtt.QoS1})
+	if err != nil {
+		_ = c.unsubscribe(id, topic)
+		return nil, err
+	}
+	return func() error { return c.unsubscribe(id, topic) }, nil
+}
+
+// unsubscribe removes a handler and unsubscribes from the topic if no other
+// handler uses it.
+func (c *mqttBrokerClient) unsubscribe(id int, topic string) error {
+	c.mu.Lock()
+	var handlers []mqttHandler
+	used := false
+	for _, h := range c.handlers {
+		if h.id == id {
+			continue
+		}
+		if h.filter == topic {
+			used = true
+		}
+		handlers = append(handlers, h)
+	}
+	err := c.setHandlers(handlers)
+	c.mu.Unlock()
+	if err != nil || used {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
+	return c.client.Unsubscribe(ctx, c.subscriptionTopic(topic))
+}
+
+// setHandlers must be called with mu held.
+func (c *mqttBrokerClient) setHandlers(handlers []mqttHandler) error {
+	// ServeMux is not safe to modify while serving, so a new one is
+	// registered each time.
+	mux := &mqtt.ServeMux{}
+	for _, h := range handlers {
+		err := mux.HandleFunc(h.filter, func(message *mqtt.Message) {
+			h.handler(message.Payload)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	c.handlers = handlers
+	c.client.Handle(mux)
+	return nil
+}
+
+// subscriptionTopic returns a topic filter to subscribe with. Shared
+// subscriptions deliver each message to one member of the group only.
+func (c *mqttBrokerClient) subscriptionTopic(topic string) string {
+	if c.shared {
+		return "$share/" + brokerQueueGroup + "/" + topic
+	}
+	return topic
+}
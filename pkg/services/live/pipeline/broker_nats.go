@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// brokerQueueGroup is a group of Grafana instances sharing broker subscriptions.
+const brokerQueueGroup = "grafana-live"
+
+// natsBrokerClient is a brokerClient for NATS servers.
+type natsBrokerClient struct {
+	conn *nats.Conn
+	// shared subscriptions use a queue group.
+	shared bool
+}
+
+func newNATSBrokerClient(endpoint string, basicAuth *BasicAuth, shared bool) (*natsBrokerClient, error) {
+	opts := []nats.Option{
+		nats.Name("grafana-live"),
+		nats.Timeout(brokerTimeout),
+		nats.MaxReconnects(-1),
+	}
+	if basicAuth != nil {
+		opts = append(opts, nats.UserInfo(basicAuth.User, basicAuth.Password))
+	}
+	conn, err := nats.Connect(endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &natsBrokerClient{conn: conn, shared: shared}, nil
+}
+
+func (c *natsBrokerClient) Publish(_ context.Context, topic string, data []byte) error {
+	return c.conn.Publish(topic, data)
+}
+
+func (c *natsBrokerClient) Subscribe(_ context.Context, topic string, handler func(data []byte)) (func() error, error) {
+	cb := func(msg *nats.Msg) {
+		handler(msg.Data)
+	}
+	var sub *nats.Subscription
+	var err error
+	if c.shared {
+		sub, err = c.conn.QueueSubscribe(topic, brokerQueueGroup, cb)
+	} else {
+		sub, err = c.conn.Subscribe(topic, cb)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sub.Unsubscribe, nil
+}
@@ -0,0 +1,163 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+type testBrokerClient struct {
+	mu        sync.Mutex
+	published map[string][][]byte
+	handlers  map[string][]func(data []byte)
+}
+
+func newTestBrokerClient() *testBrokerClient {
+	return &testBrokerClient{
+		published: map[string][][]byte{},
+		handlers:  map[string][]func(data []byte){},
+	}
+}
+
+func (c *testBrokerClient) Publish(_ context.Context, topic string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.published[topic] = append(c.published[topic], data)
+	return nil
+}
+
+func (c *testBrokerClient) Subscribe(_ context.Context, topic string, handler func(data []byte)) (func() error, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[topic] = append(c.handlers[topic], handler)
+	return func() error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.handlers, topic)
+		return nil
+	}, nil
+}
+
+func (c *testBrokerClient) deliver(topic string, data []byte) {
+	c.mu.Lock()
+	handlers := c.handlers[topic]
+	c.mu.Unlock()
+	for _, h := range handlers {
+		h(data)
+	}
+}
+
+func newTestBrokerPool(client *testBrokerClient) (*BrokerPool, *int) {
+	numDials := 0
+	pool := NewBrokerPool(false)
+	pool.dial = func(_ context.Context, _ string, _ *BasicAuth, _ bool) (brokerClient, error) {
+		numDials++
+		return client, nil
+	}
+	return pool, &numDials
+}
+
+type testInputProcessor struct {
+	mu     sync.Mutex
+	inputs map[string][]string
+}
+
+func (p *testInputProcessor) ProcessInput(_ context.Context, _ string, channelID string, body []byte) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.inputs == nil {
+		p.inputs = map[string][]string{}
+	}
+	p.inputs[channelID] = append(p.inputs[channelID], string(body))
+	return true, nil
+}
+
+func TestBrokerTopic(t *testing.T) {
+	vars := Vars{Stream: "factory", Path: "line1"}
+	require.Equal(t, "factory/line1/telemetry", brokerTopic("{stream}/{path}/telemetry", vars))
+	require.Equal(t, "plain", brokerTopic("plain", vars))
+}
+
+func TestBrokerFrameOutput(t *testing.T) {
+	client := newTestBrokerClient()
+	pool, numDials := newTestBrokerPool(client)
+	out := NewBrokerFrameOutput(pool, "mqtt://localhost:1883", nil, "grafana/{path}")
+
+	frame := data.NewFrame("test", data.NewField("value", nil, []float64{1}))
+	vars := Vars{Channel: "stream/factory/line1", Stream: "factory", Path: "line1"}
+	for i := 0; i < 2; i++ {
+		_, err := out.OutputFrame(context.Background(), vars, frame)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 1, *numDials)
+	require.Len(t, client.published["grafana/line1"], 2)
+	frameJSON, err := data.FrameToJSON(frame, data.IncludeAll)
+	require.NoError(t, err)
+	require.JSONEq(t, string(frameJSON), string(client.published["grafana/line1"][0]))
+}
+
+func TestBrokerDataOutput(t *testing.T) {
+	client := newTestBrokerClient()
+	pool, _ := newTestBrokerPool(client)
+	out := NewBrokerDataOutput(pool, "nats://localhost:4222", nil, "grafana.{path}")
+
+	_, err := out.OutputData(context.Background(), Vars{Path: "line1"}, []byte(`{"value":1}`))
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte(`{"value":1}`)}, client.published["grafana.line1"])
+}
+
+func TestBrokerSubscriber(t *testing.T) {
+	client := newTestBrokerClient()
+	pool, _ := newTestBrokerPool(client)
+	processor := &testInputProcessor{}
+	sub := NewBrokerSubscriber(pool, processor, "mqtt://localhost:1883", nil, "factory/{path}/telemetry")
+
+	vars := Vars{NS: "default", Channel: "stream/factory/line1", Scope: "stream", Stream: "factory", Path: "line1"}
+	for i := 0; i < 2; i++ {
+		_, status, err := sub.Subscribe(context.Background(), vars, nil)
+		require.NoError(t, err)
+		require.Equal(t, backend.SubscribeStreamStatusOK, status)
+	}
+	// Repeated subscriptions to the same channel reuse broker subscription.
+	require.Len(t, client.handlers["factory/line1/telemetry"], 1)
+
+	client.deliver("factory/line1/telemetry", []byte("temperature value=22.5"))
+	require.Equal(t, []string{"temperature value=22.5"}, processor.inputs["stream/factory/line1"])
+
+	// Broker subscription is kept until the last subscriber leaves.
+	sub.Unsubscribe(context.Background(), vars)
+	require.Len(t, client.handlers["factory/line1/telemetry"], 1)
+	sub.Unsubscribe(context.Background(), vars)
+	require.Empty(t, client.handlers["factory/line1/telemetry"])
+	require.Empty(t, pool.subscriptions)
+}
+
+func TestBrokerPool_DialDoesNotBlockOtherBrokers(t *testing.T) {
+	client := newTestBrokerClient()
+	pool := NewBrokerPool(false)
+	dialing := make(chan struct{})
+	release := make(chan struct{})
+	pool.dial = func(_ context.Context, endpoint string, _ *BasicAuth, _ bool) (brokerClient, error) {
+		if endpoint == "mqtt://slow:1883" {
+			close(dialing)
+			<-release
+		}
+		return client, nil
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- pool.Publish(context.Background(), "mqtt://slow:1883", nil, "a", []byte("1"))
+	}()
+	<-dialing
+	require.NoError(t, pool.Publish(context.Background(), "mqtt://fast:1883", nil, "b", []byte("2")))
+	close(release)
+	require.NoError(t, <-done)
+	require.Len(t, client.published["a"], 1)
+	require.Len(t, client.published["b"], 1)
+}
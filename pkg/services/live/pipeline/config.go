@@ -26,6 +26,15 @@ type ChannelRuleSettings struct {
 	Converter       *ConverterConfig        `json:"converter,omitempty"`
 	FrameProcessors []*FrameProcessorConfig `json:"frameProcessors,omitempty"`
 	FrameOutputters []*FrameOutputterConfig `json:"frameOutputs,omitempty"`
+	RateLimit       *RateLimitConfig        `json:"rateLimit,omitempty"`
+}
+
+// RateLimitConfig limits publication throughput for each channel matching a rule.
+type RateLimitConfig struct {
+	MessagesPerSecond float64 `json:"messagesPerSecond,omitempty"`
+	BytesPerSecond    float64 `json:"bytesPerSecond,omitempty"`
+	// Policy is one of "reject" (default), "drop" or "lastValueWins".
+	Policy string `json:"policy,omitempty"`
 }
 
 type ChannelRule struct {
@@ -50,11 +59,58 @@ type KeepFieldsFrameProcessorConfig struct {
 	FieldNames []string `json:"fieldNames"`
 }
 
+type RenameFieldsFrameProcessorConfig struct {
+	// Renames maps current field names to new ones.
+	Renames map[string]string `json:"renames"`
+}
+
+// FieldConfigOverride sets display properties of a field.
+type FieldConfigOverride struct {
+	FieldName   string `json:"fieldName"`
+	Unit        string `json:"unit,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+type FieldConfigFrameProcessorConfig struct {
+	Fields []FieldConfigOverride `json:"fields"`
+}
+
+type AddLabelsFrameProcessorConfig struct {
+	Labels map[string]string `json:"labels"`
+	// FieldNames to add labels to. Labels are added to all numeric fields if empty.
+	FieldNames []string `json:"fieldNames,omitempty"`
+}
+
+type MathFrameProcessorConfig struct {
+	// FieldName is a name of the field with expression results. Existing
+	// field with the same name is replaced.
+	FieldName string `json:"fieldName"`
+	// Expression is a math expression evaluated for every row. Other fields
+	// are referenced as variables, for example "$temperature * 1.8 + 32".
+	Expression string `json:"expression"`
+}
+
+type AggregateFrameProcessorConfig struct {
+	// Window is a duration of the aggregation window, for example "5s".
+	Window string `json:"window"`
+	// Reducer is applied to values of numeric fields within a window,
+	// for example "mean", "max" or "last".
+	Reducer string `json:"reducer"`
+	// Timeout is a duration after which a window that received no rows is
+	// flushed, for example "10s". Defaults to Window.
+	Timeout string `json:"timeout,omitempty"`
+}
+
 type FrameProcessorConfig struct {
-	Type                      string                          `json:"type" ts_type:"Omit<keyof FrameProcessorConfig, 'type'>"`
-	DropFieldsProcessorConfig *DropFieldsFrameProcessorConfig `json:"dropFields,omitempty"`
-	KeepFieldsProcessorConfig *KeepFieldsFrameProcessorConfig `json:"keepFields,omitempty"`
-	MultipleProcessorConfig   *MultipleFrameProcessorConfig   `json:"multiple,omitempty"`
+	Type                        string                            `json:"type" ts_type:"Omit<keyof FrameProcessorConfig, 'type'>"`
+	DropFieldsProcessorConfig   *DropFieldsFrameProcessorConfig   `json:"dropFields,omitempty"`
+	KeepFieldsProcessorConfig   *KeepFieldsFrameProcessorConfig   `json:"keepFields,omitempty"`
+	RenameFieldsProcessorConfig *RenameFieldsFrameProcessorConfig `json:"renameFields,omitempty"`
+	FieldConfigProcessorConfig  *FieldConfigFrameProcessorConfig  `json:"fieldConfig,omitempty"`
+	AddLabelsProcessorConfig    *AddLabelsFrameProcessorConfig    `json:"addLabels,omitempty"`
+	MathProcessorConfig         *MathFrameProcessorConfig         `json:"math,omitempty"`
+	AggregateProcessorConfig    *AggregateFrameProcessorConfig    `json:"aggregate,omitempty"`
+	MultipleProcessorConfig     *MultipleFrameProcessorConfig     `json:"multiple,omitempty"`
 }
 
 type MultipleFrameProcessorConfig struct {
@@ -79,6 +135,15 @@ type LokiOutputConfig struct {
 	UID string `json:"uid"`
 }
 
+// BrokerConfig references a write config with a message broker endpoint,
+// for example mqtt://localhost:1883 or nats://localhost:4222.
+type BrokerConfig struct {
+	UID string `json:"uid"`
+	// Topic to subscribe or publish to. {stream} and {path} placeholders are
+	// replaced with values of a channel. Subscriptions support broker wildcards.
+	Topic string `json:"topic"`
+}
+
 type MultipleSubscriberConfig struct {
 	Subscribers []SubscriberConfig `json:"subscribers"`
 }
@@ -86,6 +151,7 @@ type MultipleSubscriberConfig struct {
 type SubscriberConfig struct {
 	Type                     string                    `json:"type" ts_type:"Omit<keyof SubscriberConfig, 'type'>"`
 	MultipleSubscriberConfig *MultipleSubscriberConfig `json:"multiple,omitempty"`
+	BrokerSubscriberConfig   *BrokerConfig             `json:"broker,omitempty"`
 }
 
 // RedirectDataOutputConfig ...
@@ -97,6 +163,7 @@ type DataOutputterConfig struct {
 	Type                     string                    `json:"type" ts_type:"Omit<keyof DataOutputterConfig, 'type'>"`
 	RedirectDataOutputConfig *RedirectDataOutputConfig `json:"redirect,omitempty"`
 	LokiOutputConfig         *LokiOutputConfig         `json:"loki,omitempty"`
+	BrokerOutputConfig       *BrokerConfig             `json:"broker,omitempty"`
 }
 
 type FrameOutputterConfig struct {
@@ -109,6 +176,7 @@ type FrameOutputterConfig struct {
 	RemoteWriteOutputConfig *RemoteWriteOutputConfig   `json:"remoteWrite,omitempty"`
 	LokiOutputConfig        *LokiOutputConfig          `json:"loki,omitempty"`
 	ChangeLogOutputConfig   *ChangeLogOutputConfig     `json:"changeLog,omitempty"`
+	BrokerOutputConfig      *BrokerConfig              `json:"broker,omitempty"`
 }
 
 type MultipleFrameConditionCheckerConfig struct {
@@ -0,0 +1,32 @@
+package pipeline
+
+import (
+	"context"
+)
+
+// BrokerDataOutput republishes raw data to a message broker topic.
+type BrokerDataOutput struct {
+	pool      *BrokerPool
+	endpoint  string
+	basicAuth *BasicAuth
+	topic     string
+}
+
+func NewBrokerDataOutput(pool *BrokerPool, endpoint string, basicAuth *BasicAuth, topic string) *BrokerDataOutput {
+	return &BrokerDataOutput{
+		pool:      pool,
+		endpoint:  endpoint,
+		basicAuth: basicAuth,
+		topic:     topic,
+	}
+}
+
+const DataOutputTypeBroker = "broker"
+
+func (out *BrokerDataOutput) Type() string {
+	return DataOutputTypeBroker
+}
+
+func (out *BrokerDataOutput) OutputData(ctx context.Context, vars Vars, data []byte) ([]*ChannelData, error) {
+	return nil, out.pool.Publish(ctx, out.endpoint, out.basicAuth, brokerTopic(out.topic, vars), data)
+}
@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// BrokerFrameOutput republishes frames encoded to JSON to a message broker topic.
+type BrokerFrameOutput struct {
+	pool      *BrokerPool
+	endpoint  string
+	basicAuth *BasicAuth
+	topic     string
+}
+
+func NewBrokerFrameOutput(pool *BrokerPool, endpoint string, basicAuth *BasicAuth, topic string) *BrokerFrameOutput {
+	return &BrokerFrameOutput{
+		pool:      pool,
+		endpoint:  endpoint,
+		basicAuth: basicAuth,
+		topic:     topic,
+	}
+}
+
+const FrameOutputTypeBroker = "broker"
+
+func (out *BrokerFrameOutput) Type() string {
+	return FrameOutputTypeBroker
+}
+
+func (out *BrokerFrameOutput) OutputFrame(ctx context.Context, vars Vars, frame *data.Frame) ([]*ChannelFrame, error) {
+	frameJSON, err := data.FrameToJSON(frame, data.IncludeAll)
+	if err != nil {
+		return nil, err
+	}
+	return nil, out.pool.Publish(ctx, out.endpoint, out.basicAuth, brokerTopic(out.topic, vars), frameJSON)
+}
@@ -0,0 +1,303 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+)
+
+// AggregateFrameProcessor downsamples a stream of frames. Rows are collected
+// into time windows by a value of the time field and values of numeric fields
+// within a window are reduced to a single value. Rows with different values
+// of other fields (like labels column) are aggregated separately.
+// Frames are not passed further until a window is closed by a row of a
+// following window, so it returns nil frame most of the time. A window that
+// receives no rows during the timeout is flushed to the rest of the channel
+// rule and forgotten.
+type AggregateFrameProcessor struct {
+	window  time.Duration
+	timeout time.Duration
+	reduce  mathexp.ReducerFunc
+
+	mu      sync.Mutex
+	windows map[string]*aggregateWindow
+}
+
+func NewAggregateFrameProcessor(config AggregateFrameProcessorConfig) (*AggregateFrameProcessor, error) {
+	window, err := gtime.ParseDuration(config.Window)
+	if err != nil {
+		return nil, fmt.Errorf("invalid window: %w", err)
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be positive")
+	}
+	timeout := window
+	if config.Timeout != "" {
+		timeout, err = gtime.ParseDuration(config.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("timeout must be positive")
+		}
+	}
+	reduce, err := mathexp.GetReduceFunc(mathexp.ReducerID(config.Reducer))
+	if err != nil {
+		return nil, err
+	}
+	return &AggregateFrameProcessor{
+		window:  window,
+		timeout: timeout,
+		reduce:  reduce,
+		windows: map[string]*aggregateWindow{},
+	}, nil
+}
+
+const FrameProcessorTypeAggregate = "aggregate"
+
+func (p *AggregateFrameProcessor) Type() string {
+	return FrameProcessorTypeAggregate
+}
+
+func (p *AggregateFrameProcessor) ProcessFrame(ctx context.Context, vars Vars, frame *data.Frame) (*data.Frame, error) {
+	timeIndex := -1
+	for i, field := range frame.Fields {
+		if field.Type().Time() {
+			timeIndex = i
+			break
+		}
+	}
+	if timeIndex < 0 {
+		return nil, fmt.Errorf("frame %s has no time field to aggregate by", frame.Name)
+	}
+	rowLen, err := frame.RowLen()
+	if err != nil {
+		return nil, err
+	}
+
+	key := vars.NS + "/" + vars.Channel + "/" + frame.Name
+	flush := flushFuncFromContext(ctx)
+
+	// Windows with the schema other than the schema of frame are flushed
+	// separately, so rows of different schemas are never mixed in out.
+	var out *data.Frame
+	var flushed []*data.Frame
+	err = func() error {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		for row := 0; row < rowLen; row++ {
+			v, ok := frame.ConcreteAt(timeIndex, row)
+			if !ok {
+				continue
+			}
+			start := v.(time.Time).Truncate(p.window)
+			w, ok := p.windows[key]
+			if ok && (!w.start.Equal(start) || !sameFrameSchema(w.schema, frame)) {
+				if start.Before(w.start) {
+					// Late rows of already closed windows are dropped.
+					continue
+				}
+				w.timer.Stop()
+				if sameFrameSchema(w.schema, frame) {
+					out = appendFrame(out, w.frame(p.reduce))
+				} else {
+					flushed = append(flushed, w.frame(p.reduce))
+				}
+				ok = false
+			}
+			if !ok {
+				w = newAggregateWindow(start, frame, timeIndex)
+				p.windows[key] = w
+				p.scheduleTimeout(key, w)
+			}
+			w.flush = flush
+			w.timer.Reset(p.timeout)
+			if err := w.add(frame, row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range flushed {
+		if flush == nil {
+			logger.Warn("Dropping aggregated frame with previous schema", "channel", vars.Channel, "frame", f.Name)
+			continue
+		}
+		flush(f)
+	}
+	return out, nil
+}
+
+// scheduleTimeout flushes the window and removes it if it receives no rows
+// during the timeout. This way the last window of a channel is not lost and
+// windows of channels that stopped receiving data don't pile up.
+func (p *AggregateFrameProcessor) scheduleTimeout(key string, w *aggregateWindow) {
+	w.timer = time.AfterFunc(p.timeout, func() {
+		p.mu.Lock()
+		if p.windows[key] != w {
+			// The window was closed by a row of the following window.
+			p.mu.Unlock()
+			return
+		}
+		delete(p.windows, key)
+		frame := w.frame(p.reduce)
+		flush := w.flush
+		p.mu.Unlock()
+
+		if flush != nil {
+			flush(frame)
+		}
+	})
+}
+
+// appendFrame appends rows of src frame to dst. Src and dst must have the same schema.
+func appendFrame(dst *data.Frame, src *data.Frame) *data.Frame {
+	if dst == nil {
+		return src
+	}
+	rowLen, _ := src.RowLen()
+	for i := 0; i < rowLen; i++ {
+		dst.AppendRow(src.RowCopy(i)...)
+	}
+	return dst
+}
+
+func sameFrameSchema(a, b *data.Frame) bool {
+	if len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for i := range a.Fields {
+		if a.Fields[i].Name != b.Fields[i].Name ||
+			a.Fields[i].Type() != b.Fields[i].Type() ||
+			a.Fields[i].Labels.String() != b.Fields[i].Labels.String() {
+			return false
+		}
+	}
+	return true
+}
+
+type aggregateWindow struct {
+	start     time.Time
+	schema    *data.Frame
+	timeIndex int
+	groups    map[string]*aggregateGroup
+	order     []string
+
+	// timer flushes the window on timeout with the latest flush function.
+	timer *time.Timer
+	flush FlushFunc
+}
+
+type aggregateGroup struct {
+	// row keeps values of non-numeric fields.
+	row []any
+	// values keeps collected values of numeric fields.
+	values []*data.Field
+}
+
+func newAggregateWindow(start time.Time, frame *data.Frame, timeIndex int) *aggregateWindow {
+	schema := frame.EmptyCopy()
+	schema.Meta = frame.Meta
+	for i, field := range frame.Fields {
+		schema.Fields[i].Config = field.Config
+	}
+	return &aggregateWindow{
+		start:     start,
+		schema:    schema,
+		timeIndex: timeIndex,
+		groups:    map[string]*aggregateGroup{},
+	}
+}
+
+func (w *aggregateWindow) add(frame *data.Frame, row int) error {
+	var keyParts []string
+	for i, field := range frame.Fields {
+		if i == w.timeIndex || field.Type().Numeric() {
+			continue
+		}
+		v, _ := field.ConcreteAt(row)
+		keyParts = append(keyParts, fmt.Sprintf("%v", v))
+	}
+	key := strings.Join(keyParts, "\x00")
+
+	g, ok := w.groups[key]
+	if !ok {
+		g = &aggregateGroup{
+			row:    frame.RowCopy(row),
+			values: make([]*data.Field, len(frame.Fields)),
+		}
+		for i, field := range frame.Fields {
+			if i != w.timeIndex && field.Type().Numeric() {
+				g.values[i] = data.NewField(field.Name, nil, []*float64{})
+			}
+		}
+		w.groups[key] = g
+		w.order = append(w.order, key)
+	}
+	for i, values := range g.values {
+		if values == nil {
+			continue
+		}
+		v, err := frame.Fields[i].NullableFloatAt(row)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			// Null values are skipped so they don't turn reduced values into NaN.
+			continue
+		}
+		values.Append(v)
+	}
+	return nil
+}
+
+// frame returns a frame with a row per group of the window. Numeric fields
+// become nullable float64 fields with reduced values.
+func (w *aggregateWindow) frame(reduce mathexp.ReducerFunc) *data.Frame {
+	fields := make([]*data.Field, len(w.schema.Fields))
+	for i, field := range w.schema.Fields {
+		switch {
+		case i == w.timeIndex:
+			fields[i] = data.NewField(field.Name, field.Labels, []time.Time{})
+		case field.Type().Numeric():
+			fields[i] = data.NewField(field.Name, field.Labels, []*float64{})
+		default:
+			fields[i] = data.NewFieldFromFieldType(field.Type(), 0)
+			fields[i].Name = field.Name
+			fields[i].Labels = field.Labels
+		}
+		fields[i].Config = field.Config
+	}
+	frame := data.NewFrame(w.schema.Name, fields...)
+	frame.Meta = w.schema.Meta
+
+	for _, key := range w.order {
+		g := w.groups[key]
+		row := make([]any, len(fields))
+		for i := range fields {
+			switch {
+			case i == w.timeIndex:
+				row[i] = w.start
+			case g.values[i] != nil:
+				ff := mathexp.Float64Field(*g.values[i])
+				row[i] = reduce(&ff)
+			default:
+				row[i] = g.row[i]
+			}
+		}
+		frame.AppendRow(row...)
+	}
+	return frame
+}
@@ -0,0 +1,182 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateFrameProcessor(t *testing.T) {
+	p, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{
+		Window:  "10s",
+		Reducer: "mean",
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	vars := Vars{NS: "default", Channel: "stream/test/cpu"}
+	frame := func(offset time.Duration, host string, value float64) *data.Frame {
+		return data.NewFrame("cpu",
+			data.NewField("labels", nil, []string{host}),
+			data.NewField("time", nil, []time.Time{start.Add(offset)}),
+			data.NewField("value", nil, []float64{value}),
+		)
+	}
+
+	// Window is not closed yet.
+	for _, f := range []*data.Frame{
+		frame(time.Second, "host=a", 1),
+		frame(2*time.Second, "host=b", 10),
+		frame(3*time.Second, "host=a", 3),
+	} {
+		out, err := p.ProcessFrame(context.Background(), vars, f)
+		require.NoError(t, err)
+		require.Nil(t, out)
+	}
+
+	// Row of the next window closes the previous one.
+	out, err := p.ProcessFrame(context.Background(), vars, frame(11*time.Second, "host=a", 5))
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, 2, out.Fields[0].Len())
+
+	require.Equal(t, "host=a", out.Fields[0].At(0))
+	require.Equal(t, start, out.Fields[1].At(0))
+	require.Equal(t, 2.0, *out.Fields[2].At(0).(*float64))
+
+	require.Equal(t, "host=b", out.Fields[0].At(1))
+	require.Equal(t, 10.0, *out.Fields[2].At(1).(*float64))
+
+	// Late rows are dropped.
+	out, err = p.ProcessFrame(context.Background(), vars, frame(4*time.Second, "host=a", 100))
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	out, err = p.ProcessFrame(context.Background(), vars, frame(21*time.Second, "host=a", 1))
+	require.NoError(t, err)
+	require.Equal(t, 1, out.Fields[0].Len())
+	require.Equal(t, 5.0, *out.Fields[2].At(0).(*float64))
+}
+
+func TestAggregateFrameProcessor_Timeout(t *testing.T) {
+	p, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{
+		Window:  "10s",
+		Reducer: "max",
+		Timeout: "10ms",
+	})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var flushed []*data.Frame
+	ctx := withFlushFunc(context.Background(), func(frame *data.Frame) {
+		mu.Lock()
+		defer mu.Unlock()
+		flushed = append(flushed, frame)
+	})
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	out, err := p.ProcessFrame(ctx, Vars{NS: "default", Channel: "stream/test/cpu"}, data.NewFrame("cpu",
+		data.NewField("time", nil, []time.Time{start, start.Add(time.Second)}),
+		data.NewField("value", nil, []float64{1, 2}),
+	))
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(flushed) == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, 2.0, *flushed[0].Fields[1].At(0).(*float64))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	require.Empty(t, p.windows)
+}
+
+func TestAggregateFrameProcessor_SchemaChange(t *testing.T) {
+	p, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{
+		Window:  "10s",
+		Reducer: "last",
+	})
+	require.NoError(t, err)
+
+	var flushed []*data.Frame
+	ctx := withFlushFunc(context.Background(), func(frame *data.Frame) {
+		flushed = append(flushed, frame)
+	})
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	vars := Vars{NS: "default", Channel: "stream/test/cpu"}
+	out, err := p.ProcessFrame(ctx, vars, data.NewFrame("cpu",
+		data.NewField("time", nil, []time.Time{start}),
+		data.NewField("value", nil, []float64{1}),
+	))
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	// Frame with a new field spans two windows: the window with the previous
+	// schema is flushed, the first window with the new schema is returned.
+	out, err = p.ProcessFrame(ctx, vars, data.NewFrame("cpu",
+		data.NewField("time", nil, []time.Time{start.Add(time.Second), start.Add(11 * time.Second)}),
+		data.NewField("value", nil, []float64{2, 3}),
+		data.NewField("idle", nil, []float64{20, 30}),
+	))
+	require.NoError(t, err)
+	require.Len(t, flushed, 1)
+	require.Len(t, flushed[0].Fields, 2)
+	require.Equal(t, 1.0, *flushed[0].Fields[1].At(0).(*float64))
+
+	require.NotNil(t, out)
+	require.Len(t, out.Fields, 3)
+	require.Equal(t, 1, out.Fields[0].Len())
+	require.Equal(t, 2.0, *out.Fields[1].At(0).(*float64))
+	require.Equal(t, 20.0, *out.Fields[2].At(0).(*float64))
+}
+
+func TestMultipleFrameProcessor_Aggregate(t *testing.T) {
+	aggregate, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{
+		Window:  "10s",
+		Reducer: "sum",
+	})
+	require.NoError(t, err)
+	p := NewMultipleFrameProcessor(aggregate, NewRenameFieldsFrameProcessor(RenameFieldsFrameProcessorConfig{
+		Renames: map[string]string{"value": "total"},
+	}))
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	vars := Vars{NS: "default", Channel: "stream/test/cpu"}
+	frame := func(offset time.Duration, value float64) *data.Frame {
+		return data.NewFrame("cpu",
+			data.NewField("time", nil, []time.Time{start.Add(offset)}),
+			data.NewField("value", nil, []float64{value}),
+		)
+	}
+
+	// Window is open, processors after aggregate are not called.
+	out, err := p.ProcessFrame(context.Background(), vars, frame(time.Second, 1))
+	require.NoError(t, err)
+	require.Nil(t, out)
+	out, err = p.ProcessFrame(context.Background(), vars, frame(2*time.Second, 2))
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	out, err = p.ProcessFrame(context.Background(), vars, frame(11*time.Second, 5))
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, "total", out.Fields[1].Name)
+	require.Equal(t, 3.0, *out.Fields[1].At(0).(*float64))
+}
+
+func TestNewAggregateFrameProcessor_Invalid(t *testing.T) {
+	_, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{Window: "10s", Reducer: "unknown"})
+	require.Error(t, err)
+	_, err = NewAggregateFrameProcessor(AggregateFrameProcessorConfig{Window: "", Reducer: "mean"})
+	require.Error(t, err)
+	_, err = NewAggregateFrameProcessor(AggregateFrameProcessorConfig{Window: "10s", Reducer: "mean", Timeout: "-1s"})
+	require.Error(t, err)
+}
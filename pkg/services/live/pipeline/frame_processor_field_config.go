@@ -0,0 +1,42 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// FieldConfigFrameProcessor can set unit and display name of data.Frame fields.
+type FieldConfigFrameProcessor struct {
+	config FieldConfigFrameProcessorConfig
+}
+
+func NewFieldConfigFrameProcessor(config FieldConfigFrameProcessorConfig) *FieldConfigFrameProcessor {
+	return &FieldConfigFrameProcessor{config: config}
+}
+
+const FrameProcessorTypeFieldConfig = "fieldConfig"
+
+func (p *FieldConfigFrameProcessor) Type() string {
+	return FrameProcessorTypeFieldConfig
+}
+
+func (p *FieldConfigFrameProcessor) ProcessFrame(_ context.Context, _ Vars, frame *data.Frame) (*data.Frame, error) {
+	for _, override := range p.config.Fields {
+		for _, field := range frame.Fields {
+			if field.Name != override.FieldName {
+				continue
+			}
+			if field.Config == nil {
+				field.Config = &data.FieldConfig{}
+			}
+			if override.Unit != "" {
+				field.Config.Unit = override.Unit
+			}
+			if override.DisplayName != "" {
+				field.Config.DisplayNameFromDS = override.DisplayName
+			}
+		}
+	}
+	return frame, nil
+}
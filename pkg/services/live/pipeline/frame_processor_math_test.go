@@ -0,0 +1,57 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMathFrameProcessor(t *testing.T) {
+	p, err := NewMathFrameProcessor(MathFrameProcessorConfig{
+		FieldName:  "fahrenheit",
+		Expression: "$celsius * 1.8 + 32",
+	})
+	require.NoError(t, err)
+
+	temp := 100.0
+	frame := data.NewFrame("test",
+		data.NewField("celsius", nil, []*float64{&temp, nil}),
+	)
+	frame, err = p.ProcessFrame(context.Background(), Vars{}, frame)
+	require.NoError(t, err)
+	require.Len(t, frame.Fields, 2)
+	require.Equal(t, "fahrenheit", frame.Fields[1].Name)
+	require.Equal(t, 212.0, *frame.Fields[1].At(0).(*float64))
+	require.Nil(t, frame.Fields[1].At(1))
+
+	t.Run("replaces existing field", func(t *testing.T) {
+		p, err := NewMathFrameProcessor(MathFrameProcessorConfig{
+			FieldName:  "value",
+			Expression: "$value * 2",
+		})
+		require.NoError(t, err)
+		frame, err := p.ProcessFrame(context.Background(), Vars{}, data.NewFrame("test",
+			data.NewField("value", nil, []int64{2}),
+		))
+		require.NoError(t, err)
+		require.Len(t, frame.Fields, 1)
+		require.Equal(t, 4.0, *frame.Fields[0].At(0).(*float64))
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		_, err := p.ProcessFrame(context.Background(), Vars{}, data.NewFrame("test",
+			data.NewField("value", nil, []float64{1}),
+		))
+		require.ErrorContains(t, err, "celsius")
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := NewMathFrameProcessor(MathFrameProcessorConfig{
+			FieldName:  "value",
+			Expression: "$value *",
+		})
+		require.Error(t, err)
+	})
+}
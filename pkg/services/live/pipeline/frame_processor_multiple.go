@@ -19,17 +19,42 @@ func (p *MultipleFrameProcessor) Type() string {
 }
 
 func (p *MultipleFrameProcessor) ProcessFrame(ctx context.Context, vars Vars, frame *data.Frame) (*data.Frame, error) {
-	for _, p := range p.Processors {
+	return p.processFrom(ctx, vars, 0, frame)
+}
+
+func (p *MultipleFrameProcessor) processFrom(ctx context.Context, vars Vars, first int, frame *data.Frame) (*data.Frame, error) {
+	flush := flushFuncFromContext(ctx)
+	for i := first; i < len(p.Processors); i++ {
+		procCtx := ctx
+		if flush != nil {
+			procCtx = withFlushFunc(ctx, p.flushFunc(ctx, vars, i+1, flush))
+		}
 		var err error
-		frame, err = p.ProcessFrame(ctx, vars, frame)
+		frame, err = p.Processors[i].ProcessFrame(procCtx, vars, frame)
 		if err != nil {
 			logger.Error("Error processing frame", "error", err)
 			return nil, err
 		}
+		if frame == nil {
+			return nil, nil
+		}
 	}
 	return frame, nil
 }
 
+// flushFunc returns FlushFunc which passes frames released later by one of
+// Processors through the following ones to the flush function of the chain.
+func (p *MultipleFrameProcessor) flushFunc(ctx context.Context, vars Vars, next int, flush FlushFunc) FlushFunc {
+	ctx = context.WithoutCancel(ctx)
+	return func(frame *data.Frame) {
+		frame, err := p.processFrom(ctx, vars, next, frame)
+		if err != nil || frame == nil {
+			return
+		}
+		flush(frame)
+	}
+}
+
 func NewMultipleFrameProcessor(processors ...FrameProcessor) *MultipleFrameProcessor {
 	return &MultipleFrameProcessor{Processors: processors}
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/services/live/model"
+	"github.com/grafana/grafana/pkg/services/live/ratelimit"
 )
 
 const (
@@ -94,11 +95,30 @@ type Converter interface {
 }
 
 // FrameProcessor can modify data.Frame in a custom way before it will be outputted.
+// Returning nil frame stops processing of the frame.
 type FrameProcessor interface {
 	Type() string
 	ProcessFrame(ctx context.Context, vars Vars, frame *data.Frame) (*data.Frame, error)
 }
 
+// FlushFunc passes a frame released by a FrameProcessor after ProcessFrame
+// returned, for example when an aggregation window times out, to the rest of
+// the channel rule.
+type FlushFunc func(frame *data.Frame)
+
+type flushFuncKey struct{}
+
+func withFlushFunc(ctx context.Context, fn FlushFunc) context.Context {
+	return context.WithValue(ctx, flushFuncKey{}, fn)
+}
+
+// flushFuncFromContext returns FlushFunc set for the FrameProcessor called
+// with ctx, nil if frames released later can't be processed.
+func flushFuncFromContext(ctx context.Context) FlushFunc {
+	fn, _ := ctx.Value(flushFuncKey{}).(FlushFunc)
+	return fn
+}
+
 // FrameOutputter outputs data.Frame to a custom destination. Or simply
 // do nothing if some conditions not met.
 type FrameOutputter interface {
@@ -112,6 +132,12 @@ type Subscriber interface {
 	Subscribe(ctx context.Context, vars Vars, data []byte) (model.SubscribeReply, backend.SubscribeStreamStatus, error)
 }
 
+// Unsubscriber is implemented by Subscribers which release resources when a
+// client unsubscribes from a channel.
+type Unsubscriber interface {
+	Unsubscribe(ctx context.Context, vars Vars)
+}
+
 // PublishAuthChecker checks whether current user can publish to a channel.
 type PublishAuthChecker interface {
 	CanPublish(ctx context.Context, u identity.Requester) (bool, error)
@@ -163,6 +189,9 @@ type LiveChannelRule struct {
 	// can optionally return a slice of ChannelFrame to pass the control to a rule defined
 	// by ChannelFrame.Channel.
 	FrameOutputters []FrameOutputter
+	// RateLimit limits publication throughput for each channel matching Pattern. Zero
+	// value means no limit, organization limits are still applied by the Pipeline.
+	RateLimit ratelimit.Limits
 }
 
 // Label ...
@@ -181,8 +210,9 @@ type ChannelRuleGetter interface {
 // * do some processing on these frames
 // * output resulting frames to various destinations.
 type Pipeline struct {
-	ruleGetter ChannelRuleGetter
-	tracer     trace.Tracer
+	ruleGetter     ChannelRuleGetter
+	tracer         trace.Tracer
+	publishLimiter *ratelimit.PublishLimiter
 }
 
 // New creates new Pipeline.
@@ -215,6 +245,11 @@ func (p *Pipeline) Get(ns string, channel string) (*LiveChannelRule, bool, error
 	return p.ruleGetter.Get(ns, channel)
 }
 
+// SetPublishLimiter enables publish rate limits for input processed by Pipeline.
+func (p *Pipeline) SetPublishLimiter(l *ratelimit.PublishLimiter) {
+	p.publishLimiter = l
+}
+
 func (p *Pipeline) ProcessInput(ctx context.Context, ns string, channelID string, body []byte) (bool, error) {
 	var span trace.Span
 	if p.tracer != nil {
@@ -226,6 +261,15 @@ func (p *Pipeline) ProcessInput(ctx context.Context, ns string, channelID string
 		)
 		defer span.End()
 	}
+	if p.publishLimiter != nil {
+		ok, limited, err := p.checkPublishLimits(ctx, ns, channelID, body)
+		if err != nil || !ok || limited {
+			if err != nil && p.tracer != nil && span != nil {
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return ok, err
+		}
+	}
 	ok, err := p.processInput(ctx, ns, channelID, body, nil)
 	if err != nil {
 		if p.tracer != nil && span != nil {
@@ -236,6 +280,30 @@ func (p *Pipeline) ProcessInput(ctx context.Context, ns string, channelID string
 	return ok, err
 }
 
+// checkPublishLimits applies rate limits of a rule matching channelID. Limited
+// publications must not be processed, ratelimit.ErrRateLimited is returned for
+// rejected ones.
+func (p *Pipeline) checkPublishLimits(ctx context.Context, ns string, channelID string, body []byte) (bool, bool, error) {
+	rule, ok, err := p.ruleGetter.Get(ns, channelID)
+	if err != nil || !ok {
+		return false, false, err
+	}
+	deferredCtx := context.WithoutCancel(ctx)
+	result := p.publishLimiter.Check(ns, channelID, rule.RateLimit, len(body), func() {
+		_, err := p.processInput(deferredCtx, ns, channelID, body, nil)
+		if err != nil {
+			logger.Error("Error processing deferred input", "error", err, "channel", channelID)
+		}
+	})
+	switch result {
+	case ratelimit.Rejected:
+		return true, true, ratelimit.ErrRateLimited
+	case ratelimit.Dropped, ratelimit.Deferred:
+		return true, true, nil
+	}
+	return true, false, nil
+}
+
 func (p *Pipeline) processInput(ctx context.Context, ns string, channelID string, body []byte, visitedChannels map[string]struct{}) (bool, error) {
 	var span trace.Span
 	if p.tracer != nil {
@@ -406,16 +474,22 @@ func (p *Pipeline) processFrame(ctx context.Context, ns string, channelID string
 		Path:    ch.Path,
 	}
 
-	if len(rule.FrameProcessors) > 0 {
-		for _, proc := range rule.FrameProcessors {
-			frame, err = p.execProcessor(ctx, proc, vars, frame)
-			if err != nil {
-				logger.Error("Error processing frame", "error", err)
-				return nil, err
-			}
-			if frame == nil {
-				return nil, nil
-			}
+	return p.processRuleFrame(ctx, rule, vars, 0, frame)
+}
+
+// processRuleFrame applies rule FrameProcessors starting from the given one
+// and FrameOutputters to the frame.
+func (p *Pipeline) processRuleFrame(ctx context.Context, rule *LiveChannelRule, vars Vars, firstProcessor int, frame *data.Frame) ([]*ChannelFrame, error) {
+	for i := firstProcessor; i < len(rule.FrameProcessors); i++ {
+		var err error
+		procCtx := withFlushFunc(ctx, p.flushFunc(ctx, rule, vars, i+1))
+		frame, err = p.execProcessor(procCtx, rule.FrameProcessors[i], vars, frame)
+		if err != nil {
+			logger.Error("Error processing frame", "error", err)
+			return nil, err
+		}
+		if frame == nil {
+			return nil, nil
 		}
 	}
 
@@ -435,6 +509,21 @@ func (p *Pipeline) processFrame(ctx context.Context, ns string, channelID string
 	return nil, nil
 }
 
+// flushFunc returns FlushFunc which processes frames released later by a rule
+// FrameProcessor with the following FrameProcessors and FrameOutputters.
+func (p *Pipeline) flushFunc(ctx context.Context, rule *LiveChannelRule, vars Vars, nextProcessor int) FlushFunc {
+	ctx = context.WithoutCancel(ctx)
+	return func(frame *data.Frame) {
+		frames, err := p.processRuleFrame(ctx, rule, vars, nextProcessor, frame)
+		if err == nil && len(frames) > 0 {
+			err = p.processChannelFrames(ctx, vars.NS, vars.Channel, frames, map[string]struct{}{vars.Channel: {}})
+		}
+		if err != nil {
+			logger.Error("Error processing flushed frame", "error", err, "channel", vars.Channel)
+		}
+	}
+}
+
 func (p *Pipeline) execProcessor(ctx context.Context, proc FrameProcessor, vars Vars, frame *data.Frame) (*data.Frame, error) {
 	var span trace.Span
 	if p.tracer != nil {
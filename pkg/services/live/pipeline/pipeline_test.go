@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/live/ratelimit"
 )
 
 type testRuleGetter struct {
@@ -118,6 +120,32 @@ func TestPipeline_OutputError(t *testing.T) {
 	require.ErrorIs(t, err, boomErr)
 }
 
+func TestPipeline_RateLimit(t *testing.T) {
+	outputter := &testOutputter{}
+	p, err := New(&testRuleGetter{
+		rules: map[string]*LiveChannelRule{
+			"stream/test/xxx": {
+				Converter:       &testConverter{"", data.NewFrame("test")},
+				FrameOutputters: []FrameOutputter{outputter},
+				RateLimit:       ratelimit.Limits{MessagesPerSecond: 1},
+			},
+		},
+	})
+	require.NoError(t, err)
+	p.SetPublishLimiter(ratelimit.NewPublishLimiter(ratelimit.Limits{}))
+
+	ok, err := p.ProcessInput(context.Background(), "default", "stream/test/xxx", []byte(`{}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NotNil(t, outputter.frame)
+
+	outputter.frame = nil
+	ok, err = p.ProcessInput(context.Background(), "default", "stream/test/xxx", []byte(`{}`))
+	require.ErrorIs(t, err, ratelimit.ErrRateLimited)
+	require.True(t, ok)
+	require.Nil(t, outputter.frame)
+}
+
 func TestPipeline_Recursion(t *testing.T) {
 	p, err := New(&testRuleGetter{
 		rules: map[string]*LiveChannelRule{
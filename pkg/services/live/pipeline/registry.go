@@ -15,6 +15,14 @@ var SubscribersRegistry = []EntityInfo{
 		Type:        SubscriberTypeManagedStream,
 		Description: "apply managed stream subscribe logic",
 	},
+	{
+		Type:        SubscriberTypeBroker,
+		Description: "subscribe to MQTT or NATS topic and process its messages as channel input",
+		Example: BrokerConfig{
+			UID:   "factory-mqtt",
+			Topic: "factory/{path}/telemetry",
+		},
+	},
 }
 
 var FrameOutputsRegistry = []EntityInfo{
@@ -48,6 +56,14 @@ var FrameOutputsRegistry = []EntityInfo{
 		Type:        FrameOutputTypeLoki,
 		Description: "output frame as JSON to Loki",
 	},
+	{
+		Type:        FrameOutputTypeBroker,
+		Description: "publish frame as JSON to MQTT or NATS topic",
+		Example: BrokerConfig{
+			UID:   "factory-mqtt",
+			Topic: "grafana/{path}",
+		},
+	},
 }
 
 var ConvertersRegistry = []EntityInfo{
@@ -79,6 +95,43 @@ var FrameProcessorsRegistry = []EntityInfo{
 		Description: "list the fields that should be removed",
 		Example:     DropFieldsFrameProcessorConfig{},
 	},
+	{
+		Type:        FrameProcessorTypeRenameFields,
+		Description: "rename fields",
+		Example: RenameFieldsFrameProcessorConfig{
+			Renames: map[string]string{"temp": "temperature"},
+		},
+	},
+	{
+		Type:        FrameProcessorTypeFieldConfig,
+		Description: "set unit and display name of fields",
+		Example: FieldConfigFrameProcessorConfig{
+			Fields: []FieldConfigOverride{{FieldName: "temperature", Unit: "celsius", DisplayName: "Temperature"}},
+		},
+	},
+	{
+		Type:        FrameProcessorTypeAddLabels,
+		Description: "add constant labels to fields",
+		Example: AddLabelsFrameProcessorConfig{
+			Labels: map[string]string{"site": "factory"},
+		},
+	},
+	{
+		Type:        FrameProcessorTypeMath,
+		Description: "compute a field with a math expression over other fields",
+		Example: MathFrameProcessorConfig{
+			FieldName:  "fahrenheit",
+			Expression: "$temperature * 1.8 + 32",
+		},
+	},
+	{
+		Type:        FrameProcessorTypeAggregate,
+		Description: "downsample frames by reducing values within a time window",
+		Example: AggregateFrameProcessorConfig{
+			Window:  "5s",
+			Reducer: "mean",
+		},
+	},
 }
 
 var DataOutputsRegistry = []EntityInfo{
@@ -94,4 +147,8 @@ var DataOutputsRegistry = []EntityInfo{
 		Type:        DataOutputTypeLoki,
 		Description: "output data to Loki as logs",
 	},
+	{
+		Type:        DataOutputTypeBroker,
+		Description: "publish data to MQTT or NATS topic",
+	},
 }
@@ -2,11 +2,13 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/centrifugal/centrifuge"
 
 	"github.com/grafana/grafana/pkg/services/live/managedstream"
+	"github.com/grafana/grafana/pkg/services/live/ratelimit"
 	"github.com/grafana/grafana/pkg/services/secrets"
 )
 
@@ -17,9 +19,11 @@ type StorageRuleBuilder struct {
 	Storage              Storage
 	ChannelHandlerGetter ChannelHandlerGetter
 	SecretsService       secrets.Service
+	Brokers              *BrokerPool
+	InputProcessor       InputProcessor
 }
 
-func (f *StorageRuleBuilder) extractSubscriber(config *SubscriberConfig) (Subscriber, error) {
+func (f *StorageRuleBuilder) extractSubscriber(config *SubscriberConfig, writeConfigs []WriteConfig) (Subscriber, error) {
 	if config == nil {
 		return nil, nil
 	}
@@ -36,13 +40,25 @@ func (f *StorageRuleBuilder) extractSubscriber(config *SubscriberConfig) (Subscr
 		var subscribers []Subscriber
 		for _, outConf := range config.MultipleSubscriberConfig.Subscribers {
 			out := outConf
-			sub, err := f.extractSubscriber(&out)
+			sub, err := f.extractSubscriber(&out, writeConfigs)
 			if err != nil {
 				return nil, err
 			}
 			subscribers = append(subscribers, sub)
 		}
 		return NewMultipleSubscriber(subscribers...), nil
+	case SubscriberTypeBroker:
+		if config.BrokerSubscriberConfig == nil {
+			return nil, missingConfiguration
+		}
+		if f.InputProcessor == nil {
+			return nil, errors.New("no input processor for broker subscriber")
+		}
+		endpoint, basicAuth, err := f.getBroker(*config.BrokerSubscriberConfig, writeConfigs)
+		if err != nil {
+			return nil, err
+		}
+		return NewBrokerSubscriber(f.Brokers, f.InputProcessor, endpoint, basicAuth, config.BrokerSubscriberConfig.Topic), nil
 	default:
 		return nil, fmt.Errorf("unknown subscriber type: %s", config.Type)
 	}
@@ -90,6 +106,31 @@ func (f *StorageRuleBuilder) extractFrameProcessor(config *FrameProcessorConfig)
 			return nil, missingConfiguration
 		}
 		return NewKeepFieldsFrameProcessor(*config.KeepFieldsProcessorConfig), nil
+	case FrameProcessorTypeRenameFields:
+		if config.RenameFieldsProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewRenameFieldsFrameProcessor(*config.RenameFieldsProcessorConfig), nil
+	case FrameProcessorTypeFieldConfig:
+		if config.FieldConfigProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewFieldConfigFrameProcessor(*config.FieldConfigProcessorConfig), nil
+	case FrameProcessorTypeAddLabels:
+		if config.AddLabelsProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewAddLabelsFrameProcessor(*config.AddLabelsProcessorConfig), nil
+	case FrameProcessorTypeMath:
+		if config.MathProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewMathFrameProcessor(*config.MathProcessorConfig)
+	case FrameProcessorTypeAggregate:
+		if config.AggregateProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewAggregateFrameProcessor(*config.AggregateProcessorConfig)
 	case FrameProcessorTypeMultiple:
 		if config.MultipleProcessorConfig == nil {
 			return nil, missingConfiguration
@@ -249,6 +290,15 @@ func (f *StorageRuleBuilder) extractFrameOutputter(config *FrameOutputterConfig,
 			return nil, missingConfiguration
 		}
 		return NewChangeLogFrameOutput(f.FrameStorage, *config.ChangeLogOutputConfig), nil
+	case FrameOutputTypeBroker:
+		if config.BrokerOutputConfig == nil {
+			return nil, missingConfiguration
+		}
+		endpoint, basicAuth, err := f.getBroker(*config.BrokerOutputConfig, writeConfigs)
+		if err != nil {
+			return nil, err
+		}
+		return NewBrokerFrameOutput(f.Brokers, endpoint, basicAuth, config.BrokerOutputConfig.Topic), nil
 	default:
 		return nil, fmt.Errorf("unknown output type: %s", config.Type)
 	}
@@ -285,11 +335,39 @@ func (f *StorageRuleBuilder) extractDataOutputter(config *DataOutputterConfig, w
 		return NewBuiltinDataOutput(f.ChannelHandlerGetter), nil
 	case DataOutputTypeLocalSubscribers:
 		return NewLocalSubscribersDataOutput(f.Node), nil
+	case DataOutputTypeBroker:
+		if config.BrokerOutputConfig == nil {
+			return nil, missingConfiguration
+		}
+		endpoint, basicAuth, err := f.getBroker(*config.BrokerOutputConfig, writeConfigs)
+		if err != nil {
+			return nil, err
+		}
+		return NewBrokerDataOutput(f.Brokers, endpoint, basicAuth, config.BrokerOutputConfig.Topic), nil
 	default:
 		return nil, fmt.Errorf("unknown data output type: %s", config.Type)
 	}
 }
 
+// getBroker returns a message broker endpoint and credentials from a write config.
+func (f *StorageRuleBuilder) getBroker(config BrokerConfig, writeConfigs []WriteConfig) (string, *BasicAuth, error) {
+	if f.Brokers == nil {
+		return "", nil, errors.New("message brokers are not configured")
+	}
+	if config.Topic == "" {
+		return "", nil, errors.New("broker topic required")
+	}
+	writeConfig, ok := f.getWriteConfig(config.UID, writeConfigs)
+	if !ok {
+		return "", nil, fmt.Errorf("unknown broker uid: %s", config.UID)
+	}
+	basicAuth, err := f.constructBasicAuth(writeConfig)
+	if err != nil {
+		return "", nil, fmt.Errorf("error constructing basicAuth: %w", err)
+	}
+	return writeConfig.Settings.Endpoint, basicAuth, nil
+}
+
 func (f *StorageRuleBuilder) getWriteConfig(uid string, writeConfigs []WriteConfig) (WriteConfig, bool) {
 	for _, rwb := range writeConfigs {
 		if rwb.UID == uid {
@@ -326,6 +404,18 @@ func (f *StorageRuleBuilder) BuildRules(ctx context.Context, ns string) ([]*Live
 			rule.PublishAuth = NewRoleCheckAuthorizer(ruleConfig.Settings.Auth.Publish.RequireRole)
 		}
 
+		if ruleConfig.Settings.RateLimit != nil {
+			rateLimit := ruleConfig.Settings.RateLimit
+			rule.RateLimit = ratelimit.Limits{
+				MessagesPerSecond: rateLimit.MessagesPerSecond,
+				BytesPerSecond:    rateLimit.BytesPerSecond,
+				Policy:            ratelimit.Policy(rateLimit.Policy),
+			}
+			if !rule.RateLimit.Policy.Valid() {
+				return nil, fmt.Errorf("unknown rate limit policy for %s: %s", rule.Pattern, rateLimit.Policy)
+			}
+		}
+
 		var err error
 
 		rule.Converter, err = f.extractConverter(ruleConfig.Settings.Converter)
@@ -365,7 +455,7 @@ func (f *StorageRuleBuilder) BuildRules(ctx context.Context, ns string) ([]*Live
 
 		var subscribers []Subscriber
 		for _, subConfig := range ruleConfig.Settings.Subscribers {
-			sub, err := f.extractSubscriber(subConfig)
+			sub, err := f.extractSubscriber(subConfig, writeConfigs)
 			if err != nil {
 				return nil, fmt.Errorf("error building subscriber for %s: %w", rule.Pattern, err)
 			}
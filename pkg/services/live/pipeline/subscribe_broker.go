@@ -0,0 +1,65 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/services/live/model"
+)
+
+// InputProcessor processes raw channel data, implemented by Pipeline.
+type InputProcessor interface {
+	ProcessInput(ctx context.Context, ns string, channelID string, body []byte) (bool, error)
+}
+
+// BrokerSubscriber subscribes to a message broker topic when a client subscribes
+// to a channel. Messages from the topic are then processed as channel input, i.e.
+// by the rule converter, processors and outputs. The topic subscription is
+// removed when the last client unsubscribes from the channel.
+type BrokerSubscriber struct {
+	pool           *BrokerPool
+	inputProcessor InputProcessor
+	endpoint       string
+	basicAuth      *BasicAuth
+	topic          string
+}
+
+const SubscriberTypeBroker = "broker"
+
+func NewBrokerSubscriber(pool *BrokerPool, inputProcessor InputProcessor, endpoint string, basicAuth *BasicAuth, topic string) *BrokerSubscriber {
+	return &BrokerSubscriber{
+		pool:           pool,
+		inputProcessor: inputProcessor,
+		endpoint:       endpoint,
+		basicAuth:      basicAuth,
+		topic:          topic,
+	}
+}
+
+func (s *BrokerSubscriber) Type() string {
+	return SubscriberTypeBroker
+}
+
+func (s *BrokerSubscriber) Subscribe(ctx context.Context, vars Vars, _ []byte) (model.SubscribeReply, backend.SubscribeStreamStatus, error) {
+	topic := brokerTopic(s.topic, vars)
+	err := s.pool.Subscribe(ctx, s.endpoint, s.basicAuth, topic, vars.NS+"/"+vars.Channel, func(data []byte) {
+		_, err := s.inputProcessor.ProcessInput(context.Background(), vars.NS, vars.Channel, data)
+		if err != nil {
+			logger.Error("Error processing broker message", "error", err, "topic", topic, "channel", vars.Channel)
+		}
+	})
+	if err != nil {
+		logger.Error("Error subscribing to broker topic", "error", err, "topic", topic)
+		return model.SubscribeReply{}, 0, err
+	}
+	return model.SubscribeReply{}, backend.SubscribeStreamStatusOK, nil
+}
+
+func (s *BrokerSubscriber) Unsubscribe(_ context.Context, vars Vars) {
+	topic := brokerTopic(s.topic, vars)
+	err := s.pool.Unsubscribe(s.endpoint, s.basicAuth, topic, vars.NS+"/"+vars.Channel)
+	if err != nil {
+		logger.Error("Error unsubscribing from broker topic", "error", err, "topic", topic)
+	}
+}
@@ -21,15 +21,20 @@ func (s *MultipleSubscriber) Type() string {
 	return SubscriberTypeMultiple
 }
 
+// Subscribe subscribes with all subscribers. If one of them fails or does not
+// return OK, the subscribers that already succeeded are unsubscribed, because
+// the client is not subscribed and will not unsubscribe.
 func (s *MultipleSubscriber) Subscribe(ctx context.Context, vars Vars, data []byte) (model.SubscribeReply, backend.SubscribeStreamStatus, error) {
 	finalReply := model.SubscribeReply{}
 
-	for _, s := range s.Subscribers {
-		reply, status, err := s.Subscribe(ctx, vars, data)
+	for i, sub := range s.Subscribers {
+		reply, status, err := sub.Subscribe(ctx, vars, data)
 		if err != nil {
+			unsubscribeAll(ctx, vars, s.Subscribers[:i])
 			return model.SubscribeReply{}, 0, err
 		}
 		if status != backend.SubscribeStreamStatusOK {
+			unsubscribeAll(ctx, vars, s.Subscribers[:i])
 			return model.SubscribeReply{}, status, nil
 		}
 		if finalReply.Data == nil {
@@ -47,3 +52,16 @@ func (s *MultipleSubscriber) Subscribe(ctx context.Context, vars Vars, data []by
 	}
 	return finalReply, backend.SubscribeStreamStatusOK, nil
 }
+
+func (s *MultipleSubscriber) Unsubscribe(ctx context.Context, vars Vars) {
+	unsubscribeAll(ctx, vars, s.Subscribers)
+}
+
+// unsubscribeAll unsubscribes the subscribers that implement Unsubscriber.
+func unsubscribeAll(ctx context.Context, vars Vars, subscribers []Subscriber) {
+	for _, s := range subscribers {
+		if u, ok := s.(Unsubscriber); ok {
+			u.Unsubscribe(ctx, vars)
+		}
+	}
+}
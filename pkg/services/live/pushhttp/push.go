@@ -13,6 +13,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/live"
 	"github.com/grafana/grafana/pkg/services/live/convert"
 	"github.com/grafana/grafana/pkg/services/live/pushurl"
+	"github.com/grafana/grafana/pkg/services/live/ratelimit"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/web"
 )
@@ -58,6 +59,7 @@ func (g *Gateway) Handle(ctx *contextmodel.ReqContext) {
 	// TODO Grafana 8: decide which formats to use or keep all.
 	urlValues := ctx.Req.URL.Query()
 	frameFormat := pushurl.FrameFormatFromValues(urlValues)
+	inputFormat := pushurl.InputFormatFromValues(urlValues)
 
 	body, err := io.ReadAll(ctx.Req.Body)
 	if err != nil {
@@ -70,12 +72,24 @@ func (g *Gateway) Handle(ctx *contextmodel.ReqContext) {
 		"streamId", streamID,
 		"bodyLength", len(body),
 		"frameFormat", frameFormat,
+		"inputFormat", inputFormat,
 	)
 
-	metricFrames, err := g.converter.Convert(body, frameFormat)
+	channel := liveDto.Channel{Scope: liveDto.ScopeStream, Namespace: streamID}.String()
+	switch g.GrafanaLive.PublishLimiter.Check(ctx.GetNamespace(), channel, ratelimit.Limits{}, len(body), nil) {
+	case ratelimit.Rejected:
+		logger.Debug("Publish rate limit exceeded", "streamId", streamID)
+		ctx.Resp.WriteHeader(http.StatusTooManyRequests)
+		return
+	case ratelimit.Dropped, ratelimit.Deferred:
+		ctx.Resp.WriteHeader(http.StatusOK)
+		return
+	}
+
+	metricFrames, err := g.converter.Convert(body, inputFormat, frameFormat)
 	if err != nil {
-		logger.Error("Error converting metrics", "error", err, "frameFormat", frameFormat)
-		if errors.Is(err, convert.ErrUnsupportedFrameFormat) {
+		logger.Error("Error converting metrics", "error", err, "inputFormat", inputFormat, "frameFormat", frameFormat)
+		if errors.Is(err, convert.ErrUnsupportedInputFormat) || errors.Is(err, convert.ErrUnsupportedFrameFormat) {
 			ctx.Resp.WriteHeader(http.StatusBadRequest)
 		} else {
 			ctx.Resp.WriteHeader(http.StatusInternalServerError)
@@ -115,6 +129,10 @@ func (g *Gateway) HandlePipelinePush(ctx *contextmodel.ReqContext) {
 
 	ruleFound, err := g.GrafanaLive.Pipeline.ProcessInput(ctx.Req.Context(), ctx.GetNamespace(), channelID, body)
 	if err != nil {
+		if errors.Is(err, ratelimit.ErrRateLimited) {
+			ctx.Resp.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
 		logger.Error("Pipeline input processing error", "error", err, "body", string(body))
 		if errors.Is(err, liveDto.ErrInvalidChannelID) {
 			ctx.Resp.WriteHeader(http.StatusBadRequest)
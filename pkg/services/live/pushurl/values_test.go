@@ -13,3 +13,10 @@ func TestFrameFormatFromValues(t *testing.T) {
 	values.Set(frameFormatParam, "wide")
 	require.Equal(t, "wide", FrameFormatFromValues(values))
 }
+
+func TestInputFormatFromValues(t *testing.T) {
+	values := url.Values{}
+	require.Equal(t, "influx", InputFormatFromValues(values))
+	values.Set(inputFormatParam, "OTLP")
+	require.Equal(t, "otlp", InputFormatFromValues(values))
+}
@@ -1,6 +1,7 @@
 package pushws
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/websocket"
@@ -9,6 +10,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/live/convert"
 	"github.com/grafana/grafana/pkg/services/live/livecontext"
 	"github.com/grafana/grafana/pkg/services/live/pipeline"
+	"github.com/grafana/grafana/pkg/services/live/ratelimit"
 )
 
 // PipelinePushHandler handles WebSocket client connections that push data to Live Pipeline.
@@ -74,6 +76,10 @@ func (s *PipelinePushHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request)
 
 		ruleFound, err := s.pipeline.ProcessInput(r.Context(), user.GetNamespace(), channelID, body)
 		if err != nil {
+			if errors.Is(err, ratelimit.ErrRateLimited) {
+				writeStatus(conn, http.StatusTooManyRequests)
+				continue
+			}
 			logger.Error("Pipeline input processing error", "error", err, "body", string(body))
 			return
 		}
@@ -12,18 +12,20 @@ import (
 	"github.com/grafana/grafana/pkg/services/live/livecontext"
 	"github.com/grafana/grafana/pkg/services/live/managedstream"
 	"github.com/grafana/grafana/pkg/services/live/pushurl"
+	"github.com/grafana/grafana/pkg/services/live/ratelimit"
 )
 
 // Handler handles WebSocket client connections that push data to Live.
 type Handler struct {
 	managedStreamRunner *managedstream.Runner
+	publishLimiter      *ratelimit.PublishLimiter
 	config              Config
 	upgrade             *websocket.Upgrader
 	converter           *convert.Converter
 }
 
 // NewHandler creates new Handler.
-func NewHandler(managedStreamRunner *managedstream.Runner, c Config) *Handler {
+func NewHandler(managedStreamRunner *managedstream.Runner, publishLimiter *ratelimit.PublishLimiter, c Config) *Handler {
 	if c.CheckOrigin == nil {
 		c.CheckOrigin = sameHostOriginCheck()
 	}
@@ -34,6 +36,7 @@ func NewHandler(managedStreamRunner *managedstream.Runner, c Config) *Handler {
 	}
 	return &Handler{
 		managedStreamRunner: managedStreamRunner,
+		publishLimiter:      publishLimiter,
 		config:              c,
 		upgrade:             upgrade,
 		converter:           convert.NewConverter(),
@@ -80,18 +83,29 @@ func (s *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		// TODO Grafana 8: decide which formats to use or keep all.
 		urlValues := r.URL.Query()
 		frameFormat := pushurl.FrameFormatFromValues(urlValues)
+		inputFormat := pushurl.InputFormatFromValues(urlValues)
 
 		logger.Debug("Live Push request",
 			"protocol", "ws",
 			"streamId", streamID,
 			"bodyLength", len(body),
 			"frameFormat", frameFormat,
+			"inputFormat", inputFormat,
 			"duration", time.Since(started).String(),
 		)
 
-		metricFrames, err := s.converter.Convert(body, frameFormat)
+		channel := liveDto.Channel{Scope: liveDto.ScopeStream, Namespace: streamID}.String()
+		result := s.publishLimiter.Check(user.GetNamespace(), channel, ratelimit.Limits{}, len(body), nil)
+		if result == ratelimit.Rejected {
+			writeStatus(conn, http.StatusTooManyRequests)
+		}
+		if result != ratelimit.Allowed {
+			continue
+		}
+
+		metricFrames, err := s.converter.Convert(body, inputFormat, frameFormat)
 		if err != nil {
-			logger.Error("Error converting metrics", "error", err, "frameFormat", frameFormat)
+			logger.Error("Error converting metrics", "error", err, "inputFormat", inputFormat, "frameFormat", frameFormat)
 			continue
 		}
 
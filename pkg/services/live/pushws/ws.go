@@ -72,6 +72,19 @@ const (
 	DefaultWebsocketMessageSizeLimit = 1024 * 1024 // 1MB
 )
 
+// pushStatus is sent to push clients when a message was not accepted.
+type pushStatus struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func writeStatus(conn *websocket.Conn, status int) {
+	err := conn.WriteJSON(pushStatus{Status: status, Message: http.StatusText(status)})
+	if err != nil {
+		logger.Debug("Error writing push status", "error", err)
+	}
+}
+
 func setupWSConn(ctx context.Context, conn *websocket.Conn, config Config) {
 	pingInterval := config.PingInterval
 	if pingInterval == 0 {
@@ -0,0 +1,355 @@
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/grafana/grafana/pkg/services/live/telemetry"
+)
+
+// ErrRateLimited returned when a publication was rejected due to exceeded limits.
+var ErrRateLimited = errors.New("publish rate limit exceeded")
+
+// Policy defines what happens with a publication which exceeds limits.
+type Policy string
+
+const (
+	// PolicyReject rejects a publication, publisher receives an error.
+	PolicyReject Policy = "reject"
+	// PolicyDrop silently drops a publication.
+	PolicyDrop Policy = "drop"
+	// PolicyLastValueWins keeps the latest publication and publishes it as
+	// soon as limits allow. Previously kept publication is discarded.
+	PolicyLastValueWins Policy = "lastValueWins"
+)
+
+// Valid returns true for known policies. Empty policy is valid and means PolicyReject.
+func (p Policy) Valid() bool {
+	switch p {
+	case "", PolicyReject, PolicyDrop, PolicyLastValueWins:
+		return true
+	}
+	return false
+}
+
+// Limits describe allowed publication throughput.
+type Limits struct {
+	// MessagesPerSecond limits the number of publications per second. Zero means no limit.
+	MessagesPerSecond float64
+	// BytesPerSecond limits the size of publications per second. Zero means no limit.
+	// A single publication larger than BytesPerSecond never passes.
+	BytesPerSecond float64
+	// Policy to apply to publications exceeding limits. Zero value means PolicyReject.
+	Policy Policy
+}
+
+// Enabled returns true if any limit is set.
+func (l Limits) Enabled() bool {
+	return l.MessagesPerSecond > 0 || l.BytesPerSecond > 0
+}
+
+func (l Limits) policy() Policy {
+	if l.Policy == "" {
+		return PolicyReject
+	}
+	return l.Policy
+}
+
+// Result of a limits check.
+type Result int
+
+const (
+	// Allowed means publication can proceed.
+	Allowed Result = iota
+	// Rejected means publication must not proceed and publisher should get ErrRateLimited.
+	Rejected
+	// Dropped means publication must not proceed, publisher is not notified.
+	Dropped
+	// Deferred means publication must not proceed now, it will be published later
+	// by calling the deferred function passed to Check.
+	Deferred
+)
+
+// bucketIdleTimeout is a time after which unused buckets are removed.
+const bucketIdleTimeout = time.Minute
+
+// Limiter tracks throughput for arbitrary keys using token buckets.
+type Limiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+
+	// onParentLimited is called when a deferred publication is dropped by parent limits.
+	onParentLimited func(parentLimits Limits)
+}
+
+// NewLimiter creates new Limiter.
+func NewLimiter() *Limiter {
+	return &Limiter{
+		buckets:     map[string]*bucket{},
+		lastCleanup: time.Now(),
+	}
+}
+
+type bucket struct {
+	limits      Limits
+	messages    *rate.Limiter
+	bytes       *rate.Limiter
+	lastUsed    time.Time
+	pending     func()
+	pendingSize int
+	timer       *time.Timer
+
+	// Parent limits the pending publication must also fit into.
+	parentKey    string
+	parentLimits Limits
+}
+
+func newBucket(limits Limits) *bucket {
+	b := &bucket{limits: limits}
+	if limits.MessagesPerSecond > 0 {
+		b.messages = rate.NewLimiter(rate.Limit(limits.MessagesPerSecond), burst(limits.MessagesPerSecond))
+	}
+	if limits.BytesPerSecond > 0 {
+		b.bytes = rate.NewLimiter(rate.Limit(limits.BytesPerSecond), burst(limits.BytesPerSecond))
+	}
+	return b
+}
+
+func burst(perSecond float64) int {
+	return int(math.Max(1, math.Ceil(perSecond)))
+}
+
+// reservation holds tokens taken for a publication until it is cancelled.
+type reservation struct {
+	reservations []*rate.Reservation
+	// delay after which tokens will be available.
+	delay time.Duration
+	// ok is false if the publication can never pass.
+	ok bool
+}
+
+func (r reservation) allowed() bool {
+	return r.ok && r.delay == 0
+}
+
+// cancel returns taken tokens back to the bucket.
+func (r reservation) cancel(now time.Time) {
+	for _, res := range r.reservations {
+		res.CancelAt(now)
+	}
+}
+
+// reserve takes tokens for a publication of the given size. Tokens must be
+// returned with cancel if the publication is not allowed.
+func (b *bucket) reserve(now time.Time, size int) reservation {
+	r := reservation{ok: true}
+	if b.messages != nil {
+		r.reservations = append(r.reservations, b.messages.ReserveN(now, 1))
+	}
+	if b.bytes != nil {
+		r.reservations = append(r.reservations, b.bytes.ReserveN(now, size))
+	}
+	for _, res := range r.reservations {
+		if !res.OK() {
+			r.ok = false
+			continue
+		}
+		if d := res.DelayFrom(now); d > r.delay {
+			r.delay = d
+		}
+	}
+	return r
+}
+
+// Check checks whether a publication of the given size under key fits into limits.
+// The deferred function is only used with PolicyLastValueWins, if it's nil such
+// publications are dropped.
+func (l *Limiter) Check(key string, limits Limits, size int, deferred func()) Result {
+	result, _ := l.check(key, limits, "", Limits{}, size, deferred)
+	return result
+}
+
+// check is like Check, but a publication must also fit into parent limits
+// under parentKey. Tokens are only taken when a publication fits into both
+// limits. Deferred publications are checked against parent limits when they
+// are published. Returns true if a publication was limited by parent limits.
+func (l *Limiter) check(key string, limits Limits, parentKey string, parentLimits Limits, size int, deferred func()) (Result, bool) {
+	if !limits.Enabled() && !parentLimits.Enabled() {
+		return Allowed, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.cleanup(now)
+
+	var r reservation
+	if limits.Enabled() {
+		b := l.bucket(now, key, limits)
+		policy := limits.policy()
+
+		if b.pending != nil && policy == PolicyLastValueWins && deferred != nil {
+			// Do not overtake the pending publication, replace it instead.
+			b.pending, b.pendingSize = deferred, size
+			b.parentKey, b.parentLimits = parentKey, parentLimits
+			return Deferred, false
+		}
+
+		r = b.reserve(now, size)
+		if !r.allowed() {
+			r.cancel(now)
+			switch policy {
+			case PolicyDrop:
+				return Dropped, false
+			case PolicyLastValueWins:
+				if !r.ok || deferred == nil {
+					return Dropped, false
+				}
+				b.pending, b.pendingSize = deferred, size
+				b.parentKey, b.parentLimits = parentKey, parentLimits
+				if b.timer == nil {
+					b.timer = time.AfterFunc(r.delay, func() { l.flush(key, b) })
+				}
+				return Deferred, false
+			default:
+				return Rejected, false
+			}
+		}
+	}
+
+	if !l.reserveParent(now, parentKey, parentLimits, size) {
+		r.cancel(now)
+		if parentLimits.policy() == PolicyReject {
+			return Rejected, true
+		}
+		// PolicyLastValueWins is not supported for parent limits.
+		return Dropped, true
+	}
+	return Allowed, false
+}
+
+// bucket returns a bucket for key with the given limits, must be called with mu held.
+func (l *Limiter) bucket(now time.Time, key string, limits Limits) *bucket {
+	b, ok := l.buckets[key]
+	if !ok || b.limits != limits {
+		if ok && b.timer != nil {
+			b.timer.Stop()
+		}
+		b = newBucket(limits)
+		l.buckets[key] = b
+	}
+	b.lastUsed = now
+	return b
+}
+
+// reserveParent takes tokens of parent limits, returns false if a publication
+// does not fit into them. Must be called with mu held.
+func (l *Limiter) reserveParent(now time.Time, parentKey string, parentLimits Limits, size int) bool {
+	if !parentLimits.Enabled() {
+		return true
+	}
+	r := l.bucket(now, parentKey, parentLimits).reserve(now, size)
+	if !r.allowed() {
+		r.cancel(now)
+		return false
+	}
+	return true
+}
+
+func (l *Limiter) flush(key string, b *bucket) {
+	l.mu.Lock()
+	if l.buckets[key] != b || b.pending == nil {
+		l.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	r := b.reserve(now, b.pendingSize)
+	if r.ok && r.delay > 0 {
+		r.cancel(now)
+		b.timer = time.AfterFunc(r.delay, func() { l.flush(key, b) })
+		l.mu.Unlock()
+		return
+	}
+	publish := b.pending
+	ok := r.ok
+	parentLimited := false
+	if ok && !l.reserveParent(now, b.parentKey, b.parentLimits, b.pendingSize) {
+		r.cancel(now)
+		ok = false
+		parentLimited = true
+	}
+	parentLimits := b.parentLimits
+	b.pending = nil
+	b.pendingSize = 0
+	b.parentKey, b.parentLimits = "", Limits{}
+	b.timer = nil
+	b.lastUsed = now
+	l.mu.Unlock()
+
+	if parentLimited && l.onParentLimited != nil {
+		l.onParentLimited(parentLimits)
+	}
+	if ok {
+		publish()
+	}
+}
+
+// cleanup removes idle buckets, must be called with mu held.
+func (l *Limiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < bucketIdleTimeout {
+		return
+	}
+	l.lastCleanup = now
+	for key, b := range l.buckets {
+		if b.pending == nil && now.Sub(b.lastUsed) > bucketIdleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// PublishLimiter applies per-channel and per-organization limits to Live publications.
+// Limits are tracked in memory, so with several Grafana instances each of them
+// allows the configured throughput.
+type PublishLimiter struct {
+	limiter   *Limiter
+	orgLimits Limits
+}
+
+// NewPublishLimiter creates new PublishLimiter. Organization limits are applied
+// to all publications inside an organization namespace, PolicyLastValueWins is
+// not supported for them and works as PolicyDrop.
+func NewPublishLimiter(orgLimits Limits) *PublishLimiter {
+	limiter := NewLimiter()
+	limiter.onParentLimited = func(parentLimits Limits) {
+		telemetry.PublishLimitedTotal.WithLabelValues("org", string(parentLimits.policy())).Inc()
+	}
+	return &PublishLimiter{
+		limiter:   limiter,
+		orgLimits: orgLimits,
+	}
+}
+
+// Check checks a publication into a channel against channel limits and
+// organization limits. Channel tokens are only taken if a publication fits into
+// organization limits too. The deferred function is called later for
+// publications kept by PolicyLastValueWins channel limits, if they still fit
+// into organization limits at that time. Nil PublishLimiter allows everything.
+func (l *PublishLimiter) Check(ns string, channel string, channelLimits Limits, size int, deferred func()) Result {
+	if l == nil {
+		return Allowed
+	}
+	result, orgLimited := l.limiter.check(ns+"/"+channel, channelLimits, ns, l.orgLimits, size, deferred)
+	switch {
+	case orgLimited:
+		telemetry.PublishLimitedTotal.WithLabelValues("org", string(l.orgLimits.policy())).Inc()
+	case result != Allowed:
+		telemetry.PublishLimitedTotal.WithLabelValues("channel", string(channelLimits.policy())).Inc()
+	}
+	return result
+}
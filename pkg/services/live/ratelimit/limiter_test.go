@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Disabled(t *testing.T) {
+	l := NewLimiter()
+	for i := 0; i < 100; i++ {
+		require.Equal(t, Allowed, l.Check("test", Limits{}, 1024, nil))
+	}
+}
+
+func TestLimiter_MessagesReject(t *testing.T) {
+	l := NewLimiter()
+	limits := Limits{MessagesPerSecond: 2}
+	require.Equal(t, Allowed, l.Check("test", limits, 10, nil))
+	require.Equal(t, Allowed, l.Check("test", limits, 10, nil))
+	require.Equal(t, Rejected, l.Check("test", limits, 10, nil))
+	// Other keys are not affected.
+	require.Equal(t, Allowed, l.Check("other", limits, 10, nil))
+}
+
+func TestLimiter_BytesDrop(t *testing.T) {
+	l := NewLimiter()
+	limits := Limits{BytesPerSecond: 100, Policy: PolicyDrop}
+	require.Equal(t, Allowed, l.Check("test", limits, 60, nil))
+	require.Equal(t, Dropped, l.Check("test", limits, 60, nil))
+	// Rejected publication should not consume tokens.
+	require.Equal(t, Allowed, l.Check("test", limits, 40, nil))
+	// Publication larger than BytesPerSecond never passes.
+	require.Equal(t, Dropped, l.Check("large", limits, 101, nil))
+}
+
+func TestLimiter_LastValueWins(t *testing.T) {
+	l := NewLimiter()
+	limits := Limits{MessagesPerSecond: 20, Policy: PolicyLastValueWins}
+
+	published := make(chan int, 10)
+	publish := func(i int) func() {
+		return func() { published <- i }
+	}
+
+	for i := 0; i < 20; i++ {
+		require.Equal(t, Allowed, l.Check("test", limits, 1, publish(i)))
+	}
+	require.Equal(t, Deferred, l.Check("test", limits, 1, publish(20)))
+	require.Equal(t, Deferred, l.Check("test", limits, 1, publish(21)))
+	require.Equal(t, Deferred, l.Check("test", limits, 1, publish(22)))
+
+	select {
+	case i := <-published:
+		require.Equal(t, 22, i)
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for deferred publication")
+	}
+	select {
+	case i := <-published:
+		require.Fail(t, "unexpected publication", i)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestLimiter_LimitsChange(t *testing.T) {
+	l := NewLimiter()
+	require.Equal(t, Allowed, l.Check("test", Limits{MessagesPerSecond: 1}, 1, nil))
+	require.Equal(t, Rejected, l.Check("test", Limits{MessagesPerSecond: 1}, 1, nil))
+	require.Equal(t, Allowed, l.Check("test", Limits{MessagesPerSecond: 2}, 1, nil))
+}
+
+func TestPublishLimiter_Check(t *testing.T) {
+	l := NewPublishLimiter(Limits{MessagesPerSecond: 3})
+	channelLimits := Limits{MessagesPerSecond: 2, Policy: PolicyDrop}
+
+	require.Equal(t, Allowed, l.Check("default", "stream/test/a", channelLimits, 1, nil))
+	require.Equal(t, Allowed, l.Check("default", "stream/test/a", channelLimits, 1, nil))
+	require.Equal(t, Dropped, l.Check("default", "stream/test/a", channelLimits, 1, nil))
+	require.Equal(t, Allowed, l.Check("default", "stream/test/b", channelLimits, 1, nil))
+	// Organization limit exceeded.
+	require.Equal(t, Rejected, l.Check("default", "stream/test/b", channelLimits, 1, nil))
+	// Other organizations are not affected.
+	require.Equal(t, Allowed, l.Check("org-2", "stream/test/a", channelLimits, 1, nil))
+}
+
+func TestPublishLimiter_OrgLimitedKeepsChannelTokens(t *testing.T) {
+	l := NewPublishLimiter(Limits{BytesPerSecond: 100})
+	channelLimits := Limits{MessagesPerSecond: 2}
+
+	require.Equal(t, Allowed, l.Check("default", "stream/test/a", channelLimits, 60, nil))
+	require.Equal(t, Rejected, l.Check("default", "stream/test/a", channelLimits, 60, nil))
+	// Publication rejected by organization limits did not take a channel token.
+	require.Equal(t, Allowed, l.Check("default", "stream/test/a", channelLimits, 40, nil))
+	require.Equal(t, Rejected, l.Check("default", "stream/test/a", channelLimits, 1, nil))
+}
+
+func TestPublishLimiter_LastValueWinsOrgLimits(t *testing.T) {
+	l := NewPublishLimiter(Limits{MessagesPerSecond: 0.5, Policy: PolicyDrop})
+	channelLimits := Limits{BytesPerSecond: 10, Policy: PolicyLastValueWins}
+
+	published := make(chan struct{}, 1)
+	require.Equal(t, Allowed, l.Check("default", "stream/test/a", channelLimits, 10, nil))
+	require.Equal(t, Deferred, l.Check("default", "stream/test/a", channelLimits, 10, func() {
+		published <- struct{}{}
+	}))
+
+	// Channel limits allow the deferred publication after a second, but
+	// organization limits do not, so it is dropped.
+	select {
+	case <-published:
+		require.Fail(t, "deferred publication exceeded organization limits")
+	case <-time.After(1500 * time.Millisecond):
+	}
+}
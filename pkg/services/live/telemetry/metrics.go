@@ -0,0 +1,14 @@
+package telemetry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PublishLimitedTotal counts publications which exceeded Live publish rate limits.
+var PublishLimitedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "grafana",
+	Subsystem: "live",
+	Name:      "publish_limited_total",
+	Help:      "Total number of Live publications exceeding publish rate limits",
+}, []string{"scope", "policy"}) // scope: "channel" or "org", policy: "reject", "drop" or "lastValueWins"
@@ -0,0 +1,198 @@
+package otlp
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+
+	influx "github.com/influxdata/line-protocol"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/live/telemetry"
+	"github.com/grafana/grafana/pkg/services/live/telemetry/telegraf"
+)
+
+var (
+	logger = log.New("live.telemetry.otlp")
+)
+
+var _ telemetry.Converter = (*Converter)(nil)
+
+// valueField is a name of a field which holds data point values.
+const valueField = "value"
+
+// Converter converts OpenTelemetry metrics to Grafana frames.
+type Converter struct {
+	frames *telegraf.Converter
+}
+
+// NewConverter creates new Converter from OTLP metrics to Grafana Data Frames.
+// Frames have the same shape as the ones produced by telegraf.Converter with the same options.
+// Histograms and summaries are split into _count, _sum, _bucket and quantile series the same
+// way Prometheus does.
+func NewConverter(opts ...telegraf.ConverterOption) *Converter {
+	return &Converter{
+		frames: telegraf.NewConverter(opts...),
+	}
+}
+
+// Convert OTLP export metrics request. Both protobuf and JSON encodings are supported.
+func (c *Converter) Convert(body []byte) ([]telemetry.FrameWrapper, error) {
+	req := pmetricotlp.NewExportRequest()
+	var err error
+	if isJSON(body) {
+		err = req.UnmarshalJSON(body)
+	} else {
+		err = req.UnmarshalProto(body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OTLP metrics: %w", err)
+	}
+	metrics, err := metricsFromOTLP(req.Metrics())
+	if err != nil {
+		return nil, err
+	}
+	return c.frames.ConvertMetrics(metrics)
+}
+
+// isJSON reports whether body looks like a JSON object. Protobuf encoded requests
+// never start with '{' since the first byte is a tag of resource_metrics field.
+func isJSON(body []byte) bool {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+type metricsBuilder struct {
+	metrics []influx.Metric
+	err     error
+}
+
+func (b *metricsBuilder) add(name string, tags map[string]string, value any, ts pcommon.Timestamp) {
+	if b.err != nil {
+		return
+	}
+	m, err := influx.New(name, tags, map[string]any{valueField: value}, ts.AsTime().UTC())
+	if err != nil {
+		b.err = fmt.Errorf("error creating metric %s: %w", name, err)
+		return
+	}
+	b.metrics = append(b.metrics, m)
+}
+
+func metricsFromOTLP(md pmetric.Metrics) ([]influx.Metric, error) {
+	b := &metricsBuilder{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resourceTags := attributesToTags(rm.Resource().Attributes(), nil)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				b.addMetric(sm.Metrics().At(k), resourceTags)
+			}
+		}
+	}
+	return b.metrics, b.err
+}
+
+func (b *metricsBuilder) addMetric(m pmetric.Metric, resourceTags map[string]string) {
+	name := m.Name()
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		b.addNumberDataPoints(name, m.Gauge().DataPoints(), resourceTags)
+	case pmetric.MetricTypeSum:
+		b.addNumberDataPoints(name, m.Sum().DataPoints(), resourceTags)
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			tags := attributesToTags(dp.Attributes(), resourceTags)
+			b.add(name+"_count", tags, float64(dp.Count()), dp.Timestamp())
+			if dp.HasSum() {
+				b.add(name+"_sum", tags, dp.Sum(), dp.Timestamp())
+			}
+			// OTLP bucket counts are not cumulative, Prometheus ones are.
+			var cumulative uint64
+			for j := 0; j < dp.BucketCounts().Len(); j++ {
+				cumulative += dp.BucketCounts().At(j)
+				upperBound := math.Inf(1)
+				if j < dp.ExplicitBounds().Len() {
+					upperBound = dp.ExplicitBounds().At(j)
+				}
+				bucketTags := withTag(tags, "le", formatFloat(upperBound))
+				b.add(name+"_bucket", bucketTags, float64(cumulative), dp.Timestamp())
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			tags := attributesToTags(dp.Attributes(), resourceTags)
+			b.add(name+"_count", tags, float64(dp.Count()), dp.Timestamp())
+			if dp.HasSum() {
+				b.add(name+"_sum", tags, dp.Sum(), dp.Timestamp())
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			tags := attributesToTags(dp.Attributes(), resourceTags)
+			b.add(name+"_count", tags, float64(dp.Count()), dp.Timestamp())
+			b.add(name+"_sum", tags, dp.Sum(), dp.Timestamp())
+			for j := 0; j < dp.QuantileValues().Len(); j++ {
+				q := dp.QuantileValues().At(j)
+				quantileTags := withTag(tags, "quantile", formatFloat(q.Quantile()))
+				b.add(name, quantileTags, q.Value(), dp.Timestamp())
+			}
+		}
+	default:
+		logger.Debug("Skipping metric of unsupported type", "name", name, "type", m.Type().String())
+	}
+}
+
+func (b *metricsBuilder) addNumberDataPoints(name string, dps pmetric.NumberDataPointSlice, resourceTags map[string]string) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		tags := attributesToTags(dp.Attributes(), resourceTags)
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			b.add(name, tags, dp.IntValue(), dp.Timestamp())
+		case pmetric.NumberDataPointValueTypeDouble:
+			b.add(name, tags, dp.DoubleValue(), dp.Timestamp())
+		}
+	}
+}
+
+// attributesToTags merges attributes into a copy of base tags. Attributes take
+// precedence over base tags with the same key.
+func attributesToTags(attrs pcommon.Map, base map[string]string) map[string]string {
+	tags := make(map[string]string, len(base)+attrs.Len())
+	for k, v := range base {
+		tags[k] = v
+	}
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		tags[k] = v.AsString()
+		return true
+	})
+	return tags
+}
+
+func withTag(tags map[string]string, key, value string) map[string]string {
+	result := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,100 @@
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+
+	"github.com/grafana/grafana/pkg/services/live/telemetry/telegraf"
+)
+
+func testMetrics() pmetric.Metrics {
+	ts := pcommon.NewTimestampFromTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "job")
+	sm := rm.ScopeMetrics().AppendEmpty()
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("cpu")
+	gdp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	gdp.Attributes().PutStr("host", "a")
+	gdp.SetDoubleValue(1)
+	gdp.SetTimestamp(ts)
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sdp := sum.SetEmptySum().DataPoints().AppendEmpty()
+	sdp.SetIntValue(10)
+	sdp.SetTimestamp(ts)
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(6)
+	hdp.ExplicitBounds().FromRaw([]float64{1, 2})
+	hdp.BucketCounts().FromRaw([]uint64{1, 1, 1})
+	hdp.SetTimestamp(ts)
+	return md
+}
+
+func TestConverter_Convert(t *testing.T) {
+	req := pmetricotlp.NewExportRequestFromMetrics(testMetrics())
+	protoBody, err := req.MarshalProto()
+	require.NoError(t, err)
+	jsonBody, err := req.MarshalJSON()
+	require.NoError(t, err)
+
+	for name, body := range map[string][]byte{"proto": protoBody, "json": jsonBody} {
+		t.Run(name, func(t *testing.T) {
+			frameWrappers, err := NewConverter(telegraf.WithUseLabelsColumn(true)).Convert(body)
+			require.NoError(t, err)
+
+			keys := make([]string, 0, len(frameWrappers))
+			for _, fw := range frameWrappers {
+				keys = append(keys, fw.Key())
+			}
+			require.Equal(t, []string{"cpu", "requests", "latency_count", "latency_sum", "latency_bucket"}, keys)
+
+			cpu := frameWrappers[0].Frame()
+			require.Len(t, cpu.Fields, 3)
+			require.Equal(t, "host=a, service.name=job", cpu.Fields[0].At(0))
+
+			bucket := frameWrappers[4].Frame()
+			require.Equal(t, 3, bucket.Fields[0].Len())
+			require.Equal(t, "le=+Inf, service.name=job", bucket.Fields[0].At(2))
+			v, ok := bucket.Fields[2].ConcreteAt(2)
+			require.True(t, ok)
+			require.Equal(t, 3.0, v)
+		})
+	}
+}
+
+func TestConverter_ConvertWide(t *testing.T) {
+	body, err := pmetricotlp.NewExportRequestFromMetrics(testMetrics()).MarshalProto()
+	require.NoError(t, err)
+
+	frameWrappers, err := NewConverter().Convert(body)
+	require.NoError(t, err)
+	require.Len(t, frameWrappers, 5)
+
+	cpu := frameWrappers[0].Frame()
+	require.Len(t, cpu.Fields, 2)
+	require.Equal(t, "time", cpu.Fields[0].Name)
+	require.Equal(t, "value", cpu.Fields[1].Name)
+	require.Equal(t, "job", cpu.Fields[1].Labels["service.name"])
+
+	// Buckets share metric name and time, so they end up in the same frame.
+	bucket := frameWrappers[4].Frame()
+	require.Len(t, bucket.Fields, 4)
+}
+
+func TestConverter_ConvertInvalid(t *testing.T) {
+	_, err := NewConverter().Convert([]byte("{invalid"))
+	require.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package remotewrite
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	influx "github.com/influxdata/line-protocol"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/grafana/grafana/pkg/services/live/telemetry"
+	"github.com/grafana/grafana/pkg/services/live/telemetry/telegraf"
+)
+
+var _ telemetry.Converter = (*Converter)(nil)
+
+// valueField is a name of a field which holds sample values.
+const valueField = "value"
+
+// maxDecodedSize limits the size of decompressed remote write requests, so that a small request
+// that declares a large decoded length does not allocate that much memory.
+const maxDecodedSize = 32 << 20
+
+var errRequestTooLarge = errors.New("decompressed remote write request is too large")
+
+// Converter converts Prometheus remote write requests to Grafana frames.
+type Converter struct {
+	frames *telegraf.Converter
+}
+
+// NewConverter creates new Converter from Prometheus remote write format to Grafana Data Frames.
+// Frames have the same shape as the ones produced by telegraf.Converter with the same options,
+// every time series sample becomes a metric with a single value field.
+func NewConverter(opts ...telegraf.ConverterOption) *Converter {
+	return &Converter{
+		frames: telegraf.NewConverter(opts...),
+	}
+}
+
+// Convert snappy compressed remote write request.
+func (c *Converter) Convert(body []byte) ([]telemetry.FrameWrapper, error) {
+	size, err := snappy.DecodedLen(body)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing remote write request: %w", err)
+	}
+	if size > maxDecodedSize {
+		return nil, fmt.Errorf("%w: %d bytes, limit is %d bytes", errRequestTooLarge, size, maxDecodedSize)
+	}
+	decoded, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing remote write request: %w", err)
+	}
+	var req prompb.WriteRequest
+	if err := proto.Unmarshal(decoded, &req); err != nil {
+		return nil, fmt.Errorf("error parsing remote write request: %w", err)
+	}
+	metrics, err := metricsFromTimeSeries(req.Timeseries)
+	if err != nil {
+		return nil, err
+	}
+	return c.frames.ConvertMetrics(metrics)
+}
+
+func metricsFromTimeSeries(timeSeries []prompb.TimeSeries) ([]influx.Metric, error) {
+	var metrics []influx.Metric
+	for _, ts := range timeSeries {
+		var name string
+		tags := make(map[string]string, len(ts.Labels))
+		for _, l := range ts.Labels {
+			if l.Name == labels.MetricName {
+				name = l.Value
+				continue
+			}
+			tags[l.Name] = l.Value
+		}
+		if name == "" {
+			return nil, fmt.Errorf("time series without %s label", labels.MetricName)
+		}
+		for _, s := range ts.Samples {
+			if value.IsStaleNaN(s.Value) {
+				// Staleness markers have no meaning for frames.
+				continue
+			}
+			m, err := influx.New(name, tags, map[string]any{valueField: s.Value}, time.UnixMilli(s.Timestamp))
+			if err != nil {
+				return nil, fmt.Errorf("error creating metric %s: %w", name, err)
+			}
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics, nil
+}
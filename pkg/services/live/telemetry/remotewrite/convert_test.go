@@ -0,0 +1,93 @@
+package remotewrite
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/live/remotewrite"
+	"github.com/grafana/grafana/pkg/services/live/telemetry/telegraf"
+)
+
+func testWriteRequest(t *testing.T) []byte {
+	t.Helper()
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	body, err := remotewrite.TimeSeriesToBytes([]prompb.TimeSeries{
+		{
+			Labels: []prompb.Label{
+				{Name: "__name__", Value: "cpu"},
+				{Name: "host", Value: "a"},
+			},
+			Samples: []prompb.Sample{
+				{Timestamp: now, Value: 1},
+				{Timestamp: now + 1000, Value: 2},
+				{Timestamp: now + 2000, Value: math.Float64frombits(value.StaleNaN)},
+			},
+		},
+		{
+			Labels: []prompb.Label{
+				{Name: "__name__", Value: "cpu"},
+				{Name: "host", Value: "b"},
+			},
+			Samples: []prompb.Sample{
+				{Timestamp: now, Value: 3},
+			},
+		},
+	})
+	require.NoError(t, err)
+	return body
+}
+
+func TestConverter_Convert(t *testing.T) {
+	t.Run("wide", func(t *testing.T) {
+		frameWrappers, err := NewConverter().Convert(testWriteRequest(t))
+		require.NoError(t, err)
+		// One frame for each metric name and time combination.
+		require.Len(t, frameWrappers, 2)
+
+		frame := frameWrappers[0].Frame()
+		require.Equal(t, "cpu", frame.Name)
+		require.Len(t, frame.Fields, 3)
+		require.Equal(t, "time", frame.Fields[0].Name)
+		require.Equal(t, "value", frame.Fields[1].Name)
+		require.Equal(t, "a", frame.Fields[1].Labels["host"])
+		require.Equal(t, "b", frame.Fields[2].Labels["host"])
+
+		frame = frameWrappers[1].Frame()
+		require.Len(t, frame.Fields, 2)
+		v, ok := frame.Fields[1].ConcreteAt(0)
+		require.True(t, ok)
+		require.Equal(t, 2.0, v)
+	})
+
+	t.Run("labels column", func(t *testing.T) {
+		frameWrappers, err := NewConverter(telegraf.WithUseLabelsColumn(true)).Convert(testWriteRequest(t))
+		require.NoError(t, err)
+		require.Len(t, frameWrappers, 1)
+
+		frame := frameWrappers[0].Frame()
+		require.Equal(t, "cpu", frameWrappers[0].Key())
+		require.Len(t, frame.Fields, 3)
+		require.Equal(t, "labels", frame.Fields[0].Name)
+		require.Equal(t, 3, frame.Fields[0].Len())
+		require.Equal(t, "host=a", frame.Fields[0].At(0))
+		require.Equal(t, "host=b", frame.Fields[0].At(2))
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		_, err := NewConverter().Convert([]byte("cpu value=1"))
+		require.Error(t, err)
+	})
+
+	t.Run("too large body", func(t *testing.T) {
+		// A snappy block starts with the decoded length as uvarint.
+		body := binary.AppendUvarint(nil, maxDecodedSize+1)
+		_, err := NewConverter().Convert(body)
+		require.ErrorIs(t, err, errRequestTooLarge)
+	})
+}
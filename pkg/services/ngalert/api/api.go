@@ -67,6 +67,7 @@ type API struct {
 	DataProxy             *datasourceproxy.DataSourceProxyService
 	MultiOrgAlertmanager  *notifier.MultiOrgAlertmanager
 	StateManager          state.AlertInstanceManager
+	Acknowledger          AlertInstanceAcknowledger
 	RuleStatusReader      apiprometheus.StatusReader
 	AccessControl         ac.AccessControl
 	ReceiverService       *notifier.ReceiverService
@@ -78,11 +79,14 @@ type API struct {
 	MuteTimings           *provisioning.MuteTimingService
 	InhibitionRules       *inhibition_rules.Service
 	AlertRules            *provisioning.AlertRuleService
+	AlertRuleTemplates    *provisioning.AlertRuleTemplateService
+	TemplateLibraries     *provisioning.TemplateLibraryService
 	AlertsRouter          *sender.AlertsRouter
 	EvaluatorFactory      eval.EvaluatorFactory
 	ConditionValidator    *eval.ConditionValidator
 	FeatureManager        featuremgmt.FeatureToggles
 	Historian             Historian
+	HistoryBackends       HistoryBackendFactory
 	Tracer                tracing.Tracer
 	AppUrl                *url.URL
 	UserService           user.Service
@@ -158,6 +162,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			amRefresher:        api.MultiOrgAlertmanager,
 			featureManager:     api.FeatureManager,
 			userService:        api.UserService,
+			acknowledger:       api.Acknowledger,
 		},
 	), m)
 	api.RegisterTestingApiEndpoints(NewTestingApi(
@@ -169,10 +174,13 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			evaluator:       api.EvaluatorFactory,
 			cfg:             &api.Cfg.UnifiedAlerting,
 			backtesting:     backtesting.NewEngine(api.AppUrl, api.EvaluatorFactory, api.Tracer, api.Cfg.UnifiedAlerting, api.FeatureManager),
+			amConfig:        api.MultiOrgAlertmanager,
 			featureManager:  api.FeatureManager,
 			appUrl:          api.AppUrl,
 			tracer:          api.Tracer,
 			folderService:   api.RuleStore,
+			rules:           api.RuleStore,
+			stateManager:    api.StateManager,
 		}), m)
 	api.RegisterConfigurationApiEndpoints(NewConfiguration(
 		&ConfigSrv{
@@ -191,13 +199,18 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		templates:           api.Templates,
 		muteTimings:         api.MuteTimings,
 		alertRules:          api.AlertRules,
+		alertRuleTemplates:  api.AlertRuleTemplates,
+		templateLibraries:   api.TemplateLibraries,
 		// XXX: Used to flag recording rules, remove when FT is removed
 		featureManager: api.FeatureManager,
 	}), m)
 
 	api.RegisterHistoryApiEndpoints(NewStateHistoryApi(&HistorySrv{
-		logger: logger,
-		hist:   api.Historian,
+		logger:     logger,
+		hist:       api.Historian,
+		rules:      api.RuleStore,
+		backends:   api.HistoryBackends,
+		migrations: newHistoryMigrations(),
 	}), m)
 
 	api.RegisterConvertPrometheusApiEndpoints(NewConvertPrometheusApi(convertSrv), m)
@@ -12,6 +12,7 @@ import (
 	alertingmodels "github.com/grafana/alerting/models"
 	alertingNotify "github.com/grafana/alerting/notify"
 	"github.com/grafana/alerting/receivers/schema"
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
@@ -279,6 +280,48 @@ func (srv AlertmanagerSrv) RoutePostTestTemplates(c *contextmodel.ReqContext, bo
 	return response.JSON(http.StatusOK, newTestTemplateResult(res))
 }
 
+// RoutePostRoutingSimulation returns how the notification policies of the organization handle alerts with the given labels.
+func (srv AlertmanagerSrv) RoutePostRoutingSimulation(c *contextmodel.ReqContext, body apimodels.RoutingSimulationConfig) response.Response {
+	if len(body.Labels) == 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("at least one label set is required"), "")
+	}
+	labelSets := make([]model.LabelSet, 0, len(body.Labels))
+	for _, l := range body.Labels {
+		lset := make(model.LabelSet, len(l))
+		for k, v := range l {
+			lset[model.LabelName(k)] = model.LabelValue(v)
+		}
+		if err := lset.Validate(); err != nil {
+			return ErrResp(http.StatusBadRequest, err, "invalid labels")
+		}
+		labelSets = append(labelSets, lset)
+	}
+	at := time.Now()
+	if body.Time != nil {
+		at = *body.Time
+	}
+
+	simulations, err := srv.mam.SimulateRouting(c.Req.Context(), c.GetOrgID(), labelSets, at)
+	if err != nil {
+		if errors.Is(err, notifier.ErrNoAlertmanagerForOrg) {
+			return response.Error(http.StatusNotFound, err.Error(), err)
+		}
+		if errors.Is(err, notifier.ErrAlertmanagerNotReady) {
+			return response.Error(http.StatusConflict, err.Error(), err)
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to simulate routing")
+	}
+
+	result := apimodels.RoutingSimulationResults{
+		Time:    at,
+		Results: make([]apimodels.RoutingSimulationResult, 0, len(simulations)),
+	}
+	for _, s := range simulations {
+		result.Results = append(result.Results, newRoutingSimulationResult(s))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
 // contextWithTimeoutFromRequest returns a context with a deadline set from the
 // Request-Timeout header in the HTTP request. If the header is absent then the
 // context will use the default timeout. The timeout in the Request-Timeout
@@ -343,6 +386,45 @@ func newTestTemplateResult(res *notifier.TestTemplatesResults) apimodels.TestTem
 	return apiRes
 }
 
+func newRoutingSimulationResult(s notifier.RoutingSimulation) apimodels.RoutingSimulationResult {
+	result := apimodels.RoutingSimulationResult{
+		Labels:      labelSetToMap(s.Labels),
+		Routes:      make([]apimodels.SimulatedRoute, 0, len(s.Routes)),
+		Silences:    s.Silences,
+		InhibitedBy: make([]map[string]string, 0, len(s.InhibitedBy)),
+	}
+	for _, r := range s.Routes {
+		route := apimodels.SimulatedRoute{
+			Path:                r.Path,
+			Receiver:            r.Receiver,
+			GroupBy:             r.GroupBy,
+			GroupWait:           model.Duration(r.GroupWait).String(),
+			GroupInterval:       model.Duration(r.GroupInterval).String(),
+			RepeatInterval:      model.Duration(r.RepeatInterval).String(),
+			MuteTimeIntervals:   r.MuteTimeIntervals,
+			ActiveTimeIntervals: r.ActiveTimeIntervals,
+			Muted:               r.Muted,
+			Integrations:        make([]apimodels.SimulatedIntegration, 0, len(r.Integrations)),
+		}
+		for _, i := range r.Integrations {
+			route.Integrations = append(route.Integrations, apimodels.SimulatedIntegration{UID: i.UID, Name: i.Name, Type: i.Type})
+		}
+		result.Routes = append(result.Routes, route)
+	}
+	for _, lset := range s.InhibitedBy {
+		result.InhibitedBy = append(result.InhibitedBy, labelSetToMap(lset))
+	}
+	return result
+}
+
+func labelSetToMap(lset model.LabelSet) map[string]string {
+	result := make(map[string]string, len(lset))
+	for k, v := range lset {
+		result[string(k)] = string(v)
+	}
+	return result
+}
+
 func (srv AlertmanagerSrv) AlertmanagerFor(orgID int64) (notifier.Alertmanager, *response.NormalResponse) {
 	am, err := srv.mam.AlertmanagerFor(orgID)
 	if err == nil {
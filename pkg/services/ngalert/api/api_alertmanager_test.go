@@ -442,6 +442,56 @@ func TestRoutePostTestTemplates(t *testing.T) {
 	})
 }
 
+func TestRoutePostRoutingSimulation(t *testing.T) {
+	sut := createSut(t)
+	body := apimodels.RoutingSimulationConfig{
+		Labels: []map[string]string{{"alertname": "test"}},
+	}
+
+	t.Run("assert 400 when no labels are given", func(tt *testing.T) {
+		rc := createRequestCtxInOrg(1)
+
+		response := sut.RoutePostRoutingSimulation(rc, apimodels.RoutingSimulationConfig{})
+		require.Equal(tt, 400, response.Status())
+	})
+
+	t.Run("assert 400 when labels are invalid", func(tt *testing.T) {
+		rc := createRequestCtxInOrg(1)
+
+		response := sut.RoutePostRoutingSimulation(rc, apimodels.RoutingSimulationConfig{
+			Labels: []map[string]string{{"invalid-name": "test"}},
+		})
+		require.Equal(tt, 400, response.Status())
+	})
+
+	t.Run("assert 404 when no alertmanager found", func(tt *testing.T) {
+		rc := createRequestCtxInOrg(10)
+
+		response := sut.RoutePostRoutingSimulation(rc, body)
+		require.Equal(tt, 404, response.Status())
+	})
+
+	t.Run("assert 409 when alertmanager not ready", func(tt *testing.T) {
+		rc := createRequestCtxInOrg(3)
+
+		response := sut.RoutePostRoutingSimulation(rc, body)
+		require.Equal(tt, 409, response.Status())
+	})
+
+	t.Run("assert 200 for a valid alertmanager", func(tt *testing.T) {
+		rc := createRequestCtxInOrg(1)
+
+		response := sut.RoutePostRoutingSimulation(rc, body)
+		require.Equal(tt, 200, response.Status())
+
+		var result apimodels.RoutingSimulationResults
+		require.NoError(tt, json.Unmarshal(response.Body(), &result))
+		require.Len(tt, result.Results, 1)
+		require.Equal(tt, map[string]string{"alertname": "test"}, result.Results[0].Labels)
+		require.NotEmpty(tt, result.Results[0].Routes)
+	})
+}
+
 func createSut(t *testing.T) AlertmanagerSrv {
 	t.Helper()
 
@@ -134,6 +134,7 @@ func TestRouteGetAlertStatuses(t *testing.T) {
 				"annotation": "test"
 			},
 			"state": "Normal",
+			"fingerprint": "0000000000000000",
 			"activeAt": "0001-01-01T00:00:00Z",
 			"value": ""
 		}, {
@@ -146,6 +147,7 @@ func TestRouteGetAlertStatuses(t *testing.T) {
 				"annotation": "test"
 			},
 			"state": "Normal",
+			"fingerprint": "0000000000000000",
 			"activeAt": "0001-01-01T00:00:00Z",
 			"value": ""
 		}]
@@ -176,6 +178,7 @@ func TestRouteGetAlertStatuses(t *testing.T) {
 				"annotation": "test"
 			},
 			"state": "Alerting",
+			"fingerprint": "0000000000000000",
 			"activeAt": "0001-01-01T00:00:00Z",
 			"value": "1.1e+00"
 		}, {
@@ -188,6 +191,7 @@ func TestRouteGetAlertStatuses(t *testing.T) {
 				"annotation": "test"
 			},
 			"state": "Alerting",
+			"fingerprint": "0000000000000000",
 			"activeAt": "0001-01-01T00:00:00Z",
 			"value": "1.1e+00"
 		}]
@@ -218,6 +222,7 @@ func TestRouteGetAlertStatuses(t *testing.T) {
 							"annotation": "test"
 						},
 						"state": "Recovering",
+						"fingerprint": "0000000000000000",
 						"activeAt": "0001-01-01T00:00:00Z",
 						"value": "1.1e+00"
 					}]
@@ -252,6 +257,7 @@ func TestRouteGetAlertStatuses(t *testing.T) {
 				"annotation": "test"
 			},
 			"state": "Normal",
+			"fingerprint": "0000000000000000",
 			"activeAt": "0001-01-01T00:00:00Z",
 			"value": ""
 		}, {
@@ -266,6 +272,7 @@ func TestRouteGetAlertStatuses(t *testing.T) {
 				"annotation": "test"
 			},
 			"state": "Normal",
+			"fingerprint": "0000000000000000",
 			"activeAt": "0001-01-01T00:00:00Z",
 			"value": ""
 		}]
@@ -387,6 +394,7 @@ func TestRouteGetRuleStatuses(t *testing.T) {
 						"severity": "critical"
 					},
 					"state": "Normal",
+					"fingerprint": "0000000000000000",
 					"activeAt": "0001-01-01T00:00:00Z",
 					"value": ""
 				}],
@@ -493,6 +501,7 @@ func TestRouteGetRuleStatuses(t *testing.T) {
 						"severity": "critical"
 					},
 					"state": "Normal",
+					"fingerprint": "0000000000000000",
 					"activeAt": "0001-01-01T00:00:00Z",
 					"value": ""
 				}],
@@ -604,6 +613,7 @@ func TestRouteGetRuleStatuses(t *testing.T) {
 						"severity": "critical"
 					},
 					"state": "Normal",
+					"fingerprint": "0000000000000000",
 					"activeAt": "0001-01-01T00:00:00Z",
 					"value": ""
 				}],
@@ -670,6 +680,7 @@ func TestRouteGetRuleStatuses(t *testing.T) {
 						"severity": "critical"
 					},
 					"state": "Normal",
+					"fingerprint": "0000000000000000",
 					"activeAt": "0001-01-01T00:00:00Z",
 					"value": ""
 				}],
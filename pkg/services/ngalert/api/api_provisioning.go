@@ -33,6 +33,8 @@ type ProvisioningSrv struct {
 	templates           TemplateService
 	muteTimings         MuteTimingService
 	alertRules          AlertRuleService
+	alertRuleTemplates  AlertRuleTemplateService
+	templateLibraries   TemplateLibraryService
 	folderSvc           folder.Service
 
 	// XXX: Used to flag recording rules, remove when FT is removed
@@ -83,6 +85,24 @@ type AlertRuleService interface {
 	GetAlertGroupsWithFolderFullpath(ctx context.Context, u identity.Requester, opts *provisioning.FilterOptions) ([]alerting_models.AlertRuleGroupWithFolderFullpath, error)
 }
 
+type AlertRuleTemplateService interface {
+	GetTemplates(ctx context.Context, user identity.Requester) ([]alerting_models.AlertRuleTemplate, error)
+	GetTemplate(ctx context.Context, user identity.Requester, uid string) (alerting_models.AlertRuleTemplate, error)
+	CreateTemplate(ctx context.Context, user identity.Requester, t alerting_models.AlertRuleTemplate) (alerting_models.AlertRuleTemplate, error)
+	UpdateTemplate(ctx context.Context, user identity.Requester, t alerting_models.AlertRuleTemplate) (alerting_models.AlertRuleTemplate, error)
+	DeleteTemplate(ctx context.Context, user identity.Requester, uid string) error
+	ReconcileTemplate(ctx context.Context, user identity.Requester, uid string) (alerting_models.AlertRuleTemplate, error)
+}
+
+type TemplateLibraryService interface {
+	GetLibraries(ctx context.Context) ([]alerting_models.TemplateLibrary, error)
+	GetLibrary(ctx context.Context, uid string) (alerting_models.TemplateLibrary, error)
+	GetLibraryVersions(ctx context.Context, uid string) ([]alerting_models.TemplateLibraryVersion, error)
+	CreateLibrary(ctx context.Context, l alerting_models.TemplateLibrary) (alerting_models.TemplateLibrary, error)
+	UpdateLibrary(ctx context.Context, l alerting_models.TemplateLibrary) (alerting_models.TemplateLibrary, error)
+	DeleteLibrary(ctx context.Context, uid string) error
+}
+
 func (srv *ProvisioningSrv) RouteGetPolicyTree(c *contextmodel.ReqContext) response.Response {
 	policies, _, err := srv.policies.GetPolicyTree(c.Req.Context(), c.GetOrgID())
 	if errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
@@ -534,6 +554,118 @@ func (srv *ProvisioningSrv) RouteDeleteAlertRuleGroup(c *contextmodel.ReqContext
 	return response.JSON(http.StatusNoContent, "")
 }
 
+func (srv *ProvisioningSrv) RouteGetAlertRuleTemplates(c *contextmodel.ReqContext) response.Response {
+	templates, err := srv.alertRuleTemplates.GetTemplates(c.Req.Context(), c.SignedInUser)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get alert rule templates", err)
+	}
+	return response.JSON(http.StatusOK, ApiAlertRuleTemplatesFromAlertRuleTemplates(templates))
+}
+
+func (srv *ProvisioningSrv) RouteGetAlertRuleTemplate(c *contextmodel.ReqContext, UID string) response.Response {
+	t, err := srv.alertRuleTemplates.GetTemplate(c.Req.Context(), c.SignedInUser, UID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get alert rule template", err)
+	}
+	return response.JSON(http.StatusOK, ApiAlertRuleTemplateFromAlertRuleTemplate(t))
+}
+
+func (srv *ProvisioningSrv) RoutePostAlertRuleTemplate(c *contextmodel.ReqContext, body definitions.AlertRuleTemplate) response.Response {
+	created, err := srv.alertRuleTemplates.CreateTemplate(c.Req.Context(), c.SignedInUser, AlertRuleTemplateFromApiAlertRuleTemplate(body))
+	if err != nil {
+		return alertRuleTemplateErrorResponse(err, "failed to create alert rule template")
+	}
+	return response.JSON(http.StatusCreated, ApiAlertRuleTemplateFromAlertRuleTemplate(created))
+}
+
+func (srv *ProvisioningSrv) RoutePutAlertRuleTemplate(c *contextmodel.ReqContext, body definitions.AlertRuleTemplate, UID string) response.Response {
+	t := AlertRuleTemplateFromApiAlertRuleTemplate(body)
+	t.UID = UID
+	updated, err := srv.alertRuleTemplates.UpdateTemplate(c.Req.Context(), c.SignedInUser, t)
+	if err != nil {
+		return alertRuleTemplateErrorResponse(err, "failed to update alert rule template")
+	}
+	return response.JSON(http.StatusOK, ApiAlertRuleTemplateFromAlertRuleTemplate(updated))
+}
+
+func (srv *ProvisioningSrv) RouteDeleteAlertRuleTemplate(c *contextmodel.ReqContext, UID string) response.Response {
+	if err := srv.alertRuleTemplates.DeleteTemplate(c.Req.Context(), c.SignedInUser, UID); err != nil {
+		return alertRuleTemplateErrorResponse(err, "failed to delete alert rule template")
+	}
+	return response.JSON(http.StatusNoContent, "")
+}
+
+func (srv *ProvisioningSrv) RoutePostAlertRuleTemplateReconcile(c *contextmodel.ReqContext, UID string) response.Response {
+	t, err := srv.alertRuleTemplates.ReconcileTemplate(c.Req.Context(), c.SignedInUser, UID)
+	if err != nil {
+		return alertRuleTemplateErrorResponse(err, "failed to reconcile alert rule template")
+	}
+	return response.JSON(http.StatusOK, ApiAlertRuleTemplateFromAlertRuleTemplate(t))
+}
+
+func (srv *ProvisioningSrv) RouteGetTemplateLibraries(c *contextmodel.ReqContext) response.Response {
+	libraries, err := srv.templateLibraries.GetLibraries(c.Req.Context())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get template libraries", err)
+	}
+	return response.JSON(http.StatusOK, ApiTemplateLibrariesFromTemplateLibraries(libraries))
+}
+
+func (srv *ProvisioningSrv) RouteGetTemplateLibrary(c *contextmodel.ReqContext, UID string) response.Response {
+	l, err := srv.templateLibraries.GetLibrary(c.Req.Context(), UID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get template library", err)
+	}
+	return response.JSON(http.StatusOK, ApiTemplateLibraryFromTemplateLibrary(l))
+}
+
+func (srv *ProvisioningSrv) RouteGetTemplateLibraryVersions(c *contextmodel.ReqContext, UID string) response.Response {
+	versions, err := srv.templateLibraries.GetLibraryVersions(c.Req.Context(), UID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get template library versions", err)
+	}
+	return response.JSON(http.StatusOK, ApiTemplateLibraryVersionsFromTemplateLibraryVersions(versions))
+}
+
+func (srv *ProvisioningSrv) RoutePostTemplateLibrary(c *contextmodel.ReqContext, body definitions.TemplateLibrary) response.Response {
+	created, err := srv.templateLibraries.CreateLibrary(c.Req.Context(), TemplateLibraryFromApiTemplateLibrary(body))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to create template library", err)
+	}
+	return response.JSON(http.StatusCreated, ApiTemplateLibraryFromTemplateLibrary(created))
+}
+
+func (srv *ProvisioningSrv) RoutePutTemplateLibrary(c *contextmodel.ReqContext, body definitions.TemplateLibrary, UID string) response.Response {
+	l := TemplateLibraryFromApiTemplateLibrary(body)
+	l.UID = UID
+	updated, err := srv.templateLibraries.UpdateLibrary(c.Req.Context(), l)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to update template library", err)
+	}
+	return response.JSON(http.StatusOK, ApiTemplateLibraryFromTemplateLibrary(updated))
+}
+
+func (srv *ProvisioningSrv) RouteDeleteTemplateLibrary(c *contextmodel.ReqContext, UID string) response.Response {
+	if err := srv.templateLibraries.DeleteLibrary(c.Req.Context(), UID); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to delete template library", err)
+	}
+	return response.JSON(http.StatusNoContent, "")
+}
+
+// alertRuleTemplateErrorResponse maps the errors of the alert rules generated by a template to responses.
+// Errors of the template itself are errutil errors and have their own status.
+func alertRuleTemplateErrorResponse(err error, msg string) response.Response {
+	switch {
+	case errors.Is(err, alerting_models.ErrAlertRuleFailedValidation):
+		return ErrResp(http.StatusBadRequest, err, "")
+	case errors.Is(err, store.ErrOptimisticLock):
+		return ErrResp(http.StatusConflict, err, "")
+	case errors.Is(err, alerting_models.ErrQuotaReached):
+		return ErrResp(http.StatusForbidden, err, "")
+	}
+	return response.ErrOrFallback(http.StatusInternalServerError, msg, err)
+}
+
 func determineProvenance(ctx *contextmodel.ReqContext) definitions.Provenance {
 	if _, disabled := ctx.Req.Header[disableProvenanceHeaderName]; disabled {
 		return definitions.Provenance(alerting_models.ProvenanceNone)
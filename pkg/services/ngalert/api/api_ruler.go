@@ -60,6 +60,7 @@ type RulerSrv struct {
 	conditionValidator ConditionValidator
 	authz              RuleAccessControlService
 	userService        user.Service
+	acknowledger       AlertInstanceAcknowledger
 
 	amConfigStore  AMConfigStore
 	amRefresher    AMRefresher
@@ -697,6 +698,8 @@ func toGettableExtendedRuleNode(r ngmodels.AlertRule, provenanceRecords map[stri
 			Metadata:                    AlertRuleMetadataFromModelMetadata(r.Metadata),
 			GUID:                        r.GUID,
 			MissingSeriesEvalsToResolve: r.MissingSeriesEvalsToResolve,
+			SeverityConditions:          ApiSeverityConditionsFromModelSeverityConditions(r.SeverityConditions),
+			FlapDetection:               ApiFlapDetectionFromModelFlapDetection(r.FlapDetection),
 		},
 	}
 	forDuration := model.Duration(r.For)
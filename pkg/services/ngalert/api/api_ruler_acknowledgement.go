@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apiprometheus "github.com/grafana/grafana/pkg/services/ngalert/api/prometheus"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// AlertInstanceAcknowledger acknowledges alert instances of Grafana-managed rules.
+type AlertInstanceAcknowledger interface {
+	Acknowledge(ctx context.Context, orgID int64, ruleUID string, cacheID data.Fingerprint, ack ngmodels.Acknowledgement) (*state.State, error)
+	Unacknowledge(ctx context.Context, orgID int64, ruleUID string, cacheID data.Fingerprint) (*state.State, error)
+}
+
+var errAcknowledgementNotSupported = errors.New("alert instances cannot be acknowledged when Grafana runs in high availability mode")
+
+// ClusterSizer reports the number of members of the Alertmanager cluster, such as the cluster peer of the
+// MultiOrgAlertmanager. Peers that do not implement it are not clustered.
+type ClusterSizer interface {
+	ClusterSize() int
+}
+
+// singleNodeAcknowledger acknowledges alert instances only while the Alertmanager of the node is not clustered
+// with other nodes, because acknowledgements are kept in the state of the node that evaluates the rule.
+type singleNodeAcknowledger struct {
+	AlertInstanceAcknowledger
+	peer any
+}
+
+// NewSingleNodeAcknowledger returns an acknowledger that rejects acknowledgements while the Alertmanager cluster
+// of the peer has more than one member.
+func NewSingleNodeAcknowledger(acknowledger AlertInstanceAcknowledger, peer any) AlertInstanceAcknowledger {
+	return singleNodeAcknowledger{AlertInstanceAcknowledger: acknowledger, peer: peer}
+}
+
+func (a singleNodeAcknowledger) Acknowledge(ctx context.Context, orgID int64, ruleUID string, cacheID data.Fingerprint, ack ngmodels.Acknowledgement) (*state.State, error) {
+	if a.clustered() {
+		return nil, errAcknowledgementNotSupported
+	}
+	return a.AlertInstanceAcknowledger.Acknowledge(ctx, orgID, ruleUID, cacheID, ack)
+}
+
+func (a singleNodeAcknowledger) Unacknowledge(ctx context.Context, orgID int64, ruleUID string, cacheID data.Fingerprint) (*state.State, error) {
+	if a.clustered() {
+		return nil, errAcknowledgementNotSupported
+	}
+	return a.AlertInstanceAcknowledger.Unacknowledge(ctx, orgID, ruleUID, cacheID)
+}
+
+func (a singleNodeAcknowledger) clustered() bool {
+	sizer, ok := a.peer.(ClusterSizer)
+	return ok && sizer.ClusterSize() > 1
+}
+
+// RoutePostAlertInstanceAcknowledgement acknowledges a firing alert instance of the rule.
+func (srv RulerSrv) RoutePostAlertInstanceAcknowledgement(c *contextmodel.ReqContext, body apimodels.PostableAlertAcknowledgement, ruleUID, fingerprint string) response.Response {
+	if srv.acknowledger == nil {
+		return ErrResp(http.StatusNotImplemented, errAcknowledgementNotSupported, "")
+	}
+	rule, cacheID, resp := srv.getAlertInstanceRule(c, ruleUID, fingerprint)
+	if resp != nil {
+		return resp
+	}
+
+	now := time.Now()
+	if !body.ExpiresAt.After(now) {
+		return ErrResp(http.StatusBadRequest, errors.New("expiresAt must be in the future"), "")
+	}
+	ack := ngmodels.Acknowledgement{
+		By:                    c.SignedInUser.GetLogin(),
+		Comment:               body.Comment,
+		At:                    now,
+		ExpiresAt:             body.ExpiresAt,
+		SuppressNotifications: body.SuppressNotifications,
+		UntilValueChanges:     body.UntilValueChanges,
+	}
+	s, err := srv.acknowledger.Acknowledge(c.Req.Context(), rule.OrgID, rule.UID, cacheID, ack)
+	if err != nil {
+		return acknowledgementErrorResponse(err)
+	}
+	return response.JSON(http.StatusOK, apiprometheus.AlertAcknowledgementFromModel(s.Acknowledgement))
+}
+
+// RouteDeleteAlertInstanceAcknowledgement removes the acknowledgement of an alert instance of the rule.
+func (srv RulerSrv) RouteDeleteAlertInstanceAcknowledgement(c *contextmodel.ReqContext, ruleUID, fingerprint string) response.Response {
+	if srv.acknowledger == nil {
+		return ErrResp(http.StatusNotImplemented, errAcknowledgementNotSupported, "")
+	}
+	rule, cacheID, resp := srv.getAlertInstanceRule(c, ruleUID, fingerprint)
+	if resp != nil {
+		return resp
+	}
+	if _, err := srv.acknowledger.Unacknowledge(c.Req.Context(), rule.OrgID, rule.UID, cacheID); err != nil {
+		return acknowledgementErrorResponse(err)
+	}
+	return response.JSON(http.StatusAccepted, util.DynMap{"message": "acknowledgement removed"})
+}
+
+// getAlertInstanceRule returns the rule the user is authorized to access and the parsed fingerprint of its alert instance.
+func (srv RulerSrv) getAlertInstanceRule(c *contextmodel.ReqContext, ruleUID, fingerprint string) (ngmodels.AlertRule, data.Fingerprint, response.Response) {
+	cacheID, err := strconv.ParseUint(fingerprint, 16, 64)
+	if err != nil {
+		return ngmodels.AlertRule{}, 0, ErrResp(http.StatusBadRequest, err, "invalid fingerprint")
+	}
+	rule, err := srv.getAuthorizedRuleByUid(c.Req.Context(), c, ruleUID)
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return ngmodels.AlertRule{}, 0, response.Empty(http.StatusNotFound)
+		}
+		return ngmodels.AlertRule{}, 0, response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule by UID", err)
+	}
+	return rule, data.Fingerprint(cacheID), nil
+}
+
+func acknowledgementErrorResponse(err error) response.Response {
+	switch {
+	case errors.Is(err, errAcknowledgementNotSupported):
+		return ErrResp(http.StatusNotImplemented, err, "")
+	case errors.Is(err, state.ErrAlertInstanceNotFound):
+		return ErrResp(http.StatusNotFound, err, "")
+	case errors.Is(err, state.ErrAlertInstanceNotFiring), errors.Is(err, state.ErrSilencerNotConfigured):
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, "failed to update the acknowledgement of the alert instance")
+}
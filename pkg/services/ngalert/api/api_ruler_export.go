@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
@@ -13,8 +16,12 @@ import (
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	apivalidation "github.com/grafana/grafana/pkg/services/ngalert/api/validation"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/prom"
 )
 
+// prometheusExportFormat is the export format that converts the rules to a Prometheus rule file.
+const prometheusExportFormat = "prometheus"
+
 // ExportFromPayload converts the rule groups from the argument `ruleGroupConfig` to export format. All rules are expected to be fully specified. The access to data sources mentioned in the rules is not enforced.
 // Can return 403 StatusForbidden if user is not authorized to read folder `namespaceUID`
 func (srv RulerSrv) ExportFromPayload(c *contextmodel.ReqContext, ruleGroupConfig apimodels.PostableRuleGroupConfig, namespaceUID string) response.Response {
@@ -39,6 +46,10 @@ func (srv RulerSrv) ExportFromPayload(c *contextmodel.ReqContext, ruleGroupConfi
 
 	groupsWithFullpath := ngmodels.NewAlertRuleGroupWithFolderFullpath(rules[0].GetGroupKey(), rules, namespace.Fullpath)
 
+	if c.Query("format") == prometheusExportFormat {
+		return exportPrometheusRules(c, []ngmodels.AlertRuleGroupWithFolderFullpath{groupsWithFullpath})
+	}
+
 	e, err := AlertingFileExportFromAlertRuleGroupWithFolderFullpath([]ngmodels.AlertRuleGroupWithFolderFullpath{groupsWithFullpath})
 	if err != nil {
 		return ErrResp(http.StatusInternalServerError, err, "failed to create alerting file export")
@@ -97,6 +108,10 @@ func (srv RulerSrv) ExportRules(c *contextmodel.ReqContext) response.Response {
 	// sort result so the response is always stable
 	ngmodels.SortAlertRuleGroupWithFolderTitle(groups)
 
+	if c.Query("format") == prometheusExportFormat {
+		return exportPrometheusRules(c, groups)
+	}
+
 	e, err := AlertingFileExportFromAlertRuleGroupWithFolderFullpath(groups)
 	if err != nil {
 		return ErrResp(http.StatusInternalServerError, err, "failed to create alerting file export")
@@ -104,6 +119,48 @@ func (srv RulerSrv) ExportRules(c *contextmodel.ReqContext) response.Response {
 	return exportResponse(c, e)
 }
 
+// exportPrometheusRules converts the rule groups to a Prometheus rule file.
+// Rules that cannot be expressed as Prometheus rules are omitted and listed with the reason in a comment above their group.
+func exportPrometheusRules(c *contextmodel.ReqContext, groups []ngmodels.AlertRuleGroupWithFolderFullpath) response.Response {
+	// Group names are unique only within a folder but must be unique within a rule file.
+	names := make(map[string]int, len(groups))
+	for _, group := range groups {
+		names[group.Title]++
+	}
+
+	file := struct {
+		Groups []*yaml.Node `yaml:"groups"`
+	}{
+		Groups: make([]*yaml.Node, 0, len(groups)),
+	}
+	for _, group := range groups {
+		name := group.Title
+		if names[name] > 1 {
+			name = group.FolderFullpath + "/" + group.Title
+		}
+		promGroup, unsupported := prom.GrafanaRulesToPrometheus(name, group.Rules)
+
+		node := &yaml.Node{}
+		if err := node.Encode(promGroup); err != nil {
+			return ErrResp(http.StatusInternalServerError, err, "failed to create Prometheus rule file")
+		}
+		if len(unsupported) > 0 {
+			lines := make([]string, 0, len(unsupported)+1)
+			lines = append(lines, "The following rules cannot be expressed as Prometheus rules and are not exported:")
+			for _, rule := range unsupported {
+				lines = append(lines, fmt.Sprintf("- %s (%s): %s", rule.Title, rule.UID, rule.Reason))
+			}
+			node.HeadComment = strings.Join(lines, "\n")
+		}
+		file.Groups = append(file.Groups, node)
+	}
+
+	if c.QueryBoolWithDefault("download", false) {
+		return response.YAMLDownload(http.StatusOK, file, "rules.yaml")
+	}
+	return response.YAML(http.StatusOK, file)
+}
+
 // getRuleWithFolderFullpathByRuleUid calls getAuthorizedRuleByUid and combines its result with folder (aka namespace) title.
 func (srv RulerSrv) getRuleWithFolderFullpathByRuleUid(c *contextmodel.ReqContext, ruleUID string) (ngmodels.AlertRuleGroupWithFolderFullpath, error) {
 	rule, err := srv.getAuthorizedRuleByUid(c.Req.Context(), c, ruleUID)
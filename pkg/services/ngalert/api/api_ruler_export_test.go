@@ -90,6 +90,21 @@ func TestExportFromPayload(t *testing.T) {
 		require.Equal(t, "text/yaml", rc.Context.Resp.Header().Get("Content-Type"))
 	})
 
+	t.Run("query format contains prometheus, GET returns Prometheus rule file", func(t *testing.T) {
+		rc := createRequest()
+		rc.Req.Form.Set("format", "prometheus")
+
+		response := srv.ExportFromPayload(rc, body, folder.UID)
+		response.WriteTo(rc)
+
+		require.Equal(t, 200, response.Status())
+		require.Equal(t, "text/yaml", rc.Context.Resp.Header().Get("Content-Type"))
+		// None of the rules in the payload can be expressed as Prometheus rules.
+		require.Contains(t, string(response.Body()), "name: group101")
+		require.Contains(t, string(response.Body()), "# The following rules cannot be expressed as Prometheus rules and are not exported:")
+		require.Contains(t, string(response.Body()), "the rule fires on query errors, which is not supported by Prometheus")
+	})
+
 	t.Run("accept header contains json, GET returns json", func(t *testing.T) {
 		rc := createRequest()
 		rc.Req.Header.Add("Accept", "application/json")
@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/alertmanager/pkg/labels"
@@ -16,17 +18,28 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state/historian"
 )
 
 type Historian interface {
 	Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error)
 }
 
+// HistoryBackendFactory creates a state history backend of the given type. It is used to move history between backends.
+type HistoryBackendFactory func(ctx context.Context, backend historian.BackendType) (historian.Backend, error)
+
+// defaultExportRange is the time range of the exported state history if the start of the range is not specified.
+const defaultExportRange = 24 * time.Hour
+
 type HistorySrv struct {
-	logger log.Logger
-	hist   Historian
+	logger     log.Logger
+	hist       Historian
+	rules      historian.MigrationRuleStore
+	backends   HistoryBackendFactory
+	migrations *historyMigrations
 }
 
 func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) response.Response {
@@ -42,6 +55,263 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 	return response.JSON(http.StatusOK, frame)
 }
 
+// RouteMigrateStateHistory starts a background job that reads the state history of the organization from one backend
+// and writes it to another. Only one migration can run in an organization at a time.
+func (srv *HistorySrv) RouteMigrateStateHistory(c *contextmodel.ReqContext, body apimodels.StateHistoryMigrationConfig) response.Response {
+	if body.From.IsZero() {
+		return ErrResp(http.StatusBadRequest, errors.New("from is required"), "")
+	}
+	if body.Source == body.Destination {
+		return ErrResp(http.StatusBadRequest, errors.New("source and destination must be different backends"), "")
+	}
+	source, err := srv.migrationBackend(c.Req.Context(), body.Source)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid source")
+	}
+	destination, err := srv.migrationBackend(c.Req.Context(), body.Destination)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid destination")
+	}
+
+	opts := historian.MigrationOptions{
+		OrgID:        c.OrgID,
+		SignedInUser: c.SignedInUser,
+		From:         body.From,
+		To:           body.To,
+		RuleUIDs:     body.RuleUIDs,
+		Window:       time.Duration(body.Window),
+		Limit:        body.Limit,
+		MaxBatches:   body.MaxBatches,
+	}
+	if body.Resume != nil {
+		opts.Resume = &historian.MigrationCheckpoint{RuleUID: body.Resume.RuleUID, Time: body.Resume.Time}
+	}
+
+	// the migration outlives the request, it keeps the values of the request context but not its cancellation.
+	ctx := context.WithoutCancel(c.Req.Context())
+	logger := srv.logger.FromContext(ctx).New("source", body.Source, "destination", body.Destination)
+	job, started := srv.migrations.start(c.OrgID, func(update func(historian.MigrationProgress)) (historian.MigrationProgress, error) {
+		logger.Info("Migrating state history", "from", opts.From, "to", opts.To)
+		m := historian.NewMigrator(srv.rules, source, logger)
+		progress, err := m.Migrate(ctx, destination, opts, func(p historian.MigrationProgress) {
+			logger.Debug("State history migration progress", "rules_completed", p.RulesCompleted, "rules_total", p.RulesTotal, "entries", p.Entries)
+			update(p)
+		})
+		if err != nil {
+			logger.Error("State history migration stopped", "rule_uid", progress.Checkpoint.RuleUID, "time", progress.Checkpoint.Time, "error", err)
+			return progress, err
+		}
+		logger.Info("Migrated state history", "rules_completed", progress.RulesCompleted, "rules_total", progress.RulesTotal, "entries", progress.Entries, "done", progress.Done)
+		return progress, nil
+	})
+	if !started {
+		return response.JSON(http.StatusConflict, job.status())
+	}
+	return response.JSON(http.StatusAccepted, job.status())
+}
+
+// RouteGetStateHistoryMigration returns the status of the last state history migration of the organization.
+func (srv *HistorySrv) RouteGetStateHistoryMigration(c *contextmodel.ReqContext) response.Response {
+	job := srv.migrations.get(c.OrgID)
+	if job == nil {
+		return ErrResp(http.StatusNotFound, errors.New("no state history migration was started"), "")
+	}
+	return response.JSON(http.StatusOK, job.status())
+}
+
+// RouteExportStateHistory streams the state history of the organization as newline-delimited JSON.
+func (srv *HistorySrv) RouteExportStateHistory(c *contextmodel.ReqContext) response.Response {
+	query := c.Req.URL.Query()
+	backend, err := srv.migrationBackend(c.Req.Context(), query.Get("backend"))
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid backend")
+	}
+	opts, err := ParseHistoryExportQuery(c.OrgID, c.SignedInUser, query, time.Now())
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	m := historian.NewMigrator(srv.rules, backend, srv.logger.FromContext(c.Req.Context()))
+	return &exportResponse{migrator: m, opts: opts}
+}
+
+// ParseHistoryExportQuery parses the time range and rules of a state history export. Timestamps are unix seconds, the same
+// as in the state history query API. The range defaults to defaultExportRange before the end, which defaults to now.
+func ParseHistoryExportQuery(orgID int64, user identity.Requester, query url.Values, now time.Time) (historian.MigrationOptions, error) {
+	opts := historian.MigrationOptions{
+		OrgID:        orgID,
+		SignedInUser: user,
+		To:           now,
+		RuleUIDs:     query["ruleUID"],
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return historian.MigrationOptions{}, fmt.Errorf("invalid to: %w", err)
+		}
+		opts.To = time.Unix(to, 0)
+	}
+	opts.From = opts.To.Add(-defaultExportRange)
+	if v := query.Get("from"); v != "" {
+		from, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return historian.MigrationOptions{}, fmt.Errorf("invalid from: %w", err)
+		}
+		opts.From = time.Unix(from, 0)
+	}
+	if !opts.From.Before(opts.To) {
+		return historian.MigrationOptions{}, errors.New("from must be before to")
+	}
+	return opts, nil
+}
+
+// exportResponse writes the exported history directly to the response as it is read from the backend.
+type exportResponse struct {
+	migrator *historian.Migrator
+	opts     historian.MigrationOptions
+	status   int
+}
+
+func (r *exportResponse) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *exportResponse) Body() []byte {
+	return nil
+}
+
+func (r *exportResponse) WriteTo(ctx *contextmodel.ReqContext) {
+	w := &lazyHeaderWriter{w: ctx.Resp}
+	if _, err := r.migrator.Export(ctx.Req.Context(), w, r.opts); err != nil {
+		if !w.written {
+			// nothing was sent yet, so the client can still get a proper error.
+			errResp := ErrResp(http.StatusInternalServerError, err, "failed to export state history")
+			r.status = errResp.Status()
+			errResp.WriteTo(ctx)
+			return
+		}
+		// the response is already partially sent, the client sees a truncated export.
+		ctx.Logger.Error("Failed to export state history", "error", err)
+	}
+	if !w.written {
+		w.writeHeader()
+	}
+}
+
+// lazyHeaderWriter sends the response headers with the first write.
+type lazyHeaderWriter struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (w *lazyHeaderWriter) writeHeader() {
+	w.written = true
+	w.w.Header().Set("Content-Type", "application/x-ndjson")
+	w.w.WriteHeader(http.StatusOK)
+}
+
+func (w *lazyHeaderWriter) Write(b []byte) (int, error) {
+	if !w.written {
+		w.writeHeader()
+	}
+	return w.w.Write(b)
+}
+
+// historyMigrations keeps the state history migrations running in the background, at most one per organization.
+type historyMigrations struct {
+	mu   sync.Mutex
+	jobs map[int64]*historyMigration
+}
+
+func newHistoryMigrations() *historyMigrations {
+	return &historyMigrations{jobs: make(map[int64]*historyMigration)}
+}
+
+// start runs the migration in the background unless another migration of the organization is running,
+// in which case the running one is returned and started is false.
+func (m *historyMigrations) start(orgID int64, run func(update func(historian.MigrationProgress)) (historian.MigrationProgress, error)) (job *historyMigration, started bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[orgID]; ok && job.status().Running {
+		return job, false
+	}
+	job = &historyMigration{running: true}
+	m.jobs[orgID] = job
+	go func() {
+		progress, err := run(job.update)
+		job.finish(progress, err)
+	}()
+	return job, true
+}
+
+// get returns the last migration of the organization or nil.
+func (m *historyMigrations) get(orgID int64) *historyMigration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.jobs[orgID]
+}
+
+type historyMigration struct {
+	mu       sync.Mutex
+	running  bool
+	progress historian.MigrationProgress
+	err      error
+}
+
+func (j *historyMigration) update(p historian.MigrationProgress) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.progress = p
+}
+
+func (j *historyMigration) finish(p historian.MigrationProgress, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.running = false
+	j.progress = p
+	j.err = err
+}
+
+func (j *historyMigration) status() apimodels.StateHistoryMigrationStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	result := apimodels.StateHistoryMigrationStatus{
+		Running: j.running,
+		Progress: apimodels.StateHistoryMigrationProgress{
+			RulesTotal:     j.progress.RulesTotal,
+			RulesCompleted: j.progress.RulesCompleted,
+			Batches:        j.progress.Batches,
+			Entries:        j.progress.Entries,
+			Done:           j.progress.Done,
+			Checkpoint: apimodels.StateHistoryMigrationCheckpoint{
+				RuleUID: j.progress.Checkpoint.RuleUID,
+				Time:    j.progress.Checkpoint.Time,
+			},
+		},
+	}
+	if j.err != nil {
+		result.Error = j.err.Error()
+	}
+	return result
+}
+
+// migrationBackend creates a backend that history can be read from or written to.
+func (srv *HistorySrv) migrationBackend(ctx context.Context, name string) (historian.Backend, error) {
+	if srv.backends == nil {
+		return nil, errors.New("state history backends are not available")
+	}
+	backendType, err := historian.ParseBackendType(name)
+	if err != nil {
+		return nil, err
+	}
+	if backendType == historian.BackendTypeMultiple || backendType == historian.BackendTypeNoop {
+		return nil, fmt.Errorf("state history cannot be migrated from or to the %s backend", backendType)
+	}
+	return srv.backends(ctx, backendType)
+}
+
 const labelQueryPrefix = "labels_"
 
 // ParseHistoryQuery parses a HistoryQuery from request parameters.
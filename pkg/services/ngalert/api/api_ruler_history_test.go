@@ -2,10 +2,13 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +18,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state/historian"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/web"
 )
@@ -156,3 +160,59 @@ func matchersByName(ms labels.Matchers) map[string]*labels.Matcher {
 	}
 	return out
 }
+
+func TestParseHistoryExportQuery(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("defaults to a bounded range before now", func(t *testing.T) {
+		opts, err := ParseHistoryExportQuery(1, &user.SignedInUser{}, url.Values{}, now)
+		require.NoError(t, err)
+		require.Equal(t, now, opts.To)
+		require.Equal(t, now.Add(-defaultExportRange), opts.From)
+	})
+
+	t.Run("parses timestamps as seconds", func(t *testing.T) {
+		q := url.Values{
+			"from": {strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)},
+			"to":   {strconv.FormatInt(now.Unix(), 10)},
+		}
+		opts, err := ParseHistoryExportQuery(1, &user.SignedInUser{}, q, time.Now())
+		require.NoError(t, err)
+		require.True(t, now.Add(-time.Hour).Equal(opts.From))
+		require.True(t, now.Equal(opts.To))
+	})
+
+	t.Run("rejects invalid ranges", func(t *testing.T) {
+		_, err := ParseHistoryExportQuery(1, &user.SignedInUser{}, url.Values{"from": {"abc"}}, now)
+		require.Error(t, err)
+		_, err = ParseHistoryExportQuery(1, &user.SignedInUser{}, url.Values{"from": {strconv.FormatInt(now.Unix(), 10)}}, now)
+		require.Error(t, err)
+	})
+}
+
+func TestHistoryMigrations(t *testing.T) {
+	migrations := newHistoryMigrations()
+	require.Nil(t, migrations.get(1))
+
+	release := make(chan struct{})
+	job, started := migrations.start(1, func(update func(historian.MigrationProgress)) (historian.MigrationProgress, error) {
+		update(historian.MigrationProgress{RulesTotal: 2, Batches: 1})
+		<-release
+		return historian.MigrationProgress{RulesTotal: 2, RulesCompleted: 1, Batches: 2}, errors.New("source is unavailable")
+	})
+	require.True(t, started)
+	require.True(t, job.status().Running)
+
+	_, started = migrations.start(1, func(func(historian.MigrationProgress)) (historian.MigrationProgress, error) {
+		t.Fatal("a second migration must not start in the same organization")
+		return historian.MigrationProgress{}, nil
+	})
+	require.False(t, started)
+
+	close(release)
+	require.Eventually(t, func() bool { return !migrations.get(1).status().Running }, time.Second, 10*time.Millisecond)
+	status := migrations.get(1).status()
+	require.Equal(t, "source is unavailable", status.Error)
+	require.Equal(t, 1, status.Progress.RulesCompleted)
+	require.Equal(t, 2, status.Progress.Batches)
+}
@@ -333,6 +333,23 @@ func TestValidateRuleGroupFailures(t *testing.T) {
 				require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
 			},
 		},
+		{
+			name: "fail if recording rules depend on each other",
+			group: func() *apimodels.PostableRuleGroupConfig {
+				recording := func(metric, query string) apimodels.PostableExtendedRuleNode {
+					r := validRule()
+					r.GrafanaManagedAlert.Condition = ""
+					r.GrafanaManagedAlert.Record = &apimodels.Record{Metric: metric, From: "A"}
+					r.GrafanaManagedAlert.Data[0].Model = []byte(fmt.Sprintf(`{"expr": %q}`, query))
+					return r
+				}
+				g := validGroup(cfg, recording("metric_a", "metric_b"), recording("metric_b", "metric_a"))
+				return &g
+			},
+			assert: func(t *testing.T, apiModel *apimodels.PostableRuleGroupConfig, err error) {
+				require.ErrorIs(t, err, models.ErrRuleGroupDependencyCycle)
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1131,6 +1148,61 @@ func TestValidateRuleNodeEditorSettings(t *testing.T) {
 	}
 }
 
+func TestValidateRuleNodeSeverityConditions(t *testing.T) {
+	cfg := config(t)
+	limits := makeLimits(cfg)
+
+	t.Run("should convert severity conditions", func(t *testing.T) {
+		r := validRule()
+		r.GrafanaManagedAlert.SeverityConditions = []apimodels.SeverityCondition{
+			{Severity: "warning", Condition: "A"},
+		}
+		newRule, err := ValidateRuleNode(&r, util.GenerateShortUID(), cfg.BaseInterval*time.Duration(rand.Int63n(10)+1), rand.Int63(), randFolder().UID, limits)
+		require.NoError(t, err)
+		require.Equal(t, models.SeverityConditions{{Severity: "warning", Condition: "A"}}, newRule.SeverityConditions)
+	})
+
+	t.Run("should fail if condition does not exist", func(t *testing.T) {
+		r := validRule()
+		r.GrafanaManagedAlert.SeverityConditions = []apimodels.SeverityCondition{
+			{Severity: "warning", Condition: "B"},
+		}
+		_, err := ValidateRuleNode(&r, util.GenerateShortUID(), cfg.BaseInterval*time.Duration(rand.Int63n(10)+1), rand.Int63(), randFolder().UID, limits)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "invalid severity conditions")
+	})
+}
+
+func TestValidateRuleNodeFlapDetection(t *testing.T) {
+	cfg := config(t)
+	limits := makeLimits(cfg)
+	interval := cfg.BaseInterval * 2
+
+	t.Run("should convert flap detection", func(t *testing.T) {
+		r := validRule()
+		r.GrafanaManagedAlert.FlapDetection = &apimodels.AlertRuleFlapDetection{
+			Transitions:        4,
+			Window:             model.Duration(10 * interval),
+			NotificationPolicy: apimodels.FlapSuppress,
+		}
+		newRule, err := ValidateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder().UID, limits)
+		require.NoError(t, err)
+		require.Equal(t, &models.FlapDetection{Transitions: 4, Window: 10 * interval, NotificationPolicy: models.FlapSuppress}, newRule.FlapDetection)
+	})
+
+	t.Run("should fail if window is shorter than the interval", func(t *testing.T) {
+		r := validRule()
+		r.GrafanaManagedAlert.FlapDetection = &apimodels.AlertRuleFlapDetection{
+			Transitions:        4,
+			Window:             model.Duration(interval / 2),
+			NotificationPolicy: apimodels.FlapNotify,
+		}
+		_, err := ValidateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder().UID, limits)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "invalid flap detection")
+	})
+}
+
 func TestValidateRuleNodeReservedLabels(t *testing.T) {
 	cfg := config(t)
 	limits := makeLimits(cfg)
@@ -1,15 +1,18 @@
 package api
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/benbjohnson/clock"
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/alerting/models"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -40,6 +43,14 @@ type folderService interface {
 	GetNamespaceByUID(ctx context.Context, uid string, orgID int64, user identity.Requester) (*folder.Folder, error)
 }
 
+type alertmanagerConfigProvider interface {
+	GetAlertmanagerConfiguration(ctx context.Context, org int64, withAutogen bool, withMergedExtraConfig bool) (apimodels.GettableUserConfig, error)
+}
+
+type ruleReader interface {
+	GetAlertRuleByUID(ctx context.Context, query *ngmodels.GetAlertRuleByUIDQuery) (*ngmodels.AlertRule, error)
+}
+
 type TestingApiSrv struct {
 	*AlertingProxy
 	DatasourceCache datasources.CacheService
@@ -52,15 +63,170 @@ type TestingApiSrv struct {
 	appUrl          *url.URL
 	tracer          tracing.Tracer
 	folderService   folderService
+	amConfig        alertmanagerConfigProvider
+	rules           ruleReader
+	stateManager    state.AlertInstanceManager
 }
 
 // RouteTestGrafanaRuleConfig returns a list of potential alerts for a given rule configuration. This is intended to be
 // as true as possible to what would be generated by the ruler except that the resulting alerts are not filtered to
 // only Resolved / Firing and ready to send.
 func (srv TestingApiSrv) RouteTestGrafanaRuleConfig(c *contextmodel.ReqContext, body apimodels.PostableExtendedRuleNodeExtended) response.Response {
+	rule, folder, errResp := srv.prepareTestRule(c, body)
+	if errResp != nil {
+		return errResp
+	}
+
+	now := time.Now()
+	results, errResp := srv.evaluateTestRule(c, rule, now)
+	if errResp != nil {
+		return errResp
+	}
+
+	manager := srv.newTestStateManager()
+	includeFolder := !srv.cfg.ReservedLabels.IsReservedLabelDisabled(models.FolderTitleLabel)
+	transitions := manager.ProcessEvalResults(
+		c.Req.Context(),
+		now,
+		rule,
+		results,
+		state.GetRuleExtraLabels(log.New("testing"), rule, folder.Fullpath, includeFolder, srv.featureManager),
+		nil,
+	)
+
+	alerts := make([]*amv2.PostableAlert, 0, len(transitions))
+	for _, alertState := range transitions {
+		alerts = append(alerts, state.StateToPostableAlert(alertState, srv.appUrl, srv.featureManager))
+	}
+
+	return response.JSON(http.StatusOK, alerts)
+}
+
+// RouteTestGrafanaRuleDiff evaluates the edited rule now and compares the result with the current alert instances of the rule.
+// It returns the instances that would resolve, keep firing or start firing, and the instances whose labels would change.
+func (srv TestingApiSrv) RouteTestGrafanaRuleDiff(c *contextmodel.ReqContext, body apimodels.PostableExtendedRuleNodeExtended) response.Response {
+	rule, folder, errResp := srv.prepareTestRule(c, body)
+	if errResp != nil {
+		return errResp
+	}
+	if rule.Type() == ngmodels.RuleTypeRecording {
+		return ErrResp(http.StatusBadRequest, errors.New("recording rules do not have alert instances"), "")
+	}
+
+	var current []*state.State
+	if rule.UID != "" {
+		existing, err := srv.rules.GetAlertRuleByUID(c.Req.Context(), &ngmodels.GetAlertRuleByUIDQuery{OrgID: c.GetOrgID(), UID: rule.UID})
+		if err != nil && !errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return errorToResponse(err)
+		}
+		if existing != nil {
+			if err := srv.authz.AuthorizeAccessInFolder(c.Req.Context(), c.SignedInUser, existing); err != nil {
+				return errorToResponse(err)
+			}
+			current = srv.stateManager.GetStatesForRuleUID(c.Req.Context(), c.GetOrgID(), rule.UID)
+		}
+	}
+
+	now := time.Now()
+	results, errResp := srv.evaluateTestRule(c, rule, now)
+	if errResp != nil {
+		return errResp
+	}
+
+	// Continue from copies of the current states, so that pending periods and the time the alerts started firing are kept.
+	manager := srv.newTestStateManager()
+	seed := make([]*state.State, 0, len(current))
+	for _, s := range current {
+		seed = append(seed, s.Copy())
+	}
+	manager.Put(seed)
+
+	includeFolder := !srv.cfg.ReservedLabels.IsReservedLabelDisabled(models.FolderTitleLabel)
+	transitions := manager.ProcessEvalResults(
+		c.Req.Context(),
+		now,
+		rule,
+		results,
+		state.GetRuleExtraLabels(log.New("testing"), rule, folder.Fullpath, includeFolder, srv.featureManager),
+		nil,
+	)
+
+	return response.JSON(http.StatusOK, diffRuleInstances(current, transitions, now))
+}
+
+// diffRuleInstances matches the current states with the states of the evaluation at now by the labels of the query result.
+func diffRuleInstances(current []*state.State, transitions []state.StateTransition, now time.Time) apimodels.RuleInstancesDiff {
+	next := make(map[data.Fingerprint]*state.State, len(transitions))
+	for _, t := range transitions {
+		// Skip the states that were not part of the evaluation and were only processed as missing series.
+		if !t.LastEvaluationTime.Equal(now) || t.StateReason == ngmodels.StateReasonMissingSeries {
+			continue
+		}
+		next[t.ResultFingerprint] = t.State
+	}
+
+	diff := apimodels.RuleInstancesDiff{
+		Resolve: []apimodels.RuleInstanceChange{},
+		Stay:    []apimodels.RuleInstanceChange{},
+		Fire:    []apimodels.RuleInstanceChange{},
+		Relabel: []apimodels.RuleInstanceChange{},
+	}
+	for _, cur := range current {
+		nxt, ok := next[cur.ResultFingerprint]
+		delete(next, cur.ResultFingerprint)
+		change := ruleInstanceChange(cur, nxt)
+		switch {
+		case ok && nxt.CacheID != cur.CacheID:
+			diff.Relabel = append(diff.Relabel, change)
+		case isFiring(cur.State) && (!ok || !isFiring(nxt.State)):
+			if !ok {
+				change.NextState = eval.Normal.String()
+			}
+			diff.Resolve = append(diff.Resolve, change)
+		case isFiring(cur.State):
+			diff.Stay = append(diff.Stay, change)
+		case ok && (isFiring(nxt.State) || nxt.State == eval.Pending):
+			diff.Fire = append(diff.Fire, change)
+		}
+	}
+	for _, nxt := range next {
+		if isFiring(nxt.State) || nxt.State == eval.Pending {
+			diff.Fire = append(diff.Fire, ruleInstanceChange(nil, nxt))
+		}
+	}
+
+	for _, changes := range [][]apimodels.RuleInstanceChange{diff.Resolve, diff.Stay, diff.Fire, diff.Relabel} {
+		slices.SortFunc(changes, func(a, b apimodels.RuleInstanceChange) int {
+			return cmp.Or(cmp.Compare(a.Fingerprint, b.Fingerprint), cmp.Compare(a.NextFingerprint, b.NextFingerprint))
+		})
+	}
+	return diff
+}
+
+func ruleInstanceChange(cur, nxt *state.State) apimodels.RuleInstanceChange {
+	var change apimodels.RuleInstanceChange
+	if cur != nil {
+		change.Labels = cur.Labels
+		change.Fingerprint = cur.CacheID.String()
+		change.State = cur.State.String()
+	}
+	if nxt != nil {
+		change.NextLabels = nxt.Labels
+		change.NextFingerprint = nxt.CacheID.String()
+		change.NextState = nxt.State.String()
+	}
+	return change
+}
+
+func isFiring(s eval.State) bool {
+	return s == eval.Alerting || s == eval.Recovering
+}
+
+// prepareTestRule validates the rule in the request and authorizes access to the folder and the data sources it queries.
+func (srv TestingApiSrv) prepareTestRule(c *contextmodel.ReqContext, body apimodels.PostableExtendedRuleNodeExtended) (*ngmodels.AlertRule, *folder.Folder, response.Response) {
 	folder, err := srv.folderService.GetNamespaceByUID(c.Req.Context(), body.NamespaceUID, c.OrgID, c.SignedInUser)
 	if err != nil {
-		return toNamespaceErrorResponse(dashboards.ErrFolderAccessDenied)
+		return nil, nil, toNamespaceErrorResponse(dashboards.ErrFolderAccessDenied)
 	}
 	rule, err := apivalidation.ValidateRuleNode(
 		&body.Rule,
@@ -71,31 +237,36 @@ func (srv TestingApiSrv) RouteTestGrafanaRuleConfig(c *contextmodel.ReqContext,
 		apivalidation.RuleLimitsFromConfig(srv.cfg, srv.featureManager),
 	)
 	if err != nil {
-		return ErrResp(http.StatusBadRequest, err, "")
+		return nil, nil, ErrResp(http.StatusBadRequest, err, "")
 	}
 
 	if err := srv.authz.AuthorizeDatasourceAccessForRule(c.Req.Context(), c.SignedInUser, rule); err != nil {
-		return response.ErrOrFallback(http.StatusInternalServerError, "failed to authorize access to rule group", err)
+		return nil, nil, response.ErrOrFallback(http.StatusInternalServerError, "failed to authorize access to rule group", err)
 	}
+	return rule, folder, nil
+}
 
+func (srv TestingApiSrv) evaluateTestRule(c *contextmodel.ReqContext, rule *ngmodels.AlertRule, now time.Time) (eval.Results, response.Response) {
 	//nolint:staticcheck // not yet migrated to OpenFeature
 	if srv.featureManager.IsEnabled(c.Req.Context(), featuremgmt.FlagAlertingQueryOptimization) {
 		if _, err := store.OptimizeAlertQueries(rule.Data); err != nil {
-			return ErrResp(http.StatusInternalServerError, err, "Failed to optimize query")
+			return nil, ErrResp(http.StatusInternalServerError, err, "Failed to optimize query")
 		}
 	}
 
 	evaluator, err := srv.evaluator.Create(eval.NewContext(c.Req.Context(), c.SignedInUser), rule.GetEvalCondition().WithSource("preview"))
 	if err != nil {
-		return ErrResp(http.StatusBadRequest, err, "Failed to build evaluator for queries and expressions")
+		return nil, ErrResp(http.StatusBadRequest, err, "Failed to build evaluator for queries and expressions")
 	}
 
-	now := time.Now()
 	results, err := evaluator.Evaluate(c.Req.Context(), now)
 	if err != nil {
-		return ErrResp(http.StatusInternalServerError, err, "Failed to evaluate queries")
+		return nil, ErrResp(http.StatusInternalServerError, err, "Failed to evaluate queries")
 	}
+	return results, nil
+}
 
+func (srv TestingApiSrv) newTestStateManager() *state.Manager {
 	cfg := state.ManagerCfg{
 		Metrics:       nil,
 		ExternalURL:   srv.appUrl,
@@ -106,23 +277,7 @@ func (srv TestingApiSrv) RouteTestGrafanaRuleConfig(c *contextmodel.ReqContext,
 		Tracer:        srv.tracer,
 		Log:           log.New("ngalert.state.manager"),
 	}
-	manager := state.NewManager(cfg, state.NewNoopPersister())
-	includeFolder := !srv.cfg.ReservedLabels.IsReservedLabelDisabled(models.FolderTitleLabel)
-	transitions := manager.ProcessEvalResults(
-		c.Req.Context(),
-		now,
-		rule,
-		results,
-		state.GetRuleExtraLabels(log.New("testing"), rule, folder.Fullpath, includeFolder, srv.featureManager),
-		nil,
-	)
-
-	alerts := make([]*amv2.PostableAlert, 0, len(transitions))
-	for _, alertState := range transitions {
-		alerts = append(alerts, state.StateToPostableAlert(alertState, srv.appUrl, srv.featureManager))
-	}
-
-	return response.JSON(http.StatusOK, alerts)
+	return state.NewManager(cfg, state.NewNoopPersister())
 }
 
 func (srv TestingApiSrv) RouteTestRuleConfig(c *contextmodel.ReqContext, body apimodels.TestRulePayload, datasourceUID string) response.Response {
@@ -229,7 +384,83 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 		return ErrResp(http.StatusNotFound, nil, "Backgtesting API is not enabled")
 	}
 
-	rule, err := apivalidation.ValidateBacktestConfig(c.GetOrgID(), cmd, apivalidation.RuleLimitsFromConfig(srv.cfg, srv.featureManager))
+	rule, folderTitle, errResp := srv.prepareBacktest(c, cmd)
+	if errResp != nil {
+		return errResp
+	}
+
+	result, err := srv.backtesting.Test(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To, folderTitle)
+	if err != nil {
+		if errors.Is(err, backtesting.ErrInvalidInputData) {
+			return ErrResp(400, err, "Failed to evaluate")
+		}
+		return ErrResp(500, err, "Failed to evaluate")
+	}
+
+	return response.JSONStreaming(http.StatusOK, result)
+}
+
+// BacktestAlertRuleNotifications backtests the rule and routes the alerts it would have sent through the notification
+// policies, either of the current Alertmanager configuration of the organization or of the configuration in the request.
+func (srv TestingApiSrv) BacktestAlertRuleNotifications(c *contextmodel.ReqContext, cmd apimodels.BacktestNotificationsConfig) response.Response {
+	//nolint:staticcheck // not yet migrated to OpenFeature
+	if !srv.featureManager.IsEnabled(c.Req.Context(), featuremgmt.FlagAlertingBacktesting) {
+		return ErrResp(http.StatusNotFound, nil, "Backgtesting API is not enabled")
+	}
+
+	rule, folderTitle, errResp := srv.prepareBacktest(c, cmd.BacktestConfig)
+	if errResp != nil {
+		return errResp
+	}
+
+	var policies backtesting.NotificationPolicies
+	if cmd.AlertmanagerConfig != nil {
+		policies = notificationPolicies(cmd.AlertmanagerConfig.Config, cmd.AlertmanagerConfig.Receivers,
+			func(r *apimodels.PostableApiReceiver) (string, []*apimodels.PostableGrafanaReceiver) {
+				return r.Name, r.GrafanaManagedReceivers
+			},
+			func(i *apimodels.PostableGrafanaReceiver) bool { return i.DisableResolveMessage },
+		)
+	} else {
+		cfg, err := srv.amConfig.GetAlertmanagerConfiguration(c.Req.Context(), c.GetOrgID(), false, false)
+		if err != nil {
+			return ErrResp(http.StatusInternalServerError, err, "Failed to get the Alertmanager configuration")
+		}
+		policies = notificationPolicies(cfg.AlertmanagerConfig.Config, cfg.AlertmanagerConfig.Receivers,
+			func(r *apimodels.GettableApiReceiver) (string, []*apimodels.GettableGrafanaReceiver) {
+				return r.Name, r.GrafanaManagedReceivers
+			},
+			func(i *apimodels.GettableGrafanaReceiver) bool { return i.DisableResolveMessage },
+		)
+	}
+
+	states, notifications, err := srv.backtesting.TestNotifications(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To, folderTitle, policies)
+	if err != nil {
+		if errors.Is(err, backtesting.ErrInvalidInputData) {
+			return ErrResp(400, err, "Failed to evaluate")
+		}
+		return ErrResp(500, err, "Failed to evaluate")
+	}
+
+	result := apimodels.BacktestNotificationsResult{
+		States:        states,
+		Notifications: make([]apimodels.BacktestNotification, 0, len(notifications)),
+	}
+	for _, n := range notifications {
+		result.Notifications = append(result.Notifications, toBacktestNotification(n))
+	}
+	return response.JSONStreaming(http.StatusOK, result)
+}
+
+// BacktestRecordingRule evaluates the recording rule over the requested range and returns the series it would have
+// written to the target data source.
+func (srv TestingApiSrv) BacktestRecordingRule(c *contextmodel.ReqContext, cmd apimodels.BacktestRecordingConfig) response.Response {
+	//nolint:staticcheck // not yet migrated to OpenFeature
+	if !srv.featureManager.IsEnabled(c.Req.Context(), featuremgmt.FlagAlertingBacktesting) {
+		return ErrResp(http.StatusNotFound, nil, "Backgtesting API is not enabled")
+	}
+
+	rule, err := apivalidation.ValidateBacktestRecordingConfig(c.GetOrgID(), cmd, apivalidation.RuleLimitsFromConfig(srv.cfg, srv.featureManager))
 	if err != nil {
 		return ErrResp(http.StatusBadRequest, err, "")
 	}
@@ -238,6 +469,28 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 		return errorToResponse(err)
 	}
 
+	result, err := srv.backtesting.TestRecording(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To)
+	if err != nil {
+		if errors.Is(err, backtesting.ErrInvalidInputData) {
+			return ErrResp(400, err, "Failed to evaluate")
+		}
+		return ErrResp(500, err, "Failed to evaluate")
+	}
+
+	return response.JSONStreaming(http.StatusOK, result)
+}
+
+// prepareBacktest validates the backtest configuration and returns the rule to test and the title of its folder.
+func (srv TestingApiSrv) prepareBacktest(c *contextmodel.ReqContext, cmd apimodels.BacktestConfig) (*ngmodels.AlertRule, string, response.Response) {
+	rule, err := apivalidation.ValidateBacktestConfig(c.GetOrgID(), cmd, apivalidation.RuleLimitsFromConfig(srv.cfg, srv.featureManager))
+	if err != nil {
+		return nil, "", ErrResp(http.StatusBadRequest, err, "")
+	}
+
+	if err := srv.authz.AuthorizeDatasourceAccessForRule(c.Req.Context(), c.SignedInUser, rule); err != nil {
+		return nil, "", errorToResponse(err)
+	}
+
 	// Fetch folder path for alert labels, fallback to "Backtesting" if not available
 	var folderTitle string
 	if cmd.NamespaceUID != "" {
@@ -248,14 +501,59 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 			folderTitle = f.Fullpath
 		}
 	}
+	return rule, folderTitle, nil
+}
 
-	result, err := srv.backtesting.Test(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To, folderTitle)
-	if err != nil {
-		if errors.Is(err, backtesting.ErrInvalidInputData) {
-			return ErrResp(400, err, "Failed to evaluate")
+// notificationPolicies builds backtesting notification policies from the shared part of an Alertmanager
+// configuration and its receivers. Receivers of postable and gettable configurations are read with
+// receiverIntegrations, which returns the name of a receiver and its integrations, and disableResolve,
+// which returns the DisableResolveMessage setting of an integration.
+func notificationPolicies[R, I any](cfg apimodels.Config, receivers []R, receiverIntegrations func(R) (string, []I), disableResolve func(I) bool) backtesting.NotificationPolicies {
+	resolvedDisabled := make(map[string]struct{})
+	for _, r := range receivers {
+		name, integrations := receiverIntegrations(r)
+		disabled := len(integrations) > 0
+		for _, integration := range integrations {
+			disabled = disabled && disableResolve(integration)
 		}
-		return ErrResp(500, err, "Failed to evaluate")
+		if disabled {
+			resolvedDisabled[name] = struct{}{}
+		}
+	}
+	return backtesting.NotificationPolicies{
+		Route:             cfg.Route,
+		InhibitRules:      cfg.InhibitRules,
+		MuteTimeIntervals: cfg.MuteTimeIntervals,
+		TimeIntervals:     cfg.TimeIntervals,
+		ResolvedDisabled:  resolvedDisabled,
 	}
+}
 
-	return response.JSONStreaming(http.StatusOK, result)
+func toBacktestNotification(n backtesting.Notification) apimodels.BacktestNotification {
+	result := apimodels.BacktestNotification{
+		Time:        n.Time,
+		Receiver:    n.Receiver,
+		GroupKey:    n.GroupKey,
+		GroupLabels: make(map[string]string, len(n.GroupLabels)),
+		Alerts:      make([]apimodels.BacktestNotificationAlert, 0, len(n.Alerts)),
+	}
+	for k, v := range n.GroupLabels {
+		result.GroupLabels[string(k)] = string(v)
+	}
+	for _, a := range n.Alerts {
+		alert := apimodels.BacktestNotificationAlert{
+			Labels:   make(map[string]string, len(a.Labels)),
+			Status:   string(model.AlertFiring),
+			StartsAt: a.StartsAt,
+			EndsAt:   a.EndsAt,
+		}
+		if a.Resolved {
+			alert.Status = string(model.AlertResolved)
+		}
+		for k, v := range a.Labels {
+			alert.Labels[string(k)] = string(v)
+		}
+		result.Alerts = append(result.Alerts, alert)
+	}
+	return result
 }
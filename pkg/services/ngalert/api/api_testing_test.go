@@ -27,6 +27,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval/eval_mocks"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	fakes2 "github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/web"
@@ -392,6 +393,64 @@ func TestRouteEvalQueries(t *testing.T) {
 	})
 }
 
+func TestDiffRuleInstances(t *testing.T) {
+	now := time.Now()
+	instance := func(lbls data.Labels, st eval.State, evaluatedAt time.Time) *state.State {
+		return &state.State{
+			CacheID:            lbls.Fingerprint(),
+			ResultFingerprint:  data.Labels{"instance": lbls["instance"]}.Fingerprint(),
+			State:              st,
+			Labels:             lbls,
+			LastEvaluationTime: evaluatedAt,
+		}
+	}
+	transition := func(s *state.State) state.StateTransition {
+		return state.StateTransition{State: s}
+	}
+
+	resolved := instance(data.Labels{"instance": "resolved"}, eval.Alerting, now.Add(-time.Minute))
+	stale := instance(data.Labels{"instance": "stale"}, eval.Alerting, now.Add(-time.Minute))
+	staying := instance(data.Labels{"instance": "staying"}, eval.Alerting, now.Add(-time.Minute))
+	pending := instance(data.Labels{"instance": "pending"}, eval.Normal, now.Add(-time.Minute))
+	normal := instance(data.Labels{"instance": "normal"}, eval.Normal, now.Add(-time.Minute))
+	relabeled := instance(data.Labels{"instance": "relabeled", "severity": "warning"}, eval.Alerting, now.Add(-time.Minute))
+
+	nextRelabeled := instance(data.Labels{"instance": "relabeled", "severity": "critical"}, eval.Alerting, now)
+	nextStale := instance(data.Labels{"instance": "stale"}, eval.Normal, now)
+	nextStale.StateReason = models.StateReasonMissingSeries
+	transitions := []state.StateTransition{
+		transition(instance(data.Labels{"instance": "resolved"}, eval.Normal, now)),
+		transition(nextStale),
+		transition(instance(data.Labels{"instance": "staying"}, eval.Alerting, now)),
+		transition(instance(data.Labels{"instance": "pending"}, eval.Pending, now)),
+		transition(instance(data.Labels{"instance": "normal"}, eval.Normal, now)),
+		transition(nextRelabeled),
+		transition(instance(data.Labels{"instance": "new"}, eval.Alerting, now)),
+	}
+
+	diff := diffRuleInstances([]*state.State{resolved, stale, staying, pending, normal, relabeled}, transitions, now)
+
+	fingerprints := func(changes []definitions.RuleInstanceChange) []string {
+		result := make([]string, 0, len(changes))
+		for _, c := range changes {
+			result = append(result, c.Fingerprint+"->"+c.NextFingerprint)
+		}
+		return result
+	}
+	fp := func(lbls data.Labels) string {
+		return lbls.Fingerprint().String()
+	}
+	require.ElementsMatch(t, []string{fp(resolved.Labels) + "->" + fp(resolved.Labels), fp(stale.Labels) + "->"}, fingerprints(diff.Resolve))
+	require.Equal(t, []string{fp(staying.Labels) + "->" + fp(staying.Labels)}, fingerprints(diff.Stay))
+	require.ElementsMatch(t, []string{fp(pending.Labels) + "->" + fp(pending.Labels), "->" + fp(data.Labels{"instance": "new"})}, fingerprints(diff.Fire))
+	require.Equal(t, []string{fp(relabeled.Labels) + "->" + fp(nextRelabeled.Labels)}, fingerprints(diff.Relabel))
+
+	for _, c := range diff.Resolve {
+		require.Equal(t, eval.Normal.String(), c.NextState)
+	}
+	require.Equal(t, map[string]string(nextRelabeled.Labels), diff.Relabel[0].NextLabels)
+}
+
 func createTestingApiSrv(t *testing.T, ds *fakes.FakeCacheService, ac *acMock.Mock, evaluator eval.EvaluatorFactory, featureManager featuremgmt.FeatureToggles, ruleStore RuleStore) *TestingApiSrv {
 	if ac == nil {
 		ac = acMock.New()
@@ -405,5 +464,6 @@ func createTestingApiSrv(t *testing.T, ds *fakes.FakeCacheService, ac *acMock.Mo
 		tracer:          tracing.InitializeTracerForTest(),
 		featureManager:  featureManager,
 		folderService:   ruleStore,
+		rules:           ruleStore,
 	}
 }
@@ -47,6 +47,14 @@ func (api *API) authorize(method, path string) web.Handler {
 			ac.EvalPermission(ac.ActionAlertingRuleRead),
 			ac.EvalPermission(dashboards.ActionFoldersRead),
 		)
+	case http.MethodPost + "/api/ruler/grafana/api/v1/rule/{RuleUID}/instances/{Fingerprint}/acknowledgement",
+		http.MethodDelete + "/api/ruler/grafana/api/v1/rule/{RuleUID}/instances/{Fingerprint}/acknowledgement":
+		// the handler checks that the user can read the rule
+		eval = ac.EvalAll(
+			ac.EvalPermission(ac.ActionAlertingInstanceUpdate),
+			ac.EvalPermission(ac.ActionAlertingRuleRead),
+			ac.EvalPermission(dashboards.ActionFoldersRead),
+		)
 	case http.MethodPost + "/api/ruler/grafana/api/v1/rules/{Namespace}/export":
 		scope := dashboards.ScopeFoldersProvider.GetResourceScopeUID(ac.Parameter(":Namespace"))
 		// more granular permissions are enforced by the handler via "authorizeRuleChanges"
@@ -78,7 +86,8 @@ func (api *API) authorize(method, path string) web.Handler {
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
 
 	// Grafana Rules Testing Paths
-	case http.MethodPost + "/api/v1/rule/test/grafana":
+	case http.MethodPost + "/api/v1/rule/test/grafana",
+		http.MethodPost + "/api/v1/rule/test/grafana/diff":
 		// additional authorization is done in the request handler
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
 	// Grafana Rules Testing Paths
@@ -91,6 +100,25 @@ func (api *API) authorize(method, path string) web.Handler {
 				ac.EvalPermission(ac.ActionAlertingRuleCreate),
 			),
 		)
+	case http.MethodPost + "/api/v1/rule/backtest/notifications":
+		// additional authorization is done in the request handler
+		eval = ac.EvalAll(
+			ac.EvalPermission(ac.ActionAlertingRuleRead),
+			ac.EvalAny(
+				ac.EvalPermission(ac.ActionAlertingRuleUpdate),
+				ac.EvalPermission(ac.ActionAlertingRuleCreate),
+			),
+			ac.EvalPermission(ac.ActionAlertingNotificationsRead),
+		)
+	case http.MethodPost + "/api/v1/rule/backtest/recording":
+		// additional authorization is done in the request handler
+		eval = ac.EvalAll(
+			ac.EvalPermission(ac.ActionAlertingRuleRead),
+			ac.EvalAny(
+				ac.EvalPermission(ac.ActionAlertingRuleUpdate),
+				ac.EvalPermission(ac.ActionAlertingRuleCreate),
+			),
+		)
 	case http.MethodPost + "/api/v1/eval":
 		// additional authorization is done in the request handler
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
@@ -253,6 +281,8 @@ func (api *API) authorize(method, path string) web.Handler {
 			accesscontrol.TestReceiversPreconditionEval,
 			accesscontrol.TestReceiverNew,
 		)
+	case http.MethodPost + "/api/alertmanager/grafana/config/api/v1/routes/simulate":
+		eval = ac.EvalPermission(ac.ActionAlertingNotificationsRead)
 	case http.MethodPost + "/api/alertmanager/grafana/config/api/v1/templates/test":
 		eval = ac.EvalAny(
 			ac.EvalPermission(ac.ActionAlertingNotificationsWrite),
@@ -286,6 +316,12 @@ func (api *API) authorize(method, path string) web.Handler {
 		http.MethodGet + "/api/v1/ngalert/alertmanagers":
 		return middleware.ReqOrgAdmin
 
+	// Grafana rule state history migration paths
+	case http.MethodPost + "/api/v1/rules/history/migrate",
+		http.MethodGet + "/api/v1/rules/history/migrate",
+		http.MethodGet + "/api/v1/rules/history/export":
+		return middleware.ReqOrgAdmin
+
 	// Grafana-only Provisioning Export Paths for everything except contact points.
 	case http.MethodGet + "/api/v1/provisioning/policies/export":
 		eval = ac.EvalAny(
@@ -353,6 +389,17 @@ func (api *API) authorize(method, path string) web.Handler {
 				ac.EvalPermission(dashboards.ActionFoldersRead, scope),
 			),
 		)
+	case http.MethodGet + "/api/v1/provisioning/alert-rule-templates",
+		http.MethodGet + "/api/v1/provisioning/alert-rule-templates/{UID}":
+		eval = ac.EvalAny(
+			ac.EvalPermission(ac.ActionAlertingProvisioningRead),
+			ac.EvalPermission(ac.ActionAlertingRulesProvisioningRead),
+			ac.EvalPermission(ac.ActionAlertingProvisioningReadSecrets),
+			ac.EvalAll( // scopes are enforced in the handler
+				ac.EvalPermission(ac.ActionAlertingRuleRead),
+				ac.EvalPermission(dashboards.ActionFoldersRead),
+			),
+		)
 
 	case http.MethodGet + "/api/v1/provisioning/policies":
 		eval = ac.EvalAny(
@@ -373,7 +420,10 @@ func (api *API) authorize(method, path string) web.Handler {
 		)
 
 	case http.MethodGet + "/api/v1/provisioning/templates",
-		http.MethodGet + "/api/v1/provisioning/templates/{name}":
+		http.MethodGet + "/api/v1/provisioning/templates/{name}",
+		http.MethodGet + "/api/v1/provisioning/template-libraries",
+		http.MethodGet + "/api/v1/provisioning/template-libraries/{UID}",
+		http.MethodGet + "/api/v1/provisioning/template-libraries/{UID}/versions":
 		eval = ac.EvalAny(
 			ac.EvalPermission(ac.ActionAlertingProvisioningRead),
 			ac.EvalPermission(ac.ActionAlertingNotificationsProvisioningRead), // organization scope
@@ -392,6 +442,20 @@ func (api *API) authorize(method, path string) web.Handler {
 		)
 
 	// Grafana-only Provisioning Write Paths
+	// Template libraries are shared by all organizations, therefore only server admins can change them.
+	case http.MethodPost + "/api/v1/provisioning/template-libraries",
+		http.MethodPut + "/api/v1/provisioning/template-libraries/{UID}",
+		http.MethodDelete + "/api/v1/provisioning/template-libraries/{UID}":
+		return middleware.ReqGrafanaAdmin
+	// Rules generated by alert rule templates are provisioned, therefore templates can be changed only by provisioners.
+	case http.MethodPost + "/api/v1/provisioning/alert-rule-templates",
+		http.MethodPut + "/api/v1/provisioning/alert-rule-templates/{UID}",
+		http.MethodDelete + "/api/v1/provisioning/alert-rule-templates/{UID}",
+		http.MethodPost + "/api/v1/provisioning/alert-rule-templates/{UID}/reconcile":
+		eval = ac.EvalAny(
+			ac.EvalPermission(ac.ActionAlertingProvisioningWrite),
+			ac.EvalPermission(ac.ActionAlertingRulesProvisioningWrite),
+		)
 	case http.MethodPost + "/api/v1/provisioning/alert-rules":
 		eval = ac.EvalAny(
 			ac.EvalPermission(ac.ActionAlertingProvisioningWrite),
@@ -144,6 +144,135 @@ func ApiAlertRuleGroupFromAlertRuleGroup(d models.AlertRuleGroup) definitions.Al
 	}
 }
 
+// AlertRuleTemplateFromApiAlertRuleTemplate converts definitions.AlertRuleTemplate to models.AlertRuleTemplate
+func AlertRuleTemplateFromApiAlertRuleTemplate(a definitions.AlertRuleTemplate) models.AlertRuleTemplate {
+	result := models.AlertRuleTemplate{
+		UID:             a.UID,
+		Title:           a.Title,
+		FolderUID:       a.FolderUID,
+		RuleGroup:       a.RuleGroup,
+		IntervalSeconds: a.Interval,
+		Parameters:      a.Parameters,
+		Rule: models.AlertRuleTemplateRule{
+			Title:                a.Rule.Title,
+			Condition:            a.Rule.Condition,
+			Data:                 AlertQueriesFromApiAlertQueries(a.Rule.Data),
+			NoDataState:          models.NoDataState(a.Rule.NoDataState),
+			ExecErrState:         models.ExecutionErrorState(a.Rule.ExecErrState),
+			For:                  time.Duration(a.Rule.For),
+			KeepFiringFor:        time.Duration(a.Rule.KeepFiringFor),
+			Annotations:          a.Rule.Annotations,
+			Labels:               a.Rule.Labels,
+			IsPaused:             a.Rule.IsPaused,
+			NotificationSettings: NotificationSettingsFromAlertRuleNotificationSettings(a.Rule.NotificationSettings),
+		},
+		Source: models.AlertRuleTemplateSource{
+			Static: a.Source.Static,
+		},
+		Version: a.Version,
+	}
+	if a.Source.Query != nil {
+		result.Source.Query = &models.AlertRuleTemplateQuery{
+			Condition:  a.Source.Query.Condition,
+			Data:       AlertQueriesFromApiAlertQueries(a.Source.Query.Data),
+			AllowEmpty: a.Source.Query.AllowEmpty,
+		}
+	}
+	return result
+}
+
+// ApiAlertRuleTemplateFromAlertRuleTemplate converts models.AlertRuleTemplate to definitions.AlertRuleTemplate
+func ApiAlertRuleTemplateFromAlertRuleTemplate(t models.AlertRuleTemplate) definitions.AlertRuleTemplate {
+	result := definitions.AlertRuleTemplate{
+		UID:        t.UID,
+		Title:      t.Title,
+		FolderUID:  t.FolderUID,
+		RuleGroup:  t.RuleGroup,
+		Interval:   t.IntervalSeconds,
+		Parameters: t.Parameters,
+		Rule: definitions.AlertRuleTemplateRule{
+			Title:                t.Rule.Title,
+			Condition:            t.Rule.Condition,
+			Data:                 ApiAlertQueriesFromAlertQueries(t.Rule.Data),
+			NoDataState:          definitions.NoDataState(t.Rule.NoDataState),
+			ExecErrState:         definitions.ExecutionErrorState(t.Rule.ExecErrState),
+			For:                  model.Duration(t.Rule.For),
+			KeepFiringFor:        model.Duration(t.Rule.KeepFiringFor),
+			Annotations:          t.Rule.Annotations,
+			Labels:               t.Rule.Labels,
+			IsPaused:             t.Rule.IsPaused,
+			NotificationSettings: AlertRuleNotificationSettingsFromNotificationSettings(t.Rule.NotificationSettings),
+		},
+		Source: definitions.AlertRuleTemplateSource{
+			Static: t.Source.Static,
+		},
+		Version: t.Version,
+		Updated: t.Updated,
+	}
+	if t.Source.Query != nil {
+		result.Source.Query = &definitions.AlertRuleTemplateQuery{
+			Condition:  t.Source.Query.Condition,
+			Data:       ApiAlertQueriesFromAlertQueries(t.Source.Query.Data),
+			AllowEmpty: t.Source.Query.AllowEmpty,
+		}
+	}
+	return result
+}
+
+// ApiAlertRuleTemplatesFromAlertRuleTemplates converts a collection of models.AlertRuleTemplate to definitions.AlertRuleTemplates
+func ApiAlertRuleTemplatesFromAlertRuleTemplates(templates []models.AlertRuleTemplate) definitions.AlertRuleTemplates {
+	result := make(definitions.AlertRuleTemplates, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, ApiAlertRuleTemplateFromAlertRuleTemplate(t))
+	}
+	return result
+}
+
+// TemplateLibraryFromApiTemplateLibrary converts definitions.TemplateLibrary to models.TemplateLibrary
+func TemplateLibraryFromApiTemplateLibrary(l definitions.TemplateLibrary) models.TemplateLibrary {
+	return models.TemplateLibrary{
+		UID:         l.UID,
+		Name:        l.Name,
+		Description: l.Description,
+		Templates:   l.Templates,
+		Version:     l.Version,
+	}
+}
+
+// ApiTemplateLibraryFromTemplateLibrary converts models.TemplateLibrary to definitions.TemplateLibrary
+func ApiTemplateLibraryFromTemplateLibrary(l models.TemplateLibrary) definitions.TemplateLibrary {
+	return definitions.TemplateLibrary{
+		UID:         l.UID,
+		Name:        l.Name,
+		Description: l.Description,
+		Templates:   l.Templates,
+		Version:     l.Version,
+		Updated:     l.Updated,
+	}
+}
+
+// ApiTemplateLibrariesFromTemplateLibraries converts a collection of models.TemplateLibrary to definitions.TemplateLibraries
+func ApiTemplateLibrariesFromTemplateLibraries(libraries []models.TemplateLibrary) definitions.TemplateLibraries {
+	result := make(definitions.TemplateLibraries, 0, len(libraries))
+	for _, l := range libraries {
+		result = append(result, ApiTemplateLibraryFromTemplateLibrary(l))
+	}
+	return result
+}
+
+// ApiTemplateLibraryVersionsFromTemplateLibraryVersions converts a collection of models.TemplateLibraryVersion to definitions.TemplateLibraryVersions
+func ApiTemplateLibraryVersionsFromTemplateLibraryVersions(versions []models.TemplateLibraryVersion) definitions.TemplateLibraryVersions {
+	result := make(definitions.TemplateLibraryVersions, 0, len(versions))
+	for _, v := range versions {
+		result = append(result, definitions.TemplateLibraryVersion{
+			Version:   v.Version,
+			Templates: v.Templates,
+			Created:   v.Created,
+		})
+	}
+	return result
+}
+
 // AlertingFileExportFromAlertRuleGroupWithFolderFullpath creates an definitions.AlertingFileExport DTO from []models.AlertRuleGroupWithFolderTitle.
 func AlertingFileExportFromAlertRuleGroupWithFolderFullpath(groups []models.AlertRuleGroupWithFolderFullpath) (definitions.AlertingFileExport, error) {
 	f := definitions.AlertingFileExport{APIVersion: 1}
@@ -453,9 +582,16 @@ func AlertRuleEditorSettingsFromModelEditorSettings(es models.EditorSettings) *d
 
 // AlertRuleMetadataFromMetadata converts models.AlertRuleMetadata to definitions.AlertRuleMetadata
 func AlertRuleMetadataFromModelMetadata(es models.AlertRuleMetadata) *definitions.AlertRuleMetadata {
-	return &definitions.AlertRuleMetadata{
+	result := &definitions.AlertRuleMetadata{
 		EditorSettings: *AlertRuleEditorSettingsFromModelEditorSettings(es.EditorSettings),
 	}
+	if es.RuleTemplate != nil {
+		result.RuleTemplate = &definitions.AlertRuleTemplateReference{
+			UID:        es.RuleTemplate.UID,
+			Parameters: es.RuleTemplate.Parameters,
+		}
+	}
+	return result
 }
 
 // AlertRuleNotificationSettingsFromNotificationSettings converts models.NotificationSettings to definitions.AlertRuleNotificationSettings
@@ -585,3 +721,53 @@ func ApiRecordFromModelRecord(r *models.Record) *definitions.Record {
 		TargetDatasourceUID: r.TargetDatasourceUID,
 	}
 }
+
+func ModelSeverityConditionsFromApiSeverityConditions(conditions []definitions.SeverityCondition) models.SeverityConditions {
+	if len(conditions) == 0 {
+		return nil
+	}
+	result := make(models.SeverityConditions, 0, len(conditions))
+	for _, c := range conditions {
+		result = append(result, models.SeverityCondition{
+			Severity:  c.Severity,
+			Condition: c.Condition,
+		})
+	}
+	return result
+}
+
+func ModelFlapDetectionFromApiFlapDetection(f *definitions.AlertRuleFlapDetection) *models.FlapDetection {
+	if f == nil {
+		return nil
+	}
+	return &models.FlapDetection{
+		Transitions:        f.Transitions,
+		Window:             time.Duration(f.Window),
+		NotificationPolicy: models.FlapNotificationPolicy(f.NotificationPolicy),
+	}
+}
+
+func ApiFlapDetectionFromModelFlapDetection(f *models.FlapDetection) *definitions.AlertRuleFlapDetection {
+	if f == nil {
+		return nil
+	}
+	return &definitions.AlertRuleFlapDetection{
+		Transitions:        f.Transitions,
+		Window:             model.Duration(f.Window),
+		NotificationPolicy: definitions.FlapNotificationPolicy(f.NotificationPolicy),
+	}
+}
+
+func ApiSeverityConditionsFromModelSeverityConditions(conditions models.SeverityConditions) []definitions.SeverityCondition {
+	if len(conditions) == 0 {
+		return nil
+	}
+	result := make([]definitions.SeverityCondition, 0, len(conditions))
+	for _, c := range conditions {
+		result = append(result, definitions.SeverityCondition{
+			Severity:  c.Severity,
+			Condition: c.Condition,
+		})
+	}
+	return result
+}
@@ -97,6 +97,14 @@ func (f *RulerApiHandler) handleRouteGetRuleByUID(ctx *contextmodel.ReqContext,
 	return f.GrafanaRuler.RouteGetRuleByUID(ctx, ruleUID)
 }
 
+func (f *RulerApiHandler) handleRoutePostAlertInstanceAcknowledgement(ctx *contextmodel.ReqContext, ack apimodels.PostableAlertAcknowledgement, ruleUID, fingerprint string) response.Response {
+	return f.GrafanaRuler.RoutePostAlertInstanceAcknowledgement(ctx, ack, ruleUID, fingerprint)
+}
+
+func (f *RulerApiHandler) handleRouteDeleteAlertInstanceAcknowledgement(ctx *contextmodel.ReqContext, ruleUID, fingerprint string) response.Response {
+	return f.GrafanaRuler.RouteDeleteAlertInstanceAcknowledgement(ctx, ruleUID, fingerprint)
+}
+
 func (f *RulerApiHandler) handleRoutePostNameGrafanaRulesConfig(ctx *contextmodel.ReqContext, conf apimodels.PostableRuleGroupConfig, namespace string) response.Response {
 	payloadType := conf.Type()
 	if payloadType != apimodels.GrafanaBackend {
@@ -14,19 +14,50 @@ import (
 	"github.com/grafana/grafana/pkg/middleware"
 	"github.com/grafana/grafana/pkg/middleware/requestmeta"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/web"
 )
 
 type HistoryApi interface {
+	RouteExportStateHistory(*contextmodel.ReqContext) response.Response
 	RouteGetStateHistory(*contextmodel.ReqContext) response.Response
+	RouteGetStateHistoryMigration(*contextmodel.ReqContext) response.Response
+	RouteMigrateStateHistory(*contextmodel.ReqContext) response.Response
 }
 
+func (f *HistoryApiHandler) RouteExportStateHistory(ctx *contextmodel.ReqContext) response.Response {
+	return f.handleRouteExportStateHistory(ctx)
+}
 func (f *HistoryApiHandler) RouteGetStateHistory(ctx *contextmodel.ReqContext) response.Response {
 	return f.handleRouteGetStateHistory(ctx)
 }
+func (f *HistoryApiHandler) RouteGetStateHistoryMigration(ctx *contextmodel.ReqContext) response.Response {
+	return f.handleRouteGetStateHistoryMigration(ctx)
+}
+func (f *HistoryApiHandler) RouteMigrateStateHistory(ctx *contextmodel.ReqContext) response.Response {
+	// Parse Request Body
+	conf := apimodels.StateHistoryMigrationConfig{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRouteMigrateStateHistory(ctx, conf)
+}
 
 func (api *API) RegisterHistoryApiEndpoints(srv HistoryApi, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
+		group.Get(
+			toMacaronPath("/api/v1/rules/history/export"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodGet, "/api/v1/rules/history/export"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/rules/history/export",
+				api.Hooks.Wrap(srv.RouteExportStateHistory),
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/v1/rules/history"),
 			requestmeta.SetOwner(requestmeta.TeamAlerting),
@@ -39,5 +70,29 @@ func (api *API) RegisterHistoryApiEndpoints(srv HistoryApi, m *metrics.API) {
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/v1/rules/history/migrate"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodGet, "/api/v1/rules/history/migrate"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/rules/history/migrate",
+				api.Hooks.Wrap(srv.RouteGetStateHistoryMigration),
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/v1/rules/history/migrate"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodPost, "/api/v1/rules/history/migrate"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/v1/rules/history/migrate",
+				api.Hooks.Wrap(srv.RouteMigrateStateHistory),
+				m,
+			),
+		)
 	}, middleware.ReqSignedIn)
 }
@@ -169,6 +169,9 @@ func PrepareAlertStatuses(ctx context.Context, manager state.AlertInstanceManage
 			State:    state.FormatStateAndReason(alertState.State, alertState.StateReason),
 			ActiveAt: &startsAt,
 			Value:    valString,
+
+			Fingerprint:     alertState.CacheID.String(),
+			Acknowledgement: AlertAcknowledgementFromModel(alertState.Acknowledgement),
 		})
 	}
 
@@ -203,6 +206,21 @@ func FormatValues(alertState *state.State) string {
 	return fv
 }
 
+// AlertAcknowledgementFromModel returns the acknowledgement of the alert instance, or nil if it is not acknowledged.
+func AlertAcknowledgementFromModel(ack ngmodels.Acknowledgement) *apimodels.AlertAcknowledgement {
+	if ack.IsZero() {
+		return nil
+	}
+	return &apimodels.AlertAcknowledgement{
+		By:                    ack.By,
+		Comment:               ack.Comment,
+		At:                    ack.At,
+		ExpiresAt:             ack.ExpiresAt,
+		SuppressNotifications: ack.SuppressNotifications,
+		UntilValueChanges:     ack.UntilValueChanges,
+	}
+}
+
 func getPanelIDFromQuery(v url.Values) (int64, error) {
 	if s := strings.TrimSpace(v.Get("panel_id")); s != "" {
 		return strconv.ParseInt(s, 10, 64)
@@ -371,9 +389,33 @@ func RuleStatusMutatorGenerator(statusReader StatusReader) RuleStatusMutator {
 		toMutate.LastError = errorOrEmpty(status.LastError)
 		toMutate.LastEvaluation = status.EvaluationTimestamp
 		toMutate.EvaluationTime = status.EvaluationDuration.Seconds()
+		toMutate.EvaluationCost = toRuleEvaluationCost(status.EvaluationCost)
 	}
 }
 
+func toRuleEvaluationCost(cost ngmodels.EvaluationCost) *apimodels.RuleEvaluationCost {
+	if cost == nil {
+		return nil
+	}
+	result := &apimodels.RuleEvaluationCost{
+		Series:     cost.Series(),
+		DataPoints: cost.DataPoints(),
+		Bytes:      cost.Bytes(),
+		QueryTime:  cost.Duration().Seconds(),
+		Queries:    make([]apimodels.QueryEvaluationCost, 0, len(cost)),
+	}
+	for _, q := range cost {
+		result.Queries = append(result.Queries, apimodels.QueryEvaluationCost{
+			RefID:      q.RefID,
+			Series:     q.Series,
+			DataPoints: q.DataPoints,
+			Bytes:      q.Bytes,
+			QueryTime:  q.Duration.Seconds(),
+		})
+	}
+	return result
+}
+
 // ComputeRuleState computes the rule state from alert instance states.
 // Priority: Alerting > Pending/Recovering > Normal.
 func ComputeRuleState(alertStates []*state.State) eval.State {
@@ -464,6 +506,9 @@ func RuleAlertStateMutatorGenerator(manager state.AlertInstanceManager) RuleAler
 					State:    state.FormatStateAndReason(alertState.State, alertState.StateReason),
 					ActiveAt: &activeAt,
 					Value:    valString,
+
+					Fingerprint:     alertState.CacheID.String(),
+					Acknowledgement: AlertAcknowledgementFromModel(alertState.Acknowledgement),
 				})
 			}
 		}
@@ -2,10 +2,13 @@ package api
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 )
 
@@ -182,3 +185,22 @@ func TestRuleStateToAPIString(t *testing.T) {
 		})
 	}
 }
+
+func TestToRuleEvaluationCost(t *testing.T) {
+	assert.Nil(t, toRuleEvaluationCost(nil))
+
+	cost := ngmodels.EvaluationCost{
+		{RefID: "A", Series: 2, DataPoints: 20, Bytes: 320, Duration: 2 * time.Second},
+		{RefID: "B", Series: 2, DataPoints: 2, Bytes: 32, Duration: 500 * time.Millisecond},
+	}
+	assert.Equal(t, &apimodels.RuleEvaluationCost{
+		Series:     4,
+		DataPoints: 22,
+		Bytes:      352,
+		QueryTime:  2.5,
+		Queries: []apimodels.QueryEvaluationCost{
+			{RefID: "A", Series: 2, DataPoints: 20, Bytes: 320, QueryTime: 2},
+			{RefID: "B", Series: 2, DataPoints: 2, Bytes: 32, QueryTime: 0.5},
+		},
+	}, toRuleEvaluationCost(cost))
+}
@@ -172,3 +172,51 @@ func (f *ProvisioningApiHandler) handleRouteExportMuteTimings(ctx *contextmodel.
 func (f *ProvisioningApiHandler) handleRouteDeleteAlertRuleGroup(ctx *contextmodel.ReqContext, folderUID, group string) response.Response {
 	return deprecatedRuleProvisioningResponse(f.svc.RouteDeleteAlertRuleGroup(ctx, folderUID, group), replacementAlertRules)
 }
+
+func (f *ProvisioningApiHandler) handleRouteGetAlertRuleTemplates(ctx *contextmodel.ReqContext) response.Response {
+	return f.svc.RouteGetAlertRuleTemplates(ctx)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetAlertRuleTemplate(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteGetAlertRuleTemplate(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePostAlertRuleTemplate(ctx *contextmodel.ReqContext, t apimodels.AlertRuleTemplate) response.Response {
+	return f.svc.RoutePostAlertRuleTemplate(ctx, t)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePutAlertRuleTemplate(ctx *contextmodel.ReqContext, t apimodels.AlertRuleTemplate, UID string) response.Response {
+	return f.svc.RoutePutAlertRuleTemplate(ctx, t, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRouteDeleteAlertRuleTemplate(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteDeleteAlertRuleTemplate(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePostAlertRuleTemplateReconcile(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RoutePostAlertRuleTemplateReconcile(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetTemplateLibraries(ctx *contextmodel.ReqContext) response.Response {
+	return f.svc.RouteGetTemplateLibraries(ctx)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetTemplateLibrary(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteGetTemplateLibrary(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetTemplateLibraryVersions(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteGetTemplateLibraryVersions(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePostTemplateLibrary(ctx *contextmodel.ReqContext, l apimodels.TemplateLibrary) response.Response {
+	return f.svc.RoutePostTemplateLibrary(ctx, l)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePutTemplateLibrary(ctx *contextmodel.ReqContext, l apimodels.TemplateLibrary, UID string) response.Response {
+	return f.svc.RoutePutTemplateLibrary(ctx, l, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRouteDeleteTemplateLibrary(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteDeleteTemplateLibrary(ctx, UID)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/grafana/grafana/pkg/api/response"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 )
 
 type HistoryApiHandler struct {
@@ -18,3 +19,15 @@ func NewStateHistoryApi(svc *HistorySrv) *HistoryApiHandler {
 func (f *HistoryApiHandler) handleRouteGetStateHistory(ctx *contextmodel.ReqContext) response.Response {
 	return f.svc.RouteQueryStateHistory(ctx)
 }
+
+func (f *HistoryApiHandler) handleRouteMigrateStateHistory(ctx *contextmodel.ReqContext, body apimodels.StateHistoryMigrationConfig) response.Response {
+	return f.svc.RouteMigrateStateHistory(ctx, body)
+}
+
+func (f *HistoryApiHandler) handleRouteGetStateHistoryMigration(ctx *contextmodel.ReqContext) response.Response {
+	return f.svc.RouteGetStateHistoryMigration(ctx)
+}
+
+func (f *HistoryApiHandler) handleRouteExportStateHistory(ctx *contextmodel.ReqContext) response.Response {
+	return f.svc.RouteExportStateHistory(ctx)
+}
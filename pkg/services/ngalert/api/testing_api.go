@@ -25,6 +25,10 @@ func (f *TestingApiHandler) handleRouteTestRuleGrafanaConfig(c *contextmodel.Req
 	return f.svc.RouteTestGrafanaRuleConfig(c, body)
 }
 
+func (f *TestingApiHandler) handleRouteTestRuleGrafanaDiff(c *contextmodel.ReqContext, body apimodels.PostableExtendedRuleNodeExtended) response.Response {
+	return f.svc.RouteTestGrafanaRuleDiff(c, body)
+}
+
 func (f *TestingApiHandler) handleRouteEvalQueries(c *contextmodel.ReqContext, body apimodels.EvalQueriesPayload) response.Response {
 	return f.svc.RouteEvalQueries(c, body)
 }
@@ -32,3 +36,11 @@ func (f *TestingApiHandler) handleRouteEvalQueries(c *contextmodel.ReqContext, b
 func (f *TestingApiHandler) handleBacktestConfig(ctx *contextmodel.ReqContext, conf apimodels.BacktestConfig) response.Response {
 	return f.svc.BacktestAlertRule(ctx, conf)
 }
+
+func (f *TestingApiHandler) handleBacktestNotificationsConfig(ctx *contextmodel.ReqContext, conf apimodels.BacktestNotificationsConfig) response.Response {
+	return f.svc.BacktestAlertRuleNotifications(ctx, conf)
+}
+
+func (f *TestingApiHandler) handleBacktestRecordingConfig(ctx *contextmodel.ReqContext, conf apimodels.BacktestRecordingConfig) response.Response {
+	return f.svc.BacktestRecordingRule(ctx, conf)
+}
@@ -177,6 +177,25 @@ import (
 //       403: PermissionDenied
 //       409: AlertManagerNotReady
 
+// swagger:route POST /alertmanager/grafana/config/api/v1/routes/simulate alertmanager RoutePostGrafanaRoutingSimulation
+//
+// Simulate how the notification policies handle alerts with the given labels.
+//
+// Each label set is routed through the notification policies currently applied to the Alertmanager,
+// and the time intervals, silences and inhibition rules are evaluated at the given time. Nothing is sent.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: RoutingSimulationResults
+//       400: ValidationError
+//       403: PermissionDenied
+//       409: AlertManagerNotReady
+
 // swagger:route GET /alertmanager/grafana/api/v2/silences alertmanager RouteGetGrafanaSilences
 //
 // get silences
@@ -428,6 +447,69 @@ const (
 	AlertScope  TemplateScope = ".Alert"
 )
 
+// swagger:parameters RoutePostGrafanaRoutingSimulation
+type RoutingSimulationParams struct {
+	// in:body
+	Body RoutingSimulationConfig
+}
+
+// swagger:model
+type RoutingSimulationConfig struct {
+	// Label sets of the alerts to route.
+	// required: true
+	Labels []map[string]string `json:"labels"`
+
+	// Time to evaluate the time intervals, silences and inhibition rules at. Defaults to the current time.
+	Time *time.Time `json:"time,omitempty"`
+}
+
+// swagger:model
+type RoutingSimulationResults struct {
+	Time    time.Time                 `json:"time"`
+	Results []RoutingSimulationResult `json:"results"`
+}
+
+type RoutingSimulationResult struct {
+	Labels map[string]string `json:"labels"`
+
+	// Notification policies the alert is routed to. There is more than one if a policy continues matching.
+	Routes []SimulatedRoute `json:"routes"`
+
+	// IDs of the silences that mute the alert.
+	Silences []string `json:"silences"`
+
+	// Labels of the firing alerts that inhibit the alert.
+	InhibitedBy []map[string]string `json:"inhibitedBy"`
+}
+
+type SimulatedRoute struct {
+	// Matchers of the policies from the root of the tree to the matched policy.
+	Path []string `json:"path"`
+
+	Receiver       string   `json:"receiver"`
+	GroupBy        []string `json:"groupBy"`
+	GroupWait      string   `json:"groupWait"`
+	GroupInterval  string   `json:"groupInterval"`
+	RepeatInterval string   `json:"repeatInterval"`
+
+	// Mute time intervals of the policy that include the time.
+	MuteTimeIntervals []string `json:"muteTimeIntervals"`
+
+	// Active time intervals of the policy that include the time.
+	ActiveTimeIntervals []string `json:"activeTimeIntervals"`
+
+	// True if the policy does not send notifications at the time.
+	Muted bool `json:"muted"`
+
+	Integrations []SimulatedIntegration `json:"integrations"`
+}
+
+type SimulatedIntegration struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
 // swagger:parameters RouteCreateSilence RouteCreateGrafanaSilence
 type CreateSilenceParams struct {
 	// in:body
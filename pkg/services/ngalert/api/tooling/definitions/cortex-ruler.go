@@ -44,6 +44,38 @@ import (
 //       403: ForbiddenError
 //       404: description: Not found.
 
+// swagger:route Post /ruler/grafana/api/v1/rule/{RuleUID}/instances/{Fingerprint}/acknowledgement ruler RoutePostAlertInstanceAcknowledgement
+//
+// Acknowledge a firing alert instance of the rule
+//
+// The acknowledgement is removed when it expires or when the instance stops firing.
+// If untilValueChanges is set, it is also removed when the values of the instance change.
+// If suppressNotifications is set, the instance is silenced until the acknowledgement is removed.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: AlertAcknowledgement
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:route Delete /ruler/grafana/api/v1/rule/{RuleUID}/instances/{Fingerprint}/acknowledgement ruler RouteDeleteAlertInstanceAcknowledgement
+//
+// Remove the acknowledgement of an alert instance of the rule
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       202: Ack
+//       403: ForbiddenError
+//       404: description: Not found.
+
 // swagger:route Get /ruler/grafana/api/v1/rules ruler RouteGetGrafanaRulesConfig
 //
 // List rule groups
@@ -60,6 +92,8 @@ import (
 //
 // List rules in provisioning format
 //
+// Use format=prometheus to convert the rules to a Prometheus rule file. Rules that cannot be expressed as Prometheus rules are listed in a comment together with the reason.
+//
 //     Produces:
 //     - application/json
 //     - application/yaml
@@ -114,6 +148,8 @@ import (
 //
 // Converts submitted rule group to provisioning format
 //
+// Use format=prometheus to convert the rules to a Prometheus rule file. Rules that cannot be expressed as Prometheus rules are listed in a comment together with the reason.
+//
 //     Consumes:
 //     - application/json
 //
@@ -262,6 +298,33 @@ type PathGetRuleByUIDParams struct {
 	RuleUID string
 }
 
+// swagger:parameters RoutePostAlertInstanceAcknowledgement RouteDeleteAlertInstanceAcknowledgement
+type PathAlertInstanceParams struct {
+	// in: path
+	RuleUID string
+	// The fingerprint of the alert instance as returned by the alerts API.
+	// in: path
+	Fingerprint string
+}
+
+// swagger:parameters RoutePostAlertInstanceAcknowledgement
+type PostableAlertAcknowledgementParams struct {
+	// in: body
+	Body PostableAlertAcknowledgement
+}
+
+// swagger:model
+type PostableAlertAcknowledgement struct {
+	Comment string `json:"comment,omitempty"`
+	// The time the acknowledgement expires.
+	// required: true
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Silence the alert instance until the acknowledgement is removed.
+	SuppressNotifications bool `json:"suppressNotifications,omitempty"`
+	// Remove the acknowledgement when the values of the alert instance change.
+	UntilValueChanges bool `json:"untilValueChanges,omitempty"`
+}
+
 // swagger:parameters RouteDeleteRuleFromTrashByGUID
 type PathDeleteRuleFromTrashByGUIDParams struct {
 	// in: path
@@ -516,6 +579,8 @@ const (
 // swagger:model
 type AlertRuleMetadata struct {
 	EditorSettings AlertRuleEditorSettings `json:"editor_settings" yaml:"editor_settings"`
+	// RuleTemplate is set if the rule is generated by an alert rule template.
+	RuleTemplate *AlertRuleTemplateReference `json:"rule_template,omitempty" yaml:"rule_template,omitempty"`
 }
 
 // swagger:model
@@ -596,6 +661,42 @@ type Record struct {
 	TargetDatasourceUID string `json:"target_datasource_uid,omitempty" yaml:"target_datasource_uid,omitempty"`
 }
 
+// swagger:model
+type SeverityCondition struct {
+	// Value of the severity label of the alert instances that match the condition.
+	// required: true
+	// example: critical
+	Severity string `json:"severity" yaml:"severity"`
+	// Which expression node determines the alert instances that have the severity.
+	// required: true
+	// example: C
+	Condition string `json:"condition" yaml:"condition"`
+}
+
+// swagger:enum FlapNotificationPolicy
+type FlapNotificationPolicy string
+
+const (
+	FlapNotify   FlapNotificationPolicy = "Notify"
+	FlapSuppress FlapNotificationPolicy = "Suppress"
+)
+
+// swagger:model
+type AlertRuleFlapDetection struct {
+	// Number of changes between firing and not firing within the window after which an alert instance is flapping.
+	// required: true
+	// example: 4
+	Transitions int `json:"transitions" yaml:"transitions"`
+	// The period in which the changes are counted.
+	// required: true
+	// example: 30m
+	Window model.Duration `json:"window" yaml:"window"`
+	// Whether flapping alert instances are sent to the Alertmanager.
+	// required: true
+	// example: Suppress
+	NotificationPolicy FlapNotificationPolicy `json:"notification_policy" yaml:"notification_policy"`
+}
+
 // swagger:model
 type PostableGrafanaRule struct {
 	Title                string                         `json:"title" yaml:"title"`
@@ -614,6 +715,14 @@ type PostableGrafanaRule struct {
 	// required: false
 	// example: 3
 	MissingSeriesEvalsToResolve *int64 `json:"missing_series_evals_to_resolve,omitempty" yaml:"missing_series_evals_to_resolve,omitempty"`
+	// Conditions that assign a severity to the firing alert instances, ordered from the lowest to the highest severity.
+	// Each firing alert instance gets the highest matching severity as the label severity.
+	// required: false
+	SeverityConditions []SeverityCondition `json:"severity_conditions,omitempty" yaml:"severity_conditions,omitempty"`
+	// Detection of alert instances that repeatedly change between firing and not firing.
+	// Flapping alert instances are held in their firing state until they stop flapping.
+	// required: false
+	FlapDetection *AlertRuleFlapDetection `json:"flap_detection,omitempty" yaml:"flap_detection,omitempty"`
 }
 
 // swagger:model
@@ -637,6 +746,8 @@ type GettableGrafanaRule struct {
 	Metadata                    *AlertRuleMetadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	GUID                        string                         `json:"guid" yaml:"guid"`
 	MissingSeriesEvalsToResolve *int64                         `json:"missing_series_evals_to_resolve,omitempty" yaml:"missing_series_evals_to_resolve,omitempty"`
+	SeverityConditions          []SeverityCondition            `json:"severity_conditions,omitempty" yaml:"severity_conditions,omitempty"`
+	FlapDetection               *AlertRuleFlapDetection        `json:"flap_detection,omitempty" yaml:"flap_detection,omitempty"`
 
 	// Field is only populated when listing alert rule versions.
 	Message string `yaml:"message,omitempty" json:"message,omitempty"`
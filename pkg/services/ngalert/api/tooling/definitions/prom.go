@@ -159,13 +159,46 @@ type AlertingRule struct {
 	// required: true
 	Annotations promlabels.Labels `json:"annotations,omitempty"`
 	// required: true
-	ActiveAt       *time.Time       `json:"activeAt,omitempty"`
-	Alerts         []Alert          `json:"alerts,omitempty"`
-	Totals         map[string]int64 `json:"totals,omitempty"`
-	TotalsFiltered map[string]int64 `json:"totalsFiltered,omitempty"`
+	ActiveAt       *time.Time          `json:"activeAt,omitempty"`
+	Alerts         []Alert             `json:"alerts,omitempty"`
+	Totals         map[string]int64    `json:"totals,omitempty"`
+	TotalsFiltered map[string]int64    `json:"totalsFiltered,omitempty"`
+	EvaluationCost *RuleEvaluationCost `json:"evaluationCost,omitempty"`
 	Rule
 }
 
+// RuleEvaluationCost is the cost of an evaluation of a rule.
+// swagger:model
+type RuleEvaluationCost struct {
+	// required: true
+	Series int64 `json:"series"`
+	// required: true
+	DataPoints int64 `json:"dataPoints"`
+	// Approximate size of the data returned by the queries, in bytes.
+	// required: true
+	Bytes int64 `json:"bytes"`
+	// Time of the execution of the data source queries, in seconds.
+	// required: true
+	QueryTime float64 `json:"queryTime"`
+	// required: true
+	Queries []QueryEvaluationCost `json:"queries"`
+}
+
+// QueryEvaluationCost is the cost of a data source query of a rule in an evaluation.
+// swagger:model
+type QueryEvaluationCost struct {
+	// required: true
+	RefID string `json:"refId"`
+	// required: true
+	Series int64 `json:"series"`
+	// required: true
+	DataPoints int64 `json:"dataPoints"`
+	// required: true
+	Bytes int64 `json:"bytes"`
+	// required: true
+	QueryTime float64 `json:"queryTime"`
+}
+
 // adapted from cortex
 // swagger:model
 type Rule struct {
@@ -200,6 +233,23 @@ type Alert struct {
 	ActiveAt *time.Time `json:"activeAt"`
 	// required: true
 	Value string `json:"value"`
+	// Fingerprint identifies the alert instance of a Grafana-managed rule.
+	Fingerprint     string                `json:"fingerprint,omitempty"`
+	Acknowledgement *AlertAcknowledgement `json:"acknowledgement,omitempty"`
+}
+
+// AlertAcknowledgement has info about the acknowledgement of an alert instance.
+// swagger:model
+type AlertAcknowledgement struct {
+	// required: true
+	By      string `json:"by"`
+	Comment string `json:"comment,omitempty"`
+	// required: true
+	At time.Time `json:"at"`
+	// required: true
+	ExpiresAt             time.Time `json:"expiresAt"`
+	SuppressNotifications bool      `json:"suppressNotifications,omitempty"`
+	UntilValueChanges     bool      `json:"untilValueChanges,omitempty"`
 }
 
 type StateByImportance int
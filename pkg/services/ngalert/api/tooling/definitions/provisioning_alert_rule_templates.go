@@ -0,0 +1,190 @@
+package definitions
+
+import (
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// swagger:route GET /v1/provisioning/alert-rule-templates provisioning stable RouteGetAlertRuleTemplates
+//
+// Get all the alert rule templates.
+//
+//     Responses:
+//       200: AlertRuleTemplates
+//       403: ForbiddenError
+
+// swagger:route GET /v1/provisioning/alert-rule-templates/{UID} provisioning stable RouteGetAlertRuleTemplate
+//
+// Get an alert rule template.
+//
+//     Responses:
+//       200: AlertRuleTemplate
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:route POST /v1/provisioning/alert-rule-templates provisioning stable RoutePostAlertRuleTemplate
+//
+// Create a new alert rule template and generate its rule group.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: AlertRuleTemplate
+//       400: ValidationError
+//       403: ForbiddenError
+//       409: PublicError
+
+// swagger:route PUT /v1/provisioning/alert-rule-templates/{UID} provisioning stable RoutePutAlertRuleTemplate
+//
+// Update an existing alert rule template and reconcile its rule group.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: AlertRuleTemplate
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: description: Not found.
+//       409: PublicError
+
+// swagger:route DELETE /v1/provisioning/alert-rule-templates/{UID} provisioning stable RouteDeleteAlertRuleTemplate
+//
+// Delete an alert rule template and the rule group it generated.
+//
+//     Responses:
+//       204: description: The alert rule template was deleted successfully.
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:route POST /v1/provisioning/alert-rule-templates/{UID}/reconcile provisioning stable RoutePostAlertRuleTemplateReconcile
+//
+// Generate the rule group of an alert rule template again.
+// Use it to update the rules of templates whose parameters come from a query.
+//
+//     Responses:
+//       200: AlertRuleTemplate
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:parameters RouteGetAlertRuleTemplate RoutePutAlertRuleTemplate RouteDeleteAlertRuleTemplate RoutePostAlertRuleTemplateReconcile
+type AlertRuleTemplateUIDReference struct {
+	// Alert rule template UID
+	// in:path
+	UID string
+}
+
+// swagger:parameters RoutePostAlertRuleTemplate RoutePutAlertRuleTemplate
+type AlertRuleTemplatePayload struct {
+	// in:body
+	Body AlertRuleTemplate
+}
+
+// swagger:model
+type AlertRuleTemplates []AlertRuleTemplate
+
+// AlertRuleTemplate generates a rule group with one alert rule for every parameter set of its source.
+// The generated rules are managed by the template and are replaced when the template changes.
+// swagger:model
+type AlertRuleTemplate struct {
+	// required: false
+	// minLength: 1
+	// maxLength: 40
+	// pattern: ^[a-zA-Z0-9-_]+$
+	UID string `json:"uid"`
+	// required: true
+	// minLength: 1
+	// maxLength: 190
+	// example: Service availability
+	Title string `json:"title"`
+	// required: true
+	// example: project_x
+	FolderUID string `json:"folderUID"`
+	// The rule group the template generates. It must not contain other rules.
+	// required: true
+	// minLength: 1
+	// maxLength: 190
+	// example: availability
+	RuleGroup string `json:"ruleGroup"`
+	// Evaluation interval of the rule group in seconds. Defaults to the default evaluation interval.
+	// example: 60
+	Interval int64 `json:"interval,omitempty"`
+	// Names of the parameters that every parameter set must define.
+	// required: true
+	// example: ["service", "threshold", "team"]
+	Parameters []string `json:"parameters"`
+	// required: true
+	Rule AlertRuleTemplateRule `json:"rule"`
+	// required: true
+	Source AlertRuleTemplateSource `json:"source"`
+	// Version of the template. When the template is updated, it must be equal to the current version.
+	Version int64 `json:"version"`
+	// readonly: true
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+// AlertRuleTemplateRule is the definition of the generated alert rules. The title, the models of the queries,
+// the labels, the annotations and the receiver are Go templates executed with the parameter set as data.
+// swagger:model
+type AlertRuleTemplateRule struct {
+	// required: true
+	// example: {{ .service }} is unavailable
+	Title string `json:"title"`
+	// required: true
+	// example: A
+	Condition string `json:"condition"`
+	// required: true
+	Data []AlertQuery `json:"data"`
+	// required: true
+	NoDataState NoDataState `json:"noDataState"`
+	// required: true
+	ExecErrState ExecutionErrorState `json:"execErrState"`
+	// required: true
+	// swagger:strfmt duration
+	For model.Duration `json:"for"`
+	// required: false
+	// swagger:strfmt duration
+	KeepFiringFor model.Duration `json:"keep_firing_for"`
+	// example: {"summary": "Availability of {{ .service }} is below {{ .threshold }}"}
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// example: {"team": "{{ .team }}"}
+	Labels map[string]string `json:"labels,omitempty"`
+	// example: false
+	IsPaused bool `json:"isPaused"`
+	// example: {"receiver":"{{ .team }}-oncall"}
+	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty"`
+}
+
+// AlertRuleTemplateSource provides the parameter sets of an alert rule template. Exactly one of the fields must be set.
+// swagger:model
+type AlertRuleTemplateSource struct {
+	// Static table of parameter sets.
+	// example: [{"service": "checkout", "threshold": "0.99", "team": "payments"}]
+	Static []map[string]string `json:"static,omitempty"`
+	// Query that provides the parameter sets.
+	Query *AlertRuleTemplateQuery `json:"query,omitempty"`
+}
+
+// AlertRuleTemplateQuery provides a parameter set for every distinct combination of values of the parameter labels
+// of the series returned by the query. Series that do not have all parameter labels are ignored.
+// swagger:model
+type AlertRuleTemplateQuery struct {
+	// required: true
+	// example: A
+	Condition string `json:"condition"`
+	// required: true
+	Data []AlertQuery `json:"data"`
+	// Delete all generated rules when the query provides no parameter sets, for example when it returns no data.
+	// By default, the rules are kept until the query provides parameter sets again.
+	AllowEmpty bool `json:"allowEmpty,omitempty"`
+}
+
+// AlertRuleTemplateReference refers to the alert rule template that generated a rule.
+// swagger:model
+type AlertRuleTemplateReference struct {
+	UID        string            `json:"uid" yaml:"uid"`
+	Parameters map[string]string `json:"parameters" yaml:"parameters"`
+}
@@ -0,0 +1,118 @@
+package definitions
+
+import "time"
+
+// swagger:route GET /v1/provisioning/template-libraries provisioning stable RouteGetTemplateLibraries
+//
+// Get all the notification template libraries shared by all organizations.
+//
+//     Responses:
+//       200: TemplateLibraries
+//       403: ForbiddenError
+
+// swagger:route GET /v1/provisioning/template-libraries/{UID} provisioning stable RouteGetTemplateLibrary
+//
+// Get a notification template library.
+//
+//     Responses:
+//       200: TemplateLibrary
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:route GET /v1/provisioning/template-libraries/{UID}/versions provisioning stable RouteGetTemplateLibraryVersions
+//
+// Get all versions of a notification template library, the latest first.
+//
+//     Responses:
+//       200: TemplateLibraryVersions
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:route POST /v1/provisioning/template-libraries provisioning stable RoutePostTemplateLibrary
+//
+// Create a new notification template library. Requires the Grafana server admin role.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: TemplateLibrary
+//       400: ValidationError
+//       403: ForbiddenError
+//       409: PublicError
+
+// swagger:route PUT /v1/provisioning/template-libraries/{UID} provisioning stable RoutePutTemplateLibrary
+//
+// Update a notification template library and create a new version of it. Requires the Grafana server admin role.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: TemplateLibrary
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: description: Not found.
+//       409: PublicError
+
+// swagger:route DELETE /v1/provisioning/template-libraries/{UID} provisioning stable RouteDeleteTemplateLibrary
+//
+// Delete a notification template library and all its versions. Requires the Grafana server admin role.
+//
+//     Responses:
+//       204: description: The template library was deleted successfully.
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:parameters RouteGetTemplateLibrary RouteGetTemplateLibraryVersions RoutePutTemplateLibrary RouteDeleteTemplateLibrary
+type TemplateLibraryUIDReference struct {
+	// Template library UID
+	// in:path
+	UID string
+}
+
+// swagger:parameters RoutePostTemplateLibrary RoutePutTemplateLibrary
+type TemplateLibraryPayload struct {
+	// in:body
+	Body TemplateLibrary
+}
+
+// swagger:model
+type TemplateLibraries []TemplateLibrary
+
+// TemplateLibrary is a set of notification templates that is available in every organization.
+// A template of an organization with the same name as a template of a library overrides it.
+// swagger:model
+type TemplateLibrary struct {
+	// required: false
+	// minLength: 1
+	// maxLength: 40
+	// pattern: ^[a-zA-Z0-9-_]+$
+	UID string `json:"uid"`
+	// required: true
+	// minLength: 1
+	// maxLength: 190
+	// example: Shared Slack templates
+	Name string `json:"name"`
+	// example: Slack titles and messages used by all teams
+	Description string `json:"description,omitempty"`
+	// Template files of the library by name. The names must be unique across all libraries.
+	// required: true
+	// example: {"slack": "{{ define \"slack.title\" }}{{ .CommonLabels.alertname }}{{ end }}"}
+	Templates map[string]string `json:"templates"`
+	// Version of the library. When the library is updated, it must be equal to the current version.
+	Version int64 `json:"version"`
+	// readonly: true
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+// swagger:model
+type TemplateLibraryVersions []TemplateLibraryVersion
+
+// TemplateLibraryVersion is the content of a template library at a given version.
+// swagger:model
+type TemplateLibraryVersion struct {
+	Version   int64             `json:"version"`
+	Templates map[string]string `json:"templates"`
+	Created   time.Time         `json:"created"`
+}
@@ -1,6 +1,11 @@
 package definitions
 
-import "github.com/grafana/grafana-plugin-sdk-go/data"
+import (
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/common/model"
+)
 
 // swagger:route GET /v1/rules/history history RouteGetStateHistory
 //
@@ -19,6 +24,56 @@ import "github.com/grafana/grafana-plugin-sdk-go/data"
 //       403: ForbiddenError
 //       500: Failure
 
+// swagger:route POST /v1/rules/history/migrate history RouteMigrateStateHistory
+//
+// Migrate state history between backends.
+//
+// Starts a background job that reads the state history of the rules of the organization from the source backend in batches
+// and writes it to the destination backend. Only one migration can run in an organization at a time.
+// A migration that is stopped before it is done can be resumed from the checkpoint of its progress.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       202: StateHistoryMigrationStatus
+//       400: ValidationError
+//       403: ForbiddenError
+//       409: StateHistoryMigrationStatus
+//       500: Failure
+
+// swagger:route GET /v1/rules/history/migrate history RouteGetStateHistoryMigration
+//
+// Get the status of the state history migration.
+//
+// Returns the progress of the running or the last finished state history migration of the organization.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: StateHistoryMigrationStatus
+//       403: ForbiddenError
+//       404: NotFound
+
+// swagger:route GET /v1/rules/history/export history RouteExportStateHistory
+//
+// Export state history.
+//
+// Reads the state history of the rules of the organization from the backend and returns it as newline-delimited JSON, one state transition per line.
+//
+//     Produces:
+//     - application/x-ndjson
+//
+//     Responses:
+//       200: StateHistoryExport
+//       400: ValidationError
+//       403: ForbiddenError
+//       500: Failure
+
 // swagger:response StateHistory
 type StateHistory struct {
 	// in:body
@@ -51,3 +106,85 @@ type StateHistoryParams struct {
 	// Filter by dashboard's panel ID. Requires Dashboard UID to be specified.
 	PanelID int64
 }
+
+// swagger:parameters RouteMigrateStateHistory
+type StateHistoryMigrationRequest struct {
+	// in:body
+	Body StateHistoryMigrationConfig
+}
+
+// swagger:model
+type StateHistoryMigrationConfig struct {
+	// The backend the history is read from. One of annotations, loki or prometheus.
+	// required: true
+	Source string `json:"source"`
+	// The backend the history is written to. One of annotations, loki or prometheus.
+	// required: true
+	Destination string `json:"destination"`
+
+	From time.Time `json:"from"`
+	To   time.Time `json:"to,omitempty"`
+	// Migrate only the history of these rules. The history of all rules of the organization is migrated if empty.
+	RuleUIDs []string `json:"rule_uids,omitempty"`
+
+	// The time range of history that is read from the source in a single batch. Defaults to 24h.
+	Window model.Duration `json:"window,omitempty"`
+	// The maximum number of entries that is read from the source in a single batch. Defaults to 1000.
+	Limit int `json:"limit,omitempty"`
+	// Stop after the number of batches. Zero means that all history is migrated.
+	MaxBatches int `json:"max_batches,omitempty"`
+	// Continue a previous migration from the checkpoint.
+	Resume *StateHistoryMigrationCheckpoint `json:"resume,omitempty"`
+}
+
+// swagger:model
+type StateHistoryMigrationCheckpoint struct {
+	RuleUID string    `json:"rule_uid"`
+	Time    time.Time `json:"time"`
+}
+
+// swagger:model
+type StateHistoryMigrationProgress struct {
+	RulesTotal     int  `json:"rules_total"`
+	RulesCompleted int  `json:"rules_completed"`
+	Batches        int  `json:"batches"`
+	Entries        int  `json:"entries"`
+	Done           bool `json:"done"`
+	// Pass the checkpoint as resume to continue the migration.
+	Checkpoint StateHistoryMigrationCheckpoint `json:"checkpoint"`
+}
+
+// swagger:model
+type StateHistoryMigrationStatus struct {
+	// True while the migration is running in the background.
+	Running bool `json:"running"`
+	// The error that stopped the migration. The migration can be resumed from the checkpoint of the progress.
+	Error    string                        `json:"error,omitempty"`
+	Progress StateHistoryMigrationProgress `json:"progress"`
+}
+
+// swagger:parameters RouteExportStateHistory
+type StateHistoryExportParams struct {
+	// The backend the history is read from. One of annotations, loki or prometheus.
+	// in:query
+	// required: true
+	Backend string `json:"backend"`
+	// The timestamp in seconds of the start point of the time range the history is exported. Defaults to 24 hours before the end.
+	// in:query
+	// required: false
+	From int64 `json:"from"`
+	// The timestamp in seconds of the end point of the time range the history is exported. Defaults to now.
+	// in:query
+	// required: false
+	To int64 `json:"to"`
+	// Export only the history of these rules.
+	// in:query
+	// required: false
+	RuleUID []string `json:"ruleUID"`
+}
+
+// swagger:response StateHistoryExport
+type StateHistoryExport struct {
+	// in:body
+	Body string
+}
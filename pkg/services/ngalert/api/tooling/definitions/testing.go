@@ -28,6 +28,24 @@ import (
 //       400: ValidationError
 //       404: NotFound
 
+// swagger:route Post /v1/rule/test/grafana/diff testing RouteTestRuleGrafanaDiff
+//
+// Preview how an edit of a rule changes its current alert instances
+//
+// Evaluates the rule now and compares the result with the current alert instances of the rule.
+// The response lists the instances that would resolve, keep firing or start firing, and the instances whose labels would change.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: TestGrafanaRuleDiffResponse
+//       400: ValidationError
+//       404: NotFound
+
 // swagger:route Post /v1/rule/test/{DatasourceUID} testing RouteTestRuleConfig
 //
 // Test a rule against external data source ruler
@@ -68,6 +86,32 @@ import (
 //     Responses:
 //       200: BacktestResult
 
+// swagger:route Post /v1/rule/backtest/notifications testing BacktestNotificationsConfig
+//
+// Test rule and preview the notifications it would have sent
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: BacktestNotificationsResult
+
+// swagger:route Post /v1/rule/backtest/recording testing BacktestRecordingConfig
+//
+// Test recording rule and preview the series it would have written
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: BacktestRecordingResult
+
 // swagger:parameters RouteTestReceiverConfig
 type TestReceiverRequest struct {
 	// in:body
@@ -94,12 +138,40 @@ type TestGrafanaRuleResponse struct {
 	Body []amv2.PostableAlert
 }
 
-// swagger:parameters RouteTestRuleGrafanaConfig
+// swagger:response TestGrafanaRuleDiffResponse
+type TestGrafanaRuleDiffResponse struct {
+	// in:body
+	Body RuleInstancesDiff
+}
+
+// swagger:parameters RouteTestRuleGrafanaConfig RouteTestRuleGrafanaDiff
 type TestGrafanaRuleRequest struct {
 	// in:body
 	Body PostableExtendedRuleNodeExtended
 }
 
+// swagger:model
+type RuleInstancesDiff struct {
+	// Resolve contains the firing instances that would be resolved.
+	Resolve []RuleInstanceChange `json:"resolve"`
+	// Stay contains the firing instances that would keep firing.
+	Stay []RuleInstanceChange `json:"stay"`
+	// Fire contains the instances that would become pending or start firing.
+	Fire []RuleInstanceChange `json:"fire"`
+	// Relabel contains the instances whose labels, and therefore fingerprint, would change.
+	Relabel []RuleInstanceChange `json:"relabel"`
+}
+
+// swagger:model
+type RuleInstanceChange struct {
+	Labels          map[string]string `json:"labels,omitempty"`
+	Fingerprint     string            `json:"fingerprint,omitempty"`
+	State           string            `json:"state,omitempty"`
+	NextLabels      map[string]string `json:"nextLabels,omitempty"`
+	NextFingerprint string            `json:"nextFingerprint,omitempty"`
+	NextState       string            `json:"nextState"`
+}
+
 // swagger:model
 type PostableExtendedRuleNodeExtended struct {
 	// required: true
@@ -240,3 +312,69 @@ type BacktestConfig struct {
 
 // swagger:model
 type BacktestResult data.Frame
+
+// swagger:parameters BacktestNotificationsConfig
+type BacktestNotificationsConfigRequest struct {
+	// in:body
+	Body BacktestNotificationsConfig
+}
+
+// swagger:model
+type BacktestNotificationsConfig struct {
+	BacktestConfig
+
+	// The Alertmanager configuration whose notification policies are used instead of the current configuration
+	// of the organization. It allows to verify changes of the notification policies before they are saved.
+	AlertmanagerConfig *PostableApiAlertingConfig `json:"alertmanager_config,omitempty"`
+}
+
+// swagger:model
+type BacktestNotificationsResult struct {
+	// The state transitions of the rule, the same as the result of the rule backtest.
+	States *data.Frame `json:"states"`
+	// The notifications that would have been sent to the contact points, in the order they would have been sent.
+	Notifications []BacktestNotification `json:"notifications"`
+}
+
+type BacktestNotification struct {
+	Time        time.Time                   `json:"time"`
+	Receiver    string                      `json:"receiver"`
+	GroupKey    string                      `json:"groupKey"`
+	GroupLabels map[string]string           `json:"groupLabels"`
+	Alerts      []BacktestNotificationAlert `json:"alerts"`
+}
+
+type BacktestNotificationAlert struct {
+	Labels   map[string]string `json:"labels"`
+	Status   string            `json:"status"`
+	StartsAt time.Time         `json:"startsAt"`
+	EndsAt   time.Time         `json:"endsAt"`
+}
+
+// swagger:parameters BacktestRecordingConfig
+type BacktestRecordingConfigRequest struct {
+	// in:body
+	Body BacktestRecordingConfig
+}
+
+// swagger:model
+type BacktestRecordingConfig struct {
+	From     time.Time      `json:"from"`
+	To       time.Time      `json:"to"`
+	Interval model.Duration `json:"interval,omitempty"`
+
+	Data []AlertQuery `json:"data"`
+	// required: true
+	Record *Record `json:"record"`
+
+	Title  string            `json:"title"`
+	Labels map[string]string `json:"labels,omitempty"`
+
+	UID          string `json:"uid,omitempty"`
+	RuleGroup    string `json:"rule_group,omitempty"`
+	NamespaceUID string `json:"namespace_uid,omitempty"`
+}
+
+// The series the recording rule would have written, one frame per series.
+// swagger:model
+type BacktestRecordingResult data.Frames
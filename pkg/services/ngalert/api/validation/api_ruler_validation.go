@@ -157,6 +157,18 @@ func validateAlertingRuleFields(in *apimodels.PostableExtendedRuleNode, newRule
 		return ngmodels.AlertRule{}, err
 	}
 
+	newRule.SeverityConditions = ModelSeverityConditionsFromApiSeverityConditions(in.GrafanaManagedAlert.SeverityConditions)
+	if err := newRule.SeverityConditions.Validate(newRule.Data); err != nil {
+		return ngmodels.AlertRule{}, fmt.Errorf("%w: invalid severity conditions: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+	}
+
+	newRule.FlapDetection = ModelFlapDetectionFromApiFlapDetection(in.GrafanaManagedAlert.FlapDetection)
+	if newRule.FlapDetection != nil {
+		if err := newRule.FlapDetection.Validate(time.Duration(newRule.IntervalSeconds) * time.Second); err != nil {
+			return ngmodels.AlertRule{}, fmt.Errorf("%w: invalid flap detection: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+		}
+	}
+
 	newRule.For, err = validateForInterval(in)
 	if err != nil {
 		return ngmodels.AlertRule{}, err
@@ -393,6 +405,14 @@ func ValidateRuleGroup(
 
 		result = append(result, &ruleWithOptionals)
 	}
+
+	group := make(ngmodels.RulesGroup, 0, len(result))
+	for _, rule := range result {
+		group = append(group, &rule.AlertRule)
+	}
+	if _, err := group.SortByDependencies(); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -436,3 +456,30 @@ func ValidateBacktestConfig(orgId int64, config apimodels.BacktestConfig, limits
 		},
 	}, config.RuleGroup, interval, orgId, config.NamespaceUID, limits)
 }
+
+func ValidateBacktestRecordingConfig(orgId int64, config apimodels.BacktestRecordingConfig, limits RuleLimits) (*ngmodels.AlertRule, error) {
+	if config.Record == nil {
+		return nil, errors.New("recording rule definition is required")
+	}
+
+	if config.From.After(config.To) {
+		return nil, fmt.Errorf("invalid testing range: from %s must be before to %s", config.From, config.To)
+	}
+
+	interval, err := validateGroupInterval(config.Interval, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	return ValidateRuleNode(&apimodels.PostableExtendedRuleNode{
+		ApiRuleNode: &apimodels.ApiRuleNode{
+			Labels: config.Labels,
+		},
+		GrafanaManagedAlert: &apimodels.PostableGrafanaRule{
+			Title:  config.Title,
+			Data:   config.Data,
+			UID:    config.UID,
+			Record: config.Record,
+		},
+	}, config.RuleGroup, interval, orgId, config.NamespaceUID, limits)
+}
@@ -9,7 +9,10 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/prometheus/common/model"
 
+	alertingModels "github.com/grafana/alerting/models"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
@@ -23,6 +26,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/state/historian"
 	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
 )
@@ -36,8 +40,11 @@ var (
 
 type callbackFunc = func(evaluationIndex int, now time.Time, results eval.Results) (bool, error)
 
+type rawCallbackFunc = func(evaluationIndex int, now time.Time, resp *backend.QueryDataResponse) (bool, error)
+
 type backtestingEvaluator interface {
 	Eval(ctx context.Context, from time.Time, interval time.Duration, evaluations int, callback callbackFunc) error
+	EvalRaw(ctx context.Context, from time.Time, interval time.Duration, evaluations int, callback rawCallbackFunc) error
 }
 
 type stateManager interface {
@@ -46,6 +53,7 @@ type stateManager interface {
 }
 
 type Engine struct {
+	appUrl               *url.URL
 	evalFactory          eval.EvaluatorFactory
 	createStateManager   func() stateManager
 	disableGrafanaFolder bool
@@ -58,6 +66,7 @@ type Engine struct {
 
 func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracing.Tracer, cfg setting.UnifiedAlertingSettings, toggles featuremgmt.FeatureToggles) *Engine {
 	return &Engine{
+		appUrl:      appUrl,
 		evalFactory: evalFactory,
 		createStateManager: func() stateManager {
 			cfg := state.ManagerCfg{
@@ -81,7 +90,46 @@ func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracin
 	}
 }
 
-func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time, folderTitle string) (res *data.Frame, err error) {
+func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time, folderTitle string) (*data.Frame, error) {
+	return e.test(ctx, user, rule, from, to, folderTitle, nil)
+}
+
+// TestNotifications does the same as Test, and in addition routes the alerts that the rule would have sent to the
+// Alertmanager through the notification policies. It returns the notifications that would have been sent to the
+// contact points after grouping, timing options, mute timings and inhibition rules are applied.
+func (e *Engine) TestNotifications(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time, folderTitle string, policies NotificationPolicies) (*data.Frame, []Notification, error) {
+	simulator, err := newNotificationSimulator(policies)
+	if err != nil {
+		return nil, nil, err
+	}
+	send := func(now time.Time, transitions state.StateTransitions) {
+		alerts := make([]*model.Alert, 0, len(transitions))
+		for _, t := range transitions {
+			postable := state.StateToPostableAlert(t, e.appUrl, e.featureToggles)
+			lbls := make(model.LabelSet, len(postable.Labels))
+			for k, v := range postable.Labels {
+				// The Grafana Alertmanager skips empty and namespace UID labels.
+				if len(v) == 0 || k == alertingModels.NamespaceUIDLabel {
+					continue
+				}
+				lbls[model.LabelName(k)] = model.LabelValue(v)
+			}
+			alerts = append(alerts, &model.Alert{
+				Labels:   lbls,
+				StartsAt: time.Time(postable.StartsAt),
+				EndsAt:   time.Time(postable.EndsAt),
+			})
+		}
+		simulator.Add(now, alerts...)
+	}
+	frame, err := e.test(ctx, user, rule, from, to, folderTitle, send)
+	if err != nil {
+		return nil, nil, err
+	}
+	return frame, simulator.Flush(to), nil
+}
+
+func (e *Engine) test(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time, folderTitle string, send func(time.Time, state.StateTransitions)) (res *data.Frame, err error) {
 	if rule == nil {
 		return nil, fmt.Errorf("%w: rule is not defined", ErrInvalidInputData)
 	}
@@ -92,33 +140,11 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 	ruleCtx := models.WithRuleKey(ctx, rule.GetKey())
 	logger := logger.FromContext(ruleCtx).New("backtesting", util.GenerateShortUID())
 
-	var warns []string
-	if rule.GetInterval() < e.minInterval {
-		logger.Warn("Interval adjusted to minimal interval", "originalInterval", rule.GetInterval(), "adjustedInterval", e.minInterval)
-		rule = rule.Copy()
-		rule.IntervalSeconds = int64(e.minInterval.Seconds())
-		warns = append(warns, fmt.Sprintf("Interval adjusted to minimal interval %ds", rule.IntervalSeconds))
-	}
-
-	effectiveStrategy := e.jitterStrategy
-	if e.jitterStrategy == schedule.JitterByGroup && (rule.RuleGroup == "" || rule.NamespaceUID == "") ||
-		e.jitterStrategy == schedule.JitterByRule && rule.UID == "" {
-		logger.Warn(fmt.Sprintf("Jitter strategy is set to %s, but rule group or namespace is not set. Ignore jitter", e.jitterStrategy))
-		warns = append(warns, fmt.Sprintf("Jitter strategy is set to %s, but rule group or namespace is not set. Ignore jitter. The results of testing will be different than real evaluations", e.jitterStrategy))
-		effectiveStrategy = schedule.JitterNever
-	}
-	jitterOffset := schedule.JitterOffsetInDuration(rule, e.baseInterval, effectiveStrategy)
-	firstEval, err := getFirstEvaluationTime(from, rule, e.baseInterval, jitterOffset)
+	plan, err := e.planEvaluations(logger, rule, from, to)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", ErrInvalidInputData, err)
-	}
-
-	evaluations := calculateNumberOfEvaluations(firstEval, to, rule.GetInterval())
-	if e.maxEvaluations > 0 && evaluations > e.maxEvaluations {
-		logger.Warn("Evaluations adjusted to maximal number", "originalEvaluations", evaluations, "adjustedEvaluations", e.maxEvaluations)
-		warns = append(warns, fmt.Sprintf("Number of evaluations are adjusted to the limit of %d evaluations. Requested: %d", e.maxEvaluations, evaluations))
-		evaluations = e.maxEvaluations
+		return nil, err
 	}
+	rule = plan.rule
 
 	start := time.Now()
 	defer func() {
@@ -144,7 +170,7 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 		return nil, errors.Join(ErrInvalidInputData, err)
 	}
 
-	logger.Info("Start testing alert rule", "from", from, "to", to, "interval", rule.GetInterval(), "firstTick", firstEval, "evaluations", evaluations, "jitterOffset", jitterOffset, "jitterStrategy", effectiveStrategy)
+	logger.Info("Start testing alert rule", "from", from, "to", to, "interval", rule.GetInterval(), "firstTick", plan.firstEval, "evaluations", plan.evaluations, "jitterOffset", plan.jitterOffset, "jitterStrategy", plan.jitterStrategy)
 
 	var builder *historian.QueryResultBuilder
 
@@ -178,12 +204,18 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 	processFn := func(idx int, currentTime time.Time, results eval.Results) (bool, error) {
 		// init the builder. Do the best guess for the size of the result
 		if builder == nil {
-			builder = historian.NewQueryResultBuilder(evaluations * len(results))
-			for _, warn := range warns {
+			builder = historian.NewQueryResultBuilder(plan.evaluations * len(results))
+			for _, warn := range plan.warns {
 				builder.AddWarn(warn)
 			}
 		}
-		states := stateMgr.ProcessEvalResults(ruleCtx, currentTime, rule, results, extraLabels, nil)
+		var sender state.Sender
+		if send != nil {
+			sender = func(_ context.Context, transitions state.StateTransitions) {
+				send(currentTime, transitions)
+			}
+		}
+		states := stateMgr.ProcessEvalResults(ruleCtx, currentTime, rule, results, extraLabels, sender)
 		for _, s := range states {
 			if !historian.ShouldRecord(s) {
 				continue
@@ -194,10 +226,10 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 				return false, err
 			}
 		}
-		return idx <= evaluations, nil
+		return idx <= plan.evaluations, nil
 	}
 
-	err = evaluator.Eval(ruleCtx, firstEval, rule.GetInterval(), evaluations, processFn)
+	err = evaluator.Eval(ruleCtx, plan.firstEval, rule.GetInterval(), plan.evaluations, processFn)
 	if err != nil {
 		return nil, err
 	}
@@ -207,6 +239,130 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 	return builder.ToFrame(), nil
 }
 
+// TestRecording evaluates the query of the recording rule at every interval in the range [from, to) and returns
+// the series the rule would have written, one frame per series. The series have the metric name of the rule
+// and the labels that the writer adds to every point. The evaluations that fail or return no data do not write
+// anything, the same as in the scheduler.
+func (e *Engine) TestRecording(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time) (res data.Frames, err error) {
+	if rule == nil || rule.Record == nil {
+		return nil, fmt.Errorf("%w: recording rule is not defined", ErrInvalidInputData)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: invalid interval [%d,%d]", ErrInvalidInputData, from.Unix(), to.Unix())
+	}
+
+	ruleCtx := models.WithRuleKey(ctx, rule.GetKey())
+	logger := logger.FromContext(ruleCtx).New("backtesting", util.GenerateShortUID())
+
+	plan, err := e.planEvaluations(logger, rule, from, to)
+	if err != nil {
+		return nil, err
+	}
+	rule = plan.rule
+
+	start := time.Now()
+	defer func() {
+		if err == nil {
+			logger.Info("Recording rule testing finished successfully", "duration", time.Since(start))
+		} else {
+			logger.Error("Recording rule testing finished with error", "duration", time.Since(start), "error", err)
+		}
+	}()
+
+	evaluator, err := backtestingEvaluatorFactory(ruleCtx, e.evalFactory, user, rule.GetEvalCondition().WithSource("backtesting"), nil)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidInputData, err)
+	}
+
+	logger.Info("Start testing recording rule", "from", from, "to", to, "interval", rule.GetInterval(), "firstTick", plan.firstEval, "evaluations", plan.evaluations, "jitterOffset", plan.jitterOffset, "jitterStrategy", plan.jitterStrategy)
+
+	builder := newRecordedSeriesBuilder(rule.Record.Metric)
+	extraLabels := models.WithoutPrivateLabels(rule.Labels)
+	var failed int
+	var lastErr error
+	processFn := func(idx int, now time.Time, resp *backend.QueryDataResponse) (bool, error) {
+		if err := eval.FindConditionError(resp, rule.Record.From); err != nil {
+			failed++
+			lastErr = err
+			return idx <= plan.evaluations, nil
+		}
+		target, ok := resp.Responses[rule.Record.From]
+		if !ok || eval.IsNoData(target) {
+			return idx <= plan.evaluations, nil
+		}
+		points, err := writer.PointsFromFrames(rule.Record.Metric, now, target.Frames, extraLabels)
+		if err != nil {
+			failed++
+			lastErr = errors.Join(writer.ErrBadFrame, err)
+			return idx <= plan.evaluations, nil
+		}
+		builder.add(points)
+		return idx <= plan.evaluations, nil
+	}
+
+	err = evaluator.EvalRaw(ruleCtx, plan.firstEval, rule.GetInterval(), plan.evaluations, processFn)
+	if err != nil {
+		return nil, err
+	}
+
+	warns := plan.warns
+	if failed > 0 {
+		warns = append(warns, fmt.Sprintf("%d of %d evaluations failed and did not write any data. Last error: %s", failed, plan.evaluations, lastErr))
+	}
+	return builder.toFrames(warns), nil
+}
+
+// evaluationPlan describes when a rule is evaluated during testing.
+type evaluationPlan struct {
+	rule           *models.AlertRule
+	firstEval      time.Time
+	evaluations    int
+	jitterOffset   time.Duration
+	jitterStrategy schedule.JitterStrategy
+	warns          []string
+}
+
+// planEvaluations calculates the evaluations of the rule in the range [from, to) the same way as the scheduler does.
+// The returned rule is a copy of the rule if its interval had to be adjusted.
+func (e *Engine) planEvaluations(logger log.Logger, rule *models.AlertRule, from, to time.Time) (evaluationPlan, error) {
+	var warns []string
+	if rule.GetInterval() < e.minInterval {
+		logger.Warn("Interval adjusted to minimal interval", "originalInterval", rule.GetInterval(), "adjustedInterval", e.minInterval)
+		rule = rule.Copy()
+		rule.IntervalSeconds = int64(e.minInterval.Seconds())
+		warns = append(warns, fmt.Sprintf("Interval adjusted to minimal interval %ds", rule.IntervalSeconds))
+	}
+
+	effectiveStrategy := e.jitterStrategy
+	if e.jitterStrategy == schedule.JitterByGroup && (rule.RuleGroup == "" || rule.NamespaceUID == "") ||
+		e.jitterStrategy == schedule.JitterByRule && rule.UID == "" {
+		logger.Warn(fmt.Sprintf("Jitter strategy is set to %s, but rule group or namespace is not set. Ignore jitter", e.jitterStrategy))
+		warns = append(warns, fmt.Sprintf("Jitter strategy is set to %s, but rule group or namespace is not set. Ignore jitter. The results of testing will be different than real evaluations", e.jitterStrategy))
+		effectiveStrategy = schedule.JitterNever
+	}
+	jitterOffset := schedule.JitterOffsetInDuration(rule, e.baseInterval, effectiveStrategy)
+	firstEval, err := getFirstEvaluationTime(from, rule, e.baseInterval, jitterOffset)
+	if err != nil {
+		return evaluationPlan{}, fmt.Errorf("%w: %s", ErrInvalidInputData, err)
+	}
+
+	evaluations := calculateNumberOfEvaluations(firstEval, to, rule.GetInterval())
+	if e.maxEvaluations > 0 && evaluations > e.maxEvaluations {
+		logger.Warn("Evaluations adjusted to maximal number", "originalEvaluations", evaluations, "adjustedEvaluations", e.maxEvaluations)
+		warns = append(warns, fmt.Sprintf("Number of evaluations are adjusted to the limit of %d evaluations. Requested: %d", e.maxEvaluations, evaluations))
+		evaluations = e.maxEvaluations
+	}
+
+	return evaluationPlan{
+		rule:           rule,
+		firstEval:      firstEval,
+		evaluations:    evaluations,
+		jitterOffset:   jitterOffset,
+		jitterStrategy: effectiveStrategy,
+		warns:          warns,
+	}, nil
+}
+
 func newBacktestingEvaluator(ctx context.Context, evalFactory eval.EvaluatorFactory, user identity.Requester, condition models.Condition, reader eval.AlertingResultsReader) (backtestingEvaluator, error) {
 	for _, q := range condition.Data {
 		if q.DatasourceUID == "__data__" || q.QueryType == "__data__" {
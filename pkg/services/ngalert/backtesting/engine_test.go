@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
@@ -261,7 +262,8 @@ func (f *fakeStateManager) GetStatesForRuleUID(_ context.Context, orgID int64, a
 }
 
 type fakeBacktestingEvaluator struct {
-	evalCallback func(now time.Time) (eval.Results, error)
+	evalCallback    func(now time.Time) (eval.Results, error)
+	evalRawCallback func(now time.Time) (*backend.QueryDataResponse, error)
 }
 
 func (f *fakeBacktestingEvaluator) Eval(_ context.Context, from time.Time, interval time.Duration, evaluations int, callback callbackFunc) error {
@@ -455,3 +457,20 @@ func TestGetFirstEvaluationTime(t *testing.T) {
 		})
 	}
 }
+
+func (f *fakeBacktestingEvaluator) EvalRaw(_ context.Context, from time.Time, interval time.Duration, evaluations int, callback rawCallbackFunc) error {
+	for idx, now := 0, from; idx < evaluations; idx, now = idx+1, now.Add(interval) {
+		resp, err := f.evalRawCallback(now)
+		if err != nil {
+			return err
+		}
+		c, err := callback(idx, now, resp)
+		if err != nil {
+			return err
+		}
+		if !c {
+			break
+		}
+	}
+	return nil
+}
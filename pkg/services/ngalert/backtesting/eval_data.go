@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/expr"
@@ -37,17 +38,26 @@ func newDataEvaluator(refID string, frame *data.Frame) (*dataEvaluator, error) {
 	}, nil
 }
 
-func (d *dataEvaluator) Eval(_ context.Context, from time.Time, interval time.Duration, evaluations int, callback callbackFunc) error {
+// resample aligns the input data with the evaluations.
+func (d *dataEvaluator) resample(from time.Time, interval time.Duration, evaluations int) ([]mathexp.Series, error) {
 	var resampled = make([]mathexp.Series, 0, len(d.data))
 	to := from.Add(time.Duration(evaluations) * interval)
 	for _, s := range d.data {
 		// making sure the input data frame is aligned with the interval
 		r, err := s.Resample(d.refID, interval, d.downsampleFunction, d.upsampleFunction, from, to.Add(-interval)) // we want to query [from,to)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		resampled = append(resampled, r)
 	}
+	return resampled, nil
+}
+
+func (d *dataEvaluator) Eval(_ context.Context, from time.Time, interval time.Duration, evaluations int, callback callbackFunc) error {
+	resampled, err := d.resample(from, interval, evaluations)
+	if err != nil {
+		return err
+	}
 
 	for i := 0; i < evaluations; i++ {
 		result := make([]eval.Result, 0, len(resampled))
@@ -95,3 +105,39 @@ func (d *dataEvaluator) Eval(_ context.Context, from time.Time, interval time.Du
 	}
 	return nil
 }
+
+// EvalRaw returns the values of the series at every evaluation as numbers, the same as a query that returns
+// the data would.
+func (d *dataEvaluator) EvalRaw(_ context.Context, from time.Time, interval time.Duration, evaluations int, callback rawCallbackFunc) error {
+	resampled, err := d.resample(from, interval, evaluations)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < evaluations; i++ {
+		frames := make(data.Frames, 0, len(resampled))
+		now := from.Add(time.Duration(i) * interval)
+		for _, series := range resampled {
+			if snow := series.GetTime(i); snow != now {
+				return errors.New("failed to resample input data. timestamps are not aligned")
+			}
+			value := series.GetValue(i)
+			if value == nil {
+				continue
+			}
+			number := mathexp.NewNumber(d.refID, series.GetLabels())
+			number.SetValue(value)
+			frames = append(frames, number.AsDataFrame())
+		}
+		resp := backend.NewQueryDataResponse()
+		resp.Responses[d.refID] = backend.DataResponse{Frames: frames}
+		cont, err := callback(i, now, resp)
+		if err != nil {
+			return err
+		}
+		if !cont {
+			break
+		}
+	}
+	return nil
+}
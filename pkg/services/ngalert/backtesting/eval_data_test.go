@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -300,3 +301,34 @@ func TestDataEvaluator_Eval(t *testing.T) {
 		require.Equal(t, 2, evaluated)
 	})
 }
+
+func TestDataEvaluator_EvalRaw(t *testing.T) {
+	refID := util.GenerateShortUID()
+	frameSize := rand.Intn(100) + 100
+	frame := GenerateWideSeriesFrame(frameSize, time.Second)
+	from := frame.At(0, 0).(time.Time)
+	evaluator, err := newDataEvaluator(refID, frame)
+	require.NoErrorf(t, err, "Frame %v", frame)
+
+	var responses []*backend.QueryDataResponse
+	err = evaluator.EvalRaw(context.Background(), from, time.Second, frameSize, func(idx int, now time.Time, resp *backend.QueryDataResponse) (bool, error) {
+		responses = append(responses, resp)
+		return true, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, responses, frameSize)
+
+	for idx, resp := range responses {
+		require.Contains(t, resp.Responses, refID)
+		frames := resp.Responses[refID].Frames
+		require.Len(t, frames, len(frame.Fields)-1)
+		for i, f := range frames {
+			require.Len(t, f.Fields, 1)
+			assert.Equal(t, frame.Fields[i+1].Labels, f.Fields[0].Labels)
+			expected := frame.Fields[i+1].At(idx).(int64)
+			actual, ok := f.Fields[0].ConcreteAt(0)
+			require.True(t, ok)
+			assert.EqualValues(t, expected, actual)
+		}
+	}
+}
@@ -28,3 +28,20 @@ func (d *queryEvaluator) Eval(ctx context.Context, from time.Time, interval time
 	}
 	return nil
 }
+
+func (d *queryEvaluator) EvalRaw(ctx context.Context, from time.Time, interval time.Duration, evaluations int, callback rawCallbackFunc) error {
+	for idx, now := 0, from; idx < evaluations; idx, now = idx+1, now.Add(interval) {
+		resp, err := d.eval.EvaluateRaw(ctx, now)
+		if err != nil {
+			return err
+		}
+		cont, err := callback(idx, now, resp)
+		if err != nil {
+			return err
+		}
+		if !cont {
+			break
+		}
+	}
+	return nil
+}
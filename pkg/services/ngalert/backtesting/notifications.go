@@ -0,0 +1,397 @@
+package backtesting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+// NotificationPolicies is the part of an Alertmanager configuration that decides which contact points
+// are notified about the alerts, and when.
+type NotificationPolicies struct {
+	Route             *definitions.Route
+	InhibitRules      []config.InhibitRule
+	MuteTimeIntervals []config.MuteTimeInterval
+	TimeIntervals     []config.TimeInterval
+	// ResolvedDisabled contains the names of the receivers that do not send notifications about resolved alerts.
+	ResolvedDisabled map[string]struct{}
+}
+
+// Notification is a notification that would have been sent to a contact point.
+type Notification struct {
+	Time        time.Time
+	Receiver    string
+	GroupKey    string
+	GroupLabels model.LabelSet
+	Alerts      []NotificationAlert
+}
+
+// NotificationAlert is an alert that is part of a Notification.
+type NotificationAlert struct {
+	Labels   model.LabelSet
+	StartsAt time.Time
+	EndsAt   time.Time
+	Resolved bool
+}
+
+type inhibitRule struct {
+	source labels.Matchers
+	target labels.Matchers
+	equal  []model.LabelName
+}
+
+// notificationSimulator replays alerts through a notification policy tree the same way the Alertmanager
+// dispatcher does, but on a simulated clock. It groups the alerts, waits for group_wait and group_interval,
+// skips the notifications during mute timings and outside of active timings, drops inhibited alerts and
+// deduplicates the notifications the same way as the notification log with repeat_interval.
+type notificationSimulator struct {
+	route            *dispatch.Route
+	inhibitRules     []inhibitRule
+	timeIntervals    map[string][]timeinterval.TimeInterval
+	resolvedDisabled map[string]struct{}
+
+	alerts        map[model.Fingerprint]*model.Alert
+	groups        map[string]*simulatedGroup
+	log           map[string]*notificationLogEntry
+	notifications []Notification
+}
+
+type simulatedGroup struct {
+	key        string
+	route      *dispatch.Route
+	labels     model.LabelSet
+	alerts     map[model.Fingerprint]*model.Alert
+	next       time.Time
+	hasFlushed bool
+}
+
+type notificationLogEntry struct {
+	firing    map[model.Fingerprint]struct{}
+	resolved  map[model.Fingerprint]struct{}
+	timestamp time.Time
+}
+
+func newNotificationSimulator(policies NotificationPolicies) (*notificationSimulator, error) {
+	if policies.Route == nil {
+		return nil, fmt.Errorf("%w: notification policy tree is not defined", ErrInvalidInputData)
+	}
+	s := &notificationSimulator{
+		route:            dispatch.NewRoute(policies.Route.AsAMRoute(), nil),
+		timeIntervals:    make(map[string][]timeinterval.TimeInterval, len(policies.MuteTimeIntervals)+len(policies.TimeIntervals)),
+		resolvedDisabled: policies.ResolvedDisabled,
+		alerts:           map[model.Fingerprint]*model.Alert{},
+		groups:           map[string]*simulatedGroup{},
+		log:              map[string]*notificationLogEntry{},
+	}
+	for _, ti := range policies.MuteTimeIntervals {
+		s.timeIntervals[ti.Name] = ti.TimeIntervals
+	}
+	for _, ti := range policies.TimeIntervals {
+		s.timeIntervals[ti.Name] = ti.TimeIntervals
+	}
+	for _, ir := range policies.InhibitRules {
+		rule, err := newInhibitRule(ir)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid inhibition rule: %s", ErrInvalidInputData, err)
+		}
+		s.inhibitRules = append(s.inhibitRules, rule)
+	}
+	return s, nil
+}
+
+func newInhibitRule(ir config.InhibitRule) (inhibitRule, error) {
+	var rule inhibitRule
+	for ln, lv := range ir.SourceMatch {
+		m, err := labels.NewMatcher(labels.MatchEqual, ln, lv)
+		if err != nil {
+			return inhibitRule{}, err
+		}
+		rule.source = append(rule.source, m)
+	}
+	for ln, lv := range ir.SourceMatchRE {
+		m, err := labels.NewMatcher(labels.MatchRegexp, ln, lv.String())
+		if err != nil {
+			return inhibitRule{}, err
+		}
+		rule.source = append(rule.source, m)
+	}
+	rule.source = append(rule.source, ir.SourceMatchers...)
+	for ln, lv := range ir.TargetMatch {
+		m, err := labels.NewMatcher(labels.MatchEqual, ln, lv)
+		if err != nil {
+			return inhibitRule{}, err
+		}
+		rule.target = append(rule.target, m)
+	}
+	for ln, lv := range ir.TargetMatchRE {
+		m, err := labels.NewMatcher(labels.MatchRegexp, ln, lv.String())
+		if err != nil {
+			return inhibitRule{}, err
+		}
+		rule.target = append(rule.target, m)
+	}
+	rule.target = append(rule.target, ir.TargetMatchers...)
+	for _, ln := range ir.Equal {
+		rule.equal = append(rule.equal, model.LabelName(ln))
+	}
+	return rule, nil
+}
+
+// Add flushes all groups that are due at now and then routes the alerts to the groups.
+func (s *notificationSimulator) Add(now time.Time, alerts ...*model.Alert) {
+	s.advance(now)
+	for _, alert := range alerts {
+		fp := alert.Labels.Fingerprint()
+		if existing, ok := s.alerts[fp]; ok && existing.StartsAt.Before(alert.StartsAt) && existing.EndsAt.After(alert.StartsAt) {
+			// The alert is still active, keep the time when it started firing.
+			merged := *alert
+			merged.StartsAt = existing.StartsAt
+			alert = &merged
+		}
+		s.alerts[fp] = alert
+
+		for _, route := range s.route.Match(alert.Labels) {
+			groupLabels := getGroupLabels(alert.Labels, route)
+			key := fmt.Sprintf("%s:%s", route.Key(), groupLabels)
+			group, ok := s.groups[key]
+			if !ok {
+				group = &simulatedGroup{
+					key:    key,
+					route:  route,
+					labels: groupLabels,
+					alerts: map[model.Fingerprint]*model.Alert{},
+					next:   now.Add(route.RouteOpts.GroupWait),
+				}
+				s.groups[key] = group
+			}
+			group.alerts[fp] = alert
+			// The Alertmanager does not wait for group_wait if the alert started firing long enough ago.
+			if !group.hasFlushed && alert.StartsAt.Add(route.RouteOpts.GroupWait).Before(now) {
+				group.next = now
+			}
+		}
+	}
+}
+
+// Flush flushes all groups that are due until the time to and returns all notifications that have been sent.
+func (s *notificationSimulator) Flush(to time.Time) []Notification {
+	s.advance(to)
+	return s.notifications
+}
+
+// advance flushes the groups in the order of their flush time until all groups are due after now.
+func (s *notificationSimulator) advance(now time.Time) {
+	for {
+		var next *simulatedGroup
+		for _, g := range s.groups {
+			if g.next.After(now) {
+				continue
+			}
+			if next == nil || g.next.Before(next.next) || g.next.Equal(next.next) && g.key < next.key {
+				next = g
+			}
+		}
+		if next == nil {
+			return
+		}
+		s.flush(next)
+	}
+}
+
+func (s *notificationSimulator) flush(g *simulatedGroup) {
+	now := g.next
+	g.hasFlushed = true
+	g.next = now.Add(g.route.RouteOpts.GroupInterval)
+
+	fps := make([]model.Fingerprint, 0, len(g.alerts))
+	for fp := range g.alerts {
+		fps = append(fps, fp)
+	}
+	sort.Slice(fps, func(i, j int) bool {
+		return fps[i] < fps[j]
+	})
+
+	var resolved []model.Fingerprint
+	alerts := make([]NotificationAlert, 0, len(fps))
+	for _, fp := range fps {
+		alert := g.alerts[fp]
+		isResolved := !alert.EndsAt.After(now)
+		if isResolved {
+			resolved = append(resolved, fp)
+		}
+		if s.inhibited(alert.Labels, now) {
+			continue
+		}
+		alerts = append(alerts, NotificationAlert{
+			Labels:   alert.Labels,
+			StartsAt: alert.StartsAt,
+			EndsAt:   alert.EndsAt,
+			Resolved: isResolved,
+		})
+	}
+
+	if len(alerts) > 0 && s.active(g.route, now) {
+		s.notify(g, now, alerts)
+	}
+
+	// The resolved alerts are removed from the group once they have been flushed.
+	for _, fp := range resolved {
+		delete(g.alerts, fp)
+	}
+	if len(g.alerts) == 0 {
+		delete(s.groups, g.key)
+	}
+}
+
+// notify records the notification if it is not a duplicate of the last notification sent for the group.
+func (s *notificationSimulator) notify(g *simulatedGroup, now time.Time, alerts []NotificationAlert) {
+	receiver := g.route.RouteOpts.Receiver
+	_, noResolved := s.resolvedDisabled[receiver]
+
+	firing := make(map[model.Fingerprint]struct{})
+	resolved := make(map[model.Fingerprint]struct{})
+	for _, a := range alerts {
+		if a.Resolved {
+			resolved[a.Labels.Fingerprint()] = struct{}{}
+		} else {
+			firing[a.Labels.Fingerprint()] = struct{}{}
+		}
+	}
+
+	logKey := g.key + ":" + receiver
+	entry := s.log[logKey]
+	if !needsUpdate(entry, firing, resolved, !noResolved, g.route.RouteOpts.RepeatInterval, now) {
+		return
+	}
+	s.log[logKey] = &notificationLogEntry{
+		firing:    firing,
+		resolved:  resolved,
+		timestamp: now,
+	}
+
+	if noResolved {
+		filtered := alerts[:0:0]
+		for _, a := range alerts {
+			if !a.Resolved {
+				filtered = append(filtered, a)
+			}
+		}
+		alerts = filtered
+		if len(alerts) == 0 {
+			return
+		}
+	}
+	s.notifications = append(s.notifications, Notification{
+		Time:        now,
+		Receiver:    receiver,
+		GroupKey:    g.key,
+		GroupLabels: g.labels,
+		Alerts:      alerts,
+	})
+}
+
+// needsUpdate follows the same rules as the deduplication stage of the Alertmanager notification pipeline.
+func needsUpdate(entry *notificationLogEntry, firing, resolved map[model.Fingerprint]struct{}, sendResolved bool, repeat time.Duration, now time.Time) bool {
+	// Notify right away about a group that was not notified before, unless all alerts are resolved.
+	if entry == nil {
+		return len(firing) > 0
+	}
+	if !isSubset(firing, entry.firing) {
+		return true
+	}
+	// Notify about all alerts being resolved, but only if the receiver knows about firing alerts.
+	if len(firing) == 0 {
+		return len(entry.firing) > 0
+	}
+	if sendResolved && !isSubset(resolved, entry.resolved) {
+		return true
+	}
+	// Nothing changed, only notify if the repeat interval has passed.
+	return entry.timestamp.Before(now.Add(-repeat))
+}
+
+func isSubset(subset, set map[model.Fingerprint]struct{}) bool {
+	for fp := range subset {
+		if _, ok := set[fp]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// active returns false if notifications of the route are muted at the time now.
+func (s *notificationSimulator) active(route *dispatch.Route, now time.Time) bool {
+	for _, name := range route.RouteOpts.MuteTimeIntervals {
+		if s.inTimeInterval(name, now) {
+			return false
+		}
+	}
+	if len(route.RouteOpts.ActiveTimeIntervals) == 0 {
+		return true
+	}
+	for _, name := range route.RouteOpts.ActiveTimeIntervals {
+		if s.inTimeInterval(name, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *notificationSimulator) inTimeInterval(name string, now time.Time) bool {
+	for _, ti := range s.timeIntervals[name] {
+		if ti.ContainsTime(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// inhibited returns true if an alert that fires at the time now inhibits the alert with the labels lset.
+func (s *notificationSimulator) inhibited(lset model.LabelSet, now time.Time) bool {
+	for _, rule := range s.inhibitRules {
+		if !rule.target.Matches(lset) {
+			continue
+		}
+		for _, source := range s.alerts {
+			if !source.EndsAt.After(now) || source.StartsAt.After(now) {
+				continue
+			}
+			if !rule.source.Matches(source.Labels) {
+				continue
+			}
+			// Alerts that match both sides of the rule do not inhibit each other.
+			if rule.source.Matches(lset) && rule.target.Matches(source.Labels) {
+				continue
+			}
+			equal := true
+			for _, ln := range rule.equal {
+				if source.Labels[ln] != lset[ln] {
+					equal = false
+					break
+				}
+			}
+			if equal {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getGroupLabels(lset model.LabelSet, route *dispatch.Route) model.LabelSet {
+	groupLabels := model.LabelSet{}
+	for ln, lv := range lset {
+		if _, ok := route.RouteOpts.GroupBy[ln]; ok || route.RouteOpts.GroupByAll {
+			groupLabels[ln] = lv
+		}
+	}
+	return groupLabels
+}
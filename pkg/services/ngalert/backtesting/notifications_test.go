@@ -0,0 +1,224 @@
+package backtesting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestNotificationSimulator(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	interval := time.Minute
+
+	newRoute := func(t *testing.T, mutate func(r *definitions.Route)) *definitions.Route {
+		r := &definitions.Route{
+			Receiver:       "default",
+			GroupByStr:     []string{model.AlertNameLabel},
+			GroupWait:      util.Pointer(model.Duration(30 * time.Second)),
+			GroupInterval:  util.Pointer(model.Duration(5 * time.Minute)),
+			RepeatInterval: util.Pointer(model.Duration(4 * time.Hour)),
+		}
+		if mutate != nil {
+			mutate(r)
+		}
+		require.NoError(t, r.Validate())
+		return r
+	}
+
+	// fire sends the alert on every evaluation between from and to like the scheduler does, and resolves it at to.
+	fire := func(s *notificationSimulator, lbls model.LabelSet, from, to time.Time) {
+		for now := from; now.Before(to); now = now.Add(interval) {
+			s.Add(now, &model.Alert{Labels: lbls, StartsAt: from, EndsAt: now.Add(4 * interval)})
+		}
+		s.Add(to, &model.Alert{Labels: lbls, StartsAt: from, EndsAt: to})
+	}
+
+	t.Run("should notify after group_wait and when the alerts are resolved", func(t *testing.T) {
+		s, err := newNotificationSimulator(NotificationPolicies{Route: newRoute(t, nil)})
+		require.NoError(t, err)
+
+		fire(s, model.LabelSet{model.AlertNameLabel: "test", "instance": "a"}, start, start.Add(10*time.Minute))
+		notifications := s.Flush(start.Add(time.Hour))
+
+		require.Len(t, notifications, 2)
+		assert.Equal(t, start.Add(30*time.Second), notifications[0].Time)
+		assert.Equal(t, "default", notifications[0].Receiver)
+		assert.Equal(t, model.LabelSet{model.AlertNameLabel: "test"}, notifications[0].GroupLabels)
+		require.Len(t, notifications[0].Alerts, 1)
+		assert.False(t, notifications[0].Alerts[0].Resolved)
+
+		assert.Equal(t, start.Add(30*time.Second+10*time.Minute), notifications[1].Time)
+		require.Len(t, notifications[1].Alerts, 1)
+		assert.True(t, notifications[1].Alerts[0].Resolved)
+	})
+
+	t.Run("should group alerts by labels", func(t *testing.T) {
+		s, err := newNotificationSimulator(NotificationPolicies{Route: newRoute(t, nil)})
+		require.NoError(t, err)
+
+		s.Add(start,
+			&model.Alert{Labels: model.LabelSet{model.AlertNameLabel: "one", "instance": "a"}, StartsAt: start, EndsAt: start.Add(time.Hour)},
+			&model.Alert{Labels: model.LabelSet{model.AlertNameLabel: "one", "instance": "b"}, StartsAt: start, EndsAt: start.Add(time.Hour)},
+			&model.Alert{Labels: model.LabelSet{model.AlertNameLabel: "two", "instance": "a"}, StartsAt: start, EndsAt: start.Add(time.Hour)},
+		)
+		notifications := s.Flush(start.Add(time.Minute))
+
+		require.Len(t, notifications, 2)
+		byGroup := map[model.LabelValue]int{}
+		for _, n := range notifications {
+			byGroup[n.GroupLabels[model.AlertNameLabel]] = len(n.Alerts)
+		}
+		assert.Equal(t, map[model.LabelValue]int{"one": 2, "two": 1}, byGroup)
+	})
+
+	t.Run("should repeat notifications after repeat_interval", func(t *testing.T) {
+		s, err := newNotificationSimulator(NotificationPolicies{Route: newRoute(t, func(r *definitions.Route) {
+			r.RepeatInterval = util.Pointer(model.Duration(time.Hour))
+		})})
+		require.NoError(t, err)
+
+		fire(s, model.LabelSet{model.AlertNameLabel: "test"}, start, start.Add(3*time.Hour))
+		notifications := s.Flush(start.Add(3 * time.Hour))
+
+		var times []time.Time
+		for _, n := range notifications {
+			times = append(times, n.Time)
+		}
+		assert.Equal(t, []time.Time{
+			start.Add(30 * time.Second),
+			start.Add(30*time.Second + 65*time.Minute),
+			start.Add(30*time.Second + 130*time.Minute),
+		}, times)
+	})
+
+	t.Run("should route alerts to the matching policies", func(t *testing.T) {
+		s, err := newNotificationSimulator(NotificationPolicies{Route: newRoute(t, func(r *definitions.Route) {
+			r.Routes = []*definitions.Route{
+				{
+					Receiver:       "team-a",
+					ObjectMatchers: definitions.ObjectMatchers{{Type: labels.MatchEqual, Name: "team", Value: "a"}},
+					Continue:       true,
+				},
+				{
+					Receiver:       "team-a-critical",
+					ObjectMatchers: definitions.ObjectMatchers{{Type: labels.MatchEqual, Name: "severity", Value: "critical"}},
+				},
+			}
+		})})
+		require.NoError(t, err)
+
+		s.Add(start,
+			&model.Alert{Labels: model.LabelSet{model.AlertNameLabel: "one", "team": "a", "severity": "critical"}, StartsAt: start, EndsAt: start.Add(time.Hour)},
+			&model.Alert{Labels: model.LabelSet{model.AlertNameLabel: "two", "team": "b"}, StartsAt: start, EndsAt: start.Add(time.Hour)},
+		)
+		notifications := s.Flush(start.Add(time.Minute))
+
+		var receivers []string
+		for _, n := range notifications {
+			receivers = append(receivers, n.Receiver)
+		}
+		assert.ElementsMatch(t, []string{"team-a", "team-a-critical", "default"}, receivers)
+	})
+
+	t.Run("should not notify during mute timings and outside of active timings", func(t *testing.T) {
+		always := []timeinterval.TimeInterval{{}}
+		never := []timeinterval.TimeInterval{{
+			Years: []timeinterval.YearRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 2000, End: 2001}}},
+		}}
+		s, err := newNotificationSimulator(NotificationPolicies{
+			Route: newRoute(t, func(r *definitions.Route) {
+				r.Routes = []*definitions.Route{
+					{
+						Receiver:          "muted",
+						ObjectMatchers:    definitions.ObjectMatchers{{Type: labels.MatchEqual, Name: "route", Value: "muted"}},
+						MuteTimeIntervals: []string{"always"},
+					},
+					{
+						Receiver:            "inactive",
+						ObjectMatchers:      definitions.ObjectMatchers{{Type: labels.MatchEqual, Name: "route", Value: "inactive"}},
+						ActiveTimeIntervals: []string{"never"},
+					},
+					{
+						Receiver:            "active",
+						ObjectMatchers:      definitions.ObjectMatchers{{Type: labels.MatchEqual, Name: "route", Value: "active"}},
+						ActiveTimeIntervals: []string{"always"},
+						MuteTimeIntervals:   []string{"never"},
+					},
+				}
+			}),
+			MuteTimeIntervals: []config.MuteTimeInterval{{Name: "always", TimeIntervals: always}},
+			TimeIntervals:     []config.TimeInterval{{Name: "never", TimeIntervals: never}},
+		})
+		require.NoError(t, err)
+
+		for _, route := range []model.LabelValue{"muted", "inactive", "active"} {
+			fire(s, model.LabelSet{model.AlertNameLabel: "test", "route": route}, start, start.Add(10*time.Minute))
+		}
+		notifications := s.Flush(start.Add(time.Hour))
+
+		require.Len(t, notifications, 2)
+		for _, n := range notifications {
+			assert.Equal(t, "active", n.Receiver)
+		}
+	})
+
+	t.Run("should drop inhibited alerts", func(t *testing.T) {
+		s, err := newNotificationSimulator(NotificationPolicies{
+			Route: newRoute(t, func(r *definitions.Route) {
+				r.GroupByStr = []string{"..."}
+			}),
+			InhibitRules: []config.InhibitRule{{
+				SourceMatch: map[string]string{"severity": "critical"},
+				TargetMatch: map[string]string{"severity": "warning"},
+				Equal:       []string{"cluster"},
+			}},
+		})
+		require.NoError(t, err)
+
+		critical := model.LabelSet{model.AlertNameLabel: "critical", "severity": "critical", "cluster": "a"}
+		inhibited := model.LabelSet{model.AlertNameLabel: "warning", "severity": "warning", "cluster": "a"}
+		notInhibited := model.LabelSet{model.AlertNameLabel: "warning", "severity": "warning", "cluster": "b"}
+		s.Add(start,
+			&model.Alert{Labels: critical, StartsAt: start, EndsAt: start.Add(time.Hour)},
+			&model.Alert{Labels: inhibited, StartsAt: start, EndsAt: start.Add(time.Hour)},
+			&model.Alert{Labels: notInhibited, StartsAt: start, EndsAt: start.Add(time.Hour)},
+		)
+		notifications := s.Flush(start.Add(time.Minute))
+
+		var notified []model.LabelSet
+		for _, n := range notifications {
+			for _, a := range n.Alerts {
+				notified = append(notified, a.Labels)
+			}
+		}
+		assert.ElementsMatch(t, []model.LabelSet{critical, notInhibited}, notified)
+	})
+
+	t.Run("should not notify about resolved alerts if the receiver disables it", func(t *testing.T) {
+		s, err := newNotificationSimulator(NotificationPolicies{
+			Route:            newRoute(t, nil),
+			ResolvedDisabled: map[string]struct{}{"default": {}},
+		})
+		require.NoError(t, err)
+
+		fire(s, model.LabelSet{model.AlertNameLabel: "test"}, start, start.Add(10*time.Minute))
+		notifications := s.Flush(start.Add(time.Hour))
+
+		require.Len(t, notifications, 1)
+		assert.False(t, notifications[0].Alerts[0].Resolved)
+	})
+
+	t.Run("should fail without a notification policy tree", func(t *testing.T) {
+		_, err := newNotificationSimulator(NotificationPolicies{})
+		require.ErrorIs(t, err, ErrInvalidInputData)
+	})
+}
@@ -0,0 +1,63 @@
+package backtesting
+
+import (
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
+)
+
+// recordedSeriesBuilder collects the points that a recording rule writes into one frame per series.
+type recordedSeriesBuilder struct {
+	metric string
+	series map[data.Fingerprint]*data.Frame
+	frames data.Frames
+}
+
+func newRecordedSeriesBuilder(metric string) *recordedSeriesBuilder {
+	return &recordedSeriesBuilder{
+		metric: metric,
+		series: map[data.Fingerprint]*data.Frame{},
+	}
+}
+
+func (b *recordedSeriesBuilder) add(points []writer.Point) {
+	for _, p := range points {
+		labels := data.Labels(p.Labels).Copy()
+		if labels == nil {
+			labels = data.Labels{}
+		}
+		labels["__name__"] = p.Name
+		fp := labels.Fingerprint()
+		frame, ok := b.series[fp]
+		if !ok {
+			frame = data.NewFrame(p.Name,
+				data.NewField("Time", nil, []time.Time{}),
+				data.NewField("Value", labels, []float64{}),
+			).SetMeta(&data.FrameMeta{
+				Type:        data.FrameTypeTimeSeriesMulti,
+				TypeVersion: data.FrameTypeVersion{0, 1},
+			})
+			b.series[fp] = frame
+			b.frames = append(b.frames, frame)
+		}
+		frame.AppendRow(p.Metric.T, p.Metric.V)
+	}
+}
+
+// toFrames returns the series in the order they were first written. The warnings are added as notices to the
+// first frame. If nothing was written, it returns a single empty frame.
+func (b *recordedSeriesBuilder) toFrames(warns []string) data.Frames {
+	frames := b.frames
+	if len(frames) == 0 {
+		frames = data.Frames{data.NewFrame(b.metric)}
+	}
+	for _, warn := range warns {
+		frames[0].AppendNotices(data.Notice{
+			Severity: data.NoticeSeverityWarning,
+			Text:     warn,
+		})
+	}
+	return frames
+}
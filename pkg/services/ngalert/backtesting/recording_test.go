@@ -0,0 +1,149 @@
+package backtesting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/schedule"
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
+)
+
+func TestRecordedSeriesBuilder(t *testing.T) {
+	start := time.Unix(0, 0)
+	b := newRecordedSeriesBuilder("test_metric")
+	b.add([]writer.Point{
+		{Name: "test_metric", Labels: map[string]string{"instance": "a"}, Metric: writer.Metric{T: start, V: 1}},
+		{Name: "test_metric", Labels: map[string]string{"instance": "b"}, Metric: writer.Metric{T: start, V: 2}},
+	})
+	b.add([]writer.Point{
+		{Name: "test_metric", Labels: map[string]string{"instance": "a"}, Metric: writer.Metric{T: start.Add(time.Minute), V: 3}},
+	})
+
+	frames := b.toFrames([]string{"warning"})
+	require.Len(t, frames, 2)
+
+	assert.Equal(t, "test_metric", frames[0].Name)
+	assert.Equal(t, data.FrameTypeTimeSeriesMulti, frames[0].Meta.Type)
+	assert.Equal(t, data.Labels{"__name__": "test_metric", "instance": "a"}, frames[0].Fields[1].Labels)
+	assert.Equal(t, 2, frames[0].Rows())
+	assert.Equal(t, start.Add(time.Minute), frames[0].At(0, 1))
+	assert.Equal(t, 3.0, frames[0].At(1, 1))
+	assert.Equal(t, []data.Notice{{Severity: data.NoticeSeverityWarning, Text: "warning"}}, frames[0].Meta.Notices)
+
+	assert.Equal(t, data.Labels{"__name__": "test_metric", "instance": "b"}, frames[1].Fields[1].Labels)
+	assert.Equal(t, 1, frames[1].Rows())
+
+	t.Run("should return empty frame if nothing was written", func(t *testing.T) {
+		frames := newRecordedSeriesBuilder("test_metric").toFrames([]string{"warning"})
+		require.Len(t, frames, 1)
+		assert.Equal(t, "test_metric", frames[0].Name)
+		assert.Empty(t, frames[0].Fields)
+		assert.Len(t, frames[0].Meta.Notices, 1)
+	})
+}
+
+func TestEngine_TestRecording(t *testing.T) {
+	evaluator := &fakeBacktestingEvaluator{}
+	backtestingEvaluatorFactory = func(ctx context.Context, evalFactory eval.EvaluatorFactory, user identity.Requester, condition models.Condition, r eval.AlertingResultsReader) (backtestingEvaluator, error) {
+		return evaluator, nil
+	}
+	t.Cleanup(func() {
+		backtestingEvaluatorFactory = newBacktestingEvaluator
+	})
+
+	engine := &Engine{
+		featureToggles: featuremgmt.WithFeatures(),
+		minInterval:    1 * time.Second,
+		baseInterval:   1 * time.Second,
+		jitterStrategy: schedule.JitterNever,
+		maxEvaluations: 10000,
+	}
+	gen := models.RuleGen
+	rule := gen.With(gen.WithInterval(time.Second), gen.WithAllRecordingRules(), gen.WithLabels(map[string]string{"team": "a"})).GenerateRef()
+	from := time.Unix(0, 0)
+	to := from.Add(5 * time.Second)
+
+	numberResponse := func(refID string, value float64, labels data.Labels) *backend.QueryDataResponse {
+		n := mathexp.NewNumber(refID, labels)
+		n.SetValue(&value)
+		resp := backend.NewQueryDataResponse()
+		resp.Responses[refID] = backend.DataResponse{Frames: data.Frames{n.AsDataFrame()}}
+		return resp
+	}
+
+	t.Run("should return the series the rule would write", func(t *testing.T) {
+		evaluator.evalRawCallback = func(now time.Time) (*backend.QueryDataResponse, error) {
+			return numberResponse(rule.Record.From, float64(now.Unix()), data.Labels{"instance": "a"}), nil
+		}
+
+		frames, err := engine.TestRecording(context.Background(), nil, rule, from, to)
+		require.NoError(t, err)
+		require.Len(t, frames, 1)
+		assert.Equal(t, rule.Record.Metric, frames[0].Name)
+		assert.Equal(t, data.Labels{"__name__": rule.Record.Metric, "instance": "a", "team": "a"}, frames[0].Fields[1].Labels)
+		assert.Equal(t, 5, frames[0].Rows())
+		for i := 0; i < frames[0].Rows(); i++ {
+			assert.Equal(t, from.Add(time.Duration(i)*time.Second), frames[0].At(0, i))
+			assert.Equal(t, float64(i), frames[0].At(1, i))
+		}
+	})
+
+	t.Run("should skip evaluations without data", func(t *testing.T) {
+		evaluator.evalRawCallback = func(now time.Time) (*backend.QueryDataResponse, error) {
+			if now.Unix()%2 == 0 {
+				return backend.NewQueryDataResponse(), nil
+			}
+			return numberResponse(rule.Record.From, 1, nil), nil
+		}
+
+		frames, err := engine.TestRecording(context.Background(), nil, rule, from, to)
+		require.NoError(t, err)
+		require.Len(t, frames, 1)
+		assert.Equal(t, 2, frames[0].Rows())
+	})
+
+	t.Run("should warn about failed evaluations", func(t *testing.T) {
+		evaluator.evalRawCallback = func(now time.Time) (*backend.QueryDataResponse, error) {
+			resp := backend.NewQueryDataResponse()
+			resp.Responses[rule.Record.From] = backend.DataResponse{Error: errors.New("query failed")}
+			return resp, nil
+		}
+
+		frames, err := engine.TestRecording(context.Background(), nil, rule, from, to)
+		require.NoError(t, err)
+		require.Len(t, frames, 1)
+		require.Len(t, frames[0].Meta.Notices, 1)
+		assert.Contains(t, frames[0].Meta.Notices[0].Text, "5 of 5 evaluations failed")
+	})
+
+	t.Run("should fail", func(t *testing.T) {
+		t.Run("when rule is not a recording rule", func(t *testing.T) {
+			_, err := engine.TestRecording(context.Background(), nil, gen.GenerateRef(), from, to)
+			require.ErrorIs(t, err, ErrInvalidInputData)
+		})
+		t.Run("when from > to", func(t *testing.T) {
+			_, err := engine.TestRecording(context.Background(), nil, rule, to, from)
+			require.ErrorIs(t, err, ErrInvalidInputData)
+		})
+		t.Run("when evaluation fails", func(t *testing.T) {
+			expectedError := errors.New("test-error")
+			evaluator.evalRawCallback = func(now time.Time) (*backend.QueryDataResponse, error) {
+				return nil, expectedError
+			}
+			_, err := engine.TestRecording(context.Background(), nil, rule, from, to)
+			require.ErrorIs(t, err, expectedError)
+		})
+	})
+}
@@ -0,0 +1,100 @@
+package eval
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ErrEvaluationBudgetExceeded is returned when the cost of an evaluation exceeds the budget of the organization.
+var ErrEvaluationBudgetExceeded = errors.New("evaluation exceeded the budget")
+
+// evaluationCost calculates the cost of every data source query of the condition from the response of the pipeline
+// and the execution time of the queries. Expressions do not query data sources and are not counted.
+// The response can be nil if the pipeline failed.
+func evaluationCost(condition models.Condition, resp *backend.QueryDataResponse, durations map[string]time.Duration) models.EvaluationCost {
+	queries := make(map[string]struct{}, len(condition.Data))
+	for _, q := range condition.Data {
+		if expr.NodeTypeFromDatasourceUID(q.DatasourceUID) == expr.TypeDatasourceNode {
+			queries[q.RefID] = struct{}{}
+		}
+	}
+	byRefID := make(map[string]*models.QueryCost, len(durations))
+	get := func(refID string) *models.QueryCost {
+		c, ok := byRefID[refID]
+		if !ok {
+			c = &models.QueryCost{RefID: refID}
+			byRefID[refID] = c
+		}
+		return c
+	}
+	for refID, d := range durations {
+		if _, ok := queries[refID]; ok {
+			get(refID).Duration = d
+		}
+	}
+	if resp != nil {
+		for refID, r := range resp.Responses {
+			if _, ok := queries[refID]; !ok {
+				continue
+			}
+			c := get(refID)
+			for _, frame := range r.Frames {
+				if frame == nil {
+					continue
+				}
+				c.Series++
+				c.DataPoints += int64(frame.Rows())
+				for _, field := range frame.Fields {
+					c.Bytes += fieldSize(field)
+				}
+			}
+		}
+	}
+
+	result := make(models.EvaluationCost, 0, len(byRefID))
+	for _, c := range byRefID {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RefID < result[j].RefID
+	})
+	return result
+}
+
+// fieldSize returns the approximate size of the values of the field in bytes.
+func fieldSize(field *data.Field) int64 {
+	switch field.Type().NonNullableType() {
+	case data.FieldTypeString:
+		var size int64
+		for i := 0; i < field.Len(); i++ {
+			if v, ok := field.ConcreteAt(i); ok {
+				size += int64(len(v.(string)))
+			}
+		}
+		return size
+	case data.FieldTypeJSON:
+		var size int64
+		for i := 0; i < field.Len(); i++ {
+			if v, ok := field.ConcreteAt(i); ok {
+				size += int64(len(v.(json.RawMessage)))
+			}
+		}
+		return size
+	case data.FieldTypeInt8, data.FieldTypeUint8, data.FieldTypeBool:
+		return int64(field.Len())
+	case data.FieldTypeInt16, data.FieldTypeUint16:
+		return int64(field.Len()) * 2
+	case data.FieldTypeInt32, data.FieldTypeUint32, data.FieldTypeFloat32:
+		return int64(field.Len()) * 4
+	default:
+		return int64(field.Len()) * 8
+	}
+}
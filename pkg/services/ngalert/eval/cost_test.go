@@ -0,0 +1,71 @@
+package eval
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestEvaluationCost(t *testing.T) {
+	resp := &backend.QueryDataResponse{
+		Responses: backend.Responses{
+			"A": {Frames: data.Frames{
+				data.NewFrame("",
+					data.NewField("time", nil, []time.Time{time.Unix(1, 0), time.Unix(2, 0)}),
+					data.NewField("value", data.Labels{"host": "a"}, []float64{1, 2}),
+				),
+				data.NewFrame("",
+					data.NewField("time", nil, []time.Time{time.Unix(1, 0), time.Unix(2, 0)}),
+					data.NewField("value", data.Labels{"host": "b"}, []*float64{nil, nil}),
+				),
+			}},
+			"B": {Frames: data.Frames{
+				data.NewFrame("", data.NewField("name", nil, []string{"abc"})),
+			}},
+			"C": {Error: errors.New("failed")},
+		},
+	}
+	durations := map[string]time.Duration{
+		"A": time.Second,
+		"B": time.Millisecond,
+		"C": time.Millisecond,
+		"D": time.Millisecond,
+	}
+
+	condition := models.Condition{
+		Condition: "E",
+		Data: []models.AlertQuery{
+			{RefID: "A", DatasourceUID: "prometheus"},
+			{RefID: "B", DatasourceUID: "loki"},
+			{RefID: "C", DatasourceUID: "prometheus"},
+			{RefID: "D", DatasourceUID: "prometheus"},
+			{RefID: "E", DatasourceUID: expr.DatasourceUID},
+		},
+	}
+	resp.Responses["E"] = backend.DataResponse{Frames: data.Frames{
+		data.NewFrame("", data.NewField("value", nil, []float64{1})),
+	}}
+	durations["E"] = time.Second
+
+	cost := evaluationCost(condition, resp, durations)
+
+	require.Equal(t, models.EvaluationCost{
+		{RefID: "A", Series: 2, DataPoints: 4, Bytes: 64, Duration: time.Second},
+		{RefID: "B", Series: 1, DataPoints: 1, Bytes: 3, Duration: time.Millisecond},
+		{RefID: "C", Duration: time.Millisecond},
+		{RefID: "D", Duration: time.Millisecond},
+	}, cost)
+}
+
+func TestEvaluationCostWithoutResponse(t *testing.T) {
+	condition := models.Condition{Condition: "A", Data: []models.AlertQuery{{RefID: "A", DatasourceUID: "prometheus"}}}
+	cost := evaluationCost(condition, nil, map[string]time.Duration{"A": time.Second})
+	require.Equal(t, models.EvaluationCost{{RefID: "A", Duration: time.Second}}, cost)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/tsdb/grafanads"
 )
 
 var logger = log.New("ngalert.eval")
@@ -38,6 +39,9 @@ type ConditionEvaluator interface {
 	EvaluateRaw(ctx context.Context, now time.Time) (resp *backend.QueryDataResponse, err error)
 	// Evaluate evaluates the condition and converts the response to Results
 	Evaluate(ctx context.Context, now time.Time) (Results, error)
+	// EvaluateWithCost evaluates the condition like Evaluate and also returns the cost of every data source query.
+	// The cost is returned even if the evaluation fails.
+	EvaluateWithCost(ctx context.Context, now time.Time) (Results, models.EvaluationCost, error)
 }
 
 type expressionExecutor interface {
@@ -96,12 +100,20 @@ func (r *conditionEvaluator) EvaluateRaw(ctx context.Context, now time.Time) (re
 
 // Evaluate evaluates the condition and converts the response to Results
 func (r *conditionEvaluator) Evaluate(ctx context.Context, scheduledAt time.Time) (Results, error) {
+	results, _, err := r.EvaluateWithCost(ctx, scheduledAt)
+	return results, err
+}
+
+// EvaluateWithCost evaluates the condition, converts the response to Results and calculates the cost of the evaluation
+func (r *conditionEvaluator) EvaluateWithCost(ctx context.Context, scheduledAt time.Time) (Results, models.EvaluationCost, error) {
 	start := time.Now()
+	ctx, durations := expr.WithNodeDurations(ctx)
 	response, err := r.EvaluateRaw(ctx, scheduledAt)
+	cost := evaluationCost(r.condition, response, durations)
 	if err != nil {
-		return nil, err
+		return nil, cost, err
 	}
-	return EvaluateAlert(response, r.condition, scheduledAt, start), nil
+	return EvaluateAlert(response, r.condition, scheduledAt, start), cost, nil
 }
 
 type evaluatorImpl struct {
@@ -127,7 +139,40 @@ func NewEvaluatorFactory(
 // EvaluateAlert takes the results of an executed query and evaluates it as an alert rule, returning alert states that the query produces.
 func EvaluateAlert(queryResponse *backend.QueryDataResponse, condition models.Condition, scheduledAt time.Time, evalStart time.Time) Results {
 	execResults := queryDataResponseToExecutionResults(condition, queryResponse)
-	return evaluateExecutionResult(execResults, scheduledAt, evalStart)
+	results := evaluateExecutionResult(execResults, scheduledAt, evalStart)
+	if len(condition.SeverityConditions) > 0 {
+		setSeverities(results, condition.SeverityConditions, execResults.Results)
+	}
+	return results
+}
+
+// setSeverities sets the severity of every alerting result to the highest severity whose condition
+// returned a non-zero value for the same labels.
+func setSeverities(results Results, conditions models.SeverityConditions, frames map[string]data.Frames) {
+	firing := make(map[string]map[data.Fingerprint]struct{}, len(conditions))
+	for _, c := range conditions {
+		fps := make(map[data.Fingerprint]struct{})
+		for _, f := range frames[c.Condition] {
+			if len(f.Fields) != 1 || f.Fields[0].Type() != data.FieldTypeNullableFloat64 || f.Fields[0].Len() != 1 {
+				continue
+			}
+			if v := f.Fields[0].At(0).(*float64); v != nil && *v != 0 { // type checked above
+				fps[f.Fields[0].Labels.Fingerprint()] = struct{}{}
+			}
+		}
+		firing[c.Condition] = fps
+	}
+
+	for i := range results {
+		if results[i].State != Alerting {
+			continue
+		}
+		fp := results[i].Instance.Fingerprint()
+		results[i].Severity = conditions.Highest(func(condition string) bool {
+			_, ok := firing[condition][fp]
+			return ok
+		})
+	}
 }
 
 // invalidEvalResultFormatError is an error for invalid format of the alert definition evaluation results.
@@ -194,7 +239,7 @@ func (evalResults Results) HasNonRetryableErrors() bool {
 }
 
 // IsNonRetryableError indicates whether an error is considered persistent and not worth performing evaluation retries.
-// Currently it is true if err is `&invalidEvalResultFormatError` or `ErrSeriesMustBeWide`
+// Currently it is true if err is `&invalidEvalResultFormatError`, `ErrSeriesMustBeWide` or `ErrEvaluationBudgetExceeded`
 func IsNonRetryableError(err error) bool {
 	var nonRetryableError *invalidEvalResultFormatError
 	if errors.As(err, &nonRetryableError) {
@@ -203,6 +248,9 @@ func IsNonRetryableError(err error) bool {
 	if errors.Is(err, expr.ErrSeriesMustBeWide) {
 		return true
 	}
+	if errors.Is(err, ErrEvaluationBudgetExceeded) {
+		return true
+	}
 	return false
 }
 
@@ -262,6 +310,10 @@ type Result struct {
 	// as EvalMatches (from "classic condition"), and in the future from operations
 	// like SSE "math".
 	EvaluationString string
+
+	// Severity is the highest severity of the rule's severity conditions that matches the instance.
+	// It is only set for alerting results of rules with severity conditions.
+	Severity string
 }
 
 func NewResultFromError(err error, evaluatedAt time.Time, duration time.Duration) Result {
@@ -372,6 +424,11 @@ func getExprRequest(ctx EvaluationContext, condition models.Condition, dsCacheSe
 		if !ok {
 			switch nodeType := expr.NodeTypeFromDatasourceUID(q.DatasourceUID); nodeType {
 			case expr.TypeDatasourceNode:
+				if q.DatasourceUID == grafanads.DatasourceUID {
+					// the built-in data source is not stored in the database
+					ds = grafanads.DataSourceModel(ctx.User.GetOrgID())
+					break
+				}
 				ds, err = dsCacheService.GetDatasourceByUID(ctx.Ctx, q.DatasourceUID, ctx.User, false /*skipCache*/)
 			default:
 				ds, err = expr.DataSourceModelFromNodeType(nodeType)
@@ -9,6 +9,8 @@ import (
 
 	eval "github.com/grafana/grafana/pkg/services/ngalert/eval"
 
+	models "github.com/grafana/grafana/pkg/services/ngalert/models"
+
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
@@ -145,6 +147,74 @@ func (_c *ConditionEvaluatorMock_EvaluateRaw_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// EvaluateWithCost provides a mock function with given fields: ctx, now
+func (_m *ConditionEvaluatorMock) EvaluateWithCost(ctx context.Context, now time.Time) (eval.Results, models.EvaluationCost, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateWithCost")
+	}
+
+	var r0 eval.Results
+	var r1 models.EvaluationCost
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (eval.Results, models.EvaluationCost, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) eval.Results); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(eval.Results)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) models.EvaluationCost); ok {
+		r1 = rf(ctx, now)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(models.EvaluationCost)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ConditionEvaluatorMock_EvaluateWithCost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvaluateWithCost'
+type ConditionEvaluatorMock_EvaluateWithCost_Call struct {
+	*mock.Call
+}
+
+// EvaluateWithCost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *ConditionEvaluatorMock_Expecter) EvaluateWithCost(ctx interface{}, now interface{}) *ConditionEvaluatorMock_EvaluateWithCost_Call {
+	return &ConditionEvaluatorMock_EvaluateWithCost_Call{Call: _e.mock.On("EvaluateWithCost", ctx, now)}
+}
+
+func (_c *ConditionEvaluatorMock_EvaluateWithCost_Call) Run(run func(ctx context.Context, now time.Time)) *ConditionEvaluatorMock_EvaluateWithCost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ConditionEvaluatorMock_EvaluateWithCost_Call) Return(_a0 eval.Results, _a1 models.EvaluationCost, _a2 error) *ConditionEvaluatorMock_EvaluateWithCost_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ConditionEvaluatorMock_EvaluateWithCost_Call) RunAndReturn(run func(context.Context, time.Time) (eval.Results, models.EvaluationCost, error)) *ConditionEvaluatorMock_EvaluateWithCost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewConditionEvaluatorMock creates a new instance of ConditionEvaluatorMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewConditionEvaluatorMock(t interface {
@@ -814,6 +814,59 @@ func TestQueryDataResponseToExecutionResults(t *testing.T) {
 	})
 }
 
+func TestEvaluateAlertSeverity(t *testing.T) {
+	frame := func(refID string, lbls data.Labels, v float64) *data.Frame {
+		return &data.Frame{
+			RefID:  refID,
+			Fields: []*data.Field{data.NewField("Value", lbls, []*float64{util.Pointer(v)})},
+		}
+	}
+	c := models.Condition{
+		Condition: "B",
+		Data: []models.AlertQuery{
+			{RefID: "A", DatasourceUID: "test-ds"},
+			{RefID: "B", DatasourceUID: expr.DatasourceUID},
+			{RefID: "C", DatasourceUID: expr.DatasourceUID},
+		},
+		SeverityConditions: models.SeverityConditions{
+			{Severity: "warning", Condition: "B"},
+			{Severity: "critical", Condition: "C"},
+		},
+	}
+	// B is the threshold > 80 and C is the threshold > 95.
+	resp := &backend.QueryDataResponse{
+		Responses: backend.Responses{
+			"A": {Frames: data.Frames{
+				frame("A", data.Labels{"host": "a"}, 90),
+				frame("A", data.Labels{"host": "b"}, 99),
+				frame("A", data.Labels{"host": "c"}, 10),
+			}},
+			"B": {Frames: data.Frames{
+				frame("B", data.Labels{"host": "a"}, 1),
+				frame("B", data.Labels{"host": "b"}, 1),
+				frame("B", data.Labels{"host": "c"}, 0),
+			}},
+			"C": {Frames: data.Frames{
+				frame("C", data.Labels{"host": "a"}, 0),
+				frame("C", data.Labels{"host": "b"}, 1),
+				frame("C", data.Labels{"host": "c"}, 0),
+			}},
+		},
+	}
+
+	results := EvaluateAlert(resp, c, time.Now(), time.Now())
+	require.Len(t, results, 3)
+
+	severities := make(map[string]string, len(results))
+	states := make(map[string]State, len(results))
+	for _, r := range results {
+		severities[r.Instance["host"]] = r.Severity
+		states[r.Instance["host"]] = r.State
+	}
+	require.Equal(t, map[string]State{"a": Alerting, "b": Alerting, "c": Normal}, states)
+	require.Equal(t, map[string]string{"a": "warning", "b": "critical", "c": ""}, severities)
+}
+
 func TestEvaluate(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -78,3 +78,42 @@ func readQuotaConfig(cfg *setting.Cfg) (*quota.Map, error) {
 	limits.Set(orgQuotaTag, cfg.Quota.Org.AlertRule)
 	return limits, nil
 }
+
+// EvaluationBudgets provides the budgets of alert rule evaluations of organizations, as configured in settings.
+type EvaluationBudgets struct {
+	defaultBudget models.EvaluationBudget
+	orgBudgets    map[int64]models.EvaluationBudget
+}
+
+func NewEvaluationBudgets(cfg setting.UnifiedAlertingSettings) *EvaluationBudgets {
+	budgets := &EvaluationBudgets{
+		defaultBudget: evaluationBudgetFromSettings(cfg.EvaluationBudget),
+		orgBudgets:    make(map[int64]models.EvaluationBudget, len(cfg.OrgEvaluationBudgets)),
+	}
+	for orgID, b := range cfg.OrgEvaluationBudgets {
+		budgets.orgBudgets[orgID] = evaluationBudgetFromSettings(b)
+	}
+	return budgets
+}
+
+// EvaluationBudget returns the budget of a single evaluation of an alert rule in the organization.
+func (b *EvaluationBudgets) EvaluationBudget(orgID int64) models.EvaluationBudget {
+	if budget, ok := b.orgBudgets[orgID]; ok {
+		return budget
+	}
+	return b.defaultBudget
+}
+
+func evaluationBudgetFromSettings(b setting.EvaluationBudgetSettings) models.EvaluationBudget {
+	action := models.EvaluationBudgetActionReject
+	if b.Action == string(models.EvaluationBudgetActionThrottle) {
+		action = models.EvaluationBudgetActionThrottle
+	}
+	return models.EvaluationBudget{
+		MaxSeries:     b.MaxSeries,
+		MaxDataPoints: b.MaxDataPoints,
+		MaxBytes:      b.MaxBytes,
+		MaxQueryTime:  b.MaxQueryTime,
+		Action:        action,
+	}
+}
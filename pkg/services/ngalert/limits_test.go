@@ -3,6 +3,7 @@ package ngalert
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/quota"
@@ -111,3 +112,15 @@ func (f fakeUsageReader) Count(_ context.Context, orgID int64) (int64, error) {
 	}
 	return 0, nil
 }
+
+func TestEvaluationBudgets(t *testing.T) {
+	budgets := NewEvaluationBudgets(setting.UnifiedAlertingSettings{
+		EvaluationBudget: setting.EvaluationBudgetSettings{MaxSeries: 1000, Action: "reject"},
+		OrgEvaluationBudgets: map[int64]setting.EvaluationBudgetSettings{
+			2: {MaxSeries: 100, MaxQueryTime: time.Second, Action: "throttle"},
+		},
+	})
+
+	require.Equal(t, models.EvaluationBudget{MaxSeries: 1000, Action: models.EvaluationBudgetActionReject}, budgets.EvaluationBudget(1))
+	require.Equal(t, models.EvaluationBudget{MaxSeries: 100, MaxQueryTime: time.Second, Action: models.EvaluationBudgetActionThrottle}, budgets.EvaluationBudget(2))
+}
@@ -20,6 +20,11 @@ type Scheduler struct {
 	EvalDuration                        *prometheus.HistogramVec
 	EvalAttemptTotal                    *prometheus.CounterVec
 	EvalAttemptFailures                 *prometheus.CounterVec
+	EvalSeries                          *prometheus.CounterVec
+	EvalDataPoints                      *prometheus.CounterVec
+	EvalBytes                           *prometheus.CounterVec
+	EvalBudgetExceeded                  *prometheus.CounterVec
+	EvalThrottled                       *prometheus.CounterVec
 	ProcessDuration                     *prometheus.HistogramVec
 	SendDuration                        *prometheus.HistogramVec
 	SimpleNotificationRules             *prometheus.GaugeVec
@@ -94,6 +99,51 @@ func NewSchedulerMetrics(r prometheus.Registerer) *Scheduler {
 			},
 			[]string{"org"},
 		),
+		EvalSeries: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "rule_evaluation_series_total",
+				Help:      "The total number of series returned by the queries of rule evaluations.",
+			},
+			[]string{"org"},
+		),
+		EvalDataPoints: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "rule_evaluation_data_points_total",
+				Help:      "The total number of data points returned by the queries of rule evaluations.",
+			},
+			[]string{"org"},
+		),
+		EvalBytes: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "rule_evaluation_bytes_total",
+				Help:      "The approximate total size of the data returned by the queries of rule evaluations.",
+			},
+			[]string{"org"},
+		),
+		EvalBudgetExceeded: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "rule_evaluation_budget_exceeded_total",
+				Help:      "The total number of rule evaluations that exceeded the evaluation budget of the organization.",
+			},
+			[]string{"org", "action"},
+		),
+		EvalThrottled: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "rule_evaluations_throttled_total",
+				Help:      "The total number of rule evaluations that were skipped because the rule exceeded the evaluation budget.",
+			},
+			[]string{"org"},
+		),
 		ProcessDuration: promauto.With(r).NewHistogramVec(
 			prometheus.HistogramOpts{
 				Namespace: Namespace,
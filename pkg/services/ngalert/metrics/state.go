@@ -8,6 +8,7 @@ import (
 type State struct {
 	StateUpdateDuration   prometheus.Histogram
 	StateFullSyncDuration prometheus.Histogram
+	FlappingDetected      prometheus.Counter
 	r                     prometheus.Registerer
 }
 
@@ -37,5 +38,13 @@ func NewStateMetrics(r prometheus.Registerer) *State {
 				Buckets:   []float64{0.01, 0.1, 1, 2, 5, 10, 60},
 			},
 		),
+		FlappingDetected: promauto.With(r).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "state_flapping_detected_total",
+				Help:      "The total number of times an alert instance started flapping.",
+			},
+		),
 	}
 }
@@ -145,6 +145,9 @@ const (
 	// StateReasonAnnotation is the name of the annotation that explains the difference between evaluation state and alert state (i.e. changing state when NoData or Error).
 	StateReasonAnnotation = GrafanaReservedLabelPrefix + "state_reason"
 
+	// FlappingAnnotation is the name of the annotation that contains the number of transitions of a flapping alert instance within the flap detection window.
+	FlappingAnnotation = GrafanaReservedLabelPrefix + "flapping"
+
 	// MigratedLabelPrefix is a label prefix for all labels created during legacy migration.
 	MigratedLabelPrefix = "__legacy_"
 	// MigratedUseLegacyChannelsLabel is created during legacy migration to route to separate nested policies for migrated channels.
@@ -184,6 +187,7 @@ const (
 	StateReasonUpdated       = "Updated"
 	StateReasonRuleDeleted   = "RuleDeleted"
 	StateReasonKeepLast      = "KeepLast"
+	StateReasonFlapping      = "Flapping"
 )
 
 func ConcatReasons(reasons ...string) string {
@@ -374,6 +378,11 @@ type AlertRule struct {
 	// If nil, alerts resolve after 2 missing evaluation intervals
 	// (i.e., resolution occurs during the second evaluation where data is absent).
 	MissingSeriesEvalsToResolve *int64
+	// SeverityConditions assign a severity label to the alert instances of the rule.
+	SeverityConditions SeverityConditions
+	// FlapDetection holds the alert instances that repeatedly change between firing and not firing in their firing state.
+	// If nil, flapping is not detected.
+	FlapDetection *FlapDetection
 }
 
 type AlertRuleVersion struct {
@@ -386,6 +395,8 @@ type AlertRuleVersion struct {
 type AlertRuleMetadata struct {
 	EditorSettings      EditorSettings       `json:"editor_settings"`
 	PrometheusStyleRule *PrometheusStyleRule `json:"prometheus_style_rule,omitempty"`
+	// RuleTemplate is set if the rule is generated by an alert rule template.
+	RuleTemplate *RuleTemplateMetadata `json:"rule_template,omitempty"`
 }
 
 type EditorSettings struct {
@@ -590,9 +601,10 @@ func (alertRule *AlertRule) GetEvalCondition() Condition {
 		}
 	}
 	return Condition{
-		Metadata:  meta,
-		Condition: alertRule.Condition,
-		Data:      alertRule.Data,
+		Metadata:           meta,
+		Condition:          alertRule.Condition,
+		Data:               alertRule.Data,
+		SeverityConditions: alertRule.SeverityConditions,
 	}
 }
 
@@ -830,6 +842,21 @@ func validateAlertRuleFields(rule *AlertRule) error {
 		return errors.New("field `missing_series_evals_to_resolve` must be greater than 0")
 	}
 
+	if len(rule.SeverityConditions) > 0 {
+		if err := rule.SeverityConditions.Validate(rule.Data); err != nil {
+			return fmt.Errorf("invalid severity conditions: %w", err)
+		}
+		if _, ok := rule.Labels[SeverityLabel]; ok {
+			return fmt.Errorf("label %s cannot be defined when the rule has severity conditions", SeverityLabel)
+		}
+	}
+
+	if rule.FlapDetection != nil {
+		if err := rule.FlapDetection.Validate(time.Duration(rule.IntervalSeconds) * time.Second); err != nil {
+			return fmt.Errorf("invalid flap detection: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -914,6 +941,7 @@ func (alertRule *AlertRule) Copy() *AlertRule {
 		Metadata:                    alertRule.Metadata,
 		KeepFiringFor:               alertRule.KeepFiringFor,
 		MissingSeriesEvalsToResolve: alertRule.MissingSeriesEvalsToResolve,
+		SeverityConditions:          slices.Clone(alertRule.SeverityConditions),
 	}
 
 	if alertRule.DashboardUID != nil {
@@ -964,10 +992,22 @@ func (alertRule *AlertRule) Copy() *AlertRule {
 		result.Metadata.PrometheusStyleRule = &prometheusStyleRule
 	}
 
+	if alertRule.Metadata.RuleTemplate != nil {
+		result.Metadata.RuleTemplate = &RuleTemplateMetadata{
+			UID:        alertRule.Metadata.RuleTemplate.UID,
+			Parameters: maps.Clone(alertRule.Metadata.RuleTemplate.Parameters),
+		}
+	}
+
 	if alertRule.NotificationSettings != nil {
 		result.NotificationSettings = util.Pointer(CopyNotificationSettings(*alertRule.NotificationSettings))
 	}
 
+	if alertRule.FlapDetection != nil {
+		flapDetection := *alertRule.FlapDetection
+		result.FlapDetection = &flapDetection
+	}
+
 	return &result
 }
 
@@ -979,6 +1019,8 @@ func ClearRecordingRuleIgnoredFields(rule *AlertRule) {
 	rule.KeepFiringFor = 0
 	rule.NotificationSettings = nil
 	rule.MissingSeriesEvalsToResolve = nil
+	rule.SeverityConditions = nil
+	rule.FlapDetection = nil
 }
 
 // GetAlertRuleByUIDQuery is the query for retrieving/deleting an alert rule by UID and organisation ID.
@@ -1139,6 +1181,9 @@ type Condition struct {
 
 	// Data is an array of data source queries and/or server side expressions.
 	Data []AlertQuery `json:"data"`
+
+	// SeverityConditions are evaluated together with the condition to assign a severity to the results.
+	SeverityConditions SeverityConditions `json:"severity_conditions,omitempty"`
 }
 
 func (c Condition) withMetadata(key, value string) Condition {
@@ -1146,9 +1191,10 @@ func (c Condition) withMetadata(key, value string) Condition {
 	maps.Copy(meta, c.Metadata)
 	meta[key] = value
 	return Condition{
-		Metadata:  meta,
-		Condition: c.Condition,
-		Data:      c.Data,
+		Metadata:           meta,
+		Condition:          c.Condition,
+		Data:               c.Data,
+		SeverityConditions: c.SeverityConditions,
 	}
 }
 
@@ -1319,4 +1365,6 @@ type RuleStatus struct {
 	LastError           error
 	EvaluationTimestamp time.Time
 	EvaluationDuration  time.Duration
+	// EvaluationCost is the cost of the last evaluation. It is nil if the cost is not known.
+	EvaluationCost EvaluationCost
 }
@@ -0,0 +1,394 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"text/template"
+	"time"
+)
+
+var templateParameterNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// AlertRuleTemplate is a parametrized alert rule. It generates a rule group that contains one alert rule for every set
+// of parameters provided by its source. The generated rules are managed by the template: they are replaced every time
+// the template is reconciled and should not be changed directly.
+type AlertRuleTemplate struct {
+	ID              int64
+	UID             string
+	OrgID           int64
+	Title           string
+	FolderUID       string
+	RuleGroup       string
+	IntervalSeconds int64
+	// Parameters are the names of the parameters that every parameter set must define.
+	Parameters []string
+	Rule       AlertRuleTemplateRule
+	Source     AlertRuleTemplateSource
+	Version    int64
+	Updated    time.Time
+}
+
+// AlertRuleTemplateRule is the definition of the generated alert rules. The title, labels, annotations, receiver
+// and the string values of the query models are Go templates that are executed with the parameter set as data,
+// for example {{ .service }}. Query models are templated value by value, so that parameters cannot change
+// their structure.
+type AlertRuleTemplateRule struct {
+	Title                string                `json:"title"`
+	Condition            string                `json:"condition"`
+	Data                 []AlertQuery          `json:"data"`
+	NoDataState          NoDataState           `json:"no_data_state"`
+	ExecErrState         ExecutionErrorState   `json:"exec_err_state"`
+	For                  time.Duration         `json:"for"`
+	KeepFiringFor        time.Duration         `json:"keep_firing_for,omitempty"`
+	Annotations          map[string]string     `json:"annotations,omitempty"`
+	Labels               map[string]string     `json:"labels,omitempty"`
+	IsPaused             bool                  `json:"is_paused,omitempty"`
+	NotificationSettings *NotificationSettings `json:"notification_settings,omitempty"`
+}
+
+// AlertRuleTemplateSource provides the parameter sets of a template. Exactly one of the fields must be set.
+type AlertRuleTemplateSource struct {
+	// Static is a table of parameter sets.
+	Static []map[string]string `json:"static,omitempty"`
+	// Query provides a parameter set for every distinct combination of values of the parameter labels
+	// of the series returned by the query.
+	Query *AlertRuleTemplateQuery `json:"query,omitempty"`
+}
+
+// AlertRuleTemplateQuery is a query whose series provide the parameter sets of a template.
+// Series that do not have all parameter labels are ignored.
+type AlertRuleTemplateQuery struct {
+	Condition string       `json:"condition"`
+	Data      []AlertQuery `json:"data"`
+	// AllowEmpty allows the query to provide no parameter sets, which deletes all rules of the template.
+	// Otherwise, the rules are kept until the query provides parameter sets again.
+	AllowEmpty bool `json:"allow_empty,omitempty"`
+}
+
+// RuleTemplateMetadata refers to the template that generated an alert rule.
+type RuleTemplateMetadata struct {
+	UID string `json:"uid"`
+	// Parameters is the parameter set the rule was generated from.
+	Parameters map[string]string `json:"parameters"`
+}
+
+// GetGroupKey returns the key of the rule group the template generates.
+func (t *AlertRuleTemplate) GetGroupKey() AlertRuleGroupKey {
+	return AlertRuleGroupKey{OrgID: t.OrgID, NamespaceUID: t.FolderUID, RuleGroup: t.RuleGroup}
+}
+
+func (t *AlertRuleTemplate) GetNamespaceUID() string {
+	return t.FolderUID
+}
+
+// Validate checks that the template is well-formed. It does not validate the rules the template generates.
+func (t *AlertRuleTemplate) Validate() error {
+	var errs []error
+	if t.Title == "" {
+		errs = append(errs, errors.New("title is required"))
+	}
+	if t.FolderUID == "" {
+		errs = append(errs, errors.New("folder UID is required"))
+	}
+	if t.RuleGroup == "" {
+		errs = append(errs, errors.New("rule group is required"))
+	}
+	if len(t.Parameters) == 0 {
+		errs = append(errs, errors.New("at least one parameter is required"))
+	}
+	seen := make(map[string]struct{}, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !templateParameterNameRegexp.MatchString(p) {
+			errs = append(errs, fmt.Errorf("invalid parameter name %q: must match %s", p, templateParameterNameRegexp.String()))
+		}
+		if _, ok := seen[p]; ok {
+			errs = append(errs, fmt.Errorf("duplicate parameter %q", p))
+		}
+		seen[p] = struct{}{}
+	}
+
+	if t.Rule.Title == "" {
+		errs = append(errs, errors.New("rule title is required"))
+	}
+	if t.Rule.Condition == "" {
+		errs = append(errs, errors.New("rule condition is required"))
+	}
+	if len(t.Rule.Data) == 0 {
+		errs = append(errs, errors.New("rule queries are required"))
+	}
+	if _, err := t.Rule.parse(); err != nil {
+		errs = append(errs, err)
+	}
+
+	switch {
+	case t.Source.Query == nil && t.Source.Static == nil:
+		errs = append(errs, errors.New("source must define either static parameters or a query"))
+	case t.Source.Query != nil && t.Source.Static != nil:
+		errs = append(errs, errors.New("source cannot define both static parameters and a query"))
+	case t.Source.Query != nil:
+		if t.Source.Query.Condition == "" || len(t.Source.Query.Data) == 0 {
+			errs = append(errs, errors.New("source query must define a condition and queries"))
+		}
+	default:
+		for i, params := range t.Source.Static {
+			if err := t.validateParameterSet(params); err != nil {
+				errs = append(errs, fmt.Errorf("static parameter set %d: %w", i, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return MakeErrAlertRuleTemplateInvalid(errors.Join(errs...))
+	}
+	return nil
+}
+
+func (t *AlertRuleTemplate) validateParameterSet(params map[string]string) error {
+	for _, p := range t.Parameters {
+		if _, ok := params[p]; !ok {
+			return fmt.Errorf("parameter %q is missing", p)
+		}
+	}
+	for p := range params {
+		if !slices.Contains(t.Parameters, p) {
+			return fmt.Errorf("parameter %q is not defined by the template", p)
+		}
+	}
+	return nil
+}
+
+// Generate returns the rules of the group the template generates from the parameter sets. Duplicate parameter sets
+// generate a single rule. The rules are ordered by their parameters and have no UID, which is assigned on save.
+func (t *AlertRuleTemplate) Generate(paramSets []map[string]string) ([]AlertRule, error) {
+	tmpl, err := t.Rule.parse()
+	if err != nil {
+		return nil, MakeErrAlertRuleTemplateInvalid(err)
+	}
+
+	byKey := make(map[string]map[string]string, len(paramSets))
+	for _, params := range paramSets {
+		if err := t.validateParameterSet(params); err != nil {
+			return nil, MakeErrAlertRuleTemplateInvalid(err)
+		}
+		byKey[RuleTemplateParametersKey(params)] = params
+	}
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rules := make([]AlertRule, 0, len(keys))
+	titles := make(map[string]string, len(keys))
+	for idx, key := range keys {
+		rule, err := t.instantiate(tmpl, byKey[key])
+		if err != nil {
+			return nil, MakeErrAlertRuleTemplateInvalid(fmt.Errorf("failed to generate rule for parameters %s: %w", key, err))
+		}
+		if other, ok := titles[rule.Title]; ok {
+			return nil, MakeErrAlertRuleTemplateInvalid(fmt.Errorf("parameters %s and %s generate rules with the same title %q", other, key, rule.Title))
+		}
+		titles[rule.Title] = key
+		rule.RuleGroupIndex = idx + 1
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (t *AlertRuleTemplate) instantiate(tmpl *template.Template, params map[string]string) (AlertRule, error) {
+	render := func(name string) (string, error) {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, name, params); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	title, err := render(titleTemplateName)
+	if err != nil {
+		return AlertRule{}, err
+	}
+	rule := AlertRule{
+		OrgID:           t.OrgID,
+		Title:           title,
+		Condition:       t.Rule.Condition,
+		Data:            make([]AlertQuery, 0, len(t.Rule.Data)),
+		IntervalSeconds: t.IntervalSeconds,
+		NamespaceUID:    t.FolderUID,
+		RuleGroup:       t.RuleGroup,
+		NoDataState:     t.Rule.NoDataState,
+		ExecErrState:    t.Rule.ExecErrState,
+		For:             t.Rule.For,
+		KeepFiringFor:   t.Rule.KeepFiringFor,
+		IsPaused:        t.Rule.IsPaused,
+		Metadata: AlertRuleMetadata{
+			RuleTemplate: &RuleTemplateMetadata{UID: t.UID, Parameters: maps.Clone(params)},
+		},
+	}
+	for _, q := range t.Rule.Data {
+		model, err := decodeRuleTemplateModel(q)
+		if err != nil {
+			return AlertRule{}, err
+		}
+		model, err = walkRuleTemplateModel(model, q.RefID, func(name, _ string) (string, error) {
+			return render(name)
+		})
+		if err != nil {
+			return AlertRule{}, err
+		}
+		// The rendered values are encoded as JSON strings, which escapes any JSON in the parameters.
+		b, err := json.Marshal(model)
+		if err != nil {
+			return AlertRule{}, fmt.Errorf("failed to encode model of query %s: %w", q.RefID, err)
+		}
+		q.Model = b
+		rule.Data = append(rule.Data, q)
+	}
+	if rule.Labels, err = renderRuleTemplateMap(render, labelTemplateName, t.Rule.Labels); err != nil {
+		return AlertRule{}, err
+	}
+	if rule.Annotations, err = renderRuleTemplateMap(render, annotationTemplateName, t.Rule.Annotations); err != nil {
+		return AlertRule{}, err
+	}
+	if t.Rule.NotificationSettings != nil {
+		ns := CopyNotificationSettings(*t.Rule.NotificationSettings)
+		if ns.Receiver, err = render(receiverTemplateName); err != nil {
+			return AlertRule{}, err
+		}
+		rule.NotificationSettings = &ns
+	}
+	return rule, nil
+}
+
+func renderRuleTemplateMap(render func(string) (string, error), name func(string) string, m map[string]string) (map[string]string, error) {
+	if m == nil {
+		return nil, nil
+	}
+	result := make(map[string]string, len(m))
+	for k := range m {
+		v, err := render(name(k))
+		if err != nil {
+			return nil, err
+		}
+		result[k] = v
+	}
+	return result, nil
+}
+
+const (
+	titleTemplateName    = "title"
+	receiverTemplateName = "receiver"
+)
+
+func queryTemplateName(refID string, idx int) string {
+	return fmt.Sprintf("query.%s.%d", refID, idx)
+}
+
+func decodeRuleTemplateModel(q AlertQuery) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(q.Model))
+	// Numbers are kept as they are written.
+	dec.UseNumber()
+	var model any
+	if err := dec.Decode(&model); err != nil || dec.More() {
+		return nil, fmt.Errorf("model of query %s is not valid JSON", q.RefID)
+	}
+	return model, nil
+}
+
+// walkRuleTemplateModel replaces every string value of the decoded query model with the result of fn, which is called
+// with the name of the value's template and the value. Object keys are visited in sorted order, so that every value gets the same
+// template name every time the model is walked.
+func walkRuleTemplateModel(model any, refID string, fn func(name, value string) (string, error)) (any, error) {
+	idx := 0
+	var walk func(v any) (any, error)
+	walk = func(v any) (any, error) {
+		switch v := v.(type) {
+		case string:
+			name := queryTemplateName(refID, idx)
+			idx++
+			return fn(name, v)
+		case []any:
+			for i := range v {
+				r, err := walk(v[i])
+				if err != nil {
+					return nil, err
+				}
+				v[i] = r
+			}
+		case map[string]any:
+			keys := slices.Sorted(maps.Keys(v))
+			for _, k := range keys {
+				r, err := walk(v[k])
+				if err != nil {
+					return nil, err
+				}
+				v[k] = r
+			}
+		}
+		return v, nil
+	}
+	return walk(model)
+}
+
+func labelTemplateName(key string) string {
+	return "label." + key
+}
+
+func annotationTemplateName(key string) string {
+	return "annotation." + key
+}
+
+// parse parses all templated fields of the rule into a single template set.
+// Referencing a parameter that is not defined is an error.
+func (r AlertRuleTemplateRule) parse() (*template.Template, error) {
+	tmpl := template.New("").Option("missingkey=error")
+	add := func(name, text string) error {
+		if _, err := tmpl.New(name).Parse(text); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		return nil
+	}
+	if err := add(titleTemplateName, r.Title); err != nil {
+		return nil, err
+	}
+	for _, q := range r.Data {
+		model, err := decodeRuleTemplateModel(q)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := walkRuleTemplateModel(model, q.RefID, func(name, value string) (string, error) {
+			return value, add(name, value)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range r.Labels {
+		if err := add(labelTemplateName(k), v); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range r.Annotations {
+		if err := add(annotationTemplateName(k), v); err != nil {
+			return nil, err
+		}
+	}
+	if r.NotificationSettings != nil {
+		if err := add(receiverTemplateName, r.NotificationSettings.Receiver); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
+
+// RuleTemplateParametersKey returns a string that uniquely identifies the parameter set.
+func RuleTemplateParametersKey(params map[string]string) string {
+	// json.Marshal sorts the keys of maps, which makes the result deterministic.
+	b, _ := json.Marshal(params)
+	return string(b)
+}
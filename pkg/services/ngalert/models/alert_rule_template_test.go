@@ -0,0 +1,226 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ruleTemplateForTest() AlertRuleTemplate {
+	return AlertRuleTemplate{
+		UID:             "template-uid",
+		OrgID:           1,
+		Title:           "Service availability",
+		FolderUID:       "folder-uid",
+		RuleGroup:       "availability",
+		IntervalSeconds: 60,
+		Parameters:      []string{"service", "team"},
+		Rule: AlertRuleTemplateRule{
+			Title:     "{{ .service }} is unavailable",
+			Condition: "B",
+			Data: []AlertQuery{
+				CreatePrometheusQuery("A", "up{service='{{ .service }}'}", 1000, 43200, true, "prometheus"),
+				CreateReduceExpression("B", "A", "last"),
+			},
+			NoDataState:  NoData,
+			ExecErrState: ErrorErrState,
+			For:          5 * time.Minute,
+			Labels:       map[string]string{"team": "{{ .team }}"},
+			Annotations:  map[string]string{"summary": "{{ .service }} of {{ .team }} is down"},
+			NotificationSettings: &NotificationSettings{
+				Receiver: "{{ .team }}-oncall",
+			},
+		},
+		Source: AlertRuleTemplateSource{
+			Static: []map[string]string{
+				{"service": "checkout", "team": "payments"},
+				{"service": "cart", "team": "shop"},
+			},
+		},
+		Version: 1,
+	}
+}
+
+func TestAlertRuleTemplateValidate(t *testing.T) {
+	testCases := []struct {
+		name             string
+		mutate           func(*AlertRuleTemplate)
+		expErrorContains string
+	}{
+		{
+			name:   "valid template",
+			mutate: func(*AlertRuleTemplate) {},
+		},
+		{
+			name: "valid template with query source",
+			mutate: func(t *AlertRuleTemplate) {
+				t.Source = AlertRuleTemplateSource{Query: &AlertRuleTemplateQuery{
+					Condition: "A",
+					Data:      []AlertQuery{CreatePrometheusQuery("A", "up", 1000, 43200, true, "prometheus")},
+				}}
+			},
+		},
+		{
+			name:             "missing rule group",
+			mutate:           func(t *AlertRuleTemplate) { t.RuleGroup = "" },
+			expErrorContains: "rule group is required",
+		},
+		{
+			name:             "invalid parameter name",
+			mutate:           func(t *AlertRuleTemplate) { t.Parameters = []string{"service", "team-name"} },
+			expErrorContains: `invalid parameter name "team-name"`,
+		},
+		{
+			name:             "duplicate parameter",
+			mutate:           func(t *AlertRuleTemplate) { t.Parameters = []string{"service", "team", "service"} },
+			expErrorContains: `duplicate parameter "service"`,
+		},
+		{
+			name:             "invalid template",
+			mutate:           func(t *AlertRuleTemplate) { t.Rule.Title = "{{ .service " },
+			expErrorContains: "failed to parse title",
+		},
+		{
+			name:             "no source",
+			mutate:           func(t *AlertRuleTemplate) { t.Source = AlertRuleTemplateSource{} },
+			expErrorContains: "source must define either static parameters or a query",
+		},
+		{
+			name: "both sources",
+			mutate: func(t *AlertRuleTemplate) {
+				t.Source.Query = &AlertRuleTemplateQuery{Condition: "A", Data: []AlertQuery{GenerateAlertQuery()}}
+			},
+			expErrorContains: "source cannot define both static parameters and a query",
+		},
+		{
+			name: "static parameter set misses a parameter",
+			mutate: func(t *AlertRuleTemplate) {
+				t.Source.Static = append(t.Source.Static, map[string]string{"service": "search"})
+			},
+			expErrorContains: `static parameter set 2: parameter "team" is missing`,
+		},
+		{
+			name: "static parameter set has an unknown parameter",
+			mutate: func(t *AlertRuleTemplate) {
+				t.Source.Static[0]["region"] = "eu"
+			},
+			expErrorContains: `static parameter set 0: parameter "region" is not defined by the template`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := ruleTemplateForTest()
+			tc.mutate(&tmpl)
+			err := tmpl.Validate()
+			if tc.expErrorContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrAlertRuleTemplateInvalidBase)
+			require.ErrorContains(t, err, tc.expErrorContains)
+		})
+	}
+}
+
+func TestAlertRuleTemplateGenerate(t *testing.T) {
+	t.Run("should generate a rule for every parameter set", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		rules, err := tmpl.Generate(tmpl.Source.Static)
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+
+		// Rules are ordered by their parameters.
+		rule := rules[0]
+		assert.Equal(t, "cart is unavailable", rule.Title)
+		assert.Equal(t, 1, rule.RuleGroupIndex)
+		assert.Equal(t, "checkout is unavailable", rules[1].Title)
+		assert.Equal(t, 2, rules[1].RuleGroupIndex)
+
+		assert.Empty(t, rule.UID)
+		assert.EqualValues(t, 1, rule.OrgID)
+		assert.Equal(t, "folder-uid", rule.NamespaceUID)
+		assert.Equal(t, "availability", rule.RuleGroup)
+		assert.EqualValues(t, 60, rule.IntervalSeconds)
+		assert.Equal(t, "B", rule.Condition)
+		assert.Equal(t, NoData, rule.NoDataState)
+		assert.Equal(t, ErrorErrState, rule.ExecErrState)
+		assert.Equal(t, 5*time.Minute, rule.For)
+		assert.Equal(t, map[string]string{"team": "shop"}, rule.Labels)
+		assert.Equal(t, map[string]string{"summary": "cart of shop is down"}, rule.Annotations)
+		require.NotNil(t, rule.NotificationSettings)
+		assert.Equal(t, "shop-oncall", rule.NotificationSettings.Receiver)
+		assert.Equal(t, "{{ .team }}-oncall", tmpl.Rule.NotificationSettings.Receiver, "template should not be changed")
+
+		require.Len(t, rule.Data, 2)
+		var model map[string]any
+		require.NoError(t, json.Unmarshal(rule.Data[0].Model, &model))
+		assert.Equal(t, "up{service='cart'}", model["expr"])
+
+		require.NotNil(t, rule.Metadata.RuleTemplate)
+		assert.Equal(t, "template-uid", rule.Metadata.RuleTemplate.UID)
+		assert.Equal(t, map[string]string{"service": "cart", "team": "shop"}, rule.Metadata.RuleTemplate.Parameters)
+	})
+
+	t.Run("should generate a single rule for duplicate parameter sets", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		rules, err := tmpl.Generate(append(tmpl.Source.Static, map[string]string{"service": "cart", "team": "shop"}))
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+	})
+
+	t.Run("should return no rules if there are no parameter sets", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		rules, err := tmpl.Generate(nil)
+		require.NoError(t, err)
+		require.Empty(t, rules)
+	})
+
+	t.Run("should fail if rules have the same title", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		tmpl.Rule.Title = "{{ .team }} service is unavailable"
+		_, err := tmpl.Generate([]map[string]string{
+			{"service": "checkout", "team": "payments"},
+			{"service": "refunds", "team": "payments"},
+		})
+		require.ErrorIs(t, err, ErrAlertRuleTemplateInvalidBase)
+		require.ErrorContains(t, err, `generate rules with the same title "payments service is unavailable"`)
+	})
+
+	t.Run("should fail if a parameter set misses a parameter", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		_, err := tmpl.Generate([]map[string]string{{"service": "checkout"}})
+		require.ErrorIs(t, err, ErrAlertRuleTemplateInvalidBase)
+		require.ErrorContains(t, err, `parameter "team" is missing`)
+	})
+
+	t.Run("should escape parameters in query models", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		rules, err := tmpl.Generate([]map[string]string{{"service": `cart'}", "datasourceUid": "other`, "team": "shop"}})
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		var model map[string]any
+		require.NoError(t, json.Unmarshal(rules[0].Data[0].Model, &model))
+		assert.Equal(t, `up{service='cart'}", "datasourceUid": "other'}`, model["expr"])
+		assert.Equal(t, tmpl.Rule.Data[0].DatasourceUID, rules[0].Data[0].DatasourceUID)
+		assert.NotContains(t, model, "datasourceUid")
+	})
+
+	t.Run("should fail if a query model is not valid JSON", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		tmpl.Rule.Data[0].Model = json.RawMessage(`{"expr": "{{ .service }}}`)
+		_, err := tmpl.Generate(tmpl.Source.Static)
+		require.ErrorIs(t, err, ErrAlertRuleTemplateInvalidBase)
+		require.ErrorContains(t, err, "model of query A is not valid JSON")
+	})
+}
+
+func TestRuleTemplateParametersKey(t *testing.T) {
+	a := RuleTemplateParametersKey(map[string]string{"service": "checkout", "team": "payments"})
+	b := RuleTemplateParametersKey(map[string]string{"team": "payments", "service": "checkout"})
+	require.Equal(t, a, b)
+	require.NotEqual(t, a, RuleTemplateParametersKey(map[string]string{"service": "checkout", "team": "shop"}))
+}
@@ -1293,6 +1293,51 @@ func TestValidateAlertRule(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("SeverityConditions", func(t *testing.T) {
+		newRule := func(labels map[string]string, conditions ...SeverityCondition) *AlertRule {
+			rule := RuleGen.With(
+				RuleMuts.WithIntervalSeconds(10),
+				RuleMuts.WithSeverityConditions(conditions...),
+			).Generate()
+			rule.Labels = labels
+			return &rule
+		}
+		cfg := setting.UnifiedAlertingSettings{BaseInterval: 10 * time.Second}
+
+		rule := newRule(nil)
+		rule.SeverityConditions = SeverityConditions{{Severity: "critical", Condition: rule.Condition}}
+		require.NoError(t, rule.ValidateAlertRule(cfg))
+
+		rule = newRule(nil, SeverityCondition{Severity: "critical", Condition: "unknown"})
+		err := rule.ValidateAlertRule(cfg)
+		require.ErrorIs(t, err, ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "invalid severity conditions")
+
+		rule = newRule(map[string]string{SeverityLabel: "high"})
+		rule.SeverityConditions = SeverityConditions{{Severity: "critical", Condition: rule.Condition}}
+		err = rule.ValidateAlertRule(cfg)
+		require.ErrorIs(t, err, ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "label severity cannot be defined")
+	})
+
+	t.Run("FlapDetection", func(t *testing.T) {
+		cfg := setting.UnifiedAlertingSettings{BaseInterval: 10 * time.Second}
+
+		rule := RuleGen.With(
+			RuleMuts.WithIntervalSeconds(10),
+			RuleMuts.WithFlapDetection(FlapDetection{Transitions: 4, Window: time.Minute, NotificationPolicy: FlapNotify}),
+		).Generate()
+		require.NoError(t, rule.ValidateAlertRule(cfg))
+
+		rule = RuleGen.With(
+			RuleMuts.WithIntervalSeconds(120),
+			RuleMuts.WithFlapDetection(FlapDetection{Transitions: 4, Window: time.Minute, NotificationPolicy: FlapNotify}),
+		).Generate()
+		err := rule.ValidateAlertRule(cfg)
+		require.ErrorIs(t, err, ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "invalid flap detection")
+	})
 }
 
 func TestAlertRule_PrometheusRuleDefinition(t *testing.T) {
@@ -86,6 +86,42 @@ var (
 	)
 )
 
+// Alert rule template errors.
+var (
+	ErrAlertRuleTemplateNotFound = errutil.NotFound("alerting.alert-rule-template.notFound", errutil.WithPublicMessage("Alert rule template not found"))
+
+	ErrAlertRuleTemplateExists = errutil.Conflict("alerting.alert-rule-template.exists", errutil.WithPublicMessage("Alert rule template with this UID already exists or the rule group is managed by another template."))
+
+	ErrAlertRuleTemplateVersionConflict = errutil.Conflict("alerting.alert-rule-template.conflict").MustTemplate(
+		"Provided version '{{ .Public.Version }}' of alert rule template '{{ .Public.UID }}' does not match current version '{{ .Public.CurrentVersion }}'",
+		errutil.WithPublic("Provided version '{{ .Public.Version }}' of alert rule template '{{ .Public.UID }}' does not match current version '{{ .Public.CurrentVersion }}'"),
+	)
+
+	ErrAlertRuleTemplateInvalidBase = errutil.BadRequest("alerting.alert-rule-template.invalid").MustTemplate(
+		"Invalid alert rule template: {{ .Public.Reason }}",
+		errutil.WithPublic("Invalid alert rule template: {{ .Public.Reason }}"),
+	)
+
+	ErrAlertRuleTemplateNoParameterSets = errutil.UnprocessableEntity("alerting.alert-rule-template.noParameterSets", errutil.WithPublicMessage("The query of the alert rule template returned no parameter sets. The generated rules are kept unless the query allows an empty result."))
+)
+
+// Template library errors.
+var (
+	ErrTemplateLibraryNotFound = errutil.NotFound("alerting.template-library.notFound", errutil.WithPublicMessage("Template library not found"))
+
+	ErrTemplateLibraryExists = errutil.Conflict("alerting.template-library.exists", errutil.WithPublicMessage("Template library with this UID or name already exists"))
+
+	ErrTemplateLibraryVersionConflict = errutil.Conflict("alerting.template-library.conflict").MustTemplate(
+		"Provided version '{{ .Public.Version }}' of template library '{{ .Public.UID }}' does not match current version '{{ .Public.CurrentVersion }}'",
+		errutil.WithPublic("Provided version '{{ .Public.Version }}' of template library '{{ .Public.UID }}' does not match current version '{{ .Public.CurrentVersion }}'"),
+	)
+
+	ErrTemplateLibraryInvalidBase = errutil.BadRequest("alerting.template-library.invalid").MustTemplate(
+		"Invalid template library: {{ .Public.Reason }}",
+		errutil.WithPublic("Invalid template library: {{ .Public.Reason }}"),
+	)
+)
+
 func ErrAlertRuleConflict(ruleUID string, orgID int64, err error) error {
 	return ErrAlertRuleConflictBase.Build(errutil.TemplateData{Public: map[string]any{"RuleUID": ruleUID, "OrgID": orgID, "Error": err.Error()}, Error: err})
 }
@@ -166,3 +202,19 @@ func MakeErrInhibitionRuleOrigin(name, action string) error {
 		Public: map[string]interface{}{"Action": action, "Name": name},
 	})
 }
+
+func MakeErrAlertRuleTemplateInvalid(err error) error {
+	return ErrAlertRuleTemplateInvalidBase.Build(errutil.TemplateData{Public: map[string]any{"Reason": err.Error()}, Error: err})
+}
+
+func MakeErrAlertRuleTemplateVersionConflict(uid string, currentVersion, desiredVersion int64) error {
+	return ErrAlertRuleTemplateVersionConflict.Build(errutil.TemplateData{Public: map[string]any{"UID": uid, "Version": desiredVersion, "CurrentVersion": currentVersion}})
+}
+
+func MakeErrTemplateLibraryInvalid(err error) error {
+	return ErrTemplateLibraryInvalidBase.Build(errutil.TemplateData{Public: map[string]any{"Reason": err.Error()}, Error: err})
+}
+
+func MakeErrTemplateLibraryVersionConflict(uid string, currentVersion, desiredVersion int64) error {
+	return ErrTemplateLibraryVersionConflict.Build(errutil.TemplateData{Public: map[string]any{"UID": uid, "Version": desiredVersion, "CurrentVersion": currentVersion}})
+}
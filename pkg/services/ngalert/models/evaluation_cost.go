@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// QueryCost is the cost of a data source query of an alert rule in a single evaluation.
+type QueryCost struct {
+	RefID string
+	// Series is the number of series (data frames) that the query returned.
+	Series int64
+	// DataPoints is the number of rows of all series.
+	DataPoints int64
+	// Bytes is the approximate size of the values of all series.
+	Bytes int64
+	// Duration is the time it took to execute the query.
+	Duration time.Duration
+}
+
+// EvaluationCost is the cost of a single evaluation of an alert rule, per data source query.
+type EvaluationCost []QueryCost
+
+// Series returns the total number of series of all queries.
+func (c EvaluationCost) Series() int64 {
+	var total int64
+	for _, q := range c {
+		total += q.Series
+	}
+	return total
+}
+
+// DataPoints returns the total number of data points of all queries.
+func (c EvaluationCost) DataPoints() int64 {
+	var total int64
+	for _, q := range c {
+		total += q.DataPoints
+	}
+	return total
+}
+
+// Bytes returns the total size of all queries.
+func (c EvaluationCost) Bytes() int64 {
+	var total int64
+	for _, q := range c {
+		total += q.Bytes
+	}
+	return total
+}
+
+// Duration returns the total execution time of all queries.
+func (c EvaluationCost) Duration() time.Duration {
+	var total time.Duration
+	for _, q := range c {
+		total += q.Duration
+	}
+	return total
+}
+
+// EvaluationBudgetAction defines what happens to a rule whose evaluation exceeds the budget.
+type EvaluationBudgetAction string
+
+const (
+	// EvaluationBudgetActionReject makes the evaluation fail with an error.
+	EvaluationBudgetActionReject EvaluationBudgetAction = "reject"
+	// EvaluationBudgetActionThrottle keeps the results of the evaluation but skips the following evaluations
+	// of the rule in proportion to how much the budget was exceeded.
+	EvaluationBudgetActionThrottle EvaluationBudgetAction = "throttle"
+)
+
+// EvaluationBudget limits the cost of a single evaluation of an alert rule. A limit that is zero or less is not enforced.
+type EvaluationBudget struct {
+	MaxSeries     int64
+	MaxDataPoints int64
+	MaxBytes      int64
+	MaxQueryTime  time.Duration
+	Action        EvaluationBudgetAction
+}
+
+// IsUnlimited returns true if the budget does not enforce any limit.
+func (b EvaluationBudget) IsUnlimited() bool {
+	return b.MaxSeries <= 0 && b.MaxDataPoints <= 0 && b.MaxBytes <= 0 && b.MaxQueryTime <= 0
+}
+
+// Usage returns the largest ratio between the cost and a limit of the budget, and the description of the exceeded limits.
+// The ratio is greater than 1 if the cost exceeds the budget.
+func (b EvaluationBudget) Usage(cost EvaluationCost) (float64, string) {
+	var ratio float64
+	var exceeded []string
+	check := func(name string, value, limit int64) {
+		if limit <= 0 {
+			return
+		}
+		r := float64(value) / float64(limit)
+		ratio = math.Max(ratio, r)
+		if r > 1 {
+			exceeded = append(exceeded, fmt.Sprintf("%s %d (limit: %d)", name, value, limit))
+		}
+	}
+	check("series", cost.Series(), b.MaxSeries)
+	check("data points", cost.DataPoints(), b.MaxDataPoints)
+	check("bytes", cost.Bytes(), b.MaxBytes)
+	if b.MaxQueryTime > 0 {
+		r := float64(cost.Duration()) / float64(b.MaxQueryTime)
+		ratio = math.Max(ratio, r)
+		if r > 1 {
+			exceeded = append(exceeded, fmt.Sprintf("query time %s (limit: %s)", cost.Duration(), b.MaxQueryTime))
+		}
+	}
+	return ratio, strings.Join(exceeded, ", ")
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationBudgetUsage(t *testing.T) {
+	cost := EvaluationCost{
+		{RefID: "A", Series: 10, DataPoints: 100, Bytes: 1600, Duration: time.Second},
+		{RefID: "B", Series: 10, DataPoints: 10, Bytes: 160, Duration: time.Millisecond},
+	}
+
+	t.Run("unlimited budget is never exceeded", func(t *testing.T) {
+		budget := EvaluationBudget{}
+		require.True(t, budget.IsUnlimited())
+		ratio, exceeded := budget.Usage(cost)
+		require.Zero(t, ratio)
+		require.Empty(t, exceeded)
+	})
+
+	t.Run("ratio is the largest of all limits", func(t *testing.T) {
+		budget := EvaluationBudget{MaxSeries: 40, MaxDataPoints: 55}
+		ratio, exceeded := budget.Usage(cost)
+		require.Equal(t, 2.0, ratio)
+		require.Equal(t, "data points 110 (limit: 55)", exceeded)
+	})
+
+	t.Run("lists all exceeded limits", func(t *testing.T) {
+		budget := EvaluationBudget{MaxSeries: 10, MaxQueryTime: 500 * time.Millisecond}
+		ratio, exceeded := budget.Usage(cost)
+		require.Greater(t, ratio, 2.0)
+		require.Equal(t, "series 20 (limit: 10), query time 1.001s (limit: 500ms)", exceeded)
+	})
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// FlapNotificationPolicy determines whether notifications are sent for flapping alert instances.
+type FlapNotificationPolicy string
+
+const (
+	// FlapNotify sends flapping alert instances to the Alertmanager as firing alerts.
+	FlapNotify FlapNotificationPolicy = "Notify"
+	// FlapSuppress does not notify about flapping alert instances until they stop flapping. Instances that were
+	// sent as firing before they started flapping are re-sent, so that the Alertmanager does not resolve them.
+	FlapSuppress FlapNotificationPolicy = "Suppress"
+)
+
+func (p FlapNotificationPolicy) String() string {
+	return string(p)
+}
+
+func FlapNotificationPolicyFromString(policy string) (FlapNotificationPolicy, error) {
+	switch policy {
+	case string(FlapNotify):
+		return FlapNotify, nil
+	case string(FlapSuppress):
+		return FlapSuppress, nil
+	default:
+		return "", fmt.Errorf("unknown flap notification policy %s", policy)
+	}
+}
+
+// FlapDetection configures the detection of alert instances that repeatedly change between firing and not firing.
+// A firing alert instance is flapping when the number of changes within the window reaches the number of transitions.
+// Flapping instances are held in their firing state until they stop flapping.
+type FlapDetection struct {
+	// Transitions is the number of changes between firing and not firing after which an alert instance is flapping.
+	Transitions int `json:"transitions"`
+	// Window is the period in which the changes are counted.
+	Window time.Duration `json:"window"`
+	// NotificationPolicy determines whether notifications are sent for flapping alert instances.
+	NotificationPolicy FlapNotificationPolicy `json:"notification_policy"`
+}
+
+// Validate checks that the flap detection can detect flapping with the evaluation interval of the rule.
+func (f FlapDetection) Validate(interval time.Duration) error {
+	if f.Transitions < 2 {
+		return errors.New("number of transitions must be at least 2")
+	}
+	if f.Window < interval {
+		return fmt.Errorf("window %s must not be shorter than the evaluation interval %s", f.Window, interval)
+	}
+	if _, err := FlapNotificationPolicyFromString(string(f.NotificationPolicy)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlapDetectionValidate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		flap  FlapDetection
+		error string
+	}{
+		{
+			name: "valid",
+			flap: FlapDetection{Transitions: 4, Window: 10 * time.Minute, NotificationPolicy: FlapSuppress},
+		},
+		{
+			name:  "too few transitions",
+			flap:  FlapDetection{Transitions: 1, Window: 10 * time.Minute, NotificationPolicy: FlapNotify},
+			error: "number of transitions must be at least 2",
+		},
+		{
+			name:  "window shorter than interval",
+			flap:  FlapDetection{Transitions: 4, Window: 30 * time.Second, NotificationPolicy: FlapNotify},
+			error: "must not be shorter than the evaluation interval",
+		},
+		{
+			name:  "unknown policy",
+			flap:  FlapDetection{Transitions: 4, Window: 10 * time.Minute, NotificationPolicy: "Sometimes"},
+			error: "unknown flap notification policy Sometimes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.flap.Validate(time.Minute)
+			if tc.error == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.error)
+		})
+	}
+}
@@ -52,6 +52,77 @@ func (r LastResult) ToDB() ([]byte, error) {
 	return json.Marshal(safe)
 }
 
+const (
+	// AcknowledgedByAnnotation, AcknowledgedCommentAnnotation and AcknowledgedUntilAnnotation are added to the
+	// alerts that are sent to the Alertmanager for acknowledged alert instances.
+	AcknowledgedByAnnotation      = "acknowledged_by"
+	AcknowledgedCommentAnnotation = "acknowledged_comment"
+	AcknowledgedUntilAnnotation   = "acknowledged_until"
+)
+
+// Acknowledgement records that a user has acknowledged a firing alert instance.
+type Acknowledgement struct {
+	// By is the login of the user who acknowledged the instance.
+	By        string    `json:"by"`
+	Comment   string    `json:"comment,omitempty"`
+	At        time.Time `json:"at"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Values contains the values of the instance at the time it was acknowledged.
+	Values map[string]float64 `json:"values,omitempty"`
+	// SuppressNotifications silences the instance until the acknowledgement is removed.
+	SuppressNotifications bool `json:"suppressNotifications,omitempty"`
+	// SilenceID is the ID of the silence that suppresses the notifications of the instance.
+	SilenceID string `json:"silenceId,omitempty"`
+	// UntilValueChanges removes the acknowledgement when the values of the instance differ from Values.
+	UntilValueChanges bool `json:"untilValueChanges,omitempty"`
+}
+
+// IsZero returns true if the instance is not acknowledged.
+func (a Acknowledgement) IsZero() bool {
+	return a.By == "" && a.At.IsZero()
+}
+
+// IsExpired returns true if the acknowledgement is no longer valid at the given time.
+func (a Acknowledgement) IsExpired(now time.Time) bool {
+	return !a.ExpiresAt.IsZero() && !now.Before(a.ExpiresAt)
+}
+
+// FromDB loads Acknowledgement from JSON.
+func (a *Acknowledgement) FromDB(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	var raw struct {
+		Acknowledgement
+		Values map[string]any `json:"values,omitempty"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	values, err := unjsonifyValues(raw.Values)
+	if err != nil {
+		return err
+	}
+	*a = raw.Acknowledgement
+	a.Values = values
+	return nil
+}
+
+// ToDB serializes Acknowledgement to JSON.
+func (a Acknowledgement) ToDB() ([]byte, error) {
+	if a.IsZero() {
+		return nil, nil
+	}
+	safe := struct {
+		Acknowledgement
+		Values map[string]any `json:"values,omitempty"`
+	}{
+		Acknowledgement: a,
+		Values:          jsonifyValues(a.Values),
+	}
+	return json.Marshal(safe)
+}
+
 // AlertInstance represents a single alert instance.
 type AlertInstance struct {
 	AlertInstanceKey   `xorm:"extends"`
@@ -66,9 +137,10 @@ type AlertInstance struct {
 	FiredAt            *time.Time
 	ResolvedAt         *time.Time
 	ResultFingerprint  string
-	EvaluationDuration time.Duration `xorm:"evaluation_duration_ns"`
-	LastError          string        `xorm:"last_error"`
-	LastResult         LastResult    `xorm:"last_result"`
+	EvaluationDuration time.Duration   `xorm:"evaluation_duration_ns"`
+	LastError          string          `xorm:"last_error"`
+	LastResult         LastResult      `xorm:"last_result"`
+	Acknowledgement    Acknowledgement `xorm:"acknowledgement"`
 }
 
 type AlertInstanceKey struct {
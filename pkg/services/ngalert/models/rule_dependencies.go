@@ -0,0 +1,196 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+var ErrRuleGroupDependencyCycle = errors.New("rules in the group depend on each other")
+
+// QueriedMetrics returns the names of the metrics selected by the PromQL query defined by `expr` within the model.
+// Returns nil if the query does not have an `expr` or it cannot be parsed as PromQL.
+func (aq *AlertQuery) QueriedMetrics() []string {
+	isExpr, err := aq.IsExpression()
+	if err != nil || isExpr {
+		return nil
+	}
+	query, err := aq.GetQuery()
+	if err != nil || query == "" {
+		return nil
+	}
+	e, err := parser.ParseExpr(query)
+	if err != nil {
+		return nil
+	}
+	var result []string
+	parser.Inspect(e, func(node parser.Node, _ []parser.Node) error {
+		vs, ok := node.(*parser.VectorSelector)
+		if !ok {
+			return nil
+		}
+		if vs.Name != "" {
+			result = append(result, vs.Name)
+			return nil
+		}
+		for _, m := range vs.LabelMatchers {
+			if m.Name == labels.MetricName && m.Type == labels.MatchEqual {
+				result = append(result, m.Value)
+			}
+		}
+		return nil
+	})
+	return result
+}
+
+// queriedMetrics returns the metrics selected by each query of the rule, in the order of the rule's data.
+func (alertRule *AlertRule) queriedMetrics() [][]string {
+	result := make([][]string, len(alertRule.Data))
+	for i := range alertRule.Data {
+		result[i] = alertRule.Data[i].QueriedMetrics()
+	}
+	return result
+}
+
+// QueriedMetricsCache keeps the metrics queried by alert rules, so the PromQL queries of a rule are parsed once per
+// rule version rather than every time dependencies between rules are checked. The zero value is not usable, use
+// NewQueriedMetricsCache. A nil cache parses the queries on every call.
+type QueriedMetricsCache struct {
+	mu      sync.Mutex
+	entries map[AlertRuleKey]queriedMetricsEntry
+}
+
+type queriedMetricsEntry struct {
+	version int64
+	metrics [][]string
+}
+
+func NewQueriedMetricsCache() *QueriedMetricsCache {
+	return &QueriedMetricsCache{entries: make(map[AlertRuleKey]queriedMetricsEntry)}
+}
+
+func (c *QueriedMetricsCache) get(rule *AlertRule) [][]string {
+	if c == nil {
+		return rule.queriedMetrics()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := rule.GetKey()
+	if e, ok := c.entries[key]; ok && e.version == rule.Version && len(e.metrics) == len(rule.Data) {
+		return e.metrics
+	}
+	metrics := rule.queriedMetrics()
+	c.entries[key] = queriedMetricsEntry{version: rule.Version, metrics: metrics}
+	return metrics
+}
+
+// Delete removes the rules from the cache.
+func (c *QueriedMetricsCache) Delete(keys ...AlertRuleKey) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+// dependsOn returns true if any query of the rule reads the metric written by the recording rule.
+func (c *QueriedMetricsCache) dependsOn(alertRule, recording *AlertRule) bool {
+	if alertRule == recording || recording.Record == nil || recording.Record.Metric == "" {
+		return false
+	}
+	var metrics [][]string
+	for i := range alertRule.Data {
+		q := &alertRule.Data[i]
+		// if the target data source is not specified, the rule writes to the default one that is not known here.
+		if recording.Record.TargetDatasourceUID != "" && q.DatasourceUID != recording.Record.TargetDatasourceUID {
+			continue
+		}
+		if metrics == nil {
+			metrics = c.get(alertRule)
+		}
+		if slices.Contains(metrics[i], recording.Record.Metric) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasDependencies returns true if any rule in the group queries a metric written by a recording rule of the same group.
+func (g RulesGroup) HasDependencies() bool {
+	var c *QueriedMetricsCache
+	return c.HasDependencies(g)
+}
+
+// HasDependencies is like RulesGroup.HasDependencies but takes the queried metrics from the cache.
+func (c *QueriedMetricsCache) HasDependencies(g RulesGroup) bool {
+	for _, rule := range g {
+		for _, other := range g {
+			if c.dependsOn(rule, other) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SortByDependencies returns the rules of the group in the order they should be evaluated: every rule comes after
+// the recording rules of the group whose metrics it queries. Rules that do not depend on each other keep the order
+// of the group. Returns ErrRuleGroupDependencyCycle if the rules depend on each other.
+func (g RulesGroup) SortByDependencies() (RulesGroup, error) {
+	var c *QueriedMetricsCache
+	return c.SortByDependencies(g)
+}
+
+// SortByDependencies is like RulesGroup.SortByDependencies but takes the queried metrics from the cache.
+func (c *QueriedMetricsCache) SortByDependencies(g RulesGroup) (RulesGroup, error) {
+	ordered := slices.Clone(g)
+	ordered.SortByGroupIndex()
+
+	// dependents[i] contains the indices of rules that depend on the rule i.
+	dependents := make([][]int, len(ordered))
+	inDegree := make([]int, len(ordered))
+	for i, rule := range ordered {
+		for j, other := range ordered {
+			if c.dependsOn(rule, other) {
+				dependents[j] = append(dependents[j], i)
+				inDegree[i]++
+			}
+		}
+	}
+
+	result := make(RulesGroup, 0, len(ordered))
+	done := make([]bool, len(ordered))
+	for len(result) < len(ordered) {
+		// pick the first rule in the group order that does not wait for other rules.
+		next := -1
+		for i := range ordered {
+			if !done[i] && inDegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var titles []string
+			for i, rule := range ordered {
+				if !done[i] {
+					titles = append(titles, fmt.Sprintf("'%s'", rule.Title))
+				}
+			}
+			return nil, fmt.Errorf("%w: %s", ErrRuleGroupDependencyCycle, strings.Join(titles, ", "))
+		}
+		done[next] = true
+		result = append(result, ordered[next])
+		for _, d := range dependents[next] {
+			inDegree[d]--
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertQuery_QueriedMetrics(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    AlertQuery
+		expected []string
+	}{
+		{
+			name:     "metric name",
+			query:    CreatePrometheusQuery("A", "sum(rate(http_requests_total[5m]))", 1000, 43200, false, "prom"),
+			expected: []string{"http_requests_total"},
+		},
+		{
+			name:     "several metrics",
+			query:    CreatePrometheusQuery("A", `errors_total{job=\"a\"} / requests_total`, 1000, 43200, false, "prom"),
+			expected: []string{"errors_total", "requests_total"},
+		},
+		{
+			name:     "name matcher",
+			query:    CreatePrometheusQuery("A", `{__name__=\"up\"}`, 1000, 43200, false, "prom"),
+			expected: []string{"up"},
+		},
+		{
+			name:     "invalid query",
+			query:    CreatePrometheusQuery("A", "sum(", 1000, 43200, false, "prom"),
+			expected: nil,
+		},
+		{
+			name:     "expression",
+			query:    CreateClassicConditionExpression("B", "A", "last", "gt", 1),
+			expected: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.query.QueriedMetrics())
+		})
+	}
+}
+
+func TestRulesGroup_SortByDependencies(t *testing.T) {
+	recording := func(idx int, metric, query string) *AlertRule {
+		return RuleGen.With(
+			RuleGen.WithTitle(metric),
+			RuleGen.WithGroupIndex(idx),
+			RuleGen.WithQuery(CreatePrometheusQuery("A", query, 1000, 43200, false, "prom")),
+			RuleGen.WithAllRecordingRules(),
+			RuleGen.WithMetric(metric),
+			RuleGen.WithRecordFrom("A"),
+			RuleGen.WithoutTargetDataSource(),
+		).GenerateRef()
+	}
+	alerting := func(idx int, title, query string) *AlertRule {
+		return RuleGen.With(
+			RuleGen.WithTitle(title),
+			RuleGen.WithGroupIndex(idx),
+			RuleGen.WithQuery(CreatePrometheusQuery("A", query, 1000, 43200, false, "prom")),
+		).GenerateRef()
+	}
+	titles := func(g RulesGroup) []string {
+		result := make([]string, 0, len(g))
+		for _, r := range g {
+			result = append(result, r.Title)
+		}
+		return result
+	}
+
+	t.Run("should keep the group order if rules do not depend on each other", func(t *testing.T) {
+		g := RulesGroup{
+			alerting(2, "b", "up"),
+			alerting(1, "a", "up"),
+			recording(3, "c", "up"),
+		}
+		assert.False(t, g.HasDependencies())
+		sorted, err := g.SortByDependencies()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, titles(sorted))
+	})
+
+	t.Run("should put recording rules before rules that query their metrics", func(t *testing.T) {
+		g := RulesGroup{
+			alerting(1, "alert", "job:errors:ratio > 0.1"),
+			recording(2, "job:errors:ratio", "job:errors:rate / job:requests:rate"),
+			recording(3, "job:errors:rate", "rate(errors_total[5m])"),
+			recording(4, "job:requests:rate", "rate(requests_total[5m])"),
+			alerting(5, "other", "up"),
+		}
+		assert.True(t, g.HasDependencies())
+		sorted, err := g.SortByDependencies()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"job:errors:rate", "job:requests:rate", "job:errors:ratio", "alert", "other"}, titles(sorted))
+	})
+
+	t.Run("should ignore queries to other data sources than the target of the recording rule", func(t *testing.T) {
+		rec := recording(2, "job:errors:rate", "rate(errors_total[5m])")
+		rec.Record.TargetDatasourceUID = "other"
+		g := RulesGroup{
+			alerting(1, "alert", "job:errors:rate > 0"),
+			rec,
+		}
+		assert.False(t, g.HasDependencies())
+	})
+
+	t.Run("should fail if rules depend on each other", func(t *testing.T) {
+		g := RulesGroup{
+			recording(1, "a", "b"),
+			recording(2, "b", "a"),
+			alerting(3, "alert", "up"),
+		}
+		_, err := g.SortByDependencies()
+		require.ErrorIs(t, err, ErrRuleGroupDependencyCycle)
+		assert.ErrorContains(t, err, "'a', 'b'")
+	})
+	t.Run("should parse queries once per rule version", func(t *testing.T) {
+		rec := recording(2, "job:errors:rate", "rate(errors_total[5m])")
+		alert := alerting(1, "alert", "job:errors:rate > 0")
+		g := RulesGroup{alert, rec}
+		cache := NewQueriedMetricsCache()
+		assert.True(t, cache.HasDependencies(g))
+
+		// the cached metrics are used while the version does not change
+		alert.Data[0] = CreatePrometheusQuery("A", "up", 1000, 43200, false, "prom")
+		assert.True(t, cache.HasDependencies(g))
+
+		alert.Version++
+		assert.False(t, cache.HasDependencies(g))
+	})
+}
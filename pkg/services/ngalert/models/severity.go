@@ -0,0 +1,57 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// SeverityLabel is the label that is set to the severity of the alert instances of rules with severity conditions.
+const SeverityLabel = "severity"
+
+// SeverityCondition is a condition of an alert rule that assigns a severity to the alert instances it matches.
+type SeverityCondition struct {
+	// Severity is the value of the severity label.
+	Severity string `json:"severity"`
+	// Condition is the RefID of the query or expression that determines the alert instances that have the severity.
+	Condition string `json:"condition"`
+}
+
+// SeverityConditions are the severity conditions of an alert rule, ordered from the lowest to the highest severity.
+// All conditions are evaluated in the same query pipeline as the condition of the rule.
+type SeverityConditions []SeverityCondition
+
+// Validate checks that every condition has a unique severity and refers to a query or expression of the rule.
+func (s SeverityConditions) Validate(data []AlertQuery) error {
+	refIDs := make(map[string]struct{}, len(data))
+	for _, q := range data {
+		refIDs[q.RefID] = struct{}{}
+	}
+	severities := make(map[string]struct{}, len(s))
+	for _, c := range s {
+		if c.Severity == "" {
+			return errors.New("severity of a severity condition cannot be empty")
+		}
+		if !utf8.ValidString(c.Severity) {
+			return fmt.Errorf("severity %q must be a valid utf8 string", c.Severity)
+		}
+		if _, ok := severities[c.Severity]; ok {
+			return fmt.Errorf("severity %q is defined more than once", c.Severity)
+		}
+		severities[c.Severity] = struct{}{}
+		if _, ok := refIDs[c.Condition]; !ok {
+			return fmt.Errorf("condition %q of severity %q does not exist in the queries and expressions of the rule", c.Condition, c.Severity)
+		}
+	}
+	return nil
+}
+
+// Highest returns the highest severity for which matches returns true, or an empty string if none matches.
+func (s SeverityConditions) Highest(matches func(condition string) bool) string {
+	for i := len(s) - 1; i >= 0; i-- {
+		if matches(s[i].Condition) {
+			return s[i].Severity
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverityConditions(t *testing.T) {
+	data := []AlertQuery{{RefID: "A"}, {RefID: "B"}, {RefID: "C"}}
+
+	t.Run("Validate", func(t *testing.T) {
+		testCases := []struct {
+			name        string
+			conditions  SeverityConditions
+			expectedErr string
+		}{
+			{
+				name:       "valid conditions",
+				conditions: SeverityConditions{{Severity: "warning", Condition: "B"}, {Severity: "critical", Condition: "C"}},
+			},
+			{
+				name:        "empty severity",
+				conditions:  SeverityConditions{{Condition: "B"}},
+				expectedErr: "severity of a severity condition cannot be empty",
+			},
+			{
+				name:        "duplicate severity",
+				conditions:  SeverityConditions{{Severity: "warning", Condition: "B"}, {Severity: "warning", Condition: "C"}},
+				expectedErr: `severity "warning" is defined more than once`,
+			},
+			{
+				name:        "unknown condition",
+				conditions:  SeverityConditions{{Severity: "warning", Condition: "D"}},
+				expectedErr: `condition "D" of severity "warning" does not exist`,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.conditions.Validate(data)
+				if tc.expectedErr == "" {
+					require.NoError(t, err)
+					return
+				}
+				require.ErrorContains(t, err, tc.expectedErr)
+			})
+		}
+	})
+
+	t.Run("Highest", func(t *testing.T) {
+		conditions := SeverityConditions{{Severity: "warning", Condition: "B"}, {Severity: "critical", Condition: "C"}}
+		matching := func(refIDs ...string) func(string) bool {
+			return func(condition string) bool {
+				for _, refID := range refIDs {
+					if refID == condition {
+						return true
+					}
+				}
+				return false
+			}
+		}
+
+		require.Equal(t, "critical", conditions.Highest(matching("B", "C")))
+		require.Equal(t, "warning", conditions.Highest(matching("B")))
+		require.Equal(t, "", conditions.Highest(matching()))
+	})
+}
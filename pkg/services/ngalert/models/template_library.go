@@ -0,0 +1,40 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// TemplateLibrary is a set of notification templates that is shared by all organizations of the instance.
+// The templates of all libraries are added to the Alertmanager configuration of every organization, so they can
+// be referenced by contact points and templates of the organization. A template of the organization with the same
+// name as a template of a library overrides it.
+type TemplateLibrary struct {
+	ID          int64
+	UID         string
+	Name        string
+	Description string
+	// Templates maps the names of the template files of the library to their content.
+	Templates map[string]string
+	// Version is incremented every time the library is updated. Previous versions are kept as TemplateLibraryVersion.
+	Version int64
+	Updated time.Time
+}
+
+// TemplateLibraryVersion is the content of a template library at a given version.
+type TemplateLibraryVersion struct {
+	LibraryUID string
+	Version    int64
+	Templates  map[string]string
+	Created    time.Time
+}
+
+// TemplateNames returns the names of the template files of the library in alphabetical order.
+func (l TemplateLibrary) TemplateNames() []string {
+	names := make([]string, 0, len(l.Templates))
+	for name := range l.Templates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -113,6 +113,22 @@ func (g *AlertRuleGenerator) Generate() AlertRule {
 		updatedBy = util.Pointer(UserUID(util.GenerateShortUID()))
 	}
 
+	var severityConditions SeverityConditions
+	if rand.Int63()%2 == 0 {
+		severityConditions = SeverityConditions{{Severity: "critical", Condition: "A"}}
+	}
+
+	var flapDetection *FlapDetection
+	if rand.Int63()%2 == 0 {
+		policies := [...]FlapNotificationPolicy{FlapNotify, FlapSuppress}
+		flapDetection = &FlapDetection{
+			// Enough transitions that tests which change the state a few times do not see flapping alerts.
+			Transitions:        rand.Intn(5) + 6,
+			Window:             time.Hour,
+			NotificationPolicy: policies[rand.Intn(len(policies))],
+		}
+	}
+
 	rule := AlertRule{
 		ID:                          0,
 		GUID:                        uuid.NewString(),
@@ -139,6 +155,8 @@ func (g *AlertRuleGenerator) Generate() AlertRule {
 		NotificationSettings:        ns,
 		Metadata:                    GenerateMetadata(),
 		MissingSeriesEvalsToResolve: util.Pointer[int64](2),
+		SeverityConditions:          severityConditions,
+		FlapDetection:               flapDetection,
 	}
 
 	for _, mutator := range g.mutators {
@@ -549,6 +567,18 @@ func (a *AlertRuleMutators) WithMissingSeriesEvalsToResolve(timesOfInterval int6
 	}
 }
 
+func (a *AlertRuleMutators) WithSeverityConditions(conditions ...SeverityCondition) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.SeverityConditions = conditions
+	}
+}
+
+func (a *AlertRuleMutators) WithFlapDetection(flapDetection FlapDetection) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.FlapDetection = &flapDetection
+	}
+}
+
 func (a *AlertRuleMutators) WithNotificationSettingsGen(ns func() NotificationSettings) AlertRuleMutator {
 	return func(rule *AlertRule) {
 		rule.NotificationSettings = util.Pointer(ns())
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/benbjohnson/clock"
@@ -12,6 +13,7 @@ import (
 	"github.com/grafana/alerting/notify/nfstatus"
 	"github.com/prometheus/alertmanager/featurecontrol"
 	"github.com/prometheus/alertmanager/matchers/compat"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/lokiconfig"
@@ -262,6 +264,7 @@ func (ng *AlertNG) init() error {
 
 		opts = append(opts, notifier.WithAlertmanagerOverride(override))
 	}
+	opts = append(opts, notifier.WithTemplateLibraries(ng.store))
 
 	notificationHistorian, err := configureNotificationHistorian(
 		initCtx,
@@ -331,7 +334,7 @@ func (ng *AlertNG) init() error {
 	evalFactory := eval.NewEvaluatorFactory(ng.Cfg.UnifiedAlerting, ng.DataSourceCache, ng.ExpressionService)
 	conditionValidator := eval.NewConditionValidator(ng.DataSourceCache, ng.ExpressionService, ng.pluginsStore)
 
-	recordingWriter, err := createRecordingWriter(ng.Cfg.UnifiedAlerting.RecordingRules, ng.httpClientProvider, ng.DataSourceService, ng.pluginContextProvider, clk, ng.Metrics.GetRemoteWriterMetrics())
+	recordingWriter, err := createRecordingWriter(ng.Cfg.UnifiedAlerting.RecordingRules, ng.store, ng.httpClientProvider, ng.DataSourceService, ng.pluginContextProvider, clk, ng.Metrics.GetRemoteWriterMetrics())
 	if err != nil {
 		return fmt.Errorf("failed to initialize recording writer: %w", err)
 	}
@@ -351,6 +354,7 @@ func (ng *AlertNG) init() error {
 		JitterEvaluations:    schedule.JitterStrategyFrom(ng.Cfg.UnifiedAlerting, ng.FeatureToggles),
 		AppURL:               appUrl,
 		EvaluatorFactory:     evalFactory,
+		EvaluationBudgets:    NewEvaluationBudgets(ng.Cfg.UnifiedAlerting),
 		RuleStore:            ng.store,
 		RecordingRulesCfg:    ng.Cfg.UnifiedAlerting.RecordingRules,
 		Metrics:              ng.Metrics.GetSchedulerMetrics(),
@@ -382,7 +386,48 @@ func (ng *AlertNG) init() error {
 		return err
 	}
 
+	// Backends used to migrate state history report to a separate registry, so they do not appear as the configured ones.
+	migrationMetrics := metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem)
+	historyBackends := func(ctx context.Context, backend historian.BackendType) (historian.Backend, error) {
+		historyCfg := ng.Cfg.UnifiedAlerting.StateHistory
+		historyCfg.Enabled = true
+		historyCfg.Backend = backend.String()
+		return configureHistorianBackend(
+			ctx,
+			historyCfg,
+			ng.Cfg.AnnotationMaximumTagsLength,
+			ng.annotationsRepo,
+			ng.dashboardService,
+			ng.store,
+			migrationMetrics,
+			ng.Log,
+			ng.tracer,
+			ac.NewRuleService(ng.accesscontrol),
+			ng.DataSourceService,
+			ng.httpClientProvider,
+			ng.pluginContextProvider,
+			clk,
+			ng.Metrics.GetRemoteWriterMetrics(),
+		)
+	}
+
 	ng.InstanceStore, ng.StartupInstanceReader = initInstanceStore(ng.store.SQLStore, ng.Log, ng.FeatureToggles)
+	if ng.Cfg.UnifiedAlerting.StateStore.IsExternal() {
+		instanceStore, err := initExternalInstanceStore(ng.Cfg.UnifiedAlerting, ng.Log)
+		if err != nil {
+			return fmt.Errorf("failed to initialize alert state store: %w", err)
+		}
+		// Copy the state from the database the first time the external store is used, so that alerts keep their state.
+		orgIDs, err := ng.store.FetchOrgIds(initCtx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch organizations: %w", err)
+		}
+		if err := store.MigrateAlertInstances(initCtx, orgIDs, ng.StartupInstanceReader, instanceStore, ng.Log); err != nil {
+			return fmt.Errorf("failed to migrate alert state to the state store: %w", err)
+		}
+		// The state is loaded from the external store on startup, so warm restarts do not touch the database.
+		ng.InstanceStore, ng.StartupInstanceReader = instanceStore, instanceStore
+	}
 
 	stateManagerCfg := state.ManagerCfg{
 		Metrics:                        ng.Metrics.GetStateMetrics(),
@@ -392,6 +437,7 @@ func (ng *AlertNG) init() error {
 		Images:                         ng.ImageService,
 		Clock:                          clk,
 		Historian:                      history,
+		Silencer:                       ng.MultiOrgAlertmanager,
 		MaxStateSaveConcurrency:        ng.Cfg.UnifiedAlerting.MaxStateSaveConcurrency,
 		StatePeriodicSaveBatchSize:     ng.Cfg.UnifiedAlerting.StatePeriodicSaveBatchSize,
 		StatePeriodicSaveJitterEnabled: ng.Cfg.UnifiedAlerting.StatePeriodicSaveJitterEnabled,
@@ -413,6 +459,10 @@ func (ng *AlertNG) init() error {
 
 	var apiStateManager state.AlertInstanceManager
 	var apiStatusReader apiprometheus.StatusReader
+	// Acknowledgements are stored in the state of the node that acknowledged the alert instance and are not shared
+	// between the nodes of a cluster, so alert instances can be acknowledged only while the Alertmanager has no
+	// other cluster members. A Redis address alone, for example to store the state in Redis, does not disable them.
+	var apiAcknowledger api.AlertInstanceAcknowledger
 	if ng.Cfg.UnifiedAlerting.HASingleNodeEvaluation {
 		peer := ng.MultiOrgAlertmanager.Peer()
 		if peer == nil {
@@ -435,6 +485,7 @@ func (ng *AlertNG) init() error {
 
 		// Use in-memory state/scheduler for API calls
 		apiStateManager = ng.stateManager
+		apiAcknowledger = api.NewSingleNodeAcknowledger(ng.stateManager, ng.MultiOrgAlertmanager.Peer())
 		ng.schedule = schedule.NewScheduler(ng.schedCfg, ng.stateManager)
 		apiStatusReader = ng.schedule
 	}
@@ -524,6 +575,9 @@ func (ng *AlertNG) init() error {
 		int64(ng.Cfg.UnifiedAlerting.BaseInterval.Seconds()),
 		ng.Cfg.UnifiedAlerting.RulesPerRuleGroupLimit, ng.Log, notifier.NewNotificationSettingsValidationService(ng.store),
 		ac.NewRuleService(ng.accesscontrol))
+	alertRuleTemplateService := provisioning.NewAlertRuleTemplateService(ng.store, alertRuleService,
+		provisioning.NewEvalRuleTemplateParametersQuerier(evalFactory), ng.store, ng.Log)
+	templateLibraryService := provisioning.NewTemplateLibraryService(ng.store, ng.store, ng.Log)
 
 	ng.Api = &api.API{
 		Cfg:                   ng.Cfg,
@@ -539,6 +593,7 @@ func (ng *AlertNG) init() error {
 		ProvenanceStore:       ng.store,
 		MultiOrgAlertmanager:  ng.MultiOrgAlertmanager,
 		StateManager:          apiStateManager,
+		Acknowledger:          apiAcknowledger,
 		RuleStatusReader:      apiStatusReader,
 		AccessControl:         ng.accesscontrol,
 		Policies:              policyService,
@@ -550,12 +605,15 @@ func (ng *AlertNG) init() error {
 		MuteTimings:           muteTimingService,
 		InhibitionRules:       inhibitionRuleService,
 		AlertRules:            alertRuleService,
+		AlertRuleTemplates:    alertRuleTemplateService,
+		TemplateLibraries:     templateLibraryService,
 		AlertsRouter:          alertsRouter,
 		EvaluatorFactory:      evalFactory,
 		ConditionValidator:    conditionValidator,
 		FeatureManager:        ng.FeatureToggles,
 		AppUrl:                appUrl,
 		Historian:             history,
+		HistoryBackends:       historyBackends,
 		Hooks:                 api.NewHooks(ng.Log),
 		Tracer:                ng.tracer,
 		UserService:           ng.userService,
@@ -609,11 +667,35 @@ func initInstanceStore(sqlStore db.DB, logger log.Logger, featureToggles feature
 	return instanceStore, state.NewMultiInstanceReader(logger, protoInstanceStore, simpleInstanceStore)
 }
 
+// initExternalInstanceStore initializes the instance store that keeps the state of alert rules outside of the database.
+// The store is used for both writing alert instances and reading them on startup.
+func initExternalInstanceStore(uaCfg setting.UnifiedAlertingSettings, logger log.Logger) (state.InstanceStore, error) {
+	switch uaCfg.StateStore.Type {
+	case setting.StateStoreRedis:
+		logger.Info("Using Redis alert instance store")
+		client, err := notifier.NewRedisClient(uaCfg, logger.New("component", "state_store"))
+		if err != nil {
+			return nil, err
+		}
+		prefix := uaCfg.StateStore.RedisPrefix
+		if uaCfg.HARedisPrefix != "" {
+			prefix = strings.TrimSuffix(uaCfg.HARedisPrefix, ":") + ":" + prefix
+		}
+		return store.NewRedisInstanceStore(client, prefix, logger), nil
+	case setting.StateStoreSnapshot:
+		logger.Info("Using snapshot alert instance store", "path", uaCfg.StateStore.SnapshotPath)
+		return store.NewSnapshotInstanceStore(uaCfg.StateStore.SnapshotPath, logger)
+	default:
+		return nil, fmt.Errorf("unknown state store %q", uaCfg.StateStore.Type)
+	}
+}
+
 func initStatePersister(uaCfg setting.UnifiedAlertingSettings, cfg state.ManagerCfg, featureToggles featuremgmt.FeatureToggles) state.StatePersister {
 	logger := log.New("ngalert.state.manager.persist")
 
+	// External state stores keep the state of a rule as a whole, like the compressed database store.
 	//nolint:staticcheck // not yet migrated to OpenFeature
-	compressed := featureToggles.IsEnabledGlobally(featuremgmt.FlagAlertingSaveStateCompressed)
+	compressed := featureToggles.IsEnabledGlobally(featuremgmt.FlagAlertingSaveStateCompressed) || uaCfg.StateStore.IsExternal()
 	//nolint:staticcheck // not yet migrated to OpenFeature
 	periodic := featureToggles.IsEnabledGlobally(featuremgmt.FlagAlertingSaveStatePeriodic)
 
@@ -739,6 +821,11 @@ func (ng *AlertNG) Run(ctx context.Context) error {
 			runner := &evaluationRunner{ng: ng}
 			return runner.run(subCtx)
 		})
+		if interval := ng.Cfg.UnifiedAlerting.RuleTemplateReconcileInterval; interval > 0 {
+			children.Go(func() error {
+				return ng.Api.AlertRuleTemplates.RunReconciler(subCtx, ng.store, interval)
+			})
+		}
 	}
 	return children.Wait()
 }
@@ -853,7 +940,8 @@ func configureHistorianBackend(
 		if w == nil {
 			return nil, fmt.Errorf("failed to create alert state metrics writer")
 		}
-		backend := historian.NewRemotePrometheusBackend(pcfg, w, prometheusBackendLogger, met)
+		r := historian.NewPrometheusDatasourceReader(datasourceService, httpClientProvider, pluginContextProvider, prometheusBackendLogger)
+		backend := historian.NewRemotePrometheusBackend(pcfg, w, r, rs, ac, prometheusBackendLogger, met)
 
 		return backend, nil
 	}
@@ -892,7 +980,7 @@ func configureNotificationHistorian(
 	return nh, nil
 }
 
-func createRecordingWriter(settings setting.RecordingRuleSettings, httpClientProvider httpclient.Provider, datasourceService datasources.DataSourceService, pluginContextProvider *plugincontext.Provider, clock clock.Clock, m *metrics.RemoteWriter) (schedule.RecordingWriter, error) {
+func createRecordingWriter(settings setting.RecordingRuleSettings, localStore writer.LocalStore, httpClientProvider httpclient.Provider, datasourceService datasources.DataSourceService, pluginContextProvider *plugincontext.Provider, clock clock.Clock, m *metrics.RemoteWriter) (schedule.RecordingWriter, error) {
 	logger := log.New("ngalert.writer")
 
 	if settings.Enabled {
@@ -905,7 +993,13 @@ func createRecordingWriter(settings setting.RecordingRuleSettings, httpClientPro
 		logger.Info("Setting up remote write using data sources",
 			"timeout", cfg.Timeout, "default_datasource_uid", cfg.DefaultDatasourceUID)
 
-		return writer.NewDatasourceWriter(cfg, datasourceService, httpClientProvider, pluginContextProvider, clock, logger, m), nil
+		w := writer.NewDatasourceWriter(cfg, datasourceService, httpClientProvider, pluginContextProvider, clock, logger, m)
+		if settings.LocalStorageEnabled {
+			logger.Info("Setting up local storage for recording rules that target the built-in Grafana data source",
+				"retention", settings.LocalStorageRetention)
+			return writer.NewLocalWriter(localStore, w, settings.DefaultDatasourceUID, logger), nil
+		}
+		return w, nil
 	}
 
 	return writer.NoopWriter{}, nil
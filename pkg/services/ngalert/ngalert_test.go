@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
@@ -480,4 +481,39 @@ func TestInitStatePersister(t *testing.T) {
 			assert.IsType(t, tt.expectedStatePersisterType, statePersister)
 		})
 	}
+
+	t.Run("External state store uses rule state persisters", func(t *testing.T) {
+		external := ua
+		external.StateStore.Type = setting.StateStoreSnapshot
+
+		assert.IsType(t, &state.SyncRuleStatePersister{}, initStatePersister(external, cfg, featuremgmt.WithFeatures()))
+		assert.IsType(t, &state.AsyncRuleStatePersister{}, initStatePersister(external, cfg, featuremgmt.WithFeatures(featuremgmt.FlagAlertingSaveStatePeriodic)))
+	})
+}
+
+func TestInitExternalInstanceStore(t *testing.T) {
+	logger := log.NewNopLogger()
+
+	t.Run("Snapshot state store", func(t *testing.T) {
+		ua := setting.UnifiedAlertingSettings{
+			StateStore: setting.UnifiedAlertingStateStoreSettings{Type: setting.StateStoreSnapshot, SnapshotPath: t.TempDir()},
+		}
+		instanceStore, err := initExternalInstanceStore(ua, logger)
+		require.NoError(t, err)
+		assert.IsType(t, &store.SnapshotInstanceStore{}, instanceStore)
+	})
+
+	t.Run("Redis state store", func(t *testing.T) {
+		mr, err := miniredis.Run()
+		require.NoError(t, err)
+		defer mr.Close()
+
+		ua := setting.UnifiedAlertingSettings{
+			HARedisAddr: mr.Addr(),
+			StateStore:  setting.UnifiedAlertingStateStoreSettings{Type: setting.StateStoreRedis, RedisPrefix: "alert_rule_state"},
+		}
+		instanceStore, err := initExternalInstanceStore(ua, logger)
+		require.NoError(t, err)
+		assert.IsType(t, &store.RedisInstanceStore{}, instanceStore)
+	})
 }
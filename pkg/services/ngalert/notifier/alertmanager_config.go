@@ -128,7 +128,15 @@ func (moa *MultiOrgAlertmanager) PrepareConfig(
 
 	prepared.AlertmanagerConfig = preparedConfig
 
-	return PostableAPIConfigToNotificationsConfiguration(prepared, moa.limits), nil
+	result := PostableAPIConfigToNotificationsConfiguration(prepared, moa.limits)
+	if moa.templateLibraries != nil {
+		libraries, err := moa.templateLibraries.ListTemplateLibraries(ctx)
+		if err != nil {
+			return alertingNotify.NotificationsConfiguration{}, fmt.Errorf("failed to get template libraries: %w", err)
+		}
+		result.Templates = withLibraryTemplates(result.Templates, libraries)
+	}
+	return result, nil
 }
 
 func (moa *MultiOrgAlertmanager) SaveAndApplyDefaultConfig(ctx context.Context, orgId int64) error {
@@ -122,6 +122,9 @@ type MultiOrgAlertmanager struct {
 	ns      notifications.Service
 
 	receiverResourcePermissions ac.ReceiverPermissionsService
+
+	// templateLibraries provides the notification templates shared by all organizations. Optional.
+	templateLibraries TemplateLibraryStore
 }
 
 type OrgAlertmanagerFactory func(ctx context.Context, orgID int64) (Alertmanager, error)
@@ -206,22 +209,7 @@ func (moa *MultiOrgAlertmanager) setupClustering(cfg *setting.Cfg) error {
 	const settleTimeout = alertingCluster.DefaultGossipInterval * 10
 	// Redis setup.
 	if cfg.UnifiedAlerting.HARedisAddr != "" {
-		redisPeer, err := newRedisPeer(redisConfig{
-			addr:             cfg.UnifiedAlerting.HARedisAddr,
-			name:             cfg.UnifiedAlerting.HARedisPeerName,
-			prefix:           cfg.UnifiedAlerting.HARedisPrefix,
-			password:         cfg.UnifiedAlerting.HARedisPassword,
-			username:         cfg.UnifiedAlerting.HARedisUsername,
-			db:               cfg.UnifiedAlerting.HARedisDB,
-			maxConns:         cfg.UnifiedAlerting.HARedisMaxConns,
-			tlsEnabled:       cfg.UnifiedAlerting.HARedisTLSEnabled,
-			tls:              cfg.UnifiedAlerting.HARedisTLSConfig,
-			clusterMode:      cfg.UnifiedAlerting.HARedisClusterModeEnabled,
-			sentinelMode:     cfg.UnifiedAlerting.HARedisSentinelModeEnabled,
-			masterName:       cfg.UnifiedAlerting.HARedisSentinelMasterName,
-			sentinelUsername: cfg.UnifiedAlerting.HARedisSentinelUsername,
-			sentinelPassword: cfg.UnifiedAlerting.HARedisSentinelPassword,
-		}, clusterLogger, moa.metrics.Registerer, cfg.UnifiedAlerting.HAPushPullInterval)
+		redisPeer, err := newRedisPeer(redisConfigFromSettings(cfg.UnifiedAlerting), clusterLogger, moa.metrics.Registerer, cfg.UnifiedAlerting.HAPushPullInterval)
 		if err != nil {
 			return fmt.Errorf("unable to initialize redis: %w", err)
 		}
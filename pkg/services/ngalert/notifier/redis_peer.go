@@ -20,6 +20,7 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 type redisConfig struct {
@@ -90,13 +91,8 @@ type redisPeer struct {
 	membersFetchedAt time.Time
 }
 
-func newRedisPeer(cfg redisConfig, logger log.Logger, reg prometheus.Registerer,
-	pushPullInterval time.Duration) (*redisPeer, error) {
-	name := "peer-" + uuid.New().String()
-	// If a specific name is provided, overwrite default one.
-	if cfg.name != "" {
-		name = cfg.name
-	}
+// newRedisClient creates a client for the Redis server, cluster or Sentinel described by the configuration.
+func newRedisClient(cfg redisConfig, logger log.Logger) (redis.UniversalClient, error) {
 	// Allow zero through, since it'll fall back to go-redis's default.
 	poolSize := defaultPoolSize
 	if cfg.maxConns >= 0 {
@@ -131,7 +127,46 @@ func newRedisPeer(cfg redisConfig, logger log.Logger, reg prometheus.Registerer,
 
 	cmd := rdb.Ping(context.Background())
 	if cmd.Err() != nil {
-		logger.Error("Failed to ping redis - redis-based alertmanager clustering may not be available", "err", cmd.Err())
+		logger.Error("Failed to ping redis", "err", cmd.Err())
+	}
+
+	return rdb, nil
+}
+
+// NewRedisClient creates a client for the Redis server configured with the ha_redis_* settings.
+func NewRedisClient(cfg setting.UnifiedAlertingSettings, logger log.Logger) (redis.UniversalClient, error) {
+	return newRedisClient(redisConfigFromSettings(cfg), logger)
+}
+
+func redisConfigFromSettings(cfg setting.UnifiedAlertingSettings) redisConfig {
+	return redisConfig{
+		addr:             cfg.HARedisAddr,
+		name:             cfg.HARedisPeerName,
+		prefix:           cfg.HARedisPrefix,
+		password:         cfg.HARedisPassword,
+		username:         cfg.HARedisUsername,
+		db:               cfg.HARedisDB,
+		maxConns:         cfg.HARedisMaxConns,
+		tlsEnabled:       cfg.HARedisTLSEnabled,
+		tls:              cfg.HARedisTLSConfig,
+		clusterMode:      cfg.HARedisClusterModeEnabled,
+		sentinelMode:     cfg.HARedisSentinelModeEnabled,
+		masterName:       cfg.HARedisSentinelMasterName,
+		sentinelUsername: cfg.HARedisSentinelUsername,
+		sentinelPassword: cfg.HARedisSentinelPassword,
+	}
+}
+
+func newRedisPeer(cfg redisConfig, logger log.Logger, reg prometheus.Registerer,
+	pushPullInterval time.Duration) (*redisPeer, error) {
+	name := "peer-" + uuid.New().String()
+	// If a specific name is provided, overwrite default one.
+	if cfg.name != "" {
+		name = cfg.name
+	}
+	rdb, err := newRedisClient(cfg, logger)
+	if err != nil {
+		return nil, err
 	}
 
 	// Make sure that the prefix uses a colon at the end as deliminator.
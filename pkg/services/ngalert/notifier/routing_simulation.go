@@ -0,0 +1,342 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v2 "github.com/prometheus/alertmanager/api/v2"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+// RoutingSimulation describes what the Alertmanager of an organization does with an alert that has the labels.
+type RoutingSimulation struct {
+	Labels model.LabelSet
+	// Routes contains the notification policies the alert is routed to. There is more than one if a policy continues matching.
+	Routes []SimulatedRoute
+	// Silences contains the IDs of the silences that mute the alert at the time of the simulation.
+	Silences []string
+	// InhibitedBy contains the labels of the firing alerts that inhibit the alert at the time of the simulation.
+	InhibitedBy []model.LabelSet
+}
+
+// SimulatedRoute is a notification policy an alert is routed to, with the settings it inherits from its parents.
+type SimulatedRoute struct {
+	// Path contains the matchers of the policies from the root of the tree to the matched policy.
+	Path           []string
+	Receiver       string
+	GroupBy        []string
+	GroupWait      time.Duration
+	GroupInterval  time.Duration
+	RepeatInterval time.Duration
+	// MuteTimeIntervals contains the mute time intervals of the policy that include the time of the simulation.
+	MuteTimeIntervals []string
+	// ActiveTimeIntervals contains the active time intervals of the policy that include the time of the simulation.
+	ActiveTimeIntervals []string
+	// Muted is true if the policy does not send notifications at the time of the simulation.
+	Muted        bool
+	Integrations []SimulatedIntegration
+}
+
+// SimulatedIntegration is an integration of the contact point that would be notified.
+type SimulatedIntegration struct {
+	UID  string
+	Name string
+	Type string
+}
+
+// SimulateRouting routes the label sets through the notification policies currently applied to the Alertmanager
+// of the organization and evaluates its time intervals, silences and inhibition rules at the time at.
+// Nothing is sent and the state of the Alertmanager is not changed.
+func (moa *MultiOrgAlertmanager) SimulateRouting(ctx context.Context, orgID int64, labelSets []model.LabelSet, at time.Time) ([]RoutingSimulation, error) {
+	am, err := moa.AlertmanagerFor(orgID)
+	if err != nil {
+		return nil, err
+	}
+	status, err := am.GetStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the Alertmanager configuration: %w", err)
+	}
+	silences, err := am.ListSilences(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list silences: %w", err)
+	}
+	alerts, err := am.GetAlerts(ctx, true, true, true, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alerts: %w", err)
+	}
+	simulator, err := newRoutingSimulator(status.Config, silences, alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]RoutingSimulation, 0, len(labelSets))
+	for _, lset := range labelSets {
+		result = append(result, simulator.simulate(lset, at))
+	}
+	return result, nil
+}
+
+type routingSimulator struct {
+	route         *dispatch.Route
+	timeIntervals map[string][]timeinterval.TimeInterval
+	integrations  map[string][]SimulatedIntegration
+	inhibitRules  []simulatedInhibitRule
+	silences      []simulatedSilence
+	alerts        apimodels.GettableAlerts
+}
+
+type simulatedInhibitRule struct {
+	source labels.Matchers
+	target labels.Matchers
+	equal  []model.LabelName
+}
+
+type simulatedSilence struct {
+	id       string
+	matchers labels.Matchers
+	startsAt time.Time
+	endsAt   time.Time
+}
+
+func newRoutingSimulator(cfg *apimodels.PostableApiAlertingConfig, silences apimodels.GettableSilences, alerts apimodels.GettableAlerts) (*routingSimulator, error) {
+	if cfg == nil || cfg.Route == nil {
+		return nil, fmt.Errorf("notification policy tree is not defined")
+	}
+	s := &routingSimulator{
+		route:         dispatch.NewRoute(cfg.Route.AsAMRoute(), nil),
+		timeIntervals: make(map[string][]timeinterval.TimeInterval, len(cfg.MuteTimeIntervals)+len(cfg.TimeIntervals)),
+		integrations:  make(map[string][]SimulatedIntegration, len(cfg.Receivers)),
+		alerts:        alerts,
+	}
+	for _, ti := range cfg.MuteTimeIntervals {
+		s.timeIntervals[ti.Name] = ti.TimeIntervals
+	}
+	for _, ti := range cfg.TimeIntervals {
+		s.timeIntervals[ti.Name] = ti.TimeIntervals
+	}
+	for _, r := range cfg.Receivers {
+		integrations := make([]SimulatedIntegration, 0, len(r.GrafanaManagedReceivers))
+		for _, i := range r.GrafanaManagedReceivers {
+			integrations = append(integrations, SimulatedIntegration{UID: i.UID, Name: i.Name, Type: i.Type})
+		}
+		s.integrations[r.Name] = integrations
+	}
+	for _, ir := range cfg.InhibitRules {
+		rule, err := newSimulatedInhibitRule(ir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid inhibition rule: %w", err)
+		}
+		s.inhibitRules = append(s.inhibitRules, rule)
+	}
+	for _, silence := range silences {
+		if silence == nil || silence.ID == nil || silence.StartsAt == nil || silence.EndsAt == nil {
+			continue
+		}
+		matchers, err := silenceMatchers(silence.Matchers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matchers of silence %s: %w", *silence.ID, err)
+		}
+		s.silences = append(s.silences, simulatedSilence{
+			id:       *silence.ID,
+			matchers: matchers,
+			startsAt: time.Time(*silence.StartsAt),
+			endsAt:   time.Time(*silence.EndsAt),
+		})
+	}
+	return s, nil
+}
+
+func newSimulatedInhibitRule(ir config.InhibitRule) (simulatedInhibitRule, error) {
+	source, err := inhibitMatchers(ir.SourceMatch, ir.SourceMatchRE, ir.SourceMatchers)
+	if err != nil {
+		return simulatedInhibitRule{}, err
+	}
+	target, err := inhibitMatchers(ir.TargetMatch, ir.TargetMatchRE, ir.TargetMatchers)
+	if err != nil {
+		return simulatedInhibitRule{}, err
+	}
+	rule := simulatedInhibitRule{source: source, target: target}
+	for _, ln := range ir.Equal {
+		rule.equal = append(rule.equal, model.LabelName(ln))
+	}
+	return rule, nil
+}
+
+func inhibitMatchers(match map[string]string, matchRE config.MatchRegexps, matchers config.Matchers) (labels.Matchers, error) {
+	result := make(labels.Matchers, 0, len(match)+len(matchRE)+len(matchers))
+	for ln, lv := range match {
+		m, err := labels.NewMatcher(labels.MatchEqual, ln, lv)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	for ln, lv := range matchRE {
+		m, err := labels.NewMatcher(labels.MatchRegexp, ln, lv.String())
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return append(result, matchers...), nil
+}
+
+func silenceMatchers(matchers amv2.Matchers) (labels.Matchers, error) {
+	result := make(labels.Matchers, 0, len(matchers))
+	for _, m := range matchers {
+		if m == nil || m.Name == nil || m.Value == nil {
+			continue
+		}
+		isEqual := m.IsEqual == nil || *m.IsEqual
+		isRegex := m.IsRegex != nil && *m.IsRegex
+		t := labels.MatchEqual
+		switch {
+		case isEqual && isRegex:
+			t = labels.MatchRegexp
+		case !isEqual && isRegex:
+			t = labels.MatchNotRegexp
+		case !isEqual:
+			t = labels.MatchNotEqual
+		}
+		matcher, err := labels.NewMatcher(t, *m.Name, *m.Value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, matcher)
+	}
+	return result, nil
+}
+
+func (s *routingSimulator) simulate(lset model.LabelSet, at time.Time) RoutingSimulation {
+	result := RoutingSimulation{
+		Labels:      lset,
+		Routes:      []SimulatedRoute{},
+		Silences:    []string{},
+		InhibitedBy: []model.LabelSet{},
+	}
+	for _, route := range s.route.Match(lset) {
+		result.Routes = append(result.Routes, s.simulateRoute(route, at))
+	}
+	for _, silence := range s.silences {
+		if silence.startsAt.After(at) || !silence.endsAt.After(at) {
+			continue
+		}
+		if silence.matchers.Matches(lset) {
+			result.Silences = append(result.Silences, silence.id)
+		}
+	}
+	sort.Strings(result.Silences)
+	result.InhibitedBy = s.inhibitedBy(lset, at)
+	return result
+}
+
+func (s *routingSimulator) simulateRoute(route *dispatch.Route, at time.Time) SimulatedRoute {
+	opts := route.RouteOpts
+	result := SimulatedRoute{
+		Path:                routePath(s.route, route),
+		Receiver:            opts.Receiver,
+		GroupBy:             make([]string, 0, len(opts.GroupBy)),
+		GroupWait:           opts.GroupWait,
+		GroupInterval:       opts.GroupInterval,
+		RepeatInterval:      opts.RepeatInterval,
+		MuteTimeIntervals:   []string{},
+		ActiveTimeIntervals: []string{},
+		Integrations:        s.integrations[opts.Receiver],
+	}
+	if opts.GroupByAll {
+		result.GroupBy = append(result.GroupBy, "...")
+	}
+	for ln := range opts.GroupBy {
+		result.GroupBy = append(result.GroupBy, string(ln))
+	}
+	sort.Strings(result.GroupBy)
+
+	for _, name := range opts.MuteTimeIntervals {
+		if s.inTimeInterval(name, at) {
+			result.MuteTimeIntervals = append(result.MuteTimeIntervals, name)
+		}
+	}
+	for _, name := range opts.ActiveTimeIntervals {
+		if s.inTimeInterval(name, at) {
+			result.ActiveTimeIntervals = append(result.ActiveTimeIntervals, name)
+		}
+	}
+	// A policy with active time intervals only sends notifications during one of them.
+	result.Muted = len(result.MuteTimeIntervals) > 0 || len(opts.ActiveTimeIntervals) > 0 && len(result.ActiveTimeIntervals) == 0
+	return result
+}
+
+func (s *routingSimulator) inTimeInterval(name string, at time.Time) bool {
+	for _, ti := range s.timeIntervals[name] {
+		if ti.ContainsTime(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// inhibitedBy returns the labels of the alerts firing at the time at that inhibit the alert with the labels lset.
+func (s *routingSimulator) inhibitedBy(lset model.LabelSet, at time.Time) []model.LabelSet {
+	result := []model.LabelSet{}
+	seen := make(map[model.Fingerprint]struct{})
+	for _, rule := range s.inhibitRules {
+		if !rule.target.Matches(lset) {
+			continue
+		}
+		for _, alert := range s.alerts {
+			if alert == nil || alert.StartsAt == nil || time.Time(*alert.StartsAt).After(at) {
+				continue
+			}
+			if alert.EndsAt != nil && !time.Time(*alert.EndsAt).After(at) {
+				continue
+			}
+			source := v2.APILabelSetToModelLabelSet(alert.Labels)
+			if !rule.source.Matches(source) {
+				continue
+			}
+			// Alerts that match both sides of the rule do not inhibit each other.
+			if rule.source.Matches(lset) && rule.target.Matches(source) {
+				continue
+			}
+			equal := true
+			for _, ln := range rule.equal {
+				if source[ln] != lset[ln] {
+					equal = false
+					break
+				}
+			}
+			if !equal {
+				continue
+			}
+			fp := source.Fingerprint()
+			if _, ok := seen[fp]; ok {
+				continue
+			}
+			seen[fp] = struct{}{}
+			result = append(result, source)
+		}
+	}
+	return result
+}
+
+// routePath returns the matchers of the routes from the root to the route target.
+func routePath(root, target *dispatch.Route) []string {
+	if root == target {
+		return []string{root.Matchers.String()}
+	}
+	for _, child := range root.Routes {
+		if path := routePath(child, target); path != nil {
+			return append([]string{root.Matchers.String()}, path...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package notifier
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const routingSimulationConfig = `{
+	"route": {
+		"receiver": "default",
+		"group_by": ["alertname"],
+		"routes": [{
+			"receiver": "team-a",
+			"object_matchers": [["team", "=", "a"]],
+			"mute_time_intervals": ["weekends"],
+			"continue": true
+		}, {
+			"receiver": "critical",
+			"object_matchers": [["severity", "=", "critical"]],
+			"group_by": ["alertname", "cluster"],
+			"group_wait": "10s"
+		}]
+	},
+	"inhibit_rules": [{
+		"source_matchers": ["severity=\"critical\""],
+		"target_matchers": ["severity=\"warning\""],
+		"equal": ["cluster"]
+	}],
+	"time_intervals": [{
+		"name": "weekends",
+		"time_intervals": [{"weekdays": ["saturday", "sunday"]}]
+	}],
+	"receivers": [
+		{"name": "default", "grafana_managed_receiver_configs": [{"uid": "uid-default", "name": "default", "type": "email", "settings": {}}]},
+		{"name": "team-a", "grafana_managed_receiver_configs": [{"uid": "uid-a", "name": "team-a", "type": "slack", "settings": {}}]},
+		{"name": "critical", "grafana_managed_receiver_configs": [{"uid": "uid-critical", "name": "critical", "type": "pagerduty", "settings": {}}]}
+	]
+}`
+
+func TestRoutingSimulator(t *testing.T) {
+	var cfg apimodels.PostableApiAlertingConfig
+	require.NoError(t, json.Unmarshal([]byte(routingSimulationConfig), &cfg))
+
+	saturday := time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+
+	silences := apimodels.GettableSilences{
+		{
+			ID: util.Pointer("silence-1"),
+			Silence: amv2.Silence{
+				Matchers: amv2.Matchers{{Name: util.Pointer("team"), Value: util.Pointer("a"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)}},
+				StartsAt: util.Pointer(strfmt.DateTime(monday.Add(-time.Hour))),
+				EndsAt:   util.Pointer(strfmt.DateTime(monday.Add(time.Hour))),
+			},
+		},
+	}
+	alerts := apimodels.GettableAlerts{
+		{
+			Alert:    amv2.Alert{Labels: amv2.LabelSet{"alertname": "down", "severity": "critical", "cluster": "prod"}},
+			StartsAt: util.Pointer(strfmt.DateTime(monday.Add(-time.Hour))),
+			EndsAt:   util.Pointer(strfmt.DateTime(monday.Add(time.Hour))),
+		},
+	}
+
+	s, err := newRoutingSimulator(&cfg, silences, alerts)
+	require.NoError(t, err)
+
+	t.Run("should route to the default policy", func(t *testing.T) {
+		result := s.simulate(model.LabelSet{"alertname": "test"}, monday)
+		require.Len(t, result.Routes, 1)
+		route := result.Routes[0]
+		assert.Equal(t, []string{"{}"}, route.Path)
+		assert.Equal(t, "default", route.Receiver)
+		assert.Equal(t, []string{"alertname"}, route.GroupBy)
+		assert.Equal(t, 30*time.Second, route.GroupWait)
+		assert.False(t, route.Muted)
+		assert.Equal(t, []SimulatedIntegration{{UID: "uid-default", Name: "default", Type: "email"}}, route.Integrations)
+		assert.Empty(t, result.Silences)
+		assert.Empty(t, result.InhibitedBy)
+	})
+
+	t.Run("should continue matching and inherit settings", func(t *testing.T) {
+		result := s.simulate(model.LabelSet{"alertname": "test", "team": "a", "severity": "critical"}, monday)
+		require.Len(t, result.Routes, 2)
+		assert.Equal(t, []string{"{}", `{team="a"}`}, result.Routes[0].Path)
+		assert.Equal(t, "team-a", result.Routes[0].Receiver)
+		assert.Equal(t, []string{"alertname"}, result.Routes[0].GroupBy)
+		assert.Equal(t, []string{"{}", `{severity="critical"}`}, result.Routes[1].Path)
+		assert.Equal(t, "critical", result.Routes[1].Receiver)
+		assert.Equal(t, []string{"alertname", "cluster"}, result.Routes[1].GroupBy)
+		assert.Equal(t, 10*time.Second, result.Routes[1].GroupWait)
+		assert.Equal(t, []string{"silence-1"}, result.Silences)
+	})
+
+	t.Run("should mute policy during mute time interval", func(t *testing.T) {
+		result := s.simulate(model.LabelSet{"alertname": "test", "team": "a"}, saturday)
+		require.Len(t, result.Routes, 1)
+		assert.True(t, result.Routes[0].Muted)
+		assert.Equal(t, []string{"weekends"}, result.Routes[0].MuteTimeIntervals)
+		// the silence is not active at that time
+		assert.Empty(t, result.Silences)
+	})
+
+	t.Run("should return inhibiting alerts", func(t *testing.T) {
+		result := s.simulate(model.LabelSet{"alertname": "slow", "severity": "warning", "cluster": "prod"}, monday)
+		assert.Equal(t, []model.LabelSet{{"alertname": "down", "severity": "critical", "cluster": "prod"}}, result.InhibitedBy)
+
+		result = s.simulate(model.LabelSet{"alertname": "slow", "severity": "warning", "cluster": "dev"}, monday)
+		assert.Empty(t, result.InhibitedBy)
+	})
+}
@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/grafana/alerting/templates"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// TemplateLibraryStore provides the notification template libraries that are shared by all organizations.
+type TemplateLibraryStore interface {
+	ListTemplateLibraries(ctx context.Context) ([]models.TemplateLibrary, error)
+}
+
+// WithTemplateLibraries adds the templates of the libraries provided by the store to the configuration of every
+// organization. Changes of the libraries are applied with the next synchronization of the Alertmanagers.
+func WithTemplateLibraries(store TemplateLibraryStore) Option {
+	return func(moa *MultiOrgAlertmanager) {
+		moa.templateLibraries = store
+	}
+}
+
+// withLibraryTemplates returns the templates of the libraries followed by the templates of the organization.
+// A template of the organization replaces the template of a library with the same name. If several libraries have a
+// template with the same name, the template of the first library is used.
+func withLibraryTemplates(orgTemplates []templates.TemplateDefinition, libraries []models.TemplateLibrary) []templates.TemplateDefinition {
+	names := make(map[string]struct{}, len(orgTemplates))
+	for _, t := range orgTemplates {
+		names[t.Name] = struct{}{}
+	}
+	result := make([]templates.TemplateDefinition, 0, len(orgTemplates))
+	for _, l := range libraries {
+		for _, name := range l.TemplateNames() {
+			if _, ok := names[name]; ok {
+				continue
+			}
+			names[name] = struct{}{}
+			result = append(result, templates.TemplateDefinition{
+				Name:     name,
+				Template: l.Templates[name],
+				Kind:     templates.GrafanaKind,
+			})
+		}
+	}
+	return append(result, orgTemplates...)
+}
@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/grafana/alerting/templates"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type fakeTemplateLibraryStore struct {
+	libraries []models.TemplateLibrary
+	err       error
+}
+
+func (f *fakeTemplateLibraryStore) ListTemplateLibraries(context.Context) ([]models.TemplateLibrary, error) {
+	return f.libraries, f.err
+}
+
+func TestWithLibraryTemplates(t *testing.T) {
+	libraries := []models.TemplateLibrary{
+		{Name: "email", Templates: map[string]string{"email": "email content"}},
+		{Name: "slack", Templates: map[string]string{"slack-title": "title content", "slack-text": "text content"}},
+	}
+	orgTemplates := []templates.TemplateDefinition{
+		{Name: "org", Template: "org content", Kind: templates.GrafanaKind},
+		{Name: "slack-text", Template: "overridden text content", Kind: templates.GrafanaKind},
+	}
+
+	result := withLibraryTemplates(orgTemplates, libraries)
+
+	require.Equal(t, []templates.TemplateDefinition{
+		{Name: "email", Template: "email content", Kind: templates.GrafanaKind},
+		{Name: "slack-title", Template: "title content", Kind: templates.GrafanaKind},
+		{Name: "org", Template: "org content", Kind: templates.GrafanaKind},
+		{Name: "slack-text", Template: "overridden text content", Kind: templates.GrafanaKind},
+	}, result)
+}
+
+func TestPrepareConfigWithTemplateLibraries(t *testing.T) {
+	ctx := context.Background()
+	mam := setupMam(t, nil)
+	cfg := &models.AlertConfiguration{AlertmanagerConfiguration: defaultConfig}
+
+	t.Run("should add templates of libraries", func(t *testing.T) {
+		mam.templateLibraries = &fakeTemplateLibraryStore{libraries: []models.TemplateLibrary{
+			{Name: "slack", Templates: map[string]string{"slack": `{{ define "slack.title" }}{{ .CommonLabels.alertname }}{{ end }}`}},
+		}}
+
+		prepared, err := mam.PrepareConfig(ctx, 1, cfg, LogInvalidReceivers)
+		require.NoError(t, err)
+		require.Equal(t, []templates.TemplateDefinition{
+			{Name: "slack", Template: `{{ define "slack.title" }}{{ .CommonLabels.alertname }}{{ end }}`, Kind: templates.GrafanaKind},
+		}, prepared.Templates)
+	})
+
+	t.Run("should fail if libraries cannot be read", func(t *testing.T) {
+		mam.templateLibraries = &fakeTemplateLibraryStore{err: errors.New("db error")}
+
+		_, err := mam.PrepareConfig(ctx, 1, cfg, LogInvalidReceivers)
+		require.ErrorContains(t, err, "failed to get template libraries")
+	})
+}
@@ -0,0 +1,336 @@
+package prom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// UnsupportedRule describes a Grafana rule that cannot be expressed as a Prometheus rule.
+type UnsupportedRule struct {
+	UID    string
+	Title  string
+	Reason string
+}
+
+// instantReducers are the reducers that return the only value of a series that has a single sample,
+// which is what a Prometheus instant query returns.
+var instantReducers = []mathexp.ReducerID{
+	mathexp.ReducerLast,
+	mathexp.ReducerFirst,
+	mathexp.ReducerMean,
+	mathexp.ReducerMedian,
+	mathexp.ReducerMin,
+	mathexp.ReducerMax,
+	mathexp.ReducerSum,
+}
+
+// GrafanaRulesToPrometheus converts the rules of a Grafana rule group into a Prometheus rule group.
+//
+// Rules that were converted from Prometheus rules and kept their original rule definition are exported as is.
+// Other rules are converted if they consist of a single instant query to a Prometheus data source,
+// optionally followed by a reduce expression, and a threshold expression that is the condition of the rule.
+// Recording rules are converted if they record the result of a single instant query to a Prometheus data source.
+//
+// Rules that cannot be converted are not added to the group. Instead, they are returned together with the reason.
+func GrafanaRulesToPrometheus(group string, rules []models.AlertRule) (PrometheusRuleGroup, []UnsupportedRule) {
+	promGroup := PrometheusRuleGroup{
+		Name:  group,
+		Rules: make([]PrometheusRule, 0, len(rules)),
+	}
+	if len(rules) == 0 {
+		return promGroup, nil
+	}
+	promGroup.Interval = prommodel.Duration(time.Duration(rules[0].IntervalSeconds) * time.Second)
+
+	var unsupported []UnsupportedRule
+	var offset *prommodel.Duration
+	for _, rule := range rules {
+		promRule, ruleOffset, err := grafanaRuleToPrometheus(rule)
+		if err == nil && offset != nil && *offset != ruleOffset {
+			err = errors.New("the evaluation offset of the query differs from the other rules of the group")
+		}
+		if err != nil {
+			unsupported = append(unsupported, UnsupportedRule{
+				UID:    rule.UID,
+				Title:  rule.Title,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		offset = &ruleOffset
+		promGroup.Rules = append(promGroup.Rules, promRule)
+	}
+	if offset != nil && *offset != 0 {
+		promGroup.QueryOffset = offset
+	}
+
+	return promGroup, unsupported
+}
+
+// grafanaRuleToPrometheus converts a single Grafana rule into a Prometheus rule.
+// It returns the evaluation offset of the query, which Prometheus configures per rule group.
+// If the rule cannot be expressed as a Prometheus rule, the returned error explains why.
+func grafanaRuleToPrometheus(rule models.AlertRule) (PrometheusRule, prommodel.Duration, error) {
+	if rule.HasPrometheusRuleDefinition() {
+		definition, _ := rule.PrometheusRuleDefinition()
+		var promRule PrometheusRule
+		if err := yaml.Unmarshal([]byte(definition), &promRule); err != nil {
+			return PrometheusRule{}, 0, fmt.Errorf("the original Prometheus rule definition is invalid: %w", err)
+		}
+		var offset prommodel.Duration
+		if query, ok := findQuery(rule.Data, queryRefID); ok {
+			offset = prommodel.Duration(query.RelativeTimeRange.To)
+		}
+		return promRule, offset, nil
+	}
+
+	if rule.IsPaused {
+		return PrometheusRule{}, 0, errors.New("the rule is paused, which is not supported by Prometheus")
+	}
+
+	if rule.Type() == models.RuleTypeRecording {
+		query, err := findPrometheusQuery(rule.Data, rule.Record.From)
+		if err != nil {
+			return PrometheusRule{}, 0, err
+		}
+		if len(rule.Data) > 1 {
+			return PrometheusRule{}, 0, errors.New("recording rules with expressions are not supported")
+		}
+		return PrometheusRule{
+			Record: rule.Record.Metric,
+			Expr:   query.expr,
+			Labels: exportLabels(rule.Labels),
+		}, query.offset, nil
+	}
+
+	if rule.NoDataState == models.Alerting {
+		return PrometheusRule{}, 0, errors.New("the rule fires if there is no data, which is not supported by Prometheus")
+	}
+	if rule.ExecErrState == models.AlertingErrState {
+		return PrometheusRule{}, 0, errors.New("the rule fires on query errors, which is not supported by Prometheus")
+	}
+	for _, tmpl := range slices.Concat(slices.Collect(maps.Values(rule.Annotations)), slices.Collect(maps.Values(rule.Labels))) {
+		if strings.Contains(tmpl, "$values") {
+			return PrometheusRule{}, 0, errors.New("templates that use $values are not supported by Prometheus")
+		}
+	}
+
+	promExpr, offset, err := conditionToPromQL(rule.Data, rule.Condition)
+	if err != nil {
+		return PrometheusRule{}, 0, err
+	}
+
+	promRule := PrometheusRule{
+		Alert:       rule.Title,
+		Expr:        promExpr,
+		Labels:      exportLabels(rule.Labels),
+		Annotations: rule.Annotations,
+	}
+	if rule.For > 0 {
+		forDuration := prommodel.Duration(rule.For)
+		promRule.For = &forDuration
+	}
+	if rule.KeepFiringFor > 0 {
+		keepFiringFor := prommodel.Duration(rule.KeepFiringFor)
+		promRule.KeepFiringFor = &keepFiringFor
+	}
+	return promRule, offset, nil
+}
+
+// conditionToPromQL builds a PromQL expression that returns the series for which the condition of the rule is firing.
+func conditionToPromQL(data []models.AlertQuery, condition string) (string, prommodel.Duration, error) {
+	threshold, ok := findQuery(data, condition)
+	if !ok {
+		return "", 0, fmt.Errorf("condition %s does not exist", condition)
+	}
+	var thresholdModel expr.ThresholdQuery
+	if err := parseExpression(threshold, expr.QueryTypeThreshold, &thresholdModel); err != nil {
+		return "", 0, err
+	}
+	if len(thresholdModel.Conditions) != 1 {
+		return "", 0, fmt.Errorf("threshold %s must have exactly one condition", condition)
+	}
+	if thresholdModel.Conditions[0].UnloadEvaluator != nil {
+		return "", 0, errors.New("thresholds with a recovery threshold are not supported")
+	}
+
+	used := 2
+	input, ok := findQuery(data, refID(thresholdModel.Expression))
+	if !ok {
+		return "", 0, fmt.Errorf("threshold %s refers to a query that does not exist", condition)
+	}
+	if isExpression, _ := input.IsExpression(); isExpression {
+		var reduceModel expr.ReduceQuery
+		if err := parseExpression(input, expr.QueryTypeReduce, &reduceModel); err != nil {
+			return "", 0, err
+		}
+		if !slices.Contains(instantReducers, reduceModel.Reducer) {
+			return "", 0, fmt.Errorf("reducer %s is not supported", reduceModel.Reducer)
+		}
+		if reduceModel.Settings != nil && reduceModel.Settings.Mode == expr.ReduceModeReplace {
+			return "", 0, errors.New("reducers that replace non-numeric values are not supported")
+		}
+		input, ok = findQuery(data, refID(reduceModel.Expression))
+		if !ok {
+			return "", 0, fmt.Errorf("reduce expression %s refers to a query that does not exist", reduceModel.Expression)
+		}
+		used++
+	}
+
+	query, err := findPrometheusQuery(data, input.RefID)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(data) > used {
+		return "", 0, errors.New("only a single query, an optional reduce expression and a threshold are supported")
+	}
+
+	promExpr, err := thresholdToPromQL(query.expr, thresholdModel.Conditions[0].Evaluator)
+	if err != nil {
+		return "", 0, err
+	}
+	return promExpr, query.offset, nil
+}
+
+// thresholdToPromQL filters the result of the query with PromQL comparison operators that match the threshold.
+func thresholdToPromQL(query string, evaluator expr.ConditionEvalJSON) (string, error) {
+	params := make([]string, 0, len(evaluator.Params))
+	for _, p := range evaluator.Params {
+		params = append(params, strconv.FormatFloat(p, 'f', -1, 64))
+	}
+	need := 1
+	if strings.Contains(string(evaluator.Type), "range") {
+		need = 2
+	}
+	if len(params) < need {
+		return "", fmt.Errorf("threshold %s requires %d parameters", evaluator.Type, need)
+	}
+
+	q := "(" + query + ")"
+	switch evaluator.Type {
+	case expr.ThresholdIsAbove:
+		return fmt.Sprintf("%s > %s", q, params[0]), nil
+	case expr.ThresholdIsBelow:
+		return fmt.Sprintf("%s < %s", q, params[0]), nil
+	case expr.ThresholdIsEqual:
+		return fmt.Sprintf("%s == %s", q, params[0]), nil
+	case expr.ThresholdIsNotEqual:
+		return fmt.Sprintf("%s != %s", q, params[0]), nil
+	case expr.ThresholdIsGreaterThanEqual:
+		return fmt.Sprintf("%s >= %s", q, params[0]), nil
+	case expr.ThresholdIsLessThanEqual:
+		return fmt.Sprintf("%s <= %s", q, params[0]), nil
+	case expr.ThresholdIsWithinRange:
+		return fmt.Sprintf("%s > %s < %s", q, params[0], params[1]), nil
+	case expr.ThresholdIsWithinRangeIncluded:
+		return fmt.Sprintf("%s >= %s <= %s", q, params[0], params[1]), nil
+	case expr.ThresholdIsOutsideRange:
+		return fmt.Sprintf("%[1]s < %[2]s or %[1]s > %[3]s", q, params[0], params[1]), nil
+	case expr.ThresholdIsOutsideRangeIncluded:
+		return fmt.Sprintf("%[1]s <= %[2]s or %[1]s >= %[3]s", q, params[0], params[1]), nil
+	default:
+		return "", fmt.Errorf("threshold %s is not supported", evaluator.Type)
+	}
+}
+
+type prometheusQuery struct {
+	expr   string
+	offset prommodel.Duration
+}
+
+// findPrometheusQuery returns the PromQL expression of the query with the given refID.
+// The query must be an instant query to a Prometheus data source.
+func findPrometheusQuery(data []models.AlertQuery, ref string) (prometheusQuery, error) {
+	query, ok := findQuery(data, ref)
+	if !ok {
+		return prometheusQuery{}, fmt.Errorf("query %s does not exist", ref)
+	}
+	if isExpression, _ := query.IsExpression(); isExpression {
+		return prometheusQuery{}, fmt.Errorf("expression %s is not supported", ref)
+	}
+
+	var model struct {
+		Datasource struct {
+			Type string `json:"type"`
+		} `json:"datasource"`
+		Expr    string `json:"expr"`
+		Range   bool   `json:"range"`
+		Instant bool   `json:"instant"`
+	}
+	if err := json.Unmarshal(query.Model, &model); err != nil {
+		return prometheusQuery{}, fmt.Errorf("failed to parse query %s: %w", ref, err)
+	}
+	// Rules that were converted from Prometheus rules store the data source type as the query type.
+	if model.Datasource.Type != datasources.DS_PROMETHEUS && query.QueryType != datasources.DS_PROMETHEUS && query.DatasourceType != datasources.DS_PROMETHEUS {
+		return prometheusQuery{}, fmt.Errorf("query %s must query a Prometheus data source", ref)
+	}
+	if model.Expr == "" {
+		return prometheusQuery{}, fmt.Errorf("query %s has no expression", ref)
+	}
+	if !model.Range && !model.Instant {
+		// the Prometheus data source runs queries that set neither range nor instant as range queries.
+		return prometheusQuery{}, fmt.Errorf("query %s sets neither range nor instant and runs as a range query, only instant queries are supported", ref)
+	}
+	if model.Range {
+		return prometheusQuery{}, fmt.Errorf("query %s is a range query, only instant queries are supported", ref)
+	}
+
+	return prometheusQuery{
+		expr:   model.Expr,
+		offset: prommodel.Duration(query.RelativeTimeRange.To),
+	}, nil
+}
+
+func findQuery(data []models.AlertQuery, ref string) (models.AlertQuery, bool) {
+	for _, q := range data {
+		if q.RefID == ref {
+			return q, true
+		}
+	}
+	return models.AlertQuery{}, false
+}
+
+// parseExpression unmarshals the model of an expression of the given type.
+func parseExpression(query models.AlertQuery, queryType expr.QueryType, target any) error {
+	if isExpression, _ := query.IsExpression(); !isExpression {
+		return fmt.Errorf("%s must be a %s expression", query.RefID, queryType)
+	}
+	var common CommonQueryModel
+	if err := json.Unmarshal(query.Model, &common); err != nil {
+		return fmt.Errorf("failed to parse expression %s: %w", query.RefID, err)
+	}
+	if common.Type != queryType {
+		return fmt.Errorf("%s expressions are not supported", common.Type)
+	}
+	if err := json.Unmarshal(query.Model, target); err != nil {
+		return fmt.Errorf("failed to parse expression %s: %w", query.RefID, err)
+	}
+	return nil
+}
+
+func refID(expression string) string {
+	return strings.TrimPrefix(strings.TrimSpace(expression), "$")
+}
+
+func exportLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	result := maps.Clone(labels)
+	delete(result, models.ConvertedPrometheusRuleLabel)
+	return result
+}
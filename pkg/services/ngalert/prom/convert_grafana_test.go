@@ -0,0 +1,237 @@
+package prom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestGrafanaRulesToPrometheus(t *testing.T) {
+	promQuery := func(refID, promQL string, mutators ...func(map[string]any)) models.AlertQuery {
+		model := map[string]any{
+			"datasource": map[string]any{"type": datasources.DS_PROMETHEUS, "uid": "prom"},
+			"expr":       promQL,
+			"instant":    true,
+			"refId":      refID,
+		}
+		for _, m := range mutators {
+			m(model)
+		}
+		b, err := json.Marshal(model)
+		require.NoError(t, err)
+		return models.AlertQuery{
+			RefID:             refID,
+			DatasourceUID:     "prom",
+			Model:             b,
+			RelativeTimeRange: models.RelativeTimeRange{From: models.Duration(10 * time.Minute)},
+		}
+	}
+	expression := func(refID string, model map[string]any) models.AlertQuery {
+		model["refId"] = refID
+		b, err := json.Marshal(model)
+		require.NoError(t, err)
+		return models.AlertQuery{
+			RefID:         refID,
+			DatasourceUID: expr.DatasourceUID,
+			Model:         b,
+		}
+	}
+	reduce := func(refID, input, reducer string) models.AlertQuery {
+		return expression(refID, map[string]any{"type": "reduce", "expression": input, "reducer": reducer})
+	}
+	threshold := func(refID, input, evaluator string, params ...float64) models.AlertQuery {
+		return expression(refID, map[string]any{
+			"type":       "threshold",
+			"expression": input,
+			"conditions": []map[string]any{{"evaluator": map[string]any{"type": evaluator, "params": params}}},
+		})
+	}
+	alertRule := func(uid string, data ...models.AlertQuery) models.AlertRule {
+		return models.AlertRule{
+			UID:             uid,
+			Title:           "rule " + uid,
+			Condition:       data[len(data)-1].RefID,
+			Data:            data,
+			IntervalSeconds: 60,
+			NoDataState:     models.NoData,
+			ExecErrState:    models.ErrorErrState,
+		}
+	}
+
+	t.Run("converts alert rules with a query, reducer and threshold", func(t *testing.T) {
+		rule := alertRule("a", promQuery("A", `rate(http_requests_total{code="500"}[5m])`), reduce("B", "A", "last"), threshold("C", "B", "gt", 0.5))
+		rule.For = 5 * time.Minute
+		rule.KeepFiringFor = time.Minute
+		rule.Labels = map[string]string{"severity": "critical"}
+		rule.Annotations = map[string]string{"summary": "{{ $labels.instance }} returns errors"}
+
+		group, unsupported := GrafanaRulesToPrometheus("group", []models.AlertRule{rule})
+		require.Empty(t, unsupported)
+		require.Equal(t, PrometheusRuleGroup{
+			Name:     "group",
+			Interval: prommodel.Duration(time.Minute),
+			Rules: []PrometheusRule{
+				{
+					Alert:         "rule a",
+					Expr:          `(rate(http_requests_total{code="500"}[5m])) > 0.5`,
+					For:           util.Pointer(prommodel.Duration(5 * time.Minute)),
+					KeepFiringFor: util.Pointer(prommodel.Duration(time.Minute)),
+					Labels:        map[string]string{"severity": "critical"},
+					Annotations:   map[string]string{"summary": "{{ $labels.instance }} returns errors"},
+				},
+			},
+		}, group)
+	})
+
+	t.Run("converts thresholds to comparison operators", func(t *testing.T) {
+		testCases := []struct {
+			evaluator string
+			params    []float64
+			expected  string
+		}{
+			{evaluator: "gt", params: []float64{1}, expected: "(up) > 1"},
+			{evaluator: "lt", params: []float64{1}, expected: "(up) < 1"},
+			{evaluator: "eq", params: []float64{1}, expected: "(up) == 1"},
+			{evaluator: "ne", params: []float64{1}, expected: "(up) != 1"},
+			{evaluator: "gte", params: []float64{1.5}, expected: "(up) >= 1.5"},
+			{evaluator: "lte", params: []float64{1.5}, expected: "(up) <= 1.5"},
+			{evaluator: "within_range", params: []float64{1, 10}, expected: "(up) > 1 < 10"},
+			{evaluator: "within_range_included", params: []float64{1, 10}, expected: "(up) >= 1 <= 10"},
+			{evaluator: "outside_range", params: []float64{1, 10}, expected: "(up) < 1 or (up) > 10"},
+			{evaluator: "outside_range_included", params: []float64{1, 10}, expected: "(up) <= 1 or (up) >= 10"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.evaluator, func(t *testing.T) {
+				rule := alertRule("a", promQuery("A", "up"), threshold("B", "A", tc.evaluator, tc.params...))
+				group, unsupported := GrafanaRulesToPrometheus("group", []models.AlertRule{rule})
+				require.Empty(t, unsupported)
+				require.Len(t, group.Rules, 1)
+				require.Equal(t, tc.expected, group.Rules[0].Expr)
+			})
+		}
+	})
+
+	t.Run("converts recording rules", func(t *testing.T) {
+		rule := models.AlertRule{
+			UID:             "rec",
+			Title:           "recording",
+			Data:            []models.AlertQuery{promQuery("A", "sum(up)")},
+			IntervalSeconds: 30,
+			Labels:          map[string]string{"team": "a"},
+			Record:          &models.Record{From: "A", Metric: "job:up:sum"},
+		}
+
+		group, unsupported := GrafanaRulesToPrometheus("group", []models.AlertRule{rule})
+		require.Empty(t, unsupported)
+		require.Equal(t, []PrometheusRule{{Record: "job:up:sum", Expr: "sum(up)", Labels: map[string]string{"team": "a"}}}, group.Rules)
+	})
+
+	t.Run("uses the original rule definition of converted rules", func(t *testing.T) {
+		rule := alertRule("a", promQuery("query", "up == 0"))
+		rule.Labels = map[string]string{models.ConvertedPrometheusRuleLabel: "true"}
+		rule.Metadata.PrometheusStyleRule = &models.PrometheusStyleRule{
+			OriginalRuleDefinition: "alert: InstanceDown\nexpr: up == 0\nfor: 5m\n",
+		}
+
+		group, unsupported := GrafanaRulesToPrometheus("group", []models.AlertRule{rule})
+		require.Empty(t, unsupported)
+		require.Equal(t, []PrometheusRule{{Alert: "InstanceDown", Expr: "up == 0", For: util.Pointer(prommodel.Duration(5 * time.Minute))}}, group.Rules)
+	})
+
+	t.Run("sets the query offset of the group", func(t *testing.T) {
+		withOffset := func(q models.AlertQuery, offset time.Duration) models.AlertQuery {
+			q.RelativeTimeRange.To = models.Duration(offset)
+			return q
+		}
+		rules := []models.AlertRule{
+			alertRule("a", withOffset(promQuery("A", "up"), time.Minute), threshold("B", "A", "lt", 1)),
+			alertRule("b", withOffset(promQuery("A", "up"), time.Minute), threshold("B", "A", "gt", 1)),
+			alertRule("c", promQuery("A", "up"), threshold("B", "A", "gt", 1)),
+		}
+
+		group, unsupported := GrafanaRulesToPrometheus("group", rules)
+		require.Len(t, group.Rules, 2)
+		require.Equal(t, util.Pointer(prommodel.Duration(time.Minute)), group.QueryOffset)
+		require.Equal(t, []UnsupportedRule{{UID: "c", Title: "rule c", Reason: "the evaluation offset of the query differs from the other rules of the group"}}, unsupported)
+	})
+
+	t.Run("explains why rules cannot be converted", func(t *testing.T) {
+		rangeQuery := func(m map[string]any) {
+			m["instant"] = false
+			m["range"] = true
+		}
+		defaultQuery := func(m map[string]any) {
+			delete(m, "instant")
+		}
+		lokiQuery := func(m map[string]any) {
+			m["datasource"] = map[string]any{"type": datasources.DS_LOKI, "uid": "loki"}
+		}
+		paused := alertRule("paused", promQuery("A", "up"), threshold("B", "A", "gt", 1))
+		paused.IsPaused = true
+		noData := alertRule("nodata", promQuery("A", "up"), threshold("B", "A", "gt", 1))
+		noData.NoDataState = models.Alerting
+		execErr := alertRule("error", promQuery("A", "up"), threshold("B", "A", "gt", 1))
+		execErr.ExecErrState = models.AlertingErrState
+		values := alertRule("values", promQuery("A", "up"), threshold("B", "A", "gt", 1))
+		values.Annotations = map[string]string{"summary": "{{ $values.A }}"}
+		hysteresis := alertRule("hysteresis", promQuery("A", "up"), expression("B", map[string]any{
+			"type":       "threshold",
+			"expression": "A",
+			"conditions": []map[string]any{{
+				"evaluator":       map[string]any{"type": "gt", "params": []float64{10}},
+				"unloadEvaluator": map[string]any{"type": "lt", "params": []float64{5}},
+			}},
+		}))
+		replace := alertRule("replace", promQuery("A", "up"), expression("B", map[string]any{
+			"type":       "reduce",
+			"expression": "A",
+			"reducer":    "last",
+			"settings":   map[string]any{"mode": "replaceNN", "replaceWithValue": 0},
+		}), threshold("C", "B", "gt", 1))
+
+		rules := []models.AlertRule{
+			paused,
+			noData,
+			execErr,
+			values,
+			hysteresis,
+			replace,
+			alertRule("range", promQuery("A", "up", rangeQuery), threshold("B", "A", "gt", 1)),
+			alertRule("default", promQuery("A", "up", defaultQuery), threshold("B", "A", "gt", 1)),
+			alertRule("loki", promQuery("A", "up", lokiQuery), threshold("B", "A", "gt", 1)),
+			alertRule("math", promQuery("A", "up"), expression("B", map[string]any{"type": "math", "expression": "$A > 1"})),
+			alertRule("count", promQuery("A", "up"), reduce("B", "A", "count"), threshold("C", "B", "gt", 1)),
+			alertRule("two-queries", promQuery("A", "up"), promQuery("B", "up"), threshold("C", "A", "gt", 1)),
+		}
+
+		group, unsupported := GrafanaRulesToPrometheus("group", rules)
+		require.Empty(t, group.Rules)
+
+		reasons := make(map[string]string, len(unsupported))
+		for _, r := range unsupported {
+			reasons[r.UID] = r.Reason
+		}
+		require.Equal(t, map[string]string{
+			"paused":      "the rule is paused, which is not supported by Prometheus",
+			"nodata":      "the rule fires if there is no data, which is not supported by Prometheus",
+			"error":       "the rule fires on query errors, which is not supported by Prometheus",
+			"values":      "templates that use $values are not supported by Prometheus",
+			"hysteresis":  "thresholds with a recovery threshold are not supported",
+			"replace":     "reducers that replace non-numeric values are not supported",
+			"range":       "query A is a range query, only instant queries are supported",
+			"default":     "query A sets neither range nor instant and runs as a range query, only instant queries are supported",
+			"loki":        "query A must query a Prometheus data source",
+			"math":        "math expressions are not supported",
+			"count":       "reducer count is not supported",
+			"two-queries": "only a single query, an optional reduce expression and a threshold are supported",
+		}, reasons)
+	})
+}
@@ -0,0 +1,342 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// RuleTemplateParametersQuerier provides the parameter sets of alert rule templates whose source is a query.
+type RuleTemplateParametersQuerier interface {
+	QueryParameters(ctx context.Context, user identity.Requester, t models.AlertRuleTemplate) ([]map[string]string, error)
+}
+
+// AlertRuleTemplateService manages alert rule templates and the rule groups they generate.
+// Every change of a template is reconciled with its rule group in the same transaction.
+type AlertRuleTemplateService struct {
+	store   AlertRuleTemplateStore
+	rules   *AlertRuleService
+	querier RuleTemplateParametersQuerier
+	xact    TransactionManager
+	log     log.Logger
+}
+
+func NewAlertRuleTemplateService(store AlertRuleTemplateStore, rules *AlertRuleService, querier RuleTemplateParametersQuerier, xact TransactionManager, log log.Logger) *AlertRuleTemplateService {
+	return &AlertRuleTemplateService{
+		store:   store,
+		rules:   rules,
+		querier: querier,
+		xact:    xact,
+		log:     log,
+	}
+}
+
+// GetTemplates returns the alert rule templates of the organization in folders the user can read.
+func (service *AlertRuleTemplateService) GetTemplates(ctx context.Context, user identity.Requester) ([]models.AlertRuleTemplate, error) {
+	templates, err := service.store.ListAlertRuleTemplates(ctx, user.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+	can, err := service.rules.authz.CanReadAllRules(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if can {
+		return templates, nil
+	}
+	result := make([]models.AlertRuleTemplate, 0, len(templates))
+	for _, t := range templates {
+		ok, err := service.rules.authz.HasAccessInFolder(ctx, user, &t)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// GetTemplate returns the alert rule template with the UID.
+func (service *AlertRuleTemplateService) GetTemplate(ctx context.Context, user identity.Requester, uid string) (models.AlertRuleTemplate, error) {
+	t, err := service.store.GetAlertRuleTemplate(ctx, user.GetOrgID(), uid)
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	if err := service.authorizeRead(ctx, user, t); err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	return t, nil
+}
+
+// CreateTemplate stores a new alert rule template and generates its rule group.
+// The rule group must not exist or must be empty.
+func (service *AlertRuleTemplateService) CreateTemplate(ctx context.Context, user identity.Requester, t models.AlertRuleTemplate) (models.AlertRuleTemplate, error) {
+	t.OrgID = user.GetOrgID()
+	if t.IntervalSeconds == 0 {
+		t.IntervalSeconds = service.rules.defaultIntervalSeconds
+	}
+	if err := t.Validate(); err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	var created models.AlertRuleTemplate
+	err := service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = service.store.InsertAlertRuleTemplate(ctx, t)
+		if err != nil {
+			return err
+		}
+		return service.reconcile(ctx, user, created)
+	})
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	return created, nil
+}
+
+// UpdateTemplate updates the alert rule template and reconciles its rule group. The version of the template must match
+// the stored one. If the template is moved to another rule group, the rules of the previous group are deleted.
+func (service *AlertRuleTemplateService) UpdateTemplate(ctx context.Context, user identity.Requester, t models.AlertRuleTemplate) (models.AlertRuleTemplate, error) {
+	t.OrgID = user.GetOrgID()
+	if t.IntervalSeconds == 0 {
+		t.IntervalSeconds = service.rules.defaultIntervalSeconds
+	}
+	if err := t.Validate(); err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	var updated models.AlertRuleTemplate
+	err := service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		existing, err := service.store.GetAlertRuleTemplate(ctx, t.OrgID, t.UID)
+		if err != nil {
+			return err
+		}
+		if err := service.authorizeRead(ctx, user, existing); err != nil {
+			return err
+		}
+		updated, err = service.store.UpdateAlertRuleTemplate(ctx, t)
+		if err != nil {
+			return err
+		}
+		if existing.GetGroupKey() != updated.GetGroupKey() {
+			if err := service.rules.DeleteRuleGroup(ctx, user, existing.FolderUID, existing.RuleGroup, models.ProvenanceAPI); err != nil {
+				return fmt.Errorf("failed to delete rules of the previous rule group: %w", err)
+			}
+		}
+		return service.reconcile(ctx, user, updated)
+	})
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	return updated, nil
+}
+
+// DeleteTemplate deletes the alert rule template and the rule group it generated.
+func (service *AlertRuleTemplateService) DeleteTemplate(ctx context.Context, user identity.Requester, uid string) error {
+	return service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		t, err := service.store.GetAlertRuleTemplate(ctx, user.GetOrgID(), uid)
+		if err != nil {
+			return err
+		}
+		if err := service.authorizeRead(ctx, user, t); err != nil {
+			return err
+		}
+		if err := service.store.DeleteAlertRuleTemplate(ctx, t.OrgID, t.UID); err != nil {
+			return err
+		}
+		return service.rules.DeleteRuleGroup(ctx, user, t.FolderUID, t.RuleGroup, models.ProvenanceAPI)
+	})
+}
+
+// ReconcileTemplate generates the rules of the template again. This is needed for templates whose source is a query,
+// because their parameter sets change when the results of the query change. Such templates are also reconciled
+// periodically by RunReconciler.
+func (service *AlertRuleTemplateService) ReconcileTemplate(ctx context.Context, user identity.Requester, uid string) (models.AlertRuleTemplate, error) {
+	t, err := service.GetTemplate(ctx, user, uid)
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	err = service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		return service.reconcile(ctx, user, t)
+	})
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	return t, nil
+}
+
+// OrgReader lists the IDs of all organizations.
+type OrgReader interface {
+	FetchOrgIds(ctx context.Context) ([]int64, error)
+}
+
+// RunReconciler reconciles the templates whose source is a query at every interval until the context is cancelled,
+// so that their rule groups follow the results of the query.
+func (service *AlertRuleTemplateService) RunReconciler(ctx context.Context, orgs OrgReader, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			orgIDs, err := orgs.FetchOrgIds(ctx)
+			if err != nil {
+				service.log.Error("Failed to fetch organizations to reconcile alert rule templates", "error", err)
+				continue
+			}
+			for _, orgID := range orgIDs {
+				service.reconcileQueryTemplates(ctx, orgID)
+			}
+		}
+	}
+}
+
+// reconcileQueryTemplates reconciles the templates of the organization whose source is a query as the service identity.
+// A template that fails to reconcile is logged and does not stop the others.
+func (service *AlertRuleTemplateService) reconcileQueryTemplates(ctx context.Context, orgID int64) {
+	ctx, user := identity.WithServiceIdentity(ctx, orgID)
+	logger := service.log.New("org_id", orgID)
+	templates, err := service.store.ListAlertRuleTemplates(ctx, orgID)
+	if err != nil {
+		logger.Error("Failed to list alert rule templates", "error", err)
+		return
+	}
+	for _, t := range templates {
+		if t.Source.Query == nil {
+			continue
+		}
+		err := service.xact.InTransaction(ctx, func(ctx context.Context) error {
+			return service.reconcile(ctx, user, t)
+		})
+		if err != nil {
+			logger.Warn("Failed to reconcile alert rule template", "template_uid", t.UID, "error", err)
+		}
+	}
+}
+
+// reconcile replaces the rule group of the template with the rules generated from its current parameter sets.
+// Rules generated from the same parameter set keep their UID, so their state and history are preserved.
+// If the query of the template provides no parameter sets, for example because it returned no data, the rule group
+// is kept and ErrAlertRuleTemplateNoParameterSets is returned, unless the query allows an empty result.
+func (service *AlertRuleTemplateService) reconcile(ctx context.Context, user identity.Requester, t models.AlertRuleTemplate) error {
+	paramSets := t.Source.Static
+	if t.Source.Query != nil {
+		if service.querier == nil {
+			return errors.New("alert rule templates with a query source are not supported")
+		}
+		var err error
+		paramSets, err = service.querier.QueryParameters(ctx, user, t)
+		if err != nil {
+			return fmt.Errorf("failed to query parameters of alert rule template %s: %w", t.UID, err)
+		}
+		if len(paramSets) == 0 && !t.Source.Query.AllowEmpty {
+			return models.ErrAlertRuleTemplateNoParameterSets.Errorf("query of alert rule template %s returned no parameter sets", t.UID)
+		}
+	}
+	rules, err := t.Generate(paramSets)
+	if err != nil {
+		return err
+	}
+
+	existing, err := service.rules.ruleStore.ListAlertRules(ctx, &models.ListAlertRulesQuery{
+		OrgID:         t.OrgID,
+		NamespaceUIDs: []string{t.FolderUID},
+		RuleGroups:    []string{t.RuleGroup},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list alert rules: %w", err)
+	}
+	uids := make(map[string]string, len(existing))
+	for _, r := range existing {
+		if r.Metadata.RuleTemplate == nil || r.Metadata.RuleTemplate.UID != t.UID {
+			return models.MakeErrAlertRuleTemplateInvalid(fmt.Errorf("rule group %q contains rules that are not generated by the template", t.RuleGroup))
+		}
+		uids[models.RuleTemplateParametersKey(r.Metadata.RuleTemplate.Parameters)] = r.UID
+	}
+	for i := range rules {
+		rules[i].UID = uids[models.RuleTemplateParametersKey(rules[i].Metadata.RuleTemplate.Parameters)]
+	}
+
+	service.log.Debug("Reconciling rule group of alert rule template", "template_uid", t.UID, "folder_uid", t.FolderUID, "group", t.RuleGroup, "rules", len(rules))
+	group := models.AlertRuleGroup{
+		Title:     t.RuleGroup,
+		FolderUID: t.FolderUID,
+		Interval:  t.IntervalSeconds,
+		Rules:     rules,
+	}
+	return service.rules.ReplaceRuleGroup(ctx, user, group, models.ProvenanceAPI, fmt.Sprintf("Generated by alert rule template %q version %d", t.Title, t.Version))
+}
+
+func (service *AlertRuleTemplateService) authorizeRead(ctx context.Context, user identity.Requester, t models.AlertRuleTemplate) error {
+	can, err := service.rules.authz.CanReadAllRules(ctx, user)
+	if err != nil || can {
+		return err
+	}
+	ok, err := service.rules.authz.HasAccessInFolder(ctx, user, &t)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return accesscontrol.NewAuthorizationErrorGeneric(fmt.Sprintf("access alert rule template '%s'", t.UID))
+	}
+	return nil
+}
+
+// EvalRuleTemplateParametersQuerier evaluates the source query of alert rule templates and returns a parameter set
+// for every distinct combination of values of the parameter labels of the resulting series.
+type EvalRuleTemplateParametersQuerier struct {
+	factory eval.EvaluatorFactory
+}
+
+func NewEvalRuleTemplateParametersQuerier(factory eval.EvaluatorFactory) *EvalRuleTemplateParametersQuerier {
+	return &EvalRuleTemplateParametersQuerier{factory: factory}
+}
+
+func (q *EvalRuleTemplateParametersQuerier) QueryParameters(ctx context.Context, user identity.Requester, t models.AlertRuleTemplate) ([]map[string]string, error) {
+	if t.Source.Query == nil {
+		return nil, errors.New("template does not have a query source")
+	}
+	condition := models.Condition{
+		Condition: t.Source.Query.Condition,
+		Data:      t.Source.Query.Data,
+	}
+	evaluator, err := q.factory.Create(eval.NewContext(ctx, user), condition)
+	if err != nil {
+		return nil, err
+	}
+	results, err := evaluator.Evaluate(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return parameterSetsFromResults(t.Parameters, results)
+}
+
+// parameterSetsFromResults returns the values of the parameter labels of the results.
+// Results that do not have all parameter labels are ignored.
+func parameterSetsFromResults(parameters []string, results eval.Results) ([]map[string]string, error) {
+	paramSets := make([]map[string]string, 0, len(results))
+	for _, r := range results {
+		if r.State == eval.Error {
+			return nil, r.Error
+		}
+		params := make(map[string]string, len(parameters))
+		for _, p := range parameters {
+			v, ok := r.Instance[p]
+			if !ok {
+				break
+			}
+			params[p] = v
+		}
+		if len(params) == len(parameters) {
+			paramSets = append(paramSets, params)
+		}
+	}
+	return paramSets, nil
+}
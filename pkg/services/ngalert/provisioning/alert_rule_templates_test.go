@@ -0,0 +1,69 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func TestParameterSetsFromResults(t *testing.T) {
+	parameters := []string{"service", "team"}
+
+	t.Run("should return the values of the parameter labels", func(t *testing.T) {
+		results := eval.Results{
+			{State: eval.Normal, Instance: data.Labels{"service": "checkout", "team": "payments", "instance": "a"}},
+			{State: eval.Alerting, Instance: data.Labels{"service": "cart", "team": "shop"}},
+			{State: eval.Normal, Instance: data.Labels{"service": "search"}},
+			{State: eval.NoData},
+		}
+		paramSets, err := parameterSetsFromResults(parameters, results)
+		require.NoError(t, err)
+		require.Equal(t, []map[string]string{
+			{"service": "checkout", "team": "payments"},
+			{"service": "cart", "team": "shop"},
+		}, paramSets)
+	})
+
+	t.Run("should fail if the query failed", func(t *testing.T) {
+		expectedErr := errors.New("query failed")
+		results := eval.Results{
+			{State: eval.Normal, Instance: data.Labels{"service": "checkout", "team": "payments"}},
+			{State: eval.Error, Error: expectedErr},
+		}
+		_, err := parameterSetsFromResults(parameters, results)
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
+
+type fakeRuleTemplateParametersQuerier struct {
+	paramSets []map[string]string
+}
+
+func (q fakeRuleTemplateParametersQuerier) QueryParameters(context.Context, identity.Requester, models.AlertRuleTemplate) ([]map[string]string, error) {
+	return q.paramSets, nil
+}
+
+func TestReconcileQueryTemplateWithoutParameterSets(t *testing.T) {
+	service := &AlertRuleTemplateService{
+		querier: fakeRuleTemplateParametersQuerier{},
+		log:     log.NewNopLogger(),
+	}
+	template := models.AlertRuleTemplate{
+		UID: "template",
+		Source: models.AlertRuleTemplateSource{
+			Query: &models.AlertRuleTemplateQuery{Condition: "A"},
+		},
+	}
+	// The rule group is not read or replaced, because the service has no rule service.
+	err := service.reconcile(context.Background(), &user.SignedInUser{OrgID: 1}, template)
+	require.ErrorIs(t, err, models.ErrAlertRuleTemplateNoParameterSets)
+}
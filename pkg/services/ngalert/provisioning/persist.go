@@ -47,3 +47,23 @@ type RuleStore interface {
 type QuotaChecker interface {
 	CheckQuotaReached(ctx context.Context, target quota.TargetSrv, scopeParams *quota.ScopeParameters) (bool, error)
 }
+
+// AlertRuleTemplateStore represents the ability to persist and query alert rule templates.
+type AlertRuleTemplateStore interface {
+	GetAlertRuleTemplate(ctx context.Context, orgID int64, uid string) (models.AlertRuleTemplate, error)
+	ListAlertRuleTemplates(ctx context.Context, orgID int64) ([]models.AlertRuleTemplate, error)
+	InsertAlertRuleTemplate(ctx context.Context, t models.AlertRuleTemplate) (models.AlertRuleTemplate, error)
+	UpdateAlertRuleTemplate(ctx context.Context, t models.AlertRuleTemplate) (models.AlertRuleTemplate, error)
+	DeleteAlertRuleTemplate(ctx context.Context, orgID int64, uid string) error
+}
+
+// TemplateLibraryStore represents the ability to persist and query the notification template libraries
+// shared by all organizations.
+type TemplateLibraryStore interface {
+	ListTemplateLibraries(ctx context.Context) ([]models.TemplateLibrary, error)
+	GetTemplateLibrary(ctx context.Context, uid string) (models.TemplateLibrary, error)
+	GetTemplateLibraryVersions(ctx context.Context, uid string) ([]models.TemplateLibraryVersion, error)
+	InsertTemplateLibrary(ctx context.Context, l models.TemplateLibrary) (models.TemplateLibrary, error)
+	UpdateTemplateLibrary(ctx context.Context, l models.TemplateLibrary) (models.TemplateLibrary, error)
+	DeleteTemplateLibrary(ctx context.Context, uid string) error
+}
@@ -0,0 +1,146 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// TemplateLibraryService manages the notification template libraries that are shared by all organizations.
+// The templates of the libraries are added to the Alertmanager configuration of every organization.
+type TemplateLibraryService struct {
+	store TemplateLibraryStore
+	xact  TransactionManager
+	log   log.Logger
+}
+
+func NewTemplateLibraryService(store TemplateLibraryStore, xact TransactionManager, log log.Logger) *TemplateLibraryService {
+	return &TemplateLibraryService{
+		store: store,
+		xact:  xact,
+		log:   log,
+	}
+}
+
+// GetLibraries returns all template libraries ordered by name.
+func (service *TemplateLibraryService) GetLibraries(ctx context.Context) ([]models.TemplateLibrary, error) {
+	return service.store.ListTemplateLibraries(ctx)
+}
+
+// GetLibrary returns the template library with the UID.
+func (service *TemplateLibraryService) GetLibrary(ctx context.Context, uid string) (models.TemplateLibrary, error) {
+	return service.store.GetTemplateLibrary(ctx, uid)
+}
+
+// GetLibraryVersions returns all versions of the template library, the latest first.
+func (service *TemplateLibraryService) GetLibraryVersions(ctx context.Context, uid string) ([]models.TemplateLibraryVersion, error) {
+	if _, err := service.store.GetTemplateLibrary(ctx, uid); err != nil {
+		return nil, err
+	}
+	return service.store.GetTemplateLibraryVersions(ctx, uid)
+}
+
+// CreateLibrary stores a new template library.
+func (service *TemplateLibraryService) CreateLibrary(ctx context.Context, l models.TemplateLibrary) (models.TemplateLibrary, error) {
+	if err := validateTemplateLibrary(&l); err != nil {
+		return models.TemplateLibrary{}, err
+	}
+	var created models.TemplateLibrary
+	err := service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := service.checkTemplateNames(ctx, l); err != nil {
+			return err
+		}
+		var err error
+		created, err = service.store.InsertTemplateLibrary(ctx, l)
+		return err
+	})
+	if err != nil {
+		return models.TemplateLibrary{}, err
+	}
+	service.log.Info("Created template library", "uid", created.UID, "name", created.Name, "templates", len(created.Templates))
+	return created, nil
+}
+
+// UpdateLibrary updates the template library and creates a new version of it.
+// The version of the library must match the stored one.
+func (service *TemplateLibraryService) UpdateLibrary(ctx context.Context, l models.TemplateLibrary) (models.TemplateLibrary, error) {
+	if err := validateTemplateLibrary(&l); err != nil {
+		return models.TemplateLibrary{}, err
+	}
+	var updated models.TemplateLibrary
+	err := service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := service.checkTemplateNames(ctx, l); err != nil {
+			return err
+		}
+		var err error
+		updated, err = service.store.UpdateTemplateLibrary(ctx, l)
+		return err
+	})
+	if err != nil {
+		return models.TemplateLibrary{}, err
+	}
+	service.log.Info("Updated template library", "uid", updated.UID, "name", updated.Name, "version", updated.Version)
+	return updated, nil
+}
+
+// DeleteLibrary deletes the template library and all its versions.
+func (service *TemplateLibraryService) DeleteLibrary(ctx context.Context, uid string) error {
+	if _, err := service.store.GetTemplateLibrary(ctx, uid); err != nil {
+		return err
+	}
+	if err := service.store.DeleteTemplateLibrary(ctx, uid); err != nil {
+		return err
+	}
+	service.log.Info("Deleted template library", "uid", uid)
+	return nil
+}
+
+// checkTemplateNames checks that no other library has a template with the same name as a template of the library,
+// because the templates of all libraries are added to the same configuration.
+func (service *TemplateLibraryService) checkTemplateNames(ctx context.Context, l models.TemplateLibrary) error {
+	libraries, err := service.store.ListTemplateLibraries(ctx)
+	if err != nil {
+		return err
+	}
+	for _, other := range libraries {
+		if other.UID == l.UID {
+			continue
+		}
+		for name := range l.Templates {
+			if _, ok := other.Templates[name]; ok {
+				return models.MakeErrTemplateLibraryInvalid(fmt.Errorf("template %q is already defined by library %q", name, other.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// validateTemplateLibrary validates the templates of the library in the same way as the templates of organizations,
+// and normalizes their content.
+func validateTemplateLibrary(l *models.TemplateLibrary) error {
+	var errs []error
+	if l.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if len(l.Templates) == 0 {
+		errs = append(errs, errors.New("at least one template is required"))
+	}
+	templates := make(map[string]string, len(l.Templates))
+	for _, name := range l.TemplateNames() {
+		tmpl := definitions.NotificationTemplate{Name: name, Template: l.Templates[name]}
+		if err := tmpl.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("template %q: %w", name, err))
+			continue
+		}
+		templates[name] = tmpl.Template
+	}
+	if len(errs) > 0 {
+		return models.MakeErrTemplateLibraryInvalid(errors.Join(errs...))
+	}
+	l.Templates = templates
+	return nil
+}
@@ -0,0 +1,117 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type fakeTemplateLibraryStore struct {
+	libraries []models.TemplateLibrary
+	inserted  []models.TemplateLibrary
+}
+
+func (f *fakeTemplateLibraryStore) ListTemplateLibraries(context.Context) ([]models.TemplateLibrary, error) {
+	return f.libraries, nil
+}
+
+func (f *fakeTemplateLibraryStore) GetTemplateLibrary(_ context.Context, uid string) (models.TemplateLibrary, error) {
+	for _, l := range f.libraries {
+		if l.UID == uid {
+			return l, nil
+		}
+	}
+	return models.TemplateLibrary{}, models.ErrTemplateLibraryNotFound.Errorf("")
+}
+
+func (f *fakeTemplateLibraryStore) GetTemplateLibraryVersions(context.Context, string) ([]models.TemplateLibraryVersion, error) {
+	return nil, nil
+}
+
+func (f *fakeTemplateLibraryStore) InsertTemplateLibrary(_ context.Context, l models.TemplateLibrary) (models.TemplateLibrary, error) {
+	l.UID = "new-uid"
+	l.Version = 1
+	f.inserted = append(f.inserted, l)
+	return l, nil
+}
+
+func (f *fakeTemplateLibraryStore) UpdateTemplateLibrary(_ context.Context, l models.TemplateLibrary) (models.TemplateLibrary, error) {
+	l.Version++
+	return l, nil
+}
+
+func (f *fakeTemplateLibraryStore) DeleteTemplateLibrary(context.Context, string) error {
+	return nil
+}
+
+func TestTemplateLibraryService(t *testing.T) {
+	existing := models.TemplateLibrary{
+		UID:       "email-uid",
+		Name:      "email",
+		Templates: map[string]string{"email": `{{ define "email.subject" }}{{ .CommonLabels.alertname }}{{ end }}`},
+		Version:   1,
+	}
+	newService := func() (*TemplateLibraryService, *fakeTemplateLibraryStore) {
+		store := &fakeTemplateLibraryStore{libraries: []models.TemplateLibrary{existing}}
+		return NewTemplateLibraryService(store, newNopTransactionManager(), log.NewNopLogger()), store
+	}
+
+	t.Run("create should normalize templates", func(t *testing.T) {
+		service, store := newService()
+		created, err := service.CreateLibrary(context.Background(), models.TemplateLibrary{
+			Name:      "slack",
+			Templates: map[string]string{"slack.title": "{{ .CommonLabels.alertname }}"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "new-uid", created.UID)
+		require.Len(t, store.inserted, 1)
+		require.Equal(t, "{{ define \"slack.title\" }}\n  {{ .CommonLabels.alertname }}\n{{ end }}", created.Templates["slack.title"])
+	})
+
+	t.Run("create should fail if template is invalid", func(t *testing.T) {
+		service, _ := newService()
+		_, err := service.CreateLibrary(context.Background(), models.TemplateLibrary{
+			Name:      "slack",
+			Templates: map[string]string{"slack": `{{ define "slack.title" }}{{ .CommonLabels.alertname }`},
+		})
+		require.ErrorIs(t, err, models.ErrTemplateLibraryInvalidBase)
+	})
+
+	t.Run("create should fail if name or templates are missing", func(t *testing.T) {
+		service, _ := newService()
+		_, err := service.CreateLibrary(context.Background(), models.TemplateLibrary{})
+		require.ErrorIs(t, err, models.ErrTemplateLibraryInvalidBase)
+		require.ErrorContains(t, err, "name is required")
+		require.ErrorContains(t, err, "at least one template is required")
+	})
+
+	t.Run("create should fail if another library has a template with the same name", func(t *testing.T) {
+		service, store := newService()
+		_, err := service.CreateLibrary(context.Background(), models.TemplateLibrary{
+			Name:      "other",
+			Templates: map[string]string{"email": `{{ define "other" }}{{ end }}`},
+		})
+		require.ErrorIs(t, err, models.ErrTemplateLibraryInvalidBase)
+		require.ErrorContains(t, err, `template "email" is already defined by library "email"`)
+		require.Empty(t, store.inserted)
+	})
+
+	t.Run("update can keep the names of its own templates", func(t *testing.T) {
+		service, _ := newService()
+		l := existing
+		l.Templates = map[string]string{"email": `{{ define "email.subject" }}[{{ .Status }}] {{ .CommonLabels.alertname }}{{ end }}`}
+		updated, err := service.UpdateLibrary(context.Background(), l)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, updated.Version)
+	})
+
+	t.Run("delete should fail if library does not exist", func(t *testing.T) {
+		service, _ := newService()
+		err := service.DeleteLibrary(context.Background(), "unknown")
+		require.ErrorIs(t, err, models.ErrTemplateLibraryNotFound)
+	})
+}
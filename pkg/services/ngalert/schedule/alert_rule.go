@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/url"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/atomic"
 
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
@@ -60,6 +62,7 @@ func newRuleFactory(
 	sender AlertsSender,
 	stateManager *state.Manager,
 	evalFactory eval.EvaluatorFactory,
+	budgets EvaluationBudgetProvider,
 	clock clock.Clock,
 	rrCfg setting.RecordingRuleSettings,
 	met *metrics.Scheduler,
@@ -96,6 +99,7 @@ func newRuleFactory(
 			sender,
 			stateManager,
 			evalFactory,
+			budgets,
 			clock,
 			met,
 			logger,
@@ -110,6 +114,15 @@ func newRuleFactory(
 type evalAppliedFunc = func(ngmodels.AlertRuleKey, time.Time)
 type stopAppliedFunc = func(ngmodels.AlertRuleKey)
 
+// EvaluationBudgetProvider provides the budget of a single evaluation of an alert rule in an organization.
+type EvaluationBudgetProvider interface {
+	EvaluationBudget(orgID int64) ngmodels.EvaluationBudget
+}
+
+// maxThrottledEvaluations is the maximum number of consecutive evaluations that are skipped
+// when a rule exceeds the evaluation budget.
+const maxThrottledEvaluations = 10
+
 type alertRule struct {
 	key                ngmodels.AlertRuleKeyWithGroup
 	currentFingerprint fingerprint
@@ -127,6 +140,12 @@ type alertRule struct {
 	sender       AlertsSender
 	stateManager *state.Manager
 	evalFactory  eval.EvaluatorFactory
+	budgets      EvaluationBudgetProvider
+
+	// lastCost is the cost of the last evaluation of the rule.
+	lastCost *atomic.Pointer[ngmodels.EvaluationCost]
+	// throttledEvaluations is the number of next evaluations that are skipped because the rule exceeded the evaluation budget.
+	throttledEvaluations int64
 
 	// Event hooks that are only used in tests.
 	evalAppliedHook evalAppliedFunc
@@ -147,6 +166,7 @@ func newAlertRule(
 	sender AlertsSender,
 	stateManager *state.Manager,
 	evalFactory eval.EvaluatorFactory,
+	budgets EvaluationBudgetProvider,
 	clock clock.Clock,
 	met *metrics.Scheduler,
 	logger log.Logger,
@@ -173,6 +193,8 @@ func newAlertRule(
 		sender:               sender,
 		stateManager:         stateManager,
 		evalFactory:          evalFactory,
+		budgets:              budgets,
+		lastCost:             atomic.NewPointer[ngmodels.EvaluationCost](nil),
 		evalAppliedHook:      evalAppliedHook,
 		stopAppliedHook:      stopAppliedHook,
 		metrics:              met,
@@ -193,7 +215,11 @@ func (a *alertRule) Type() ngmodels.RuleType {
 }
 
 func (a *alertRule) Status() ngmodels.RuleStatus {
-	return a.stateManager.GetStatusForRuleUID(context.Background(), a.key.OrgID, a.key.UID)
+	status := a.stateManager.GetStatusForRuleUID(context.Background(), a.key.OrgID, a.key.UID)
+	if cost := a.lastCost.Load(); cost != nil {
+		status.EvaluationCost = *cost
+	}
+	return status
 }
 
 // eval signals the rule evaluation routine to perform the evaluation of the rule. Does nothing if the loop is stopped.
@@ -269,6 +295,8 @@ func (a *alertRule) Run() error {
 			// clear the state. So the next evaluation will start from the scratch.
 			a.resetState(grafanaCtx, ctx.rule, ctx.rule.IsPaused)
 			a.currentFingerprint = fp
+			// the new version of the rule might not exceed the budget.
+			a.throttledEvaluations = 0
 		// evalCh - used by the scheduler to signal that evaluation is needed.
 		case ctx, ok := <-a.evalCh:
 			if !ok {
@@ -279,6 +307,19 @@ func (a *alertRule) Run() error {
 			logger := a.logger.New("version", ctx.rule.Version, "fingerprint", f, "now", ctx.scheduledAt)
 			logger.Debug("Processing tick")
 
+			if a.throttledEvaluations > 0 && a.currentFingerprint == f {
+				a.throttledEvaluations--
+				logger.Debug("Skip rule evaluation because the rule exceeded the evaluation budget", "remainingSkippedEvaluations", a.throttledEvaluations)
+				a.metrics.EvalThrottled.WithLabelValues(fmt.Sprint(a.key.OrgID)).Inc()
+				a.evalApplied(ctx.scheduledAt)
+				if ctx.afterEval != nil {
+					ctx.afterEval()
+				}
+				continue
+			}
+			// a new version of the rule is evaluated right away.
+			a.throttledEvaluations = 0
+
 			retryer := newExponentialBackoffRetryer(
 				a.retryConfig.MaxAttempts-1, // First attempt is not a retry.
 				a.retryConfig.InitialRetryDelay,
@@ -415,11 +456,18 @@ func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span
 		dur = a.clock.Now().Sub(start)
 		logger.Error("Failed to build rule evaluator", "error", err)
 	} else {
-		results, err = ruleEval.Evaluate(ctx, e.scheduledAt)
+		var cost ngmodels.EvaluationCost
+		results, cost, err = ruleEval.EvaluateWithCost(ctx, e.scheduledAt)
 		dur = a.clock.Now().Sub(start)
 		if err != nil {
 			logger.Error("Failed to evaluate rule", "error", err, "duration", dur)
 		}
+		a.recordCost(cost)
+		if err == nil {
+			if budgetErr := a.checkBudget(cost, logger); budgetErr != nil {
+				results = eval.Results{eval.NewResultFromError(budgetErr, e.scheduledAt, dur)}
+			}
+		}
 	}
 
 	evalAttemptTotal.Inc()
@@ -494,12 +542,49 @@ func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span
 	return nil
 }
 
+// recordCost stores the cost of the evaluation for the status of the rule and updates the metrics.
+func (a *alertRule) recordCost(cost ngmodels.EvaluationCost) {
+	a.lastCost.Store(&cost)
+	orgID := fmt.Sprint(a.key.OrgID)
+	a.metrics.EvalSeries.WithLabelValues(orgID).Add(float64(cost.Series()))
+	a.metrics.EvalDataPoints.WithLabelValues(orgID).Add(float64(cost.DataPoints()))
+	a.metrics.EvalBytes.WithLabelValues(orgID).Add(float64(cost.Bytes()))
+}
+
+// checkBudget compares the cost of the evaluation with the evaluation budget of the organization.
+// If the cost exceeds the budget, it either returns an error that replaces the results of the evaluation,
+// or skips the next evaluations of the rule in proportion to how much the budget was exceeded.
+func (a *alertRule) checkBudget(cost ngmodels.EvaluationCost, logger log.Logger) error {
+	if a.budgets == nil {
+		return nil
+	}
+	budget := a.budgets.EvaluationBudget(a.key.OrgID)
+	if budget.IsUnlimited() {
+		return nil
+	}
+	ratio, exceeded := budget.Usage(cost)
+	if ratio <= 1 {
+		return nil
+	}
+	a.metrics.EvalBudgetExceeded.WithLabelValues(fmt.Sprint(a.key.OrgID), string(budget.Action)).Inc()
+	if budget.Action == ngmodels.EvaluationBudgetActionThrottle {
+		a.throttledEvaluations = min(int64(math.Ceil(ratio))-1, maxThrottledEvaluations)
+		logger.Warn("Rule evaluation exceeded the evaluation budget. Skipping the next evaluations", "exceeded", exceeded, "skippedEvaluations", a.throttledEvaluations)
+		return nil
+	}
+	logger.Warn("Rule evaluation exceeded the evaluation budget", "exceeded", exceeded)
+	return fmt.Errorf("%w: %s", eval.ErrEvaluationBudgetExceeded, exceeded)
+}
+
 // send sends alerts for the given state transitions.
 func (a *alertRule) send(ctx context.Context, logger log.Logger, states state.StateTransitions) definitions.PostableAlerts {
 	alerts := definitions.PostableAlerts{PostableAlerts: make([]models.PostableAlert, 0, len(states))}
 	for _, alertState := range states {
 		alerts.PostableAlerts = append(alerts.PostableAlerts, *state.StateToPostableAlert(alertState, a.appURL, a.featureToggles))
 	}
+	// Alerts with the previous severity are resolved, as the severity label is part of the identity of the alert.
+	stopped := state.FromSeverityChangesToStoppedAlert(states, a.appURL, a.clock, a.featureToggles)
+	alerts.PostableAlerts = append(alerts.PostableAlerts, stopped.PostableAlerts...)
 
 	if len(alerts.PostableAlerts) > 0 {
 		logger.Debug("Sending transitions to notifier", "transitions", len(alerts.PostableAlerts))
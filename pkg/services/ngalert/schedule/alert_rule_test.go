@@ -29,6 +29,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/util"
@@ -510,7 +511,55 @@ func blankRuleForTests(ctx context.Context, key models.AlertRuleKeyWithGroup) *a
 		RuleGroup: key.RuleGroup,
 	}
 	rf := ruleWithFolder{rule: rule, folderTitle: ""}
-	return newAlertRule(ctx, rf, nil, false, RetryConfig{}, nil, st, nil, nil, nil, log.NewNopLogger(), nil, featuremgmt.WithFeatures(), nil, nil)
+	return newAlertRule(ctx, rf, nil, false, RetryConfig{}, nil, st, nil, nil, nil, nil, log.NewNopLogger(), nil, featuremgmt.WithFeatures(), nil, nil)
+}
+
+type evaluationBudgetFunc func(orgID int64) models.EvaluationBudget
+
+func (f evaluationBudgetFunc) EvaluationBudget(orgID int64) models.EvaluationBudget {
+	return f(orgID)
+}
+
+func TestAlertRuleEvaluationBudget(t *testing.T) {
+	cost := models.EvaluationCost{{RefID: "A", Series: 25, Duration: time.Second}}
+	newRule := func(budget models.EvaluationBudget) *alertRule {
+		r := blankRuleForTests(context.Background(), models.GenerateRuleKeyWithGroup(1))
+		r.metrics = metrics.NewSchedulerMetrics(prometheus.NewRegistry())
+		r.budgets = evaluationBudgetFunc(func(int64) models.EvaluationBudget { return budget })
+		return r
+	}
+
+	t.Run("should store the cost for the status of the rule", func(t *testing.T) {
+		r := newRule(models.EvaluationBudget{})
+		r.recordCost(cost)
+		require.Equal(t, cost, r.Status().EvaluationCost)
+	})
+
+	t.Run("should not fail if the cost is within the budget", func(t *testing.T) {
+		r := newRule(models.EvaluationBudget{MaxSeries: 25, Action: models.EvaluationBudgetActionReject})
+		require.NoError(t, r.checkBudget(cost, log.NewNopLogger()))
+		require.Zero(t, r.throttledEvaluations)
+	})
+
+	t.Run("should reject the evaluation if the budget is exceeded", func(t *testing.T) {
+		r := newRule(models.EvaluationBudget{MaxSeries: 10, Action: models.EvaluationBudgetActionReject})
+		err := r.checkBudget(cost, log.NewNopLogger())
+		require.ErrorIs(t, err, eval.ErrEvaluationBudgetExceeded)
+		require.True(t, eval.IsNonRetryableError(err))
+		require.Zero(t, r.throttledEvaluations)
+	})
+
+	t.Run("should skip evaluations in proportion to the exceeded budget", func(t *testing.T) {
+		r := newRule(models.EvaluationBudget{MaxSeries: 10, Action: models.EvaluationBudgetActionThrottle})
+		require.NoError(t, r.checkBudget(cost, log.NewNopLogger()))
+		require.EqualValues(t, 2, r.throttledEvaluations)
+	})
+
+	t.Run("should skip at most maxThrottledEvaluations evaluations", func(t *testing.T) {
+		r := newRule(models.EvaluationBudget{MaxQueryTime: time.Millisecond, Action: models.EvaluationBudgetActionThrottle})
+		require.NoError(t, r.checkBudget(cost, log.NewNopLogger()))
+		require.EqualValues(t, maxThrottledEvaluations, r.throttledEvaluations)
+	})
 }
 
 func TestRuleRoutine(t *testing.T) {
@@ -1039,6 +1088,7 @@ func TestRuleRoutine(t *testing.T) {
 			sch.alertsSender,
 			sch.stateManager,
 			sch.evaluatorFactory,
+			sch.evaluationBudgets,
 			sch.clock,
 			sch.rrCfg,
 			sch.metrics,
@@ -1343,6 +1393,7 @@ func TestAlertRuleRetry(t *testing.T) {
 		sch.alertsSender,
 		sch.stateManager,
 		sch.evaluatorFactory,
+		sch.evaluationBudgets,
 		fakeClock,
 		sch.rrCfg,
 		sch.metrics,
@@ -1430,6 +1481,7 @@ func ruleFactoryFromScheduler(sch *schedule) ruleFactory {
 		sch.alertsSender,
 		sch.stateManager,
 		sch.evaluatorFactory,
+		sch.evaluationBudgets,
 		sch.clock,
 		sch.rrCfg,
 		sch.metrics,
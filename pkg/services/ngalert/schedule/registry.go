@@ -310,6 +310,17 @@ func (r ruleWithFolder) Fingerprint() fingerprint {
 		writeBytes(tmp)
 	}
 
+	for _, c := range rule.SeverityConditions {
+		writeString(c.Severity)
+		writeString(c.Condition)
+	}
+
+	if rule.FlapDetection != nil {
+		writeInt(int64(rule.FlapDetection.Transitions))
+		writeInt(int64(rule.FlapDetection.Window))
+		writeString(string(rule.FlapDetection.NotificationPolicy))
+	}
+
 	// fields that do not affect the state.
 	// TODO consider removing fields below from the fingerprint
 	writeInt(int64(rule.For))
@@ -213,6 +213,8 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 				},
 			},
 			MissingSeriesEvalsToResolve: util.Pointer[int64](2),
+			SeverityConditions:          models.SeverityConditions{{Severity: "warning", Condition: "A"}},
+			FlapDetection:               &models.FlapDetection{Transitions: 3, Window: time.Minute, NotificationPolicy: models.FlapNotify},
 		}
 		r2 := &models.AlertRule{
 			ID:        2,
@@ -257,6 +259,8 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 				},
 			},
 			MissingSeriesEvalsToResolve: util.Pointer[int64](1),
+			SeverityConditions:          models.SeverityConditions{{Severity: "critical", Condition: "B"}},
+			FlapDetection:               &models.FlapDetection{Transitions: 5, Window: time.Hour, NotificationPolicy: models.FlapSuppress},
 		}
 
 		excludedFields := map[string]struct{}{
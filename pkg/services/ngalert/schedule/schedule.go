@@ -84,7 +84,8 @@ type schedule struct {
 
 	log log.Logger
 
-	evaluatorFactory eval.EvaluatorFactory
+	evaluatorFactory  eval.EvaluatorFactory
+	evaluationBudgets EvaluationBudgetProvider
 
 	ruleStore RulesStore
 
@@ -109,6 +110,12 @@ type schedule struct {
 	tracer          tracing.Tracer
 	featureToggles  featuremgmt.FeatureToggles
 	recordingWriter RecordingWriter
+
+	// queriedMetrics caches the metrics queried by the rules, used to find rules that depend on recording rules of the same group.
+	queriedMetrics *ngmodels.QueriedMetricsCache
+	// dependentGroups contains the groups where rules depend on recording rules of the same group.
+	// With JitterByRule, such groups are jittered as a whole so the rules can be evaluated in order.
+	dependentGroups map[ngmodels.AlertRuleGroupKey]struct{}
 }
 
 // RetryConfig configures the exponential backoff for alert rule and recording rule evaluations.
@@ -130,6 +137,7 @@ type SchedulerCfg struct {
 	AppURL                 *url.URL
 	JitterEvaluations      JitterStrategy
 	EvaluatorFactory       eval.EvaluatorFactory
+	EvaluationBudgets      EvaluationBudgetProvider
 	RuleStore              RulesStore
 	Metrics                *metrics.Scheduler
 	AlertSender            AlertsSender
@@ -155,6 +163,7 @@ func NewScheduler(cfg SchedulerCfg, stateManager *state.Manager) *schedule {
 		baseInterval:           cfg.BaseInterval,
 		log:                    cfg.Log,
 		evaluatorFactory:       cfg.EvaluatorFactory,
+		evaluationBudgets:      cfg.EvaluationBudgets,
 		ruleStore:              cfg.RuleStore,
 		metrics:                cfg.Metrics,
 		appURL:                 cfg.AppURL,
@@ -169,6 +178,7 @@ func NewScheduler(cfg SchedulerCfg, stateManager *state.Manager) *schedule {
 		recordingWriter:        cfg.RecordingWriter,
 		ruleStopReasonProvider: cfg.RuleStopReasonProvider,
 		featureToggles:         cfg.FeatureToggles,
+		queriedMetrics:         ngmodels.NewQueriedMetricsCache(),
 	}
 
 	return &sch
@@ -224,6 +234,7 @@ func (sch *schedule) deleteAlertRule(ctx context.Context, keys ...ngmodels.Alert
 	// Our best bet at this point is that we update the metrics with what we hope to schedule in the next tick.
 	alertRules, _ := sch.schedulableAlertRules.all()
 	sch.updateRulesMetrics(alertRules)
+	sch.updateDependentGroups(alertRules)
 }
 
 func (sch *schedule) getRuleStopReason(ctx context.Context, key ngmodels.AlertRuleKeyWithGroup) error {
@@ -299,6 +310,7 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 	registeredDefinitions := sch.registry.keyMap()
 
 	sch.updateRulesMetrics(alertRules)
+	sch.updateDependentGroups(alertRules)
 
 	readyToRun := make([]readyToRunItem, 0)
 	updatedRules := make([]ngmodels.AlertRuleKeyWithVersion, 0, len(updated)) // this is needed for tests only
@@ -312,6 +324,7 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		sch.alertsSender,
 		sch.stateManager,
 		sch.evaluatorFactory,
+		sch.evaluationBudgets,
 		sch.clock,
 		sch.rrCfg,
 		sch.metrics,
@@ -369,7 +382,7 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		}
 
 		itemFrequency := item.IntervalSeconds / int64(sch.baseInterval.Seconds())
-		offset := jitterOffsetInTicks(item, sch.baseInterval, sch.jitterEvaluations)
+		offset := jitterOffsetInTicks(item, sch.baseInterval, sch.ruleJitterStrategy(item))
 		isReadyToRun := item.IntervalSeconds != 0 && (tickNum%itemFrequency)-offset == 0
 
 		if isReadyToRun {
@@ -423,6 +436,7 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		toDelete = append(toDelete, key)
 	}
 	sch.deleteAlertRule(ctx, toDelete...)
+	sch.queriedMetrics.Delete(toDelete...)
 
 	return readyToRun, registeredDefinitions, updatedRules
 }
@@ -31,7 +31,7 @@ type groupKey struct {
 // The function returns a slice of sequences, where each sequence represents a chain of rules
 // that should be evaluated in order.
 //
-// NOTE: This currently only chains rules in imported groups.
+// NOTE: This currently only chains rules in imported groups and groups where rules depend on recording rules of the same group.
 func (sch *schedule) buildSequences(items []readyToRunItem, runJobFn func(next readyToRunItem, prev ...readyToRunItem) func()) []sequence {
 	// Step 1: Group rules by their folder and group name
 	groups := map[groupKey][]readyToRunItem{}
@@ -90,6 +90,13 @@ func (sch *schedule) buildSequence(groupKey groupKey, groupItems []readyToRunIte
 		return models.RulesGroupComparer(a.rule, b.rule)
 	})
 
+	// evaluate recording rules before the rules that query the metrics they write.
+	if sorted, err := sch.sortByDependencies(groupItems); err != nil {
+		sch.log.Warn("Rules in the group will be evaluated in the group order", "folder", groupKey.folderTitle, "group", groupKey.groupName, "error", err)
+	} else {
+		groupItems = sorted
+	}
+
 	// iterate over the group items backwards to set the afterEval callback
 	for i := len(groupItems) - 2; i >= 0; i-- {
 		groupItems[i].afterEval = runJobFn(groupItems[i+1], groupItems[i])
@@ -110,13 +117,19 @@ func (sch *schedule) shouldEvaluateSequentially(groupItems []readyToRunItem) boo
 		return false
 	}
 
-	// if jitter by rule is enabled, we can't evaluate rules sequentially
-	if sch.jitterEvaluations == JitterByRule {
+	// if there is only one rule, there are no rules to chain
+	if len(groupItems) == 1 {
 		return false
 	}
 
-	// if there is only one rule, there are no rules to chain
-	if len(groupItems) == 1 {
+	// evaluate rules sequentially if they query the metrics written by recording rules of the same group.
+	// Such groups are jittered as a whole even with JitterByRule, see ruleJitterStrategy.
+	if sch.queriedMetrics.HasDependencies(groupRules(groupItems)) {
+		return true
+	}
+
+	// if jitter by rule is enabled, we can't evaluate other rules sequentially
+	if sch.jitterEvaluations == JitterByRule {
 		return false
 	}
 
@@ -130,3 +143,67 @@ func (sch *schedule) shouldEvaluateSequentially(groupItems []readyToRunItem) boo
 	// default to false
 	return false
 }
+
+func groupRules(groupItems []readyToRunItem) models.RulesGroup {
+	rules := make(models.RulesGroup, 0, len(groupItems))
+	for _, item := range groupItems {
+		rules = append(rules, item.rule)
+	}
+	return rules
+}
+
+// sortByDependencies orders the items so that every rule is evaluated after the recording rules it depends on.
+func (sch *schedule) sortByDependencies(groupItems []readyToRunItem) ([]readyToRunItem, error) {
+	sorted, err := sch.queriedMetrics.SortByDependencies(groupRules(groupItems))
+	if err != nil {
+		return nil, err
+	}
+	byRule := make(map[*models.AlertRule]readyToRunItem, len(groupItems))
+	for _, item := range groupItems {
+		byRule[item.rule] = item
+	}
+	result := make([]readyToRunItem, 0, len(groupItems))
+	for _, rule := range sorted {
+		result = append(result, byRule[rule])
+	}
+	return result, nil
+}
+
+// updateDependentGroups finds the groups where rules depend on recording rules of the same group.
+// It is only needed with JitterByRule, where the rules of such groups must run on the same tick to be evaluated in order.
+func (sch *schedule) updateDependentGroups(alertRules []*models.AlertRule) {
+	if sch.jitterEvaluations != JitterByRule {
+		return
+	}
+	groups := make(map[models.AlertRuleGroupKey]models.RulesGroup)
+	for _, rule := range alertRules {
+		key := rule.GetGroupKey()
+		if models.IsNoGroupRuleGroup(key.RuleGroup) {
+			continue
+		}
+		groups[key] = append(groups[key], rule)
+	}
+	dependentGroups := make(map[models.AlertRuleGroupKey]struct{})
+	for key, rules := range groups {
+		if len(rules) < 2 || !sch.queriedMetrics.HasDependencies(rules) {
+			continue
+		}
+		dependentGroups[key] = struct{}{}
+		if _, ok := sch.dependentGroups[key]; !ok {
+			sch.log.Info("Rules in the group depend on recording rules of the same group, the group is jittered as a whole to evaluate them in order", "org_id", key.OrgID, "folder_uid", key.NamespaceUID, "group", key.RuleGroup)
+		}
+	}
+	sch.dependentGroups = dependentGroups
+}
+
+// ruleJitterStrategy returns the jitter strategy for the rule. With JitterByRule, rules of the groups that must be
+// evaluated in order are jittered by group.
+func (sch *schedule) ruleJitterStrategy(rule *models.AlertRule) JitterStrategy {
+	if sch.jitterEvaluations != JitterByRule {
+		return sch.jitterEvaluations
+	}
+	if _, ok := sch.dependentGroups[rule.GetGroupKey()]; ok {
+		return JitterByGroup
+	}
+	return JitterByRule
+}
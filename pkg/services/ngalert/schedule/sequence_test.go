@@ -154,4 +154,87 @@ func TestSequence(t *testing.T) {
 		require.Equal(t, []string{"4", "5"}, nextByGroup["rg2"])
 		require.Equal(t, []string{"3", "4"}, prevByGroup["rg2"])
 	})
+	t.Run("should evaluate recording rules before the rules that depend on them", func(t *testing.T) {
+		var evaluated []string
+		callback := func(next readyToRunItem, prev ...readyToRunItem) func() {
+			return func() {
+				evaluated = append(evaluated, next.rule.UID)
+				next.ruleRoutine.Eval(&next.Evaluation)
+			}
+		}
+		newItem := func(uid string, idx int, mutators ...models.AlertRuleMutator) readyToRunItem {
+			mutators = append([]models.AlertRuleMutator{
+				models.RuleGen.WithUID(uid),
+				models.RuleGen.WithGroupIndex(idx),
+				models.RuleGen.WithGroupName("rg3"),
+			}, mutators...)
+			return readyToRunItem{
+				ruleRoutine: &fakeSequenceRule{UID: uid, Group: "rg3"},
+				Evaluation: Evaluation{
+					rule:        gen.With(mutators...).GenerateRef(),
+					folderTitle: "folder1",
+				},
+			}
+		}
+		items := []readyToRunItem{
+			newItem("a", 1, models.RuleGen.WithQuery(models.CreatePrometheusQuery("A", "job:errors:rate > 0", 1000, 43200, false, "prom"))),
+			newItem("b", 2,
+				models.RuleGen.WithQuery(models.CreatePrometheusQuery("A", "rate(errors_total[5m])", 1000, 43200, false, "prom")),
+				models.RuleGen.WithAllRecordingRules(),
+				models.RuleGen.WithMetric("job:errors:rate"),
+				models.RuleGen.WithRecordFrom("A"),
+				models.RuleGen.WithoutTargetDataSource(),
+			),
+		}
+
+		sequences := sch.buildSequences(items, callback)
+		require.Len(t, sequences, 1)
+		require.Equal(t, "b", sequences[0].rule.UID)
+
+		sequences[0].ruleRoutine.Eval(&sequences[0].Evaluation)
+		require.Equal(t, []string{"a"}, evaluated)
+	})
+	t.Run("should jitter dependent groups as a whole with jitter by rule", func(t *testing.T) {
+		sch := setupScheduler(t, newFakeRulesStore(), nil, prometheus.NewPedanticRegistry(), nil, nil, nil)
+		sch.jitterEvaluations = JitterByRule
+
+		gen := gen.With(models.RuleGen.WithOrgID(1))
+		alerting := gen.With(
+			models.RuleGen.WithGroupName("rg4"),
+			models.RuleGen.WithGroupIndex(1),
+			models.RuleGen.WithQuery(models.CreatePrometheusQuery("A", "job:errors:rate > 0", 1000, 43200, false, "prom")),
+		).GenerateRef()
+		recording := gen.With(
+			models.RuleGen.WithGroupName("rg4"),
+			models.RuleGen.WithGroupIndex(2),
+			models.RuleGen.WithQuery(models.CreatePrometheusQuery("A", "rate(errors_total[5m])", 1000, 43200, false, "prom")),
+			models.RuleGen.WithAllRecordingRules(),
+			models.RuleGen.WithMetric("job:errors:rate"),
+			models.RuleGen.WithRecordFrom("A"),
+			models.RuleGen.WithoutTargetDataSource(),
+		).GenerateRef()
+		independent := gen.With(models.RuleGen.WithGroupName("rg5")).GenerateRef()
+
+		sch.updateDependentGroups([]*models.AlertRule{alerting, recording, independent})
+		require.Equal(t, JitterByGroup, sch.ruleJitterStrategy(alerting))
+		require.Equal(t, JitterByGroup, sch.ruleJitterStrategy(recording))
+		require.Equal(t, JitterByRule, sch.ruleJitterStrategy(independent))
+
+		var evaluated []string
+		callback := func(next readyToRunItem, prev ...readyToRunItem) func() {
+			return func() {
+				evaluated = append(evaluated, next.rule.UID)
+				next.ruleRoutine.Eval(&next.Evaluation)
+			}
+		}
+		items := []readyToRunItem{
+			{ruleRoutine: &fakeSequenceRule{UID: alerting.UID, Group: "rg4"}, Evaluation: Evaluation{rule: alerting, folderTitle: "folder1"}},
+			{ruleRoutine: &fakeSequenceRule{UID: recording.UID, Group: "rg4"}, Evaluation: Evaluation{rule: recording, folderTitle: "folder1"}},
+		}
+		sequences := sch.buildSequences(items, callback)
+		require.Len(t, sequences, 1)
+		require.Equal(t, recording.UID, sequences[0].rule.UID)
+		sequences[0].ruleRoutine.Eval(&sequences[0].Evaluation)
+		require.Equal(t, []string{alerting.UID}, evaluated)
+	})
 }
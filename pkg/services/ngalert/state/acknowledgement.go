@@ -0,0 +1,195 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+
+	alertingModels "github.com/grafana/alerting/models"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngModels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+var (
+	ErrAlertInstanceNotFound  = errors.New("alert instance not found")
+	ErrAlertInstanceNotFiring = errors.New("only firing alert instances can be acknowledged")
+	ErrSilencerNotConfigured  = errors.New("notifications of acknowledged alert instances cannot be suppressed")
+)
+
+// Silencer creates and deletes the silences that suppress the notifications of acknowledged alert instances.
+type Silencer interface {
+	CreateSilence(ctx context.Context, orgID int64, ps ngModels.Silence) (string, error)
+	DeleteSilence(ctx context.Context, orgID int64, silenceID string) error
+}
+
+// Acknowledge acknowledges the firing alert instance of the rule. The values of the instance are stored
+// with the acknowledgement, which is removed when it expires or the instance stops firing, and, if
+// UntilValueChanges is set, when the values of the instance change.
+// If the acknowledgement suppresses notifications, the instance is silenced until the acknowledgement is removed.
+// The acknowledgement is persisted together with the state of the instance.
+func (st *Manager) Acknowledge(ctx context.Context, orgID int64, ruleUID string, cacheID data.Fingerprint, ack ngModels.Acknowledgement) (*State, error) {
+	current := st.cache.get(orgID, ruleUID, cacheID)
+	if err := validateAcknowledge(current); err != nil {
+		return nil, err
+	}
+	if ack.SuppressNotifications && st.silencer == nil {
+		return nil, ErrSilencerNotConfigured
+	}
+
+	logger := st.log.FromContext(ctx)
+	// The silence is created before the state is updated, so that the cache is not locked while calling the Alertmanager.
+	// Re-use the silence of the previous acknowledgement, so that it is updated instead of creating a new one.
+	if ack.SuppressNotifications {
+		id, err := st.silencer.CreateSilence(ctx, orgID, acknowledgementSilence(current, ack, current.Acknowledgement.SilenceID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to silence the alert instance: %w", err)
+		}
+		ack.SilenceID = id
+	}
+
+	var previous ngModels.Acknowledgement
+	next, err := st.cache.update(orgID, ruleUID, cacheID, func(s *State) (*State, error) {
+		// The instance could have been resolved or deleted by an evaluation while the silence was created.
+		if err := validateAcknowledge(s); err != nil {
+			return nil, err
+		}
+		next := s.Copy()
+		previous = s.Acknowledgement
+		ack.Values = maps.Clone(s.Values)
+		next.Acknowledgement = ack
+		return next, nil
+	})
+	if err != nil {
+		// The instance is no longer firing, so its silence must not outlive the failed acknowledgement.
+		st.deleteAcknowledgementSilence(ctx, logger, orgID, ack)
+		return nil, err
+	}
+	if previous.SilenceID != "" && previous.SilenceID != ack.SilenceID {
+		st.deleteAcknowledgementSilence(ctx, logger, orgID, previous)
+	}
+	return next, nil
+}
+
+// Unacknowledge removes the acknowledgement of the alert instance of the rule and expires its silence.
+func (st *Manager) Unacknowledge(ctx context.Context, orgID int64, ruleUID string, cacheID data.Fingerprint) (*State, error) {
+	var previous ngModels.Acknowledgement
+	next, err := st.cache.update(orgID, ruleUID, cacheID, func(s *State) (*State, error) {
+		if s == nil {
+			return nil, ErrAlertInstanceNotFound
+		}
+		if s.Acknowledgement.IsZero() {
+			return s, nil
+		}
+		next := s.Copy()
+		previous = s.Acknowledgement
+		next.Acknowledgement = ngModels.Acknowledgement{}
+		return next, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	st.deleteAcknowledgementSilence(ctx, st.log.FromContext(ctx), orgID, previous)
+	return next, nil
+}
+
+func validateAcknowledge(s *State) error {
+	if s == nil {
+		return ErrAlertInstanceNotFound
+	}
+	if !isFiring(s.State) {
+		return ErrAlertInstanceNotFiring
+	}
+	return nil
+}
+
+// removeAcknowledgements removes the acknowledgements that expired, of instances that stopped firing and,
+// if they were created with UntilValueChanges, of instances whose values changed.
+func (st *Manager) removeAcknowledgements(ctx context.Context, logger log.Logger, now time.Time, transitions StateTransitions) {
+	for _, t := range transitions {
+		ack := t.Acknowledgement
+		if ack.IsZero() {
+			continue
+		}
+		var reason string
+		switch {
+		case ack.IsExpired(now):
+			reason = "expired"
+		case !isFiring(t.State.State):
+			reason = "not firing"
+		case ack.UntilValueChanges && !valuesEqual(ack.Values, t.Values):
+			reason = "values changed"
+		default:
+			continue
+		}
+		logger.Debug("Removing acknowledgement of alert instance", "cacheID", t.CacheID, "reason", reason)
+		t.Acknowledgement = ngModels.Acknowledgement{}
+		// The silence of an expired acknowledgement expires at the same time.
+		if !ack.IsExpired(now) {
+			st.deleteAcknowledgementSilence(ctx, logger, t.OrgID, ack)
+		}
+	}
+}
+
+func (st *Manager) deleteAcknowledgementSilence(ctx context.Context, logger log.Logger, orgID int64, ack ngModels.Acknowledgement) {
+	if ack.SilenceID == "" || st.silencer == nil {
+		return
+	}
+	if err := st.silencer.DeleteSilence(ctx, orgID, ack.SilenceID); err != nil {
+		logger.Warn("Failed to expire the silence of the acknowledged alert instance", "silenceID", ack.SilenceID, "error", err)
+	}
+}
+
+// acknowledgementSilence creates a silence that matches the labels the alert instance is sent to the Alertmanager with.
+func acknowledgementSilence(s *State, ack ngModels.Acknowledgement, id string) ngModels.Silence {
+	matchers := make(amv2.Matchers, 0, len(s.Labels))
+	for name, value := range s.Labels {
+		// The Alertmanager drops the namespace UID label and labels with empty values.
+		if value == "" || name == alertingModels.NamespaceUIDLabel {
+			continue
+		}
+		matchers = append(matchers, &amv2.Matcher{
+			Name:    util.Pointer(name),
+			Value:   util.Pointer(value),
+			IsEqual: util.Pointer(true),
+			IsRegex: util.Pointer(false),
+		})
+	}
+	comment := "Acknowledged alert instance"
+	if ack.Comment != "" {
+		comment = fmt.Sprintf("%s: %s", comment, ack.Comment)
+	}
+	silence := ngModels.Silence{
+		Silence: amv2.Silence{
+			Comment:   util.Pointer(comment),
+			CreatedBy: util.Pointer(ack.By),
+			StartsAt:  util.Pointer(strfmt.DateTime(ack.At)),
+			EndsAt:    util.Pointer(strfmt.DateTime(ack.ExpiresAt)),
+			Matchers:  matchers,
+		},
+	}
+	if id != "" {
+		silence.ID = util.Pointer(id)
+	}
+	return silence
+}
+
+func isFiring(s eval.State) bool {
+	return s == eval.Alerting || s == eval.Recovering
+}
+
+// valuesEqual compares the values of an instance and treats NaN values as equal.
+func valuesEqual(a, b map[string]float64) bool {
+	return maps.EqualFunc(a, b, func(x, y float64) bool {
+		return x == y || math.IsNaN(x) && math.IsNaN(y)
+	})
+}
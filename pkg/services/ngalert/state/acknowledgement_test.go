@@ -0,0 +1,265 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type fakeSilencer struct {
+	created []ngmodels.Silence
+	deleted []string
+	err     error
+	// onCreate is called before the silence is created.
+	onCreate func()
+}
+
+func (f *fakeSilencer) CreateSilence(_ context.Context, _ int64, ps ngmodels.Silence) (string, error) {
+	if f.onCreate != nil {
+		f.onCreate()
+	}
+	if f.err != nil {
+		return "", f.err
+	}
+	f.created = append(f.created, ps)
+	if ps.ID != nil {
+		return *ps.ID, nil
+	}
+	return "silence-1", nil
+}
+
+func (f *fakeSilencer) DeleteSilence(_ context.Context, _ int64, silenceID string) error {
+	f.deleted = append(f.deleted, silenceID)
+	return f.err
+}
+
+func TestAcknowledge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newManager := func(silencer Silencer) *Manager {
+		return NewManager(ManagerCfg{
+			Clock:    clock.NewMock(),
+			Log:      log.NewNopLogger(),
+			Silencer: silencer,
+		}, NewNoopPersister())
+	}
+	newState := func(s eval.State) *State {
+		return &State{
+			OrgID:        1,
+			AlertRuleUID: "rule",
+			CacheID:      data.Fingerprint(1),
+			State:        s,
+			Labels:       data.Labels{"alertname": "test", "instance": "a", "empty": ""},
+			Values:       map[string]float64{"A": 1},
+		}
+	}
+	ack := ngmodels.Acknowledgement{
+		By:        "admin",
+		Comment:   "looking into it",
+		At:        now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	t.Run("should return error if instance does not exist", func(t *testing.T) {
+		st := newManager(nil)
+		_, err := st.Acknowledge(ctx, 1, "rule", data.Fingerprint(1), ack)
+		require.ErrorIs(t, err, ErrAlertInstanceNotFound)
+	})
+
+	t.Run("should return error if instance is not firing", func(t *testing.T) {
+		st := newManager(nil)
+		st.cache.set(newState(eval.Normal))
+		_, err := st.Acknowledge(ctx, 1, "rule", data.Fingerprint(1), ack)
+		require.ErrorIs(t, err, ErrAlertInstanceNotFiring)
+	})
+
+	t.Run("should return error if notifications are suppressed without silencer", func(t *testing.T) {
+		st := newManager(nil)
+		st.cache.set(newState(eval.Alerting))
+		a := ack
+		a.SuppressNotifications = true
+		_, err := st.Acknowledge(ctx, 1, "rule", data.Fingerprint(1), a)
+		require.ErrorIs(t, err, ErrSilencerNotConfigured)
+	})
+
+	t.Run("should store the acknowledgement with the values of the instance", func(t *testing.T) {
+		st := newManager(nil)
+		st.cache.set(newState(eval.Alerting))
+		s, err := st.Acknowledge(ctx, 1, "rule", data.Fingerprint(1), ack)
+		require.NoError(t, err)
+		assert.Equal(t, "admin", s.Acknowledgement.By)
+		assert.Equal(t, map[string]float64{"A": 1}, s.Acknowledgement.Values)
+		assert.Equal(t, s.Acknowledgement, st.cache.get(1, "rule", data.Fingerprint(1)).Acknowledgement)
+	})
+
+	t.Run("should silence the instance if notifications are suppressed", func(t *testing.T) {
+		silencer := &fakeSilencer{}
+		st := newManager(silencer)
+		st.cache.set(newState(eval.Alerting))
+		a := ack
+		a.SuppressNotifications = true
+		s, err := st.Acknowledge(ctx, 1, "rule", data.Fingerprint(1), a)
+		require.NoError(t, err)
+		assert.Equal(t, "silence-1", s.Acknowledgement.SilenceID)
+		require.Len(t, silencer.created, 1)
+		silence := silencer.created[0]
+		assert.Nil(t, silence.ID)
+		assert.Equal(t, "admin", *silence.CreatedBy)
+		assert.Len(t, silence.Matchers, 2)
+
+		// acknowledging again updates the silence
+		_, err = st.Acknowledge(ctx, 1, "rule", data.Fingerprint(1), a)
+		require.NoError(t, err)
+		require.Len(t, silencer.created, 2)
+		assert.Equal(t, "silence-1", *silencer.created[1].ID)
+	})
+
+	t.Run("should return error if silence cannot be created", func(t *testing.T) {
+		silencer := &fakeSilencer{err: errors.New("test")}
+		st := newManager(silencer)
+		st.cache.set(newState(eval.Alerting))
+		a := ack
+		a.SuppressNotifications = true
+		_, err := st.Acknowledge(ctx, 1, "rule", data.Fingerprint(1), a)
+		require.Error(t, err)
+		assert.True(t, st.cache.get(1, "rule", data.Fingerprint(1)).Acknowledgement.IsZero())
+	})
+
+	t.Run("should delete the silence if instance is resolved while it is created", func(t *testing.T) {
+		silencer := &fakeSilencer{}
+		st := newManager(silencer)
+		st.cache.set(newState(eval.Alerting))
+		silencer.onCreate = func() {
+			st.cache.set(newState(eval.Normal))
+		}
+		a := ack
+		a.SuppressNotifications = true
+		_, err := st.Acknowledge(ctx, 1, "rule", data.Fingerprint(1), a)
+		require.ErrorIs(t, err, ErrAlertInstanceNotFiring)
+		assert.Equal(t, []string{"silence-1"}, silencer.deleted)
+		assert.True(t, st.cache.get(1, "rule", data.Fingerprint(1)).Acknowledgement.IsZero())
+	})
+
+	t.Run("should keep the acknowledgement if the instance is acknowledged during evaluation", func(t *testing.T) {
+		st := newManager(nil)
+		base := newState(eval.Alerting)
+		st.cache.set(base)
+		next := base.Copy()
+		_, err := st.Acknowledge(ctx, 1, "rule", data.Fingerprint(1), ack)
+		require.NoError(t, err)
+
+		st.cache.replace(next, base)
+		assert.Equal(t, "admin", st.cache.get(1, "rule", data.Fingerprint(1)).Acknowledgement.By)
+
+		next = base.Copy()
+		st.cache.setRuleStates(ngmodels.AlertRuleKey{OrgID: 1, UID: "rule"}, ruleStates{states: map[data.Fingerprint]*State{next.CacheID: next}}, []*State{base})
+		assert.Equal(t, "admin", st.cache.get(1, "rule", data.Fingerprint(1)).Acknowledgement.By)
+	})
+
+	t.Run("unacknowledge should remove the acknowledgement and its silence", func(t *testing.T) {
+		silencer := &fakeSilencer{}
+		st := newManager(silencer)
+		s := newState(eval.Alerting)
+		s.Acknowledgement = ack
+		s.Acknowledgement.SilenceID = "silence-1"
+		st.cache.set(s)
+		s, err := st.Unacknowledge(ctx, 1, "rule", data.Fingerprint(1))
+		require.NoError(t, err)
+		assert.True(t, s.Acknowledgement.IsZero())
+		assert.Equal(t, []string{"silence-1"}, silencer.deleted)
+	})
+}
+
+func TestRemoveAcknowledgements(t *testing.T) {
+	now := time.Now()
+	ack := ngmodels.Acknowledgement{
+		By:        "admin",
+		At:        now.Add(-time.Hour),
+		ExpiresAt: now.Add(time.Hour),
+		Values:    map[string]float64{"A": 1, "B": math.NaN()},
+		SilenceID: "silence-1",
+	}
+
+	testCases := []struct {
+		name          string
+		state         eval.State
+		values        map[string]float64
+		expiresAt     time.Time
+		untilChanges  bool
+		expectRemoved bool
+		expectDeleted []string
+	}{
+		{
+			name:   "should keep acknowledgement of firing instance with same values",
+			state:  eval.Alerting,
+			values: map[string]float64{"A": 1, "B": math.NaN()},
+		},
+		{
+			name:   "should keep acknowledgement if values changed",
+			state:  eval.Alerting,
+			values: map[string]float64{"A": 2, "B": math.NaN()},
+		},
+		{
+			name:         "should keep acknowledgement until values change if values are the same",
+			state:        eval.Alerting,
+			values:       map[string]float64{"A": 1, "B": math.NaN()},
+			untilChanges: true,
+		},
+		{
+			name:          "should remove acknowledgement until values change if values changed",
+			state:         eval.Alerting,
+			values:        map[string]float64{"A": 2, "B": math.NaN()},
+			untilChanges:  true,
+			expectRemoved: true,
+			expectDeleted: []string{"silence-1"},
+		},
+		{
+			name:          "should remove acknowledgement if instance is not firing",
+			state:         eval.Normal,
+			values:        map[string]float64{"A": 1, "B": math.NaN()},
+			expectRemoved: true,
+			expectDeleted: []string{"silence-1"},
+		},
+		{
+			name:          "should remove expired acknowledgement and keep its silence",
+			state:         eval.Alerting,
+			values:        map[string]float64{"A": 1, "B": math.NaN()},
+			expiresAt:     now.Add(-time.Minute),
+			expectRemoved: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			silencer := &fakeSilencer{}
+			st := NewManager(ManagerCfg{
+				Clock:    clock.NewMock(),
+				Log:      log.NewNopLogger(),
+				Silencer: silencer,
+			}, NewNoopPersister())
+
+			a := ack
+			if !tc.expiresAt.IsZero() {
+				a.ExpiresAt = tc.expiresAt
+			}
+			a.UntilValueChanges = tc.untilChanges
+			s := &State{OrgID: 1, State: tc.state, Values: tc.values, Acknowledgement: a}
+			st.removeAcknowledgements(context.Background(), log.NewNopLogger(), now, StateTransitions{{State: s}})
+
+			assert.Equal(t, tc.expectRemoved, s.Acknowledgement.IsZero())
+			assert.Equal(t, tc.expectDeleted, silencer.deleted)
+		})
+	}
+}
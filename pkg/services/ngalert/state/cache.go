@@ -237,6 +237,13 @@ func expand(ctx context.Context, log log.Logger, name string, original map[strin
 	return expanded, errs
 }
 
+func (rs *ruleStates) get(id data.Fingerprint) *State {
+	if rs == nil {
+		return nil
+	}
+	return rs.states[id]
+}
+
 func (rs *ruleStates) deleteStates(predicate func(s *State) bool) {
 	for id, state := range rs.states {
 		if predicate(state) {
@@ -256,9 +263,20 @@ func (c *cache) deleteRuleStates(ruleKey ngModels.AlertRuleKey, predicate func(s
 	}
 }
 
-func (c *cache) setRuleStates(ruleKey ngModels.AlertRuleKey, s ruleStates) {
+// setRuleStates replaces the states of the rule with s, which was computed from the states in base.
+// The acknowledgements of states that changed in the meantime are kept, see replace.
+func (c *cache) setRuleStates(ruleKey ngModels.AlertRuleKey, s ruleStates, base []*State) {
 	c.mtxStates.Lock()
 	defer c.mtxStates.Unlock()
+	if current, ok := c.states[ruleKey.OrgID][ruleKey.UID]; ok {
+		for _, b := range base {
+			if cur := current.get(b.CacheID); cur != nil && cur != b {
+				if entry, ok := s.states[b.CacheID]; ok {
+					entry.Acknowledgement = cur.Acknowledgement
+				}
+			}
+		}
+	}
 	if _, ok := c.states[ruleKey.OrgID]; !ok {
 		c.states[ruleKey.OrgID] = make(map[string]*ruleStates)
 	}
@@ -268,6 +286,34 @@ func (c *cache) setRuleStates(ruleKey ngModels.AlertRuleKey, s ruleStates) {
 func (c *cache) set(entry *State) {
 	c.mtxStates.Lock()
 	defer c.mtxStates.Unlock()
+	c.setLocked(entry)
+}
+
+// replace sets the state of the alert instance that was computed from base. If the cached state is no longer base,
+// the instance was acknowledged or unacknowledged in the meantime and the cached acknowledgement is kept.
+func (c *cache) replace(entry, base *State) {
+	c.mtxStates.Lock()
+	defer c.mtxStates.Unlock()
+	if current := c.states[entry.OrgID][entry.AlertRuleUID].get(entry.CacheID); current != nil && current != base {
+		entry.Acknowledgement = current.Acknowledgement
+	}
+	c.setLocked(entry)
+}
+
+// update replaces the state of the alert instance with the one returned by fn. The lock is held while fn runs,
+// so that the state cannot be changed by an evaluation in between. fn is called with nil if the instance does not exist.
+func (c *cache) update(orgID int64, alertRuleUID string, stateId data.Fingerprint, fn func(current *State) (*State, error)) (*State, error) {
+	c.mtxStates.Lock()
+	defer c.mtxStates.Unlock()
+	next, err := fn(c.states[orgID][alertRuleUID].get(stateId))
+	if err != nil {
+		return nil, err
+	}
+	c.setLocked(next)
+	return next, nil
+}
+
+func (c *cache) setLocked(entry *State) {
 	if _, ok := c.states[entry.OrgID]; !ok {
 		c.states[entry.OrgID] = make(map[string]*ruleStates)
 	}
@@ -383,6 +429,7 @@ func (c *cache) GetAlertInstances() []ngModels.AlertInstance {
 					EvaluationDuration: v2.EvaluationDuration,
 					LastError:          lastError,
 					LastResult:         lastResult,
+					Acknowledgement:    v2.Acknowledgement,
 				})
 			}
 		}
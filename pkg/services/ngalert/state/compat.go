@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"path"
 	"strconv"
+	"time"
 
 	"github.com/benbjohnson/clock"
 	"github.com/go-openapi/strfmt"
@@ -60,6 +61,10 @@ func StateToPostableAlert(transition StateTransition, appURL *url.URL, featureTo
 		nA[alertingModels.StateReasonAnnotation] = alertState.StateReason
 	}
 
+	if !alertState.Acknowledgement.IsZero() {
+		attachAcknowledgementAnnotations(alertState.Acknowledgement, nA)
+	}
+
 	if alertState.OrgID != 0 {
 		nA[alertingModels.OrgIDAnnotation] = strconv.FormatInt(alertState.OrgID, 10)
 	}
@@ -172,6 +177,26 @@ func FromAlertsStateToStoppedAlert(firingStates []StateTransition, appURL *url.U
 	return alerts
 }
 
+// FromSeverityChangesToStoppedAlert selects the transitions of firing states whose severity changed
+// and converts them to models.PostableAlert with the labels of the previous severity and EndsAt set to time.Now,
+// so that the alerts with the previous severity are resolved in the Alertmanager.
+func FromSeverityChangesToStoppedAlert(transitions []StateTransition, appURL *url.URL, clock clock.Clock, featureToggles featuremgmt.FeatureToggles) apimodels.PostableAlerts {
+	alerts := apimodels.PostableAlerts{PostableAlerts: make([]models.PostableAlert, 0)}
+	ts := clock.Now()
+	for _, transition := range transitions {
+		if !transition.SeverityChanged() || transition.PreviousState != eval.Alerting && transition.PreviousState != eval.Recovering {
+			continue
+		}
+		previous := transition.Copy()
+		previous.Labels = transition.PreviousLabels()
+		previous.ResolvedAt = nil
+		postableAlert := StateToPostableAlert(StateTransition{State: previous, PreviousState: transition.PreviousState}, appURL, featureToggles)
+		postableAlert.EndsAt = strfmt.DateTime(ts)
+		alerts.PostableAlerts = append(alerts.PostableAlerts, *postableAlert)
+	}
+	return alerts
+}
+
 // attachImageAnnotations attaches image annotations to the alert.
 func attachImageAnnotations(image *ngModels.Image, a data.Labels) {
 	if image.Token != "" {
@@ -182,6 +207,15 @@ func attachImageAnnotations(image *ngModels.Image, a data.Labels) {
 	}
 }
 
+// attachAcknowledgementAnnotations attaches the acknowledgement to the alert, so that it can be used in notification templates.
+func attachAcknowledgementAnnotations(ack ngModels.Acknowledgement, a data.Labels) {
+	a[ngModels.AcknowledgedByAnnotation] = ack.By
+	a[ngModels.AcknowledgedUntilAnnotation] = ack.ExpiresAt.UTC().Format(time.RFC3339)
+	if ack.Comment != "" {
+		a[ngModels.AcknowledgedCommentAnnotation] = ack.Comment
+	}
+}
+
 // AlertInstanceToState converts a persisted AlertInstance to an in-memory State.
 func AlertInstanceToState(entry *ngModels.AlertInstance, logger log.Logger) *State {
 	cacheID := entry.Labels.Fingerprint()
@@ -232,6 +266,7 @@ func AlertInstanceToState(entry *ngModels.AlertInstance, logger log.Logger) *Sta
 		Error:                stateError,
 		Values:               values,
 		LatestResult:         latestResult,
+		Acknowledgement:      entry.Acknowledgement,
 	}
 }
 
@@ -139,6 +139,30 @@ func Test_StateToPostableAlert(t *testing.T) {
 					require.Equal(t, expected, result.Annotations)
 				})
 
+				t.Run("add acknowledgement annotations if acknowledged", func(t *testing.T) {
+					alertState := randomTransition(eval.Normal, tc.state)
+					alertState.Annotations = randomMapOfStrings()
+					expiresAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+					alertState.Acknowledgement = ngModels.Acknowledgement{
+						By:        "admin",
+						Comment:   "looking into it",
+						At:        expiresAt.Add(-time.Hour),
+						ExpiresAt: expiresAt,
+					}
+
+					result := StateToPostableAlert(alertState, appURL, featuremgmt.WithFeatures())
+
+					expected := make(models.LabelSet, len(alertState.Annotations)+3)
+					for k, v := range alertState.Annotations {
+						expected[k] = v
+					}
+					expected[ngModels.AcknowledgedByAnnotation] = "admin"
+					expected[ngModels.AcknowledgedCommentAnnotation] = "looking into it"
+					expected[ngModels.AcknowledgedUntilAnnotation] = "2024-01-01T12:00:00Z"
+
+					require.Equal(t, expected, result.Annotations)
+				})
+
 				t.Run("add both annotations if there is an image token and url", func(t *testing.T) {
 					alertState := randomTransition(eval.Normal, tc.state)
 					alertState.Annotations = randomMapOfStrings()
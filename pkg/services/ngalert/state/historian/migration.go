@@ -0,0 +1,445 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+)
+
+const (
+	defaultMigrationWindow = 24 * time.Hour
+	defaultMigrationLimit  = 1000
+	// minMigrationWindow is the shortest time range that is read from the source backend.
+	// Windows that return more entries than the limit are split until they reach this size.
+	minMigrationWindow = time.Second
+)
+
+// HistoryEntry is a single state transition read from a state history backend.
+// It is the unit that is exported as NDJSON and replayed into another backend.
+type HistoryEntry struct {
+	Timestamp time.Time          `json:"timestamp"`
+	OrgID     int64              `json:"orgID"`
+	RuleUID   string             `json:"ruleUID"`
+	RuleTitle string             `json:"ruleTitle,omitempty"`
+	Previous  string             `json:"previous"`
+	Current   string             `json:"current"`
+	Error     string             `json:"error,omitempty"`
+	Labels    map[string]string  `json:"labels,omitempty"`
+	Values    map[string]float64 `json:"values,omitempty"`
+}
+
+// MigrationCheckpoint marks the position up to which the history has been processed.
+// All history of rules with UID lower than RuleUID, and the history of the rule RuleUID before Time, is processed.
+type MigrationCheckpoint struct {
+	RuleUID string    `json:"ruleUID"`
+	Time    time.Time `json:"time"`
+}
+
+// MigrationOptions controls which history is read from the source backend and how.
+type MigrationOptions struct {
+	OrgID int64
+	// SignedInUser is used to authorize reads from the source backend.
+	SignedInUser identity.Requester
+	From         time.Time
+	To           time.Time
+	// RuleUIDs restricts the migration to specific rules. All rules of the organization are processed if empty.
+	RuleUIDs []string
+	// Window is the time range of history read from the source backend in a single batch.
+	Window time.Duration
+	// Limit is the maximum number of entries requested from the source backend in a single batch.
+	// Batches that reach the limit are split into smaller windows.
+	Limit int
+	// MaxBatches stops the migration after the given number of batches. The migration can be continued from the
+	// returned checkpoint. Zero means no limit.
+	MaxBatches int
+	// Resume continues a previous run from the checkpoint.
+	Resume *MigrationCheckpoint
+}
+
+// MigrationProgress reports the state of a migration or an export.
+type MigrationProgress struct {
+	RulesTotal     int                 `json:"rulesTotal"`
+	RulesCompleted int                 `json:"rulesCompleted"`
+	Batches        int                 `json:"batches"`
+	Entries        int                 `json:"entries"`
+	Done           bool                `json:"done"`
+	Checkpoint     MigrationCheckpoint `json:"checkpoint"`
+}
+
+// MigrationRuleStore lists the rules whose history is migrated.
+type MigrationRuleStore interface {
+	ListAlertRules(ctx context.Context, query *ngmodels.ListAlertRulesQuery) (ngmodels.RulesGroup, error)
+}
+
+// Migrator reads state history from one backend in batches, and replays it into another backend or exports it.
+type Migrator struct {
+	rules  MigrationRuleStore
+	source Querier
+	log    log.Logger
+}
+
+func NewMigrator(rules MigrationRuleStore, source Querier, l log.Logger) *Migrator {
+	return &Migrator{
+		rules:  rules,
+		source: source,
+		log:    l,
+	}
+}
+
+// Migrate replays the history read from the source backend into the destination backend.
+// The progress callback, if not nil, is called after every batch is written.
+func (m *Migrator) Migrate(ctx context.Context, destination state.Historian, opts MigrationOptions, progress func(MigrationProgress)) (MigrationProgress, error) {
+	return m.walk(ctx, opts, func(ctx context.Context, rule *ngmodels.AlertRule, entries []HistoryEntry) error {
+		transitions := make([]state.StateTransition, 0, len(entries))
+		for _, e := range entries {
+			t, err := e.StateTransition()
+			if err != nil {
+				m.log.FromContext(ctx).Warn("Skipping state history entry that cannot be replayed", "rule_uid", rule.UID, "time", e.Timestamp, "error", err)
+				continue
+			}
+			transitions = append(transitions, t)
+		}
+		if len(transitions) == 0 {
+			return nil
+		}
+		meta := history_model.NewRuleMeta(rule, m.log)
+		if err := <-destination.Record(ctx, meta, transitions); err != nil {
+			return fmt.Errorf("failed to write state history of rule %s: %w", rule.UID, err)
+		}
+		return nil
+	}, progress)
+}
+
+// Export writes the history read from the source backend to w as newline-delimited JSON, one HistoryEntry per line.
+func (m *Migrator) Export(ctx context.Context, w io.Writer, opts MigrationOptions) (MigrationProgress, error) {
+	enc := json.NewEncoder(w)
+	return m.walk(ctx, opts, func(_ context.Context, _ *ngmodels.AlertRule, entries []HistoryEntry) error {
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil)
+}
+
+type migrationSink func(ctx context.Context, rule *ngmodels.AlertRule, entries []HistoryEntry) error
+
+// walk reads the history of every rule in windows, starting from the checkpoint, and passes every window to the sink.
+func (m *Migrator) walk(ctx context.Context, opts MigrationOptions, sink migrationSink, progress func(MigrationProgress)) (MigrationProgress, error) {
+	if opts.Window <= 0 {
+		opts.Window = defaultMigrationWindow
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultMigrationLimit
+	}
+	if opts.To.IsZero() {
+		opts.To = time.Now()
+	}
+	if !opts.From.Before(opts.To) {
+		return MigrationProgress{}, fmt.Errorf("invalid time range: from %s must be before to %s", opts.From, opts.To)
+	}
+
+	rules, err := m.rules.ListAlertRules(ctx, &ngmodels.ListAlertRulesQuery{OrgID: opts.OrgID, RuleUIDs: opts.RuleUIDs})
+	if err != nil {
+		return MigrationProgress{}, fmt.Errorf("failed to list alert rules: %w", err)
+	}
+	slices.SortFunc(rules, func(a, b *ngmodels.AlertRule) int {
+		return strings.Compare(a.UID, b.UID)
+	})
+
+	p := MigrationProgress{RulesTotal: len(rules)}
+	if opts.Resume != nil {
+		p.Checkpoint = *opts.Resume
+	}
+	logger := m.log.FromContext(ctx)
+	for _, rule := range rules {
+		from := opts.From
+		if opts.Resume != nil {
+			if rule.UID < opts.Resume.RuleUID {
+				p.RulesCompleted++
+				continue
+			}
+			if rule.UID == opts.Resume.RuleUID && opts.Resume.Time.After(from) {
+				from = opts.Resume.Time
+			}
+		}
+
+		for from.Before(opts.To) {
+			if opts.MaxBatches > 0 && p.Batches >= opts.MaxBatches {
+				return p, nil
+			}
+			if err := ctx.Err(); err != nil {
+				return p, err
+			}
+			to := from.Add(opts.Window)
+			if to.After(opts.To) {
+				to = opts.To
+			}
+			entries, err := m.read(ctx, rule, opts, from, to)
+			if err != nil {
+				return p, err
+			}
+			if len(entries) > 0 {
+				if err := sink(ctx, rule, entries); err != nil {
+					return p, err
+				}
+			}
+			p.Batches++
+			p.Entries += len(entries)
+			p.Checkpoint = MigrationCheckpoint{RuleUID: rule.UID, Time: to}
+			if progress != nil {
+				progress(p)
+			}
+			from = to
+		}
+		p.RulesCompleted++
+		logger.Debug("Processed state history of rule", "rule_uid", rule.UID, "completed", p.RulesCompleted, "total", p.RulesTotal)
+	}
+	p.Done = true
+	return p, nil
+}
+
+// read returns the history of the rule in the range [from, to). If the source returns as many entries as the limit,
+// the range is split in halves because the source might have dropped entries.
+func (m *Migrator) read(ctx context.Context, rule *ngmodels.AlertRule, opts MigrationOptions, from, to time.Time) ([]HistoryEntry, error) {
+	frame, err := m.source.Query(ctx, ngmodels.HistoryQuery{
+		RuleUID:      rule.UID,
+		OrgID:        rule.OrgID,
+		From:         from,
+		To:           to,
+		Limit:        opts.Limit,
+		SignedInUser: opts.SignedInUser,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state history of rule %s: %w", rule.UID, err)
+	}
+	entries, err := EntriesFromFrame(frame, rule)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) >= opts.Limit {
+		if to.Sub(from) > minMigrationWindow {
+			mid := from.Add(to.Sub(from) / 2)
+			left, err := m.read(ctx, rule, opts, from, mid)
+			if err != nil {
+				return nil, err
+			}
+			right, err := m.read(ctx, rule, opts, mid, to)
+			if err != nil {
+				return nil, err
+			}
+			return append(left, right...), nil
+		}
+		m.log.FromContext(ctx).Warn("State history window reached the limit and cannot be split further, some entries might be lost", "rule_uid", rule.UID, "from", from, "to", to, "limit", opts.Limit)
+	}
+	// the end of the range is exclusive to not process the same entry in two adjacent windows.
+	return slices.DeleteFunc(entries, func(e HistoryEntry) bool {
+		return !e.Timestamp.Before(to) || e.Timestamp.Before(from)
+	}), nil
+}
+
+// EntriesFromFrame converts the result of a state history query of a single rule to entries.
+// It supports the frames returned by the Loki and Prometheus backends as well as by the annotation backend.
+func EntriesFromFrame(frame *data.Frame, rule *ngmodels.AlertRule) ([]HistoryEntry, error) {
+	if frame == nil || len(frame.Fields) == 0 {
+		return nil, nil
+	}
+	if _, idx := frame.FieldByName(dfLine); idx >= 0 {
+		return entriesFromLokiFrame(frame, rule)
+	}
+	if _, idx := frame.FieldByName("text"); idx >= 0 {
+		return entriesFromAnnotationFrame(frame, rule)
+	}
+	return nil, errors.New("unsupported state history frame")
+}
+
+func entriesFromLokiFrame(frame *data.Frame, rule *ngmodels.AlertRule) ([]HistoryEntry, error) {
+	timeField, _ := frame.FieldByName(dfTime)
+	lineField, _ := frame.FieldByName(dfLine)
+	if timeField == nil {
+		return nil, errors.New("state history frame has no time field")
+	}
+	result := make([]HistoryEntry, 0, frame.Rows())
+	for i := 0; i < frame.Rows(); i++ {
+		ts, ok := timeField.At(i).(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type of time field: %T", timeField.At(i))
+		}
+		line, ok := lineField.At(i).(json.RawMessage)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type of line field: %T", lineField.At(i))
+		}
+		var entry LokiEntry
+		err := json.Unmarshal(line, &entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse state history entry: %w", err)
+		}
+		if entry.RuleUID != "" && entry.RuleUID != rule.UID {
+			continue
+		}
+		var values map[string]float64
+		if entry.Values != nil {
+			values, err = parseHistoryValues(entry.Values.MustMap())
+			if err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, HistoryEntry{
+			Timestamp: ts,
+			OrgID:     rule.OrgID,
+			RuleUID:   rule.UID,
+			RuleTitle: entry.RuleTitle,
+			Previous:  entry.Previous,
+			Current:   entry.Current,
+			Error:     entry.Error,
+			Labels:    entry.InstanceLabels,
+			Values:    values,
+		})
+	}
+	return result, nil
+}
+
+func entriesFromAnnotationFrame(frame *data.Frame, rule *ngmodels.AlertRule) ([]HistoryEntry, error) {
+	fields := make(map[string]*data.Field, len(frame.Fields))
+	for _, name := range []string{"time", "text", "prev", "next", "data"} {
+		f, _ := frame.FieldByName(name)
+		if f == nil {
+			return nil, fmt.Errorf("state history frame has no %s field", name)
+		}
+		fields[name] = f
+	}
+	result := make([]HistoryEntry, 0, frame.Rows())
+	for i := 0; i < frame.Rows(); i++ {
+		ts, _ := fields["time"].At(i).(time.Time)
+		text, _ := fields["text"].At(i).(string)
+		prev, _ := fields["prev"].At(i).(string)
+		next, _ := fields["next"].At(i).(string)
+		raw, _ := fields["data"].At(i).(string)
+
+		var payload struct {
+			Values map[string]any `json:"values"`
+			Error  *string        `json:"error"`
+		}
+		if raw != "" {
+			if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+				return nil, fmt.Errorf("failed to parse annotation data: %w", err)
+			}
+		}
+		values, err := parseHistoryValues(payload.Values)
+		if err != nil {
+			return nil, err
+		}
+		entry := HistoryEntry{
+			// the annotation backend returns the epoch of the annotation in milliseconds as seconds.
+			Timestamp: time.UnixMilli(ts.Unix()),
+			OrgID:     rule.OrgID,
+			RuleUID:   rule.UID,
+			RuleTitle: rule.Title,
+			Previous:  prev,
+			Current:   next,
+			Labels:    parseAnnotationLabels(text, rule.Title),
+			Values:    values,
+		}
+		if payload.Error != nil {
+			entry.Error = *payload.Error
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// parseAnnotationLabels extracts the labels from the text of an annotation created by BuildAnnotationTextAndData.
+func parseAnnotationLabels(text, title string) map[string]string {
+	rest, ok := strings.CutPrefix(text, title+" {")
+	if !ok {
+		return nil
+	}
+	idx := strings.LastIndex(rest, "} - ")
+	if idx < 0 {
+		return nil
+	}
+	rest = rest[:idx]
+	if rest == "" {
+		return nil
+	}
+	result := make(map[string]string)
+	for _, pair := range strings.Split(rest, ", ") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// parseHistoryValues converts the values recorded by the backends, where non-finite numbers are stored as strings.
+func parseHistoryValues(values map[string]any) (map[string]float64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]float64, len(values))
+	for k, v := range values {
+		switch val := v.(type) {
+		case float64:
+			result[k] = val
+		case json.Number:
+			f, err := val.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of %s: %w", k, err)
+			}
+			result[k] = f
+		case string:
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of %s: %w", k, err)
+			}
+			result[k] = f
+		}
+	}
+	return result, nil
+}
+
+// StateTransition converts the entry to a state transition that can be recorded by a backend.
+func (e HistoryEntry) StateTransition() (state.StateTransition, error) {
+	current, currentReason, err := state.ParseFormattedState(e.Current)
+	if err != nil {
+		return state.StateTransition{}, fmt.Errorf("invalid current state: %w", err)
+	}
+	previous, previousReason, err := state.ParseFormattedState(e.Previous)
+	if err != nil {
+		return state.StateTransition{}, fmt.Errorf("invalid previous state: %w", err)
+	}
+	s := &state.State{
+		OrgID:              e.OrgID,
+		AlertRuleUID:       e.RuleUID,
+		State:              current,
+		StateReason:        currentReason,
+		Labels:             data.Labels(e.Labels),
+		Values:             e.Values,
+		LastEvaluationTime: e.Timestamp,
+	}
+	if e.Error != "" {
+		s.Error = errors.New(e.Error)
+	}
+	return state.StateTransition{
+		State:               s,
+		PreviousState:       previous,
+		PreviousStateReason: previousReason,
+	}, nil
+}
@@ -0,0 +1,273 @@
+package historian
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+)
+
+func TestMigrator(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ruleA := ngmodels.RuleGen.With(ngmodels.RuleMuts.WithOrgID(1), ngmodels.RuleMuts.WithUID("a")).GenerateRef()
+	ruleB := ngmodels.RuleGen.With(ngmodels.RuleMuts.WithOrgID(1), ngmodels.RuleMuts.WithUID("b")).GenerateRef()
+	rules := &fakeMigrationRuleStore{rules: ngmodels.RulesGroup{ruleB, ruleA}}
+
+	source := &fakeHistorySource{entries: map[string][]HistoryEntry{}}
+	for i := 0; i < 6; i++ {
+		source.add(ruleA, start.Add(time.Duration(i)*time.Hour), map[string]string{"instance": "1"})
+	}
+	for i := 0; i < 3; i++ {
+		source.add(ruleB, start.Add(30*time.Hour+time.Duration(i)*time.Minute), map[string]string{"instance": "2"})
+	}
+
+	opts := MigrationOptions{
+		OrgID:  1,
+		From:   start,
+		To:     start.Add(48 * time.Hour),
+		Window: 24 * time.Hour,
+	}
+
+	t.Run("replays history of all rules into destination", func(t *testing.T) {
+		dest := &recordingHistorian{}
+		var reported []MigrationProgress
+		m := NewMigrator(rules, source, log.NewNopLogger())
+
+		p, err := m.Migrate(context.Background(), dest, opts, func(p MigrationProgress) {
+			reported = append(reported, p)
+		})
+		require.NoError(t, err)
+
+		require.True(t, p.Done)
+		require.Equal(t, 2, p.RulesTotal)
+		require.Equal(t, 2, p.RulesCompleted)
+		require.Equal(t, 4, p.Batches)
+		require.Equal(t, 9, p.Entries)
+		require.Len(t, reported, 4)
+		require.Equal(t, MigrationCheckpoint{RuleUID: "b", Time: opts.To}, p.Checkpoint)
+
+		require.Equal(t, []string{"a", "b"}, dest.ruleUIDs())
+		require.Len(t, dest.transitions, 9)
+		first := dest.transitions[0]
+		require.Equal(t, start, first.LastEvaluationTime)
+		require.Equal(t, "Alerting", first.Formatted())
+		require.Equal(t, "Normal", first.PreviousFormatted())
+		require.Equal(t, data.Labels{"instance": "1"}, first.Labels)
+		require.Equal(t, map[string]float64{"A": 1}, first.Values)
+	})
+
+	t.Run("splits windows that reach the limit", func(t *testing.T) {
+		dest := &recordingHistorian{}
+		m := NewMigrator(rules, source, log.NewNopLogger())
+		limited := opts
+		limited.Limit = 2
+
+		p, err := m.Migrate(context.Background(), dest, limited, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, 9, p.Entries)
+		require.Len(t, dest.transitions, 9)
+	})
+
+	t.Run("resumes from checkpoint", func(t *testing.T) {
+		dest := &recordingHistorian{}
+		m := NewMigrator(rules, source, log.NewNopLogger())
+		partial := opts
+		partial.MaxBatches = 1
+
+		p, err := m.Migrate(context.Background(), dest, partial, nil)
+		require.NoError(t, err)
+		require.False(t, p.Done)
+		require.Equal(t, 6, p.Entries)
+		require.Equal(t, MigrationCheckpoint{RuleUID: "a", Time: start.Add(24 * time.Hour)}, p.Checkpoint)
+
+		partial.MaxBatches = 0
+		partial.Resume = &p.Checkpoint
+		p, err = m.Migrate(context.Background(), dest, partial, nil)
+		require.NoError(t, err)
+		require.True(t, p.Done)
+		require.Equal(t, 3, p.Entries)
+		require.Len(t, dest.transitions, 9)
+	})
+
+	t.Run("returns error if destination fails", func(t *testing.T) {
+		dest := &recordingHistorian{err: fmt.Errorf("write failed")}
+		m := NewMigrator(rules, source, log.NewNopLogger())
+
+		p, err := m.Migrate(context.Background(), dest, opts, nil)
+		require.ErrorContains(t, err, "write failed")
+		require.False(t, p.Done)
+		require.Zero(t, p.Batches)
+	})
+
+	t.Run("exports history as NDJSON", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		m := NewMigrator(rules, source, log.NewNopLogger())
+
+		p, err := m.Export(context.Background(), &buf, opts)
+		require.NoError(t, err)
+		require.Equal(t, 9, p.Entries)
+
+		var lines []HistoryEntry
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var e HistoryEntry
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+			lines = append(lines, e)
+		}
+		require.Len(t, lines, 9)
+		require.Equal(t, "a", lines[0].RuleUID)
+		require.True(t, start.Equal(lines[0].Timestamp))
+		require.Equal(t, "b", lines[8].RuleUID)
+	})
+}
+
+func TestEntriesFromFrame(t *testing.T) {
+	rule := ngmodels.RuleGen.With(ngmodels.RuleMuts.WithOrgID(1), ngmodels.RuleMuts.WithUID("my-rule"), ngmodels.RuleMuts.WithTitle("My rule")).GenerateRef()
+
+	t.Run("annotation frame", func(t *testing.T) {
+		ts := time.UnixMilli(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli())
+		lbls := data.Labels{"from": "state-history", "ruleUID": "my-rule"}
+		frame := data.NewFrame("states",
+			// the annotation backend returns epoch milliseconds as seconds
+			data.NewField("time", lbls, []time.Time{time.Unix(ts.UnixMilli(), 0), time.Unix(ts.Add(time.Minute).UnixMilli(), 0)}),
+			data.NewField("text", lbls, []string{"My rule {instance=1, job=test} - A=1.000000", "My rule {} - Error"}),
+			data.NewField("prev", lbls, []string{"Normal", "Alerting"}),
+			data.NewField("next", lbls, []string{"Alerting", "Error"}),
+			data.NewField("data", lbls, []string{`{"values":{"A":1,"B":"+Inf"}}`, `{"error":"failure"}`}),
+		)
+
+		entries, err := EntriesFromFrame(frame, rule)
+		require.NoError(t, err)
+		require.Equal(t, []HistoryEntry{
+			{
+				Timestamp: ts,
+				OrgID:     1,
+				RuleUID:   "my-rule",
+				RuleTitle: "My rule",
+				Previous:  "Normal",
+				Current:   "Alerting",
+				Labels:    map[string]string{"instance": "1", "job": "test"},
+				Values:    map[string]float64{"A": 1, "B": math.Inf(1)},
+			},
+			{
+				Timestamp: ts.Add(time.Minute),
+				OrgID:     1,
+				RuleUID:   "my-rule",
+				RuleTitle: "My rule",
+				Previous:  "Alerting",
+				Current:   "Error",
+				Error:     "failure",
+			},
+		}, entries)
+	})
+
+	t.Run("unknown frame", func(t *testing.T) {
+		_, err := EntriesFromFrame(data.NewFrame("unknown", data.NewField("value", nil, []float64{1})), rule)
+		require.Error(t, err)
+	})
+}
+
+type fakeMigrationRuleStore struct {
+	rules ngmodels.RulesGroup
+}
+
+func (f *fakeMigrationRuleStore) ListAlertRules(_ context.Context, query *ngmodels.ListAlertRulesQuery) (ngmodels.RulesGroup, error) {
+	var result ngmodels.RulesGroup
+	for _, r := range f.rules {
+		if r.OrgID == query.OrgID && (len(query.RuleUIDs) == 0 || slices.Contains(query.RuleUIDs, r.UID)) {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// fakeHistorySource returns the entries in the same format as the Loki backend, keeping the latest entries if the result is limited.
+type fakeHistorySource struct {
+	entries map[string][]HistoryEntry
+}
+
+func (f *fakeHistorySource) add(rule *ngmodels.AlertRule, ts time.Time, lbls map[string]string) {
+	f.entries[rule.UID] = append(f.entries[rule.UID], HistoryEntry{
+		Timestamp: ts,
+		OrgID:     rule.OrgID,
+		RuleUID:   rule.UID,
+		Previous:  "Normal",
+		Current:   "Alerting",
+		Labels:    lbls,
+		Values:    map[string]float64{"A": 1},
+	})
+}
+
+func (f *fakeHistorySource) Query(_ context.Context, query ngmodels.HistoryQuery) (*data.Frame, error) {
+	var matched []HistoryEntry
+	for _, e := range f.entries[query.RuleUID] {
+		if !e.Timestamp.Before(query.From) && !e.Timestamp.After(query.To) {
+			matched = append(matched, e)
+		}
+	}
+	if query.Limit > 0 && len(matched) > query.Limit {
+		matched = matched[len(matched)-query.Limit:]
+	}
+	res := NewQueryResultBuilder(len(matched))
+	for _, e := range matched {
+		values := simplejson.New()
+		for k, v := range e.Values {
+			values.Set(k, v)
+		}
+		err := res.AddRow(e.Timestamp, LokiEntry{
+			SchemaVersion:  1,
+			Previous:       e.Previous,
+			Current:        e.Current,
+			Values:         values,
+			RuleUID:        e.RuleUID,
+			InstanceLabels: e.Labels,
+		}, json.RawMessage(`{}`))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res.ToFrame(), nil
+}
+
+type recordingHistorian struct {
+	err         error
+	rules       []history_model.RuleMeta
+	transitions []state.StateTransition
+}
+
+func (r *recordingHistorian) Record(_ context.Context, rule history_model.RuleMeta, states []state.StateTransition) <-chan error {
+	ch := make(chan error, 1)
+	defer close(ch)
+	if r.err != nil {
+		ch <- r.err
+		return ch
+	}
+	r.rules = append(r.rules, rule)
+	r.transitions = append(r.transitions, states...)
+	return ch
+}
+
+func (r *recordingHistorian) ruleUIDs() []string {
+	var result []string
+	for _, rule := range r.rules {
+		if !slices.Contains(result, rule.UID) {
+			result = append(result, rule.UID)
+		}
+	}
+	return result
+}
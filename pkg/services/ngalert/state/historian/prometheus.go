@@ -2,17 +2,23 @@ package historian
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/grafana/dataplane/sdata/numeric"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	amlabels "github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
 	promValue "github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/util/strutil"
 
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
@@ -86,26 +92,86 @@ func NewPrometheusConfig(cfg setting.UnifiedAlertingStateHistorySettings) (Prome
 	}, nil
 }
 
+type seriesReader interface {
+	QueryRange(ctx context.Context, dsUID string, orgID int64, query string, start, end time.Time, step time.Duration) (model.Matrix, error)
+}
+
 type RemotePrometheusBackend struct {
 	cfg        PrometheusConfig
 	promWriter seriesWriter
+	promReader seriesReader
+	ruleStore  RuleStore
+	ac         AccessControl
 	logger     log.Logger
 	metrics    *metrics.Historian
 }
 
-func NewRemotePrometheusBackend(cfg PrometheusConfig, promWriter seriesWriter, logger log.Logger, metrics *metrics.Historian) *RemotePrometheusBackend {
+func NewRemotePrometheusBackend(cfg PrometheusConfig, promWriter seriesWriter, promReader seriesReader, ruleStore RuleStore, ac AccessControl, logger log.Logger, metrics *metrics.Historian) *RemotePrometheusBackend {
 	logger.Info("Initializing remote Prometheus backend", "datasourceUID", cfg.DatasourceUID)
 
 	return &RemotePrometheusBackend{
 		cfg:        cfg,
 		promWriter: promWriter,
+		promReader: promReader,
+		ruleStore:  ruleStore,
+		ac:         ac,
 		logger:     logger,
 		metrics:    metrics,
 	}
 }
 
+// Query reads back the alert state series written by Record and converts them into state transitions.
+// Transitions are derived by sampling the series of every alert instance at each step of the query range,
+// so a state that lasted less than a single step might not be visible in the result.
 func (b *RemotePrometheusBackend) Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error) {
-	return nil, fmt.Errorf("prometheus historian backend does not support querying")
+	logger := b.logger.FromContext(ctx)
+	if query.RuleUID == "" {
+		return nil, fmt.Errorf("ruleUID is required to query the prometheus state history backend")
+	}
+
+	rule, err := b.ruleStore.GetAlertRuleByUID(ctx, &models.GetAlertRuleByUIDQuery{
+		UID:   query.RuleUID,
+		OrgID: query.OrgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the requested rule")
+	}
+	if rule == nil {
+		return nil, fmt.Errorf("no such rule exists")
+	}
+
+	if err := b.ac.AuthorizeAccessInFolder(ctx, query.SignedInUser, rule); err != nil {
+		return nil, err
+	}
+
+	if (query.DashboardUID != "" && query.DashboardUID != rule.GetDashboardUID()) ||
+		(query.PanelID != 0 && query.PanelID != rule.GetPanelID()) {
+		return NewQueryResultBuilder(0).ToFrame(), nil
+	}
+
+	now := time.Now().UTC()
+	if query.To.IsZero() {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultQueryRange)
+	}
+	// Align the range to whole seconds so that the sample timestamps returned by Prometheus match the steps.
+	query.From = query.From.Truncate(time.Second)
+	if !query.From.Before(query.To) {
+		return nil, fmt.Errorf("invalid time range: from must be before to")
+	}
+
+	step := prometheusQueryStep(time.Duration(rule.IntervalSeconds)*time.Second, query.From, query.To)
+	promQL := buildPrometheusQuery(b.cfg.MetricName, query)
+
+	logger.Debug("Querying state history", "query", promQL, "from", query.From, "to", query.To, "step", step)
+	matrix, err := b.promReader.QueryRange(ctx, b.cfg.DatasourceUID, query.OrgID, promQL, query.From, query.To, step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query state history: %w", err)
+	}
+
+	return matrixToHistoryFrame(rule, query, matrix, step, logger)
 }
 
 func (b *RemotePrometheusBackend) Record(ctx context.Context, rule history_model.RuleMeta, transitions []state.StateTransition) <-chan error {
@@ -253,3 +319,188 @@ func getSamples(tr state.StateTransition) []*sample {
 
 	return samples
 }
+
+// maxPrometheusQueryPoints is the maximum number of points per series that Prometheus allows in a range query.
+const maxPrometheusQueryPoints = 11000
+
+// defaultPrometheusQueryStep is used as the query resolution when the rule does not define an interval.
+const defaultPrometheusQueryStep = time.Minute
+
+// prometheusQueryStep returns the resolution for a state history query. It uses the rule's evaluation interval
+// and coarsens it when needed to keep the number of points within the limits of Prometheus.
+func prometheusQueryStep(interval time.Duration, from, to time.Time) time.Duration {
+	step := interval
+	if step <= 0 {
+		step = defaultPrometheusQueryStep
+	}
+	if minStep := to.Sub(from) / maxPrometheusQueryPoints; step < minStep {
+		step = minStep.Truncate(time.Second) + time.Second
+	}
+	return step
+}
+
+// buildPrometheusQuery builds a PromQL selector for the alert state series of the queried rule.
+func buildPrometheusQuery(metricName string, query models.HistoryQuery) string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "{__name__=%q,%s=%q", metricName, alertRuleUIDLabel, query.RuleUID)
+
+	// Sort matchers by name for deterministic queries.
+	sorted := make(amlabels.Matchers, len(query.Labels))
+	copy(sorted, query.Labels)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Value < sorted[j].Value
+	})
+	for _, m := range sorted {
+		// Label names are sanitized when written, so the same has to be done for the filters.
+		fmt.Fprintf(&b, ",%s%s%q", strutil.SanitizeFullLabelName(m.Name), logQLOperator(m.Type), m.Value)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+type promHistoryInstance struct {
+	labels data.Labels
+	// states contains the Grafana state of the instance, keyed by the sample timestamp in milliseconds.
+	states map[int64]string
+}
+
+type promHistoryEntry struct {
+	t      time.Time
+	entry  LokiEntry
+	labels json.RawMessage
+}
+
+// matrixToHistoryFrame converts the alert state series into the same frame format that the Loki backend returns.
+func matrixToHistoryFrame(rule *models.AlertRule, query models.HistoryQuery, matrix model.Matrix, step time.Duration, logger log.Logger) (*data.Frame, error) {
+	instances := make(map[string]*promHistoryInstance)
+	for _, series := range matrix {
+		grafanaState := string(series.Metric[grafanaAlertStateLabel])
+		lbls := make(data.Labels, len(series.Metric))
+		for k, v := range series.Metric {
+			switch k {
+			case model.MetricNameLabel, alertStateLabel, grafanaAlertStateLabel, alertRuleUIDLabel:
+				continue
+			}
+			lbls[string(k)] = string(v)
+		}
+		fp := labelFingerprint(lbls)
+		inst, ok := instances[fp]
+		if !ok {
+			inst = &promHistoryInstance{labels: lbls, states: make(map[int64]string)}
+			instances[fp] = inst
+		}
+		for _, s := range series.Values {
+			if math.IsNaN(float64(s.Value)) {
+				continue
+			}
+			ts := int64(s.Timestamp)
+			// The same instance can briefly have two state series, e.g. when a staleness marker was lost.
+			// Prefer the state with higher precedence in this case to keep the result deterministic.
+			if existing, ok := inst.states[ts]; ok && statePrecedence(existing) >= statePrecedence(grafanaState) {
+				continue
+			}
+			inst.states[ts] = grafanaState
+		}
+	}
+
+	streamLabels, err := json.Marshal(map[string]string{
+		StateHistoryLabelKey: StateHistoryLabelValue,
+		OrgIDLabel:           fmt.Sprint(rule.OrgID),
+		GroupLabel:           rule.RuleGroup,
+		FolderUIDLabel:       rule.NamespaceUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	normal := formatPrometheusState(eval.Normal.String())
+	var entries []promHistoryEntry
+	for fp, inst := range instances {
+		prev := ""
+		for t := query.From; !t.After(query.To); t = t.Add(step) {
+			curr, ok := inst.states[t.UnixMilli()]
+			if ok {
+				curr = formatPrometheusState(curr)
+			} else {
+				curr = normal
+			}
+			// The state at the beginning of the range is unknown, so the first sample is only used as a baseline.
+			if prev != "" && prev != curr {
+				entries = append(entries, promHistoryEntry{
+					t: t,
+					entry: LokiEntry{
+						SchemaVersion:  1,
+						Previous:       prev,
+						Current:        curr,
+						Values:         simplejson.New(),
+						Condition:      rule.Condition,
+						DashboardUID:   rule.GetDashboardUID(),
+						PanelID:        rule.GetPanelID(),
+						Fingerprint:    fp,
+						RuleTitle:      rule.Title,
+						RuleID:         rule.ID,
+						RuleUID:        rule.UID,
+						InstanceLabels: inst.labels,
+					},
+					labels: streamLabels,
+				})
+			}
+			prev = curr
+		}
+	}
+
+	entries = slices.DeleteFunc(entries, func(e promHistoryEntry) bool {
+		return (query.Previous != "" && !strings.HasPrefix(e.entry.Previous, query.Previous)) ||
+			(query.Current != "" && !strings.HasPrefix(e.entry.Current, query.Current))
+	})
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].t.Equal(entries[j].t) {
+			return entries[i].t.Before(entries[j].t)
+		}
+		return entries[i].entry.Fingerprint < entries[j].entry.Fingerprint
+	})
+	// Keep the most recent entries when the result is limited, like Loki does.
+	if query.Limit > 0 && len(entries) > query.Limit {
+		entries = entries[len(entries)-query.Limit:]
+	}
+
+	result := NewQueryResultBuilder(len(entries))
+	for _, e := range entries {
+		if err := result.AddRow(e.t, e.entry, e.labels); err != nil {
+			logger.Warn("Failed to serialize state history entry, skipping", "error", err, "fingerprint", e.entry.Fingerprint)
+		}
+	}
+	return result.ToFrame(), nil
+}
+
+// formatPrometheusState converts the lower-case state stored in the grafana_alertstate label
+// back to the format used by state.State.
+func formatPrometheusState(s string) string {
+	for _, st := range []eval.State{eval.Normal, eval.Alerting, eval.Pending, eval.NoData, eval.Error, eval.Recovering} {
+		if strings.EqualFold(st.String(), s) {
+			return st.String()
+		}
+	}
+	return s
+}
+
+// statePrecedence orders the metric-emitting states when an instance has several samples at the same time.
+func statePrecedence(s string) int {
+	switch formatPrometheusState(s) {
+	case eval.Alerting.String():
+		return 5
+	case eval.Error.String():
+		return 4
+	case eval.NoData.String():
+		return 3
+	case eval.Recovering.String():
+		return 2
+	case eval.Pending.String():
+		return 1
+	default:
+		return 0
+	}
+}
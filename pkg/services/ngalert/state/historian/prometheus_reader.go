@@ -0,0 +1,135 @@
+package historian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/httpclient"
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/adapters"
+)
+
+type httpClientProvider interface {
+	New(options ...httpclient.Options) (*http.Client, error)
+}
+
+type pluginContextProvider interface {
+	GetWithDataSource(ctx context.Context, pluginID string, user identity.Requester, ds *datasources.DataSource) (backend.PluginContext, error)
+}
+
+// PrometheusDatasourceReader runs range queries against the HTTP API of a Prometheus data source.
+// It is the read counterpart of writer.DatasourceWriter and uses the same data source HTTP settings.
+type PrometheusDatasourceReader struct {
+	datasources           datasources.DataSourceService
+	httpClientProvider    httpClientProvider
+	pluginContextProvider pluginContextProvider
+	logger                log.Logger
+}
+
+func NewPrometheusDatasourceReader(
+	datasources datasources.DataSourceService,
+	httpClientProvider httpClientProvider,
+	pluginContextProvider pluginContextProvider,
+	logger log.Logger,
+) *PrometheusDatasourceReader {
+	return &PrometheusDatasourceReader{
+		datasources:           datasources,
+		httpClientProvider:    httpClientProvider,
+		pluginContextProvider: pluginContextProvider,
+		logger:                logger,
+	}
+}
+
+// QueryRange executes the PromQL expression over the given range and returns the resulting matrix.
+func (r *PrometheusDatasourceReader) QueryRange(ctx context.Context, dsUID string, orgID int64, query string, start, end time.Time, step time.Duration) (model.Matrix, error) {
+	api, err := r.makeAPI(ctx, dsUID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, warnings, err := api.QueryRange(ctx, query, promv1.Range{Start: start, End: end, Step: step})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data source: %w", err)
+	}
+	if len(warnings) > 0 {
+		r.logger.FromContext(ctx).Warn("Prometheus returned warnings for state history query", "datasource_uid", dsUID, "warnings", warnings)
+	}
+
+	matrix, ok := res.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %s, expected matrix", res.Type())
+	}
+	return matrix, nil
+}
+
+func (r *PrometheusDatasourceReader) makeAPI(ctx context.Context, dsUID string, orgID int64) (promv1.API, error) {
+	ds, err := r.datasources.GetDataSource(ctx, &datasources.GetDataSourceQuery{
+		UID:   dsUID,
+		OrgID: orgID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ds.Type != datasources.DS_PROMETHEUS {
+		return nil, errors.New("can only read from data sources of type prometheus")
+	}
+
+	is, err := adapters.ModelToInstanceSettings(ds, r.decrypt)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClientCtx := ctx
+	if r.pluginContextProvider != nil {
+		pluginCtx, err := r.pluginContextProvider.GetWithDataSource(ctx, ds.Type, nil, ds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get plugin context: %w", err)
+		}
+		httpClientCtx = backend.WithGrafanaConfig(ctx, pluginCtx.GrafanaConfig)
+	}
+
+	ho, err := is.HTTPClientOptions(httpClientCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	dsHeaders, err := r.datasources.CustomHeaders(ctx, ds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get headers for data source: %w", err)
+	}
+	ho.Header = dsHeaders
+
+	cl, err := r.httpClientProvider.New(ho)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := promapi.NewClient(promapi.Config{
+		Address: ds.URL,
+		Client:  cl,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return promv1.NewAPI(client), nil
+}
+
+func (r *PrometheusDatasourceReader) decrypt(ds *datasources.DataSource) (map[string]string, error) {
+	decryptedJsonData, err := r.datasources.DecryptedValues(context.Background(), ds)
+	if err != nil {
+		r.logger.Error("Failed to decrypt secure json data", "error", err)
+	}
+	return decryptedJsonData, err
+}
@@ -3,6 +3,7 @@ package historian
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"math"
 	"testing"
@@ -10,18 +11,22 @@ import (
 
 	"github.com/grafana/dataplane/sdata/numeric"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
 	promValue "github.com/prometheus/prometheus/model/value"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/infra/log"
+	acfakes "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
@@ -38,6 +43,18 @@ func (f *fakeRemoteWriter) WriteDatasource(ctx context.Context, dsUID string, na
 	return args.Error(0)
 }
 
+type fakeRemoteReader struct {
+	mock.Mock
+}
+
+func (f *fakeRemoteReader) QueryRange(ctx context.Context, dsUID string, orgID int64, query string, start, end time.Time, step time.Duration) (model.Matrix, error) {
+	args := f.Called(ctx, dsUID, orgID, query, start, end, step)
+	if m, ok := args.Get(0).(model.Matrix); ok {
+		return m, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 type panicRemoteWriter struct {
 	mock.Mock
 	panicMessage string
@@ -59,7 +76,7 @@ func TestNewRemotePrometheusBackend(t *testing.T) {
 	logger := log.NewNopLogger()
 	met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
 
-	backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
+	backend := NewRemotePrometheusBackend(cfg, fakeWriter, new(fakeRemoteReader), fakes.NewRuleStore(t), &acfakes.FakeRuleService{}, logger, met)
 
 	require.NotNil(t, backend)
 	require.Equal(t, cfg.DatasourceUID, backend.cfg.DatasourceUID)
@@ -287,7 +304,7 @@ func TestPrometheusBackend_Record(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			fakeWriter := new(fakeRemoteWriter)
 			met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
-			backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
+			backend := NewRemotePrometheusBackend(cfg, fakeWriter, new(fakeRemoteReader), fakes.NewRuleStore(t), &acfakes.FakeRuleService{}, logger, met)
 
 			if tc.expectedFrames != nil {
 				var extraLabels map[string]string
@@ -319,20 +336,6 @@ func TestPrometheusBackend_Record(t *testing.T) {
 	}
 }
 
-func TestPrometheusBackend_Query(t *testing.T) {
-	cfg := PrometheusConfig{DatasourceUID: "test-ds-uid", MetricName: testMetricName}
-	logger := log.NewNopLogger()
-	fakeWriter := new(fakeRemoteWriter)
-	met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
-
-	backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
-
-	frame, err := backend.Query(context.Background(), ngmodels.HistoryQuery{})
-	require.Error(t, err)
-	require.Nil(t, frame)
-	require.Contains(t, err.Error(), "prometheus historian backend does not support querying")
-}
-
 func TestPrometheusBackend_Record_Metrics(t *testing.T) {
 	cfg := PrometheusConfig{DatasourceUID: "test-ds-uid", MetricName: testMetricName}
 	logger := log.NewNopLogger()
@@ -347,7 +350,7 @@ func TestPrometheusBackend_Record_Metrics(t *testing.T) {
 
 		registry := prometheus.NewRegistry()
 		met := metrics.NewHistorianMetrics(registry, "test")
-		backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
+		backend := NewRemotePrometheusBackend(cfg, fakeWriter, new(fakeRemoteReader), fakes.NewRuleStore(t), &acfakes.FakeRuleService{}, logger, met)
 
 		states := []state.StateTransition{
 			{State: &state.State{AlertRuleUID: "rule-uid", OrgID: orgID, Labels: data.Labels{}, State: eval.Alerting, LastEvaluationTime: now}},
@@ -384,7 +387,7 @@ func TestPrometheusBackend_Record_Metrics(t *testing.T) {
 
 		registry := prometheus.NewRegistry()
 		met := metrics.NewHistorianMetrics(registry, "test")
-		backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
+		backend := NewRemotePrometheusBackend(cfg, fakeWriter, new(fakeRemoteReader), fakes.NewRuleStore(t), &acfakes.FakeRuleService{}, logger, met)
 
 		states := []state.StateTransition{
 			{State: &state.State{AlertRuleUID: "rule-uid", OrgID: orgID, Labels: data.Labels{}, State: eval.Alerting, LastEvaluationTime: now}},
@@ -434,7 +437,7 @@ func TestPrometheusBackend_Record_PanicRecovery(t *testing.T) {
 	panicWriter.On("WriteDatasource", ctx, cfg.DatasourceUID, testMetricName, now, mock.Anything, orgID, mock.Anything).Once()
 
 	met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
-	backend := NewRemotePrometheusBackend(cfg, panicWriter, logger, met)
+	backend := NewRemotePrometheusBackend(cfg, panicWriter, new(fakeRemoteReader), fakes.NewRuleStore(t), &acfakes.FakeRuleService{}, logger, met)
 
 	states := []state.StateTransition{
 		{State: &state.State{
@@ -456,3 +459,154 @@ func TestPrometheusBackend_Record_PanicRecovery(t *testing.T) {
 
 	panicWriter.AssertExpectations(t)
 }
+
+func TestPrometheusBackend_Query(t *testing.T) {
+	cfg := PrometheusConfig{DatasourceUID: "test-ds-uid", MetricName: testMetricName}
+	logger := log.NewNopLogger()
+	ctx := context.Background()
+	from := time.Unix(1700000000, 0).UTC()
+	to := from.Add(5 * time.Minute)
+
+	rule := ngmodels.RuleGen.With(
+		ngmodels.RuleMuts.WithOrgID(1),
+		withUID("my-rule"),
+		ngmodels.RuleMuts.WithTitle("my rule"),
+		ngmodels.RuleMuts.WithInterval(time.Minute),
+	).GenerateRef()
+
+	// server1 is pending at 1m, alerting at 2m and 3m, and normal again from 4m.
+	matrix := model.Matrix{
+		{
+			Metric: model.Metric{
+				model.MetricNameLabel:  testMetricName,
+				alertRuleUIDLabel:      "my-rule",
+				alertNameLabel:         "my rule",
+				alertStateLabel:        "pending",
+				grafanaAlertStateLabel: "pending",
+				"instance":             "server1",
+			},
+			Values: []model.SamplePair{
+				{Timestamp: model.TimeFromUnixNano(from.Add(time.Minute).UnixNano()), Value: 1},
+			},
+		},
+		{
+			Metric: model.Metric{
+				model.MetricNameLabel:  testMetricName,
+				alertRuleUIDLabel:      "my-rule",
+				alertNameLabel:         "my rule",
+				alertStateLabel:        "firing",
+				grafanaAlertStateLabel: "alerting",
+				"instance":             "server1",
+			},
+			Values: []model.SamplePair{
+				{Timestamp: model.TimeFromUnixNano(from.Add(2 * time.Minute).UnixNano()), Value: 1},
+				{Timestamp: model.TimeFromUnixNano(from.Add(3 * time.Minute).UnixNano()), Value: 1},
+			},
+		},
+	}
+
+	createSut := func(t *testing.T, reader *fakeRemoteReader) *RemotePrometheusBackend {
+		t.Helper()
+		rules := fakes.NewRuleStore(t)
+		rules.Rules[1] = []*ngmodels.AlertRule{rule}
+		met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
+		return NewRemotePrometheusBackend(cfg, new(fakeRemoteWriter), reader, rules, &acfakes.FakeRuleService{}, logger, met)
+	}
+
+	t.Run("requires rule UID", func(t *testing.T) {
+		backend := createSut(t, new(fakeRemoteReader))
+
+		_, err := backend.Query(ctx, ngmodels.HistoryQuery{OrgID: 1, From: from, To: to})
+
+		require.ErrorContains(t, err, "ruleUID is required")
+	})
+
+	t.Run("fails when rule does not exist", func(t *testing.T) {
+		backend := createSut(t, new(fakeRemoteReader))
+
+		_, err := backend.Query(ctx, ngmodels.HistoryQuery{OrgID: 1, RuleUID: "missing", From: from, To: to})
+
+		require.Error(t, err)
+	})
+
+	t.Run("converts series into state transitions", func(t *testing.T) {
+		reader := new(fakeRemoteReader)
+		reader.On("QueryRange", ctx, cfg.DatasourceUID, int64(1), `{__name__="test_metric_name",grafana_rule_uid="my-rule"}`, from, to, time.Minute).Return(matrix, nil).Once()
+		backend := createSut(t, reader)
+
+		res, err := backend.Query(ctx, ngmodels.HistoryQuery{OrgID: 1, RuleUID: "my-rule", From: from, To: to})
+
+		require.NoError(t, err)
+		reader.AssertExpectations(t)
+		require.Equal(t, 3, res.Rows())
+
+		expected := []struct {
+			t        time.Time
+			previous string
+			current  string
+		}{
+			{from.Add(time.Minute), "Normal", "Pending"},
+			{from.Add(2 * time.Minute), "Pending", "Alerting"},
+			{from.Add(4 * time.Minute), "Alerting", "Normal"},
+		}
+		for i, exp := range expected {
+			require.Equal(t, exp.t, res.Fields[0].At(i).(time.Time))
+
+			var entry LokiEntry
+			require.NoError(t, json.Unmarshal(res.Fields[1].At(i).(json.RawMessage), &entry))
+			require.Equal(t, exp.previous, entry.Previous)
+			require.Equal(t, exp.current, entry.Current)
+			require.Equal(t, "my-rule", entry.RuleUID)
+			require.Equal(t, "my rule", entry.RuleTitle)
+			require.Equal(t, map[string]string{"instance": "server1", alertNameLabel: "my rule"}, entry.InstanceLabels)
+
+			var streamLabels map[string]string
+			require.NoError(t, json.Unmarshal(res.Fields[2].At(i).(json.RawMessage), &streamLabels))
+			require.Equal(t, StateHistoryLabelValue, streamLabels[StateHistoryLabelKey])
+			require.Equal(t, rule.NamespaceUID, streamLabels[FolderUIDLabel])
+		}
+	})
+
+	t.Run("filters by current state and applies limit", func(t *testing.T) {
+		reader := new(fakeRemoteReader)
+		reader.On("QueryRange", ctx, cfg.DatasourceUID, int64(1), mock.Anything, from, to, time.Minute).Return(matrix, nil).Once()
+		backend := createSut(t, reader)
+
+		res, err := backend.Query(ctx, ngmodels.HistoryQuery{OrgID: 1, RuleUID: "my-rule", From: from, To: to, Current: "Normal", Limit: 1})
+
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Rows())
+		require.Equal(t, from.Add(4*time.Minute), res.Fields[0].At(0).(time.Time))
+	})
+
+	t.Run("returns error when data source query fails", func(t *testing.T) {
+		reader := new(fakeRemoteReader)
+		reader.On("QueryRange", ctx, cfg.DatasourceUID, int64(1), mock.Anything, from, to, time.Minute).Return(nil, errors.New("boom")).Once()
+		backend := createSut(t, reader)
+
+		_, err := backend.Query(ctx, ngmodels.HistoryQuery{OrgID: 1, RuleUID: "my-rule", From: from, To: to})
+
+		require.ErrorContains(t, err, "boom")
+	})
+}
+
+func TestBuildPrometheusQuery(t *testing.T) {
+	query := ngmodels.HistoryQuery{
+		RuleUID: "my-rule",
+		Labels: labels.Matchers{
+			mustNewMatcher(t, labels.MatchRegexp, "team", "a|b"),
+			mustNewMatcher(t, labels.MatchEqual, "invalid.label", "x"),
+		},
+	}
+
+	require.Equal(t, `{__name__="ALERTS",grafana_rule_uid="my-rule",invalid_label="x",team=~"a|b"}`, buildPrometheusQuery("ALERTS", query))
+}
+
+func TestPrometheusQueryStep(t *testing.T) {
+	from := time.Unix(0, 0)
+
+	require.Equal(t, time.Minute, prometheusQueryStep(0, from, from.Add(time.Hour)))
+	require.Equal(t, 10*time.Second, prometheusQueryStep(10*time.Second, from, from.Add(time.Hour)))
+	step := prometheusQueryStep(10*time.Second, from, from.Add(30*24*time.Hour))
+	require.LessOrEqual(t, int64(30*24*time.Hour/step), int64(maxPrometheusQueryPoints))
+}
@@ -53,6 +53,7 @@ type Manager struct {
 	images        ImageCapturer
 	historian     Historian
 	externalURL   *url.URL
+	silencer      Silencer
 
 	rulesPerRuleGroupLimit int64
 
@@ -68,6 +69,8 @@ type ManagerCfg struct {
 	Images        ImageCapturer
 	Clock         clock.Clock
 	Historian     Historian
+	// Silencer suppresses the notifications of acknowledged alert instances. Notifications cannot be suppressed if it is nil.
+	Silencer Silencer
 	// MaxStateSaveConcurrency controls the number of goroutines (per rule) that can save alert state in parallel.
 	MaxStateSaveConcurrency int
 	// StatePeriodicSaveBatchSize controls the size of the alert instance batch that is saved periodically when the
@@ -108,6 +111,7 @@ func NewManager(cfg ManagerCfg, statePersister StatePersister) *Manager {
 		instanceStore:          cfg.InstanceStore,
 		images:                 cfg.Images,
 		historian:              cfg.Historian,
+		silencer:               cfg.Silencer,
 		clock:                  cfg.Clock,
 		externalURL:            cfg.ExternalURL,
 		rulesPerRuleGroupLimit: cfg.RulesPerRuleGroupLimit,
@@ -194,6 +198,11 @@ func (st *Manager) Warm(ctx context.Context, orgReader OrgReader, rulesReader Ru
 			}
 
 			state := AlertInstanceToState(entry, logger)
+			if len(ruleForEntry.SeverityConditions) > 0 {
+				// The severity label is not part of the cache ID of rules with severity conditions.
+				state.Severity = state.Labels[ngModels.SeverityLabel]
+				state.CacheID = withoutSeverity(state.Labels).Fingerprint()
+			}
 
 			// Use persisted annotations if available, otherwise fall back to rule annotations
 			if len(state.Annotations) == 0 {
@@ -352,11 +361,25 @@ func (st *Manager) ProcessEvalResults(
 
 	allChanges := StateTransitions(append(states, missingSeriesStates...))
 
+	if alertRule.FlapDetection != nil && st.metrics != nil {
+		for _, t := range allChanges {
+			if t.IsFlapping() && t.PreviousStateReason != ngModels.StateReasonFlapping {
+				st.metrics.FlappingDetected.Inc()
+			}
+		}
+	}
+
+	st.removeAcknowledgements(ctx, logger, evaluatedAt, allChanges)
+
 	// It's important that this is done *before* we sync the states to the persister. Otherwise, we will not persist
 	// the LastSentAt field to the store.
 	var statesToSend StateTransitions
 	if send != nil {
-		statesToSend = st.updateLastSentAt(allChanges, evaluatedAt)
+		if alertRule.FlapDetection != nil && alertRule.FlapDetection.NotificationPolicy == ngModels.FlapSuppress {
+			statesToSend = append(st.updateLastSentAt(allChanges.NotFlapping(), evaluatedAt), st.resendFlapping(allChanges, evaluatedAt)...)
+		} else {
+			statesToSend = st.updateLastSentAt(allChanges, evaluatedAt)
+		}
 	}
 
 	st.persister.Sync(ctx, span, alertRule.GetKeyWithGroup(), allChanges)
@@ -378,6 +401,25 @@ func (st *Manager) ProcessEvalResults(
 func (st *Manager) updateLastSentAt(states StateTransitions, evaluatedAt time.Time) StateTransitions {
 	var result StateTransitions
 	for _, t := range states {
+		// A firing state whose severity changed is sent immediately, as it is a new alert in the Alertmanager.
+		if t.NeedsSending(evaluatedAt, st.ResendDelay, st.ResolvedRetention) || t.SeverityChanged() && t.State.State == eval.Alerting {
+			t.LastSentAt = &evaluatedAt
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// resendFlapping returns the flapping StateTransitions that are re-sent to the Alertmanager and updates their LastSentAt field.
+// Flapping instances are held in their firing state and must be re-sent, otherwise the Alertmanager resolves them.
+// They do not cause new notifications: instances that have not been sent are not sent,
+// and changes of the severity are sent only when the alert is re-sent.
+func (st *Manager) resendFlapping(states StateTransitions, evaluatedAt time.Time) StateTransitions {
+	var result StateTransitions
+	for _, t := range states {
+		if !t.IsFlapping() || t.LastSentAt == nil {
+			continue
+		}
 		if t.NeedsSending(evaluatedAt, st.ResendDelay, st.ResolvedRetention) {
 			t.LastSentAt = &evaluatedAt
 			result = append(result, t)
@@ -437,7 +479,8 @@ func (st *Manager) setNextStateForRule(ctx context.Context, alertRule *ngModels.
 	transitions := make([]StateTransition, 0, len(results))
 	for _, result := range results {
 		newState := newState(ctx, logger, alertRule, result, extraLabels, st.externalURL)
-		if curState := st.cache.get(alertRule.OrgID, alertRule.UID, newState.CacheID); curState != nil {
+		curState := st.cache.get(alertRule.OrgID, alertRule.UID, newState.CacheID)
+		if curState != nil {
 			patch(newState, curState, result)
 		}
 		start := st.clock.Now()
@@ -445,7 +488,7 @@ func (st *Manager) setNextStateForRule(ctx context.Context, alertRule *ngModels.
 		if st.metrics != nil {
 			st.metrics.StateUpdateDuration.Observe(st.clock.Now().Sub(start).Seconds())
 		}
-		st.cache.set(newState) // replace the existing state with the new one
+		st.cache.replace(newState, curState) // replace the existing state with the new one
 		transitions = append(transitions, s)
 	}
 	return transitions
@@ -467,7 +510,7 @@ func (st *Manager) setNextStateForAll(alertRule *ngModels.AlertRule, result eval
 		updated.states[newState.CacheID] = newState
 		transitions = append(transitions, t)
 	}
-	st.cache.setRuleStates(alertRule.GetKey(), updated)
+	st.cache.setRuleStates(alertRule.GetKey(), updated, currentStates)
 	return transitions
 }
 
@@ -28,6 +28,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/annotations/annotationstest"
 	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	acfakes "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
@@ -2248,6 +2249,159 @@ func TestStaleResults(t *testing.T) {
 	})
 }
 
+func TestProcessEvalResultsSeverity(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock()
+
+	cfg := state.ManagerCfg{
+		Metrics:       metrics.NewNGAlert(prometheus.NewPedanticRegistry()).GetStateMetrics(),
+		ExternalURL:   nil,
+		InstanceStore: &state.FakeInstanceStore{},
+		Images:        &state.NoopImageService{},
+		Clock:         clk,
+		Historian:     &state.FakeHistorian{},
+		Tracer:        tracing.InitializeTracerForTest(),
+		Log:           log.New("ngalert.state.manager"),
+	}
+	st := state.NewManager(cfg, state.NewNoopPersister())
+
+	gen := models.RuleGen
+	rule := gen.With(gen.WithFor(0), gen.WithLabels(data.Labels{"team": "a"}), gen.WithSeverityConditions(
+		models.SeverityCondition{Severity: "warning", Condition: "B"},
+		models.SeverityCondition{Severity: "critical", Condition: "C"},
+	)).GenerateRef()
+
+	process := func(resultState eval.State, severity string) (state.StateTransitions, state.StateTransitions) {
+		result := eval.ResultGen(eval.WithState(resultState), eval.WithLabels(data.Labels{"host": "a"}), eval.WithEvaluatedAt(clk.Now()))()
+		result.Severity = severity
+		var statesToSend state.StateTransitions
+		processed := st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{result}, nil, func(_ context.Context, states state.StateTransitions) {
+			statesToSend = states
+		})
+		require.Len(t, processed, 1)
+		return processed, statesToSend
+	}
+
+	processed, toSend := process(eval.Alerting, "warning")
+	cacheID := processed[0].CacheID
+	require.Equal(t, eval.Alerting, processed[0].State.State)
+	require.Equal(t, "warning", processed[0].Severity)
+	require.Equal(t, "warning", processed[0].Labels[models.SeverityLabel])
+	require.Len(t, toSend, 1)
+
+	t.Run("severity change should be a transition of the same state", func(t *testing.T) {
+		clk.Add(time.Duration(rule.IntervalSeconds) * time.Second)
+		processed, toSend := process(eval.Alerting, "critical")
+		require.Equal(t, cacheID, processed[0].CacheID)
+		require.Equal(t, eval.Alerting, processed[0].PreviousState)
+		require.Equal(t, "warning", processed[0].PreviousSeverity)
+		require.Equal(t, "critical", processed[0].Labels[models.SeverityLabel])
+		require.Equal(t, "warning", processed[0].PreviousLabels()[models.SeverityLabel])
+		require.True(t, processed[0].Changed())
+		// The state is sent even though the resend delay has not passed.
+		require.Len(t, toSend, 1)
+
+		stopped := state.FromSeverityChangesToStoppedAlert(processed, nil, clk, featuremgmt.WithFeatures())
+		require.Len(t, stopped.PostableAlerts, 1)
+		require.Equal(t, "warning", stopped.PostableAlerts[0].Labels[models.SeverityLabel])
+		require.Len(t, st.GetStatesForRuleUID(ctx, rule.OrgID, rule.UID), 1)
+	})
+
+	t.Run("resolved state should keep the last severity", func(t *testing.T) {
+		clk.Add(time.Duration(rule.IntervalSeconds) * time.Second)
+		processed, _ := process(eval.Normal, "")
+		require.Equal(t, cacheID, processed[0].CacheID)
+		require.Equal(t, eval.Normal, processed[0].State.State)
+		require.NotNil(t, processed[0].ResolvedAt)
+		require.False(t, processed[0].SeverityChanged())
+		require.Equal(t, "critical", processed[0].Labels[models.SeverityLabel])
+	})
+}
+
+func TestProcessEvalResultsFlapping(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock()
+	m := metrics.NewNGAlert(prometheus.NewPedanticRegistry()).GetStateMetrics()
+
+	cfg := state.ManagerCfg{
+		Metrics:       m,
+		ExternalURL:   nil,
+		InstanceStore: &state.FakeInstanceStore{},
+		Images:        &state.NoopImageService{},
+		Clock:         clk,
+		Historian:     &state.FakeHistorian{},
+		Tracer:        tracing.InitializeTracerForTest(),
+		Log:           log.New("ngalert.state.manager"),
+	}
+	st := state.NewManager(cfg, state.NewNoopPersister())
+
+	gen := models.RuleGen
+	rule := gen.With(gen.WithFor(0), gen.WithKeepFiringFor(0), gen.WithIntervalSeconds(60), gen.WithFlapDetection(models.FlapDetection{
+		Transitions:        3,
+		Window:             10 * time.Minute,
+		NotificationPolicy: models.FlapSuppress,
+	})).GenerateRef()
+
+	process := func(resultState eval.State) (state.StateTransition, state.StateTransitions) {
+		clk.Add(time.Minute)
+		result := eval.ResultGen(eval.WithState(resultState), eval.WithLabels(data.Labels{"host": "a"}), eval.WithEvaluatedAt(clk.Now()))()
+		var statesToSend state.StateTransitions
+		processed := st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{result}, nil, func(_ context.Context, states state.StateTransitions) {
+			statesToSend = states
+		})
+		require.Len(t, processed, 1)
+		return processed[0], statesToSend
+	}
+
+	tr, _ := process(eval.Alerting)
+	require.Equal(t, eval.Alerting, tr.State.State)
+	tr, _ = process(eval.Normal)
+	require.Equal(t, eval.Normal, tr.State.State)
+	tr, _ = process(eval.Alerting)
+	require.Equal(t, eval.Alerting, tr.State.State)
+	require.False(t, tr.IsFlapping())
+
+	t.Run("flapping instance should be held in its firing state", func(t *testing.T) {
+		tr, toSend := process(eval.Normal)
+		require.Equal(t, eval.Alerting, tr.State.State)
+		require.True(t, tr.IsFlapping())
+		require.Equal(t, "Alerting (Flapping)", tr.Formatted())
+		require.Equal(t, "3", tr.Annotations[models.FlappingAnnotation])
+		require.Nil(t, tr.ResolvedAt)
+		// The instance was sent as firing before, so it is re-sent to keep it firing in the Alertmanager.
+		require.Len(t, toSend, 1)
+		require.Equal(t, eval.Alerting, toSend[0].State.State)
+
+		tr, toSend = process(eval.Alerting)
+		require.Equal(t, eval.Alerting, tr.State.State)
+		require.True(t, tr.IsFlapping())
+		require.Len(t, toSend, 1)
+		require.Equal(t, 1.0, testutil.ToFloat64(m.FlappingDetected))
+	})
+
+	t.Run("flapping instance should not be re-sent before the resend delay", func(t *testing.T) {
+		clk.Add(state.ResendDelay / 2)
+		result := eval.ResultGen(eval.WithState(eval.Normal), eval.WithLabels(data.Labels{"host": "a"}), eval.WithEvaluatedAt(clk.Now()))()
+		var toSend state.StateTransitions
+		processed := st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{result}, nil, func(_ context.Context, states state.StateTransitions) {
+			toSend = states
+		})
+		require.Len(t, processed, 1)
+		require.True(t, processed[0].IsFlapping())
+		require.Empty(t, toSend)
+	})
+
+	t.Run("instance should be resolved when it stops flapping", func(t *testing.T) {
+		clk.Add(10 * time.Minute)
+		tr, toSend := process(eval.Normal)
+		require.Equal(t, eval.Normal, tr.State.State)
+		require.False(t, tr.IsFlapping())
+		require.NotContains(t, tr.Annotations, models.FlappingAnnotation)
+		require.NotNil(t, tr.ResolvedAt)
+		require.Len(t, toSend, 1)
+	})
+}
+
 func TestIntegrationDeleteStateByRuleUID(t *testing.T) {
 	tutil.SkipIntegrationTestInShortMode(t)
 
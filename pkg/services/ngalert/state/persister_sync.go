@@ -41,10 +41,40 @@ func (a *SyncStatePersister) Sync(ctx context.Context, span trace.Span, _ ngMode
 		))
 	}
 
+	severityChanges := allStates.SeverityChanges()
+	if len(severityChanges) > 0 {
+		a.deletePreviousSeverityStates(ctx, severityChanges)
+		span.AddEvent("deleted states with previous severity", trace.WithAttributes(
+			attribute.Int64("state_transitions", int64(len(severityChanges))),
+		))
+	}
+
 	a.saveAlertStates(ctx, allStates...)
 	span.AddEvent("updated database")
 }
 
+// deletePreviousSeverityStates deletes the alert instances that were saved with the labels of the previous severity.
+func (a *SyncStatePersister) deletePreviousSeverityStates(ctx context.Context, states []StateTransition) {
+	if a.store == nil {
+		return
+	}
+	logger := a.log.FromContext(ctx)
+	toDelete := make([]ngModels.AlertInstanceKey, 0, len(states))
+	for _, s := range states {
+		_, labelsHash, err := ngModels.InstanceLabels(s.PreviousLabels()).StringAndHash()
+		if err != nil {
+			logger.Error("Failed to delete alert instance with invalid labels", "cacheID", s.CacheID, "error", err)
+			continue
+		}
+		toDelete = append(toDelete, ngModels.AlertInstanceKey{RuleOrgID: s.OrgID, RuleUID: s.AlertRuleUID, LabelsHash: labelsHash})
+	}
+
+	err := a.store.DeleteAlertInstances(ctx, toDelete...)
+	if err != nil {
+		logger.Error("Failed to delete states with previous severity", "error", err)
+	}
+}
+
 func (a *SyncStatePersister) deleteAlertStates(ctx context.Context, states []StateTransition) {
 	if a.store == nil || len(states) == 0 {
 		return
@@ -113,6 +143,7 @@ func (a *SyncStatePersister) saveAlertStates(ctx context.Context, states ...Stat
 			EvaluationDuration: s.EvaluationDuration,
 			LastError:          lastError,
 			LastResult:         lastResult,
+			Acknowledgement:    s.Acknowledgement,
 		}
 
 		err = a.store.SaveAlertInstance(ctx, instance)
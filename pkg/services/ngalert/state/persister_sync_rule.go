@@ -75,6 +75,7 @@ func (a *SyncRuleStatePersister) Sync(ctx context.Context, span trace.Span, rule
 			EvaluationDuration: s.EvaluationDuration,
 			LastError:          lastError,
 			LastResult:         lastResult,
+			Acknowledgement:    s.Acknowledgement,
 		}
 
 		instancesToSave = append(instancesToSave, instance)
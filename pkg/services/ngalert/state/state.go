@@ -7,6 +7,7 @@ import (
 	"maps"
 	"math"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -78,10 +79,26 @@ type State struct {
 	LastEvaluationString string
 	LastEvaluationTime   time.Time
 	EvaluationDuration   time.Duration
+
+	// Acknowledgement is set if a user has acknowledged the alert instance.
+	Acknowledgement models.Acknowledgement
+
+	// Severity is the severity of the state for rules with severity conditions. It is also set as
+	// the label models.SeverityLabel, but is not part of the CacheID, so that a change of severity
+	// is a transition of the same state rather than a new one.
+	Severity string
+
+	// FlapTransitions contains the times at which the result changed between firing and not firing
+	// within the flap detection window of the rule. It is not persisted.
+	FlapTransitions []time.Time
 }
 
 func newState(ctx context.Context, log log.Logger, alertRule *models.AlertRule, result eval.Result, extraLabels data.Labels, externalURL *url.URL) *State {
 	lbs, annotations := expandAnnotationsAndLabels(ctx, log, alertRule, result, extraLabels, externalURL)
+	if len(alertRule.SeverityConditions) > 0 {
+		// The severity label is owned by the severity conditions and is set when the state transitions.
+		delete(lbs, models.SeverityLabel)
+	}
 
 	cacheID := lbs.Fingerprint()
 	// For new states, we set StartsAt & EndsAt to EvaluatedAt as this is the
@@ -138,6 +155,9 @@ func (a *State) Copy() *State {
 		LastEvaluationString: a.LastEvaluationString,
 		LastEvaluationTime:   a.LastEvaluationTime,
 		EvaluationDuration:   a.EvaluationDuration,
+		Acknowledgement:      a.Acknowledgement,
+		Severity:             a.Severity,
+		FlapTransitions:      a.FlapTransitions,
 	}
 }
 
@@ -284,11 +304,55 @@ func (a *State) SetNextValues(result eval.Result) {
 	a.Values = newValues
 }
 
+// IsFlapping returns true if the state is held in its firing state because it is flapping.
+func (a *State) IsFlapping() bool {
+	return a.StateReason == models.StateReasonFlapping
+}
+
+// updateFlapTransitions records whether the result changed between firing and not firing since the previous result,
+// forgets the changes that are outside the flap detection window of the rule, and returns the number of changes in the window.
+func (a *State) updateFlapTransitions(rule *models.AlertRule, previous *Evaluation, result eval.Result) int {
+	if rule.FlapDetection == nil {
+		a.FlapTransitions = nil
+		return 0
+	}
+	since := result.EvaluatedAt.Add(-rule.FlapDetection.Window)
+	transitions := make([]time.Time, 0, len(a.FlapTransitions)+1)
+	for _, t := range a.FlapTransitions {
+		if t.After(since) {
+			transitions = append(transitions, t)
+		}
+	}
+	if previous != nil && (previous.EvaluationState == eval.Alerting) != (result.State == eval.Alerting) {
+		transitions = append(transitions, result.EvaluatedAt)
+	}
+	a.FlapTransitions = transitions
+	return len(transitions)
+}
+
+// setSeverity sets the severity of the state and updates the severity label.
+func (a *State) setSeverity(severity string) {
+	a.Severity = severity
+	if severity == "" {
+		delete(a.Labels, models.SeverityLabel)
+		return
+	}
+	a.Labels[models.SeverityLabel] = severity
+}
+
+// withoutSeverity returns a copy of the labels without the severity label.
+func withoutSeverity(lbs data.Labels) data.Labels {
+	result := lbs.Copy()
+	delete(result, models.SeverityLabel)
+	return result
+}
+
 // StateTransition describes the transition from one state to another.
 type StateTransition struct {
 	*State
 	PreviousState       eval.State
 	PreviousStateReason string
+	PreviousSeverity    string
 }
 
 func (c StateTransition) Formatted() string {
@@ -300,11 +364,50 @@ func (c StateTransition) PreviousFormatted() string {
 }
 
 func (c StateTransition) Changed() bool {
-	return c.PreviousState != c.State.State || c.PreviousStateReason != c.StateReason
+	return c.PreviousState != c.State.State || c.PreviousStateReason != c.StateReason || c.SeverityChanged()
+}
+
+// SeverityChanged returns true if the severity of the state changed in the transition.
+func (c StateTransition) SeverityChanged() bool {
+	return c.PreviousSeverity != c.Severity
+}
+
+// PreviousLabels returns the labels of the state before the transition.
+func (c StateTransition) PreviousLabels() data.Labels {
+	if !c.SeverityChanged() {
+		return c.Labels
+	}
+	lbs := withoutSeverity(c.Labels)
+	if c.PreviousSeverity != "" {
+		lbs[models.SeverityLabel] = c.PreviousSeverity
+	}
+	return lbs
 }
 
 type StateTransitions []StateTransition
 
+// SeverityChanges returns the subset of StateTransitions whose severity changed.
+func (c StateTransitions) SeverityChanges() StateTransitions {
+	var result StateTransitions
+	for _, t := range c {
+		if t.SeverityChanged() {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// NotFlapping returns the subset of StateTransitions that are not flapping.
+func (c StateTransitions) NotFlapping() StateTransitions {
+	var result StateTransitions
+	for _, t := range c {
+		if !t.IsFlapping() {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
 // StaleStates returns the subset of StateTransitions that are stale.
 func (c StateTransitions) StaleStates() StateTransitions {
 	var result StateTransitions
@@ -811,6 +914,9 @@ func patch(newState, existingState *State, result eval.Result) {
 	newState.FiredAt = existingState.FiredAt
 	newState.ResolvedAt = existingState.ResolvedAt
 	newState.LastSentAt = existingState.LastSentAt
+	newState.Acknowledgement = existingState.Acknowledgement
+	newState.Severity = existingState.Severity
+	newState.FlapTransitions = existingState.FlapTransitions
 	// Annotations can change over time, however we also want to maintain
 	// certain annotations across evaluations
 	for key := range models.InternalAnnotationNameSet { // Changing in
@@ -830,6 +936,7 @@ func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extr
 	a.LastEvaluationTime = result.EvaluatedAt
 	a.EvaluationDuration = result.EvaluationDuration
 	a.SetNextValues(result)
+	flapTransitions := a.updateFlapTransitions(alertRule, a.LatestResult, result)
 	a.LatestResult = &Evaluation{
 		EvaluationTime:  result.EvaluatedAt,
 		EvaluationState: result.State,
@@ -839,12 +946,23 @@ func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extr
 	a.LastEvaluationString = result.EvaluationString
 	oldState := a.State
 	oldReason := a.StateReason
+	oldSeverity := a.Severity
 
 	// Add the instance to the log context to help correlate log lines for a state
 	logger = logger.New("instance", result.Instance)
 
+	// Firing states that are flapping are held in their state until they stop flapping.
+	firing := oldState == eval.Alerting || oldState == eval.Recovering
+	flapping := firing && alertRule.FlapDetection != nil && flapTransitions >= alertRule.FlapDetection.Transitions &&
+		(result.State == eval.Normal || result.State == eval.Alerting)
+
 	switch result.State {
 	case eval.Normal:
+		if flapping {
+			logger.Debug("Keeping state of flapping instance", "state", a.State, "transitions", flapTransitions)
+			a.Maintain(alertRule.IntervalSeconds, result.EvaluatedAt)
+			break
+		}
 		logger.Debug("Setting next state", "handler", "resultNormal")
 		resultNormal(a, alertRule, result, logger, "")
 	case eval.Alerting:
@@ -861,6 +979,17 @@ func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extr
 		logger.Debug("Ignoring set next state", "state", result.State)
 	}
 
+	// The severity only changes with alerting results, so that resolved alerts and alerts that keep
+	// their last state have the same labels as the alerts that fired.
+	switch {
+	case len(alertRule.SeverityConditions) == 0:
+		a.Severity = ""
+	case result.State == eval.Alerting:
+		a.setSeverity(result.Severity)
+	default:
+		a.setSeverity(a.Severity)
+	}
+
 	// Set reason iff: result and state are different, reason is not Alerting or Normal
 	a.StateReason = ""
 
@@ -870,6 +999,13 @@ func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extr
 		a.StateReason = resultStateReason(result, alertRule)
 	}
 
+	if flapping {
+		a.StateReason = models.StateReasonFlapping
+		a.Annotations[models.FlappingAnnotation] = strconv.Itoa(flapTransitions)
+	} else {
+		delete(a.Annotations, models.FlappingAnnotation)
+	}
+
 	// Set Resolved property so the scheduler knows to send a postable alert
 	// to Alertmanager.
 	newlyResolved := false
@@ -895,6 +1031,7 @@ func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extr
 		State:               a,
 		PreviousState:       oldState,
 		PreviousStateReason: oldReason,
+		PreviousSeverity:    oldSeverity,
 	}
 	return nextState
 }
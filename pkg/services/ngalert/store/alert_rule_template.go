@@ -0,0 +1,201 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type alertRuleTemplate struct {
+	ID              int64     `xorm:"pk autoincr 'id'"`
+	OrgID           int64     `xorm:"org_id"`
+	UID             string    `xorm:"uid"`
+	Title           string    `xorm:"title"`
+	FolderUID       string    `xorm:"folder_uid"`
+	RuleGroup       string    `xorm:"rule_group"`
+	IntervalSeconds int64     `xorm:"interval_seconds"`
+	Spec            string    `xorm:"spec"`
+	Version         int64     `xorm:"version"`
+	Updated         time.Time `xorm:"updated"`
+}
+
+func (t alertRuleTemplate) TableName() string {
+	return "alert_rule_template"
+}
+
+// alertRuleTemplateSpec is the part of the template that is stored as JSON.
+type alertRuleTemplateSpec struct {
+	Parameters []string                       `json:"parameters"`
+	Rule       models.AlertRuleTemplateRule   `json:"rule"`
+	Source     models.AlertRuleTemplateSource `json:"source"`
+}
+
+// GetAlertRuleTemplate returns the alert rule template with the UID.
+// Returns models.ErrAlertRuleTemplateNotFound if it does not exist.
+func (st DBstore) GetAlertRuleTemplate(ctx context.Context, orgID int64, uid string) (models.AlertRuleTemplate, error) {
+	var row alertRuleTemplate
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&row)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrAlertRuleTemplateNotFound.Errorf("")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	return alertRuleTemplateToModel(row)
+}
+
+// ListAlertRuleTemplates returns all alert rule templates of the organization ordered by title.
+func (st DBstore) ListAlertRuleTemplates(ctx context.Context, orgID int64) ([]models.AlertRuleTemplate, error) {
+	var rows []alertRuleTemplate
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ?", orgID).Asc("title", "id").Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.AlertRuleTemplate, 0, len(rows))
+	for _, row := range rows {
+		t, err := alertRuleTemplateToModel(row)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// InsertAlertRuleTemplate stores a new alert rule template and returns it with its ID, UID and version.
+// A UID is generated if the template does not have one.
+func (st DBstore) InsertAlertRuleTemplate(ctx context.Context, t models.AlertRuleTemplate) (models.AlertRuleTemplate, error) {
+	if t.UID == "" {
+		t.UID = util.GenerateShortUID()
+	}
+	t.Version = 1
+	t.Updated = TimeNow()
+	row, err := alertRuleTemplateFromModel(t)
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Insert(&row); err != nil {
+			if st.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
+				return models.ErrAlertRuleTemplateExists.Errorf("")
+			}
+			return fmt.Errorf("failed to insert alert rule template: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	t.ID = row.ID
+	return t, nil
+}
+
+// UpdateAlertRuleTemplate updates the alert rule template if its version matches the stored one, and returns it
+// with the incremented version.
+func (st DBstore) UpdateAlertRuleTemplate(ctx context.Context, t models.AlertRuleTemplate) (models.AlertRuleTemplate, error) {
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var existing alertRuleTemplate
+		has, err := sess.Where("org_id = ? AND uid = ?", t.OrgID, t.UID).Get(&existing)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrAlertRuleTemplateNotFound.Errorf("")
+		}
+		if existing.Version != t.Version {
+			return models.MakeErrAlertRuleTemplateVersionConflict(t.UID, existing.Version, t.Version)
+		}
+		t.ID = existing.ID
+		t.Version = existing.Version + 1
+		t.Updated = TimeNow()
+		row, err := alertRuleTemplateFromModel(t)
+		if err != nil {
+			return err
+		}
+		affected, err := sess.ID(existing.ID).Where("version = ?", existing.Version).AllCols().Update(&row)
+		if err != nil {
+			if st.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
+				return models.ErrAlertRuleTemplateExists.Errorf("")
+			}
+			return fmt.Errorf("failed to update alert rule template: %w", err)
+		}
+		if affected == 0 {
+			// The version changed after it was read, report the version that is stored now.
+			var current alertRuleTemplate
+			if _, err := sess.ID(existing.ID).Cols("version").Get(&current); err != nil {
+				return err
+			}
+			return models.MakeErrAlertRuleTemplateVersionConflict(t.UID, current.Version, existing.Version)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.AlertRuleTemplate{}, err
+	}
+	return t, nil
+}
+
+// DeleteAlertRuleTemplate deletes the alert rule template. It does nothing if the template does not exist.
+func (st DBstore) DeleteAlertRuleTemplate(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("DELETE FROM alert_rule_template WHERE org_id = ? AND uid = ?", orgID, uid)
+		return err
+	})
+}
+
+func alertRuleTemplateFromModel(t models.AlertRuleTemplate) (alertRuleTemplate, error) {
+	spec, err := json.Marshal(alertRuleTemplateSpec{
+		Parameters: t.Parameters,
+		Rule:       t.Rule,
+		Source:     t.Source,
+	})
+	if err != nil {
+		return alertRuleTemplate{}, fmt.Errorf("failed to marshal alert rule template %s: %w", t.UID, err)
+	}
+	return alertRuleTemplate{
+		ID:              t.ID,
+		OrgID:           t.OrgID,
+		UID:             t.UID,
+		Title:           t.Title,
+		FolderUID:       t.FolderUID,
+		RuleGroup:       t.RuleGroup,
+		IntervalSeconds: t.IntervalSeconds,
+		Spec:            string(spec),
+		Version:         t.Version,
+		Updated:         t.Updated,
+	}, nil
+}
+
+func alertRuleTemplateToModel(row alertRuleTemplate) (models.AlertRuleTemplate, error) {
+	var spec alertRuleTemplateSpec
+	if err := json.Unmarshal([]byte(row.Spec), &spec); err != nil {
+		return models.AlertRuleTemplate{}, fmt.Errorf("failed to unmarshal alert rule template %s: %w", row.UID, err)
+	}
+	return models.AlertRuleTemplate{
+		ID:              row.ID,
+		UID:             row.UID,
+		OrgID:           row.OrgID,
+		Title:           row.Title,
+		FolderUID:       row.FolderUID,
+		RuleGroup:       row.RuleGroup,
+		IntervalSeconds: row.IntervalSeconds,
+		Parameters:      spec.Parameters,
+		Rule:            spec.Rule,
+		Source:          spec.Source,
+		Version:         row.Version,
+		Updated:         row.Updated,
+	}, nil
+}
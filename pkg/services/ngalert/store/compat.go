@@ -148,6 +148,22 @@ func convertAlertRuleToModel(ar alertRule, l log.Logger, opts AlertRuleConvertOp
 		}
 	}
 
+	if ar.SeverityConditions != "" {
+		err = json.Unmarshal([]byte(ar.SeverityConditions), &result.SeverityConditions)
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("failed to parse severity conditions: %w", err)
+		}
+	}
+
+	if ar.FlapDetection != "" {
+		var flapDetection models.FlapDetection
+		err = json.Unmarshal([]byte(ar.FlapDetection), &flapDetection)
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("failed to parse flap detection: %w", err)
+		}
+		result.FlapDetection = &flapDetection
+	}
+
 	return result, nil
 }
 
@@ -248,6 +264,22 @@ func alertRuleFromModelsAlertRule(ar models.AlertRule) (alertRule, error) {
 	}
 	result.Metadata = string(metadata)
 
+	if len(ar.SeverityConditions) > 0 {
+		severityConditionsData, err := json.Marshal(ar.SeverityConditions)
+		if err != nil {
+			return alertRule{}, fmt.Errorf("failed to marshal severity conditions: %w", err)
+		}
+		result.SeverityConditions = string(severityConditionsData)
+	}
+
+	if ar.FlapDetection != nil {
+		flapDetectionData, err := json.Marshal(ar.FlapDetection)
+		if err != nil {
+			return alertRule{}, fmt.Errorf("failed to marshal flap detection: %w", err)
+		}
+		result.FlapDetection = string(flapDetectionData)
+	}
+
 	return result, nil
 }
 
@@ -281,6 +313,8 @@ func alertRuleToAlertRuleVersion(rule alertRule) alertRuleVersion {
 		AlertRoutingPolicy:          rule.AlertRoutingPolicy,
 		Metadata:                    rule.Metadata,
 		MissingSeriesEvalsToResolve: rule.MissingSeriesEvalsToResolve,
+		SeverityConditions:          rule.SeverityConditions,
+		FlapDetection:               rule.FlapDetection,
 	}
 }
 
@@ -316,6 +350,8 @@ func alertRuleVersionToAlertRule(version alertRuleVersion) alertRule {
 		AlertRoutingPolicy:          version.AlertRoutingPolicy,
 		Metadata:                    version.Metadata,
 		MissingSeriesEvalsToResolve: version.MissingSeriesEvalsToResolve,
+		SeverityConditions:          version.SeverityConditions,
+		FlapDetection:               version.FlapDetection,
 	}
 }
 
@@ -109,6 +109,10 @@ func (st InstanceDBStore) SaveAlertInstance(ctx context.Context, alertInstance m
 		if err != nil {
 			return err
 		}
+		acknowledgementJSON, err := alertInstance.Acknowledgement.ToDB()
+		if err != nil {
+			return err
+		}
 		params := append(make([]any, 0),
 			alertInstance.RuleOrgID,
 			alertInstance.RuleUID,
@@ -127,12 +131,13 @@ func (st InstanceDBStore) SaveAlertInstance(ctx context.Context, alertInstance m
 			int64(alertInstance.EvaluationDuration),
 			truncate(alertInstance.LastError, maxLastErrorLength),
 			lastResultJSON,
+			acknowledgementJSON,
 		)
 
 		upsertSQL := st.SQLStore.GetDialect().UpsertSQL(
 			"alert_instance",
 			[]string{"rule_org_id", "rule_uid", "labels_hash"},
-			[]string{"rule_org_id", "rule_uid", "labels", "labels_hash", "current_state", "current_reason", "current_state_since", "current_state_end", "last_eval_time", "fired_at", "resolved_at", "last_sent_at", "result_fingerprint", "annotations", "evaluation_duration_ns", "last_error", "last_result"})
+			[]string{"rule_org_id", "rule_uid", "labels", "labels_hash", "current_state", "current_reason", "current_state_since", "current_state_end", "last_eval_time", "fired_at", "resolved_at", "last_sent_at", "result_fingerprint", "annotations", "evaluation_duration_ns", "last_error", "last_result", "acknowledgement"})
 		_, err = sess.SQL(upsertSQL, params...).Query()
 		if err != nil {
 			return err
@@ -373,10 +378,10 @@ func (st InstanceDBStore) insertInstancesBatch(sess *sqlstore.DBSession, batch [
 
 	query := strings.Builder{}
 	placeholders := make([]string, 0, len(batch))
-	args := make([]any, 0, len(batch)*17)
+	args := make([]any, 0, len(batch)*18)
 
 	query.WriteString("INSERT INTO alert_instance ")
-	query.WriteString("(rule_org_id, rule_uid, labels, labels_hash, current_state, current_reason, current_state_since, current_state_end, last_eval_time, fired_at, resolved_at, last_sent_at, result_fingerprint, annotations, evaluation_duration_ns, last_error, last_result) VALUES ")
+	query.WriteString("(rule_org_id, rule_uid, labels, labels_hash, current_state, current_reason, current_state_since, current_state_end, last_eval_time, fired_at, resolved_at, last_sent_at, result_fingerprint, annotations, evaluation_duration_ns, last_error, last_result, acknowledgement) VALUES ")
 
 	for _, instance := range batch {
 		if err := models.ValidateAlertInstance(instance); err != nil {
@@ -402,7 +407,13 @@ func (st InstanceDBStore) insertInstancesBatch(sess *sqlstore.DBSession, batch [
 			continue
 		}
 
-		placeholders = append(placeholders, "(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")
+		acknowledgementJSON, err := instance.Acknowledgement.ToDB()
+		if err != nil {
+			st.Logger.Warn("Skipping instance with invalid acknowledgement", "err", err, "rule_uid", instance.RuleUID)
+			continue
+		}
+
+		placeholders = append(placeholders, "(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")
 		args = append(args,
 			instance.RuleOrgID,
 			instance.RuleUID,
@@ -421,6 +432,7 @@ func (st InstanceDBStore) insertInstancesBatch(sess *sqlstore.DBSession, batch [
 			int64(instance.EvaluationDuration),
 			truncate(instance.LastError, maxLastErrorLength),
 			lastResultJSON,
+			acknowledgementJSON,
 		)
 	}
 
@@ -399,6 +399,42 @@ func TestIntegrationAlertInstanceOperations(t *testing.T) {
 		}
 		require.True(t, cmp.Equal(expectedLastResult, alerts[0].LastResult, cmpopts.EquateNaNs()), "LastResult mismatch after round-trip")
 	})
+
+	t.Run("can save and read alert instance with acknowledgement", func(t *testing.T) {
+		alertRule := tests.CreateTestAlertRule(t, ctx, dbstore, 60, mainOrgID)
+		labels := models.InstanceLabels{"test": "acknowledgement"}
+		_, hash, _ := labels.StringAndHash()
+
+		ack := models.Acknowledgement{
+			By:                    "admin",
+			Comment:               "looking into it",
+			At:                    time.Unix(1700000000, 0).UTC(),
+			ExpiresAt:             time.Unix(1700003600, 0).UTC(),
+			Values:                map[string]float64{"A": 1, "B": math.Inf(1)},
+			SuppressNotifications: true,
+			SilenceID:             "silence",
+		}
+		instance := models.AlertInstance{
+			AlertInstanceKey: models.AlertInstanceKey{
+				RuleOrgID:  alertRule.OrgID,
+				RuleUID:    alertRule.UID,
+				LabelsHash: hash,
+			},
+			CurrentState:    models.InstanceStateFiring,
+			Labels:          labels,
+			Acknowledgement: ack,
+		}
+		err := ng.InstanceStore.SaveAlertInstance(ctx, instance)
+		require.NoError(t, err)
+
+		alerts, err := ng.InstanceStore.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{
+			RuleOrgID: instance.RuleOrgID,
+			RuleUID:   instance.RuleUID,
+		})
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		require.Equal(t, ack, alerts[0].Acknowledgement)
+	})
 }
 
 func TestIntegrationFullSync(t *testing.T) {
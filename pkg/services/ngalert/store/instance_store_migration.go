@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type alertInstanceLister interface {
+	ListAlertInstances(ctx context.Context, cmd *models.ListAlertInstancesQuery) ([]*models.AlertInstance, error)
+}
+
+type alertInstanceSyncer interface {
+	alertInstanceLister
+	FullSync(ctx context.Context, instances []models.AlertInstance, batchSize int, jitterFunc func(int) time.Duration) error
+}
+
+// MigrateAlertInstances copies the alert instances of all organizations from one store to another if the target store
+// does not have any alert instances yet. It is used when the state store is switched, so that the alerts keep their state.
+// The target store is checked for all organizations before the source store is read, so that the source is not read
+// once the target has the state of any organization.
+func MigrateAlertInstances(ctx context.Context, orgIDs []int64, from alertInstanceLister, to alertInstanceSyncer, logger log.Logger) error {
+	for _, orgID := range orgIDs {
+		existing, err := to.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: orgID})
+		if err != nil {
+			return fmt.Errorf("failed to list alert instances of organization %d: %w", orgID, err)
+		}
+		if len(existing) > 0 {
+			logger.Debug("State store already has alert instances, skipping migration", "org_id", orgID)
+			return nil
+		}
+	}
+
+	var instances []models.AlertInstance
+	for _, orgID := range orgIDs {
+		orgInstances, err := from.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: orgID})
+		if err != nil {
+			return fmt.Errorf("failed to list alert instances of organization %d: %w", orgID, err)
+		}
+		for _, instance := range orgInstances {
+			instances = append(instances, *instance)
+		}
+	}
+	if len(instances) == 0 {
+		return nil
+	}
+
+	logger.Info("Migrating alert instances to the state store", "instances", len(instances))
+	if err := to.FullSync(ctx, instances, len(instances), nil); err != nil {
+		return fmt.Errorf("failed to migrate alert instances: %w", err)
+	}
+	return nil
+}
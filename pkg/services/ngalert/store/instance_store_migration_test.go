@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestMigrateAlertInstances(t *testing.T) {
+	ctx := context.Background()
+	ruleKey := func(orgID int64, uid string) models.AlertRuleKeyWithGroup {
+		return models.AlertRuleKeyWithGroup{AlertRuleKey: models.AlertRuleKey{OrgID: orgID, UID: uid}}
+	}
+	newStore := func(t *testing.T) *SnapshotInstanceStore {
+		st, err := NewSnapshotInstanceStore(t.TempDir(), log.NewNopLogger())
+		require.NoError(t, err)
+		return st
+	}
+
+	t.Run("should copy the instances of all organizations to an empty store", func(t *testing.T) {
+		from, to := newStore(t), newStore(t)
+		instance1 := createTestAlertInstance(1, "rule-1", "a")
+		instance2 := createTestAlertInstance(2, "rule-2", "b")
+		require.NoError(t, from.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-1"), []models.AlertInstance{instance1}))
+		require.NoError(t, from.SaveAlertInstancesForRule(ctx, ruleKey(2, "rule-2"), []models.AlertInstance{instance2}))
+
+		require.NoError(t, MigrateAlertInstances(ctx, []int64{1, 2}, from, to, log.NewNopLogger()))
+
+		instances, err := to.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1})
+		require.NoError(t, err)
+		require.Equal(t, []*models.AlertInstance{&instance1}, instances)
+		instances, err = to.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 2})
+		require.NoError(t, err)
+		require.Equal(t, []*models.AlertInstance{&instance2}, instances)
+	})
+
+	t.Run("should not change a store that has instances", func(t *testing.T) {
+		from, to := newStore(t), newStore(t)
+		require.NoError(t, from.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-1"), []models.AlertInstance{createTestAlertInstance(1, "rule-1", "a")}))
+		existing := createTestAlertInstance(1, "rule-2", "b")
+		require.NoError(t, to.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-2"), []models.AlertInstance{existing}))
+
+		require.NoError(t, MigrateAlertInstances(ctx, []int64{1}, from, to, log.NewNopLogger()))
+
+		instances, err := to.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1})
+		require.NoError(t, err)
+		require.Equal(t, []*models.AlertInstance{&existing}, instances)
+	})
+
+	t.Run("should not read the source if any organization has instances in the target store", func(t *testing.T) {
+		from := &countingInstanceLister{}
+		to := newStore(t)
+		existing := createTestAlertInstance(2, "rule-2", "b")
+		require.NoError(t, to.SaveAlertInstancesForRule(ctx, ruleKey(2, "rule-2"), []models.AlertInstance{existing}))
+
+		require.NoError(t, MigrateAlertInstances(ctx, []int64{1, 2}, from, to, log.NewNopLogger()))
+
+		require.Zero(t, from.calls)
+		instances, err := to.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 2})
+		require.NoError(t, err)
+		require.Equal(t, []*models.AlertInstance{&existing}, instances)
+	})
+}
+
+type countingInstanceLister struct {
+	calls int
+}
+
+func (l *countingInstanceLister) ListAlertInstances(context.Context, *models.ListAlertInstancesQuery) ([]*models.AlertInstance, error) {
+	l.calls++
+	return nil, nil
+}
@@ -33,6 +33,8 @@ type alertRule struct {
 	AlertRoutingPolicy          *string `xorm:"alert_routing_policy"`
 	Metadata                    string  `xorm:"metadata"`
 	MissingSeriesEvalsToResolve *int64  `xorm:"missing_series_evals_to_resolve"`
+	SeverityConditions          string  `xorm:"severity_conditions"`
+	FlapDetection               string  `xorm:"flap_detection"`
 }
 
 func (a alertRule) TableName() string {
@@ -72,6 +74,8 @@ type alertRuleVersion struct {
 	AlertRoutingPolicy          *string `xorm:"alert_routing_policy"`
 	Metadata                    string  `xorm:"metadata"`
 	MissingSeriesEvalsToResolve *int64  `xorm:"missing_series_evals_to_resolve"`
+	SeverityConditions          string  `xorm:"severity_conditions"`
+	FlapDetection               string  `xorm:"flap_detection"`
 	Message                     string
 }
 
@@ -97,6 +101,8 @@ func (a alertRuleVersion) EqualSpec(b alertRuleVersion) bool {
 		a.IsPaused == b.IsPaused &&
 		a.NotificationSettings == b.NotificationSettings &&
 		a.Metadata == b.Metadata &&
+		a.SeverityConditions == b.SeverityConditions &&
+		a.FlapDetection == b.FlapDetection &&
 		compareInt64Pointer(a.MissingSeriesEvalsToResolve, b.MissingSeriesEvalsToResolve) &&
 		compareStringPointer(a.AlertRoutingPolicy, b.AlertRoutingPolicy)
 }
@@ -208,6 +208,23 @@ func alertInstanceModelToProto(modelInstance models.AlertInstance) *pb.AlertInst
 		EvaluationDurationNs: int64(modelInstance.EvaluationDuration),
 		LastError:            truncate(modelInstance.LastError, maxLastErrorLength),
 		LastResult:           lastResult,
+		Acknowledgement:      acknowledgementModelToProto(modelInstance.Acknowledgement),
+	}
+}
+
+func acknowledgementModelToProto(ack models.Acknowledgement) *pb.Acknowledgement {
+	if ack.IsZero() {
+		return nil
+	}
+	return &pb.Acknowledgement{
+		By:                    ack.By,
+		Comment:               ack.Comment,
+		At:                    timestamppb.New(ack.At),
+		ExpiresAt:             timestamppb.New(ack.ExpiresAt),
+		Values:                ack.Values,
+		SuppressNotifications: ack.SuppressNotifications,
+		SilenceId:             ack.SilenceID,
+		UntilValueChanges:     ack.UntilValueChanges,
 	}
 }
 
@@ -287,6 +304,23 @@ func alertInstanceProtoToModel(ruleUID string, ruleOrgID int64, protoInstance *p
 		EvaluationDuration: time.Duration(protoInstance.EvaluationDurationNs),
 		LastError:          protoInstance.LastError,
 		LastResult:         lastResult,
+		Acknowledgement:    acknowledgementProtoToModel(protoInstance.Acknowledgement),
+	}
+}
+
+func acknowledgementProtoToModel(ack *pb.Acknowledgement) models.Acknowledgement {
+	if ack == nil {
+		return models.Acknowledgement{}
+	}
+	return models.Acknowledgement{
+		By:                    ack.By,
+		Comment:               ack.Comment,
+		At:                    ack.At.AsTime(),
+		ExpiresAt:             ack.ExpiresAt.AsTime(),
+		Values:                ack.Values,
+		SuppressNotifications: ack.SuppressNotifications,
+		SilenceID:             ack.SilenceId,
+		UntilValueChanges:     ack.UntilValueChanges,
 	}
 }
 
@@ -184,6 +184,45 @@ func TestAlertInstanceModelToProto(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Acknowledgement",
+			input: models.AlertInstance{
+				Labels: map[string]string{"key": "value"},
+				AlertInstanceKey: models.AlertInstanceKey{
+					RuleUID:    "rule-uid-1",
+					RuleOrgID:  1,
+					LabelsHash: "hash123",
+				},
+				CurrentState:      models.InstanceStateFiring,
+				CurrentStateSince: currentStateSince,
+				Acknowledgement: models.Acknowledgement{
+					By:                    "admin",
+					Comment:               "looking into it",
+					At:                    lastEvalTime,
+					ExpiresAt:             currentStateEnd,
+					Values:                map[string]float64{"A": 1},
+					SuppressNotifications: true,
+					SilenceID:             "silence",
+				},
+			},
+			expected: &pb.AlertInstance{
+				Labels:            map[string]string{"key": "value"},
+				LabelsHash:        "hash123",
+				CurrentState:      "Alerting",
+				CurrentStateSince: timestamppb.New(currentStateSince),
+				CurrentStateEnd:   timestamppb.New(time.Time{}),
+				LastEvalTime:      timestamppb.New(time.Time{}),
+				Acknowledgement: &pb.Acknowledgement{
+					By:                    "admin",
+					Comment:               "looking into it",
+					At:                    timestamppb.New(lastEvalTime),
+					ExpiresAt:             timestamppb.New(currentStateEnd),
+					Values:                map[string]float64{"A": 1},
+					SuppressNotifications: true,
+					SilenceId:             "silence",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -294,7 +333,7 @@ func TestModelAlertInstanceMatchesProtobuf(t *testing.T) {
 	// and update them accordingly.
 	t.Run("when AlertInstance model changes", func(t *testing.T) {
 		modelType := reflect.TypeOf(models.AlertInstance{})
-		require.Equal(t, 15, modelType.NumField(), "AlertInstance model has changed, update the protobuf")
+		require.Equal(t, 16, modelType.NumField(), "AlertInstance model has changed, update the protobuf")
 	})
 }
 
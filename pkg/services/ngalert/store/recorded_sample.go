@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/grafana/grafana/pkg/tsdb/grafanads"
+)
+
+type recordedSample struct {
+	ID     int64   `xorm:"pk autoincr 'id'"`
+	OrgID  int64   `xorm:"org_id"`
+	Metric string  `xorm:"metric"`
+	Labels string  `xorm:"labels"`
+	Epoch  int64   `xorm:"epoch"`
+	Value  float64 `xorm:"value"`
+}
+
+func (s recordedSample) TableName() string {
+	return "alert_rule_recorded_sample"
+}
+
+// InsertRecordedSamples stores the samples written by recording rules.
+func (st DBstore) InsertRecordedSamples(ctx context.Context, samples []grafanads.RecordedSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	rows := make([]recordedSample, 0, len(samples))
+	for _, s := range samples {
+		lbls, err := json.Marshal(s.Labels)
+		if err != nil {
+			return fmt.Errorf("failed to marshal labels: %w", err)
+		}
+		rows = append(rows, recordedSample{
+			OrgID:  s.OrgID,
+			Metric: s.Metric,
+			Labels: string(lbls),
+			Epoch:  s.Timestamp.UnixMilli(),
+			Value:  s.Value,
+		})
+	}
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.BulkInsert(recordedSample{}, rows, sqlstore.NativeSettingsForDialect(st.SQLStore.GetDialect()))
+		if err != nil {
+			return fmt.Errorf("failed to insert recorded samples: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetRecordedSamples returns the samples of the metric in the requested range ordered by time.
+func (st DBstore) GetRecordedSamples(ctx context.Context, query grafanads.RecordedSamplesQuery) ([]grafanads.RecordedSample, error) {
+	return ProvideRecordedSamplesReader(st.SQLStore).GetRecordedSamples(ctx, query)
+}
+
+// RecordedSamplesReader reads the samples written by recording rules. Unlike DBstore, it depends only on the database
+// and therefore can be used by the built-in Grafana data source, which is created before the alerting services.
+type RecordedSamplesReader struct {
+	SQLStore db.DB
+}
+
+func ProvideRecordedSamplesReader(sqlStore db.DB) *RecordedSamplesReader {
+	return &RecordedSamplesReader{SQLStore: sqlStore}
+}
+
+// GetRecordedSamples returns the samples of the metric in the requested range ordered by time.
+func (r *RecordedSamplesReader) GetRecordedSamples(ctx context.Context, query grafanads.RecordedSamplesQuery) ([]grafanads.RecordedSample, error) {
+	var rows []recordedSample
+	err := r.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table(recordedSample{}).
+			Where("org_id = ? AND metric = ? AND epoch >= ? AND epoch <= ?", query.OrgID, query.Metric, query.From.UnixMilli(), query.To.UnixMilli()).
+			Asc("epoch", "id").
+			Find(&rows)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recorded samples: %w", err)
+	}
+	result := make([]grafanads.RecordedSample, 0, len(rows))
+	for _, row := range rows {
+		var lbls data.Labels
+		if err := json.Unmarshal([]byte(row.Labels), &lbls); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal labels of recorded sample %d: %w", row.ID, err)
+		}
+		result = append(result, grafanads.RecordedSample{
+			OrgID:     row.OrgID,
+			Metric:    row.Metric,
+			Labels:    lbls,
+			Timestamp: time.UnixMilli(row.Epoch),
+			Value:     row.Value,
+		})
+	}
+	return result, nil
+}
+
+// CleanUpRecordedSamples deletes the recorded samples that are older than the configured retention.
+func (st DBstore) CleanUpRecordedSamples(ctx context.Context) (int64, error) {
+	affectedRows := int64(-1)
+	if st.Cfg.RecordingRules.LocalStorageRetention <= 0 {
+		return 0, nil
+	}
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		expire := TimeNow().Add(-st.Cfg.RecordingRules.LocalStorageRetention)
+		st.Logger.Debug("Remove expired recorded samples", "writtenBefore", expire)
+		result, err := sess.Exec("DELETE FROM alert_rule_recorded_sample WHERE epoch < ?", expire.UnixMilli())
+		if err != nil {
+			return err
+		}
+		affectedRows, err = result.RowsAffected()
+		if err != nil {
+			st.Logger.Warn("Failed to get rows affected by the delete operation", "error", err)
+		}
+		return nil
+	})
+	return affectedRows, err
+}
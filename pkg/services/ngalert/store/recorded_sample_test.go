@@ -0,0 +1,88 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+	"github.com/grafana/grafana/pkg/tsdb/grafanads"
+	"github.com/grafana/grafana/pkg/util/testutil"
+)
+
+func TestIntegrationRecordedSamples(t *testing.T) {
+	testutil.SkipIntegrationTestInShortMode(t)
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	now := time.Now().Truncate(time.Millisecond)
+	sample := func(orgID int64, metric string, ts time.Time, value float64) grafanads.RecordedSample {
+		return grafanads.RecordedSample{
+			OrgID:     orgID,
+			Metric:    metric,
+			Labels:    data.Labels{"job": "test"},
+			Timestamp: ts,
+			Value:     value,
+		}
+	}
+	old := sample(1, "metric", now.Add(-2*time.Hour), 1)
+	recent := sample(1, "metric", now.Add(-time.Minute), 2)
+	latest := sample(1, "metric", now, 3)
+	other := sample(1, "other", now, 4)
+	otherOrg := sample(2, "metric", now, 5)
+
+	require.NoError(t, dbstore.InsertRecordedSamples(ctx, []grafanads.RecordedSample{latest, old, recent, other, otherOrg}))
+
+	t.Run("should return samples of the metric in the range ordered by time", func(t *testing.T) {
+		result, err := dbstore.GetRecordedSamples(ctx, grafanads.RecordedSamplesQuery{
+			OrgID:  1,
+			Metric: "metric",
+			From:   now.Add(-time.Hour),
+			To:     now,
+		})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		for i, expected := range []grafanads.RecordedSample{recent, latest} {
+			require.Equal(t, expected.Metric, result[i].Metric)
+			require.Equal(t, expected.Labels, result[i].Labels)
+			require.Equal(t, expected.Value, result[i].Value)
+			require.True(t, expected.Timestamp.Equal(result[i].Timestamp))
+		}
+	})
+
+	t.Run("should delete samples older than retention", func(t *testing.T) {
+		dbstore.Cfg.RecordingRules.LocalStorageRetention = time.Hour
+		store.TimeNow = func() time.Time {
+			return now
+		}
+		t.Cleanup(func() {
+			store.TimeNow = time.Now
+		})
+
+		deleted, err := dbstore.CleanUpRecordedSamples(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+
+		result, err := dbstore.GetRecordedSamples(ctx, grafanads.RecordedSamplesQuery{
+			OrgID:  1,
+			Metric: "metric",
+			From:   now.Add(-24 * time.Hour),
+			To:     now,
+		})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+	})
+
+	t.Run("should not delete anything if retention is disabled", func(t *testing.T) {
+		dbstore.Cfg.RecordingRules.LocalStorageRetention = 0
+
+		deleted, err := dbstore.CleanUpRecordedSamples(ctx)
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+	})
+}
@@ -0,0 +1,189 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const defaultRedisInstanceStorePrefix = "alert_rule_state"
+
+// RedisInstanceStore is a store for alert instances that keeps the state of every rule in Redis,
+// as a compressed protobuf message in the same format as ProtoInstanceDBStore.
+// The rules of an organization are stored in a single hash, keyed by the rule UID,
+// and the IDs of the organizations are kept in a set, so that the store can be synced without scanning keys.
+// All keys use the prefix as hash tag, so that they are in the same slot of a Redis cluster and can be
+// replaced in a single transaction.
+type RedisInstanceStore struct {
+	client redis.UniversalClient
+	prefix string
+	logger log.Logger
+}
+
+// NewRedisInstanceStore creates a store that uses the client and prepends the prefix to all keys as hash tag.
+func NewRedisInstanceStore(client redis.UniversalClient, prefix string, logger log.Logger) *RedisInstanceStore {
+	prefix = strings.TrimSuffix(prefix, ":")
+	// Redis Cluster ignores empty hash tags.
+	if prefix == "" {
+		prefix = defaultRedisInstanceStorePrefix
+	}
+	return &RedisInstanceStore{
+		client: client,
+		prefix: "{" + prefix + "}:",
+		logger: logger,
+	}
+}
+
+func (st *RedisInstanceStore) orgsKey() string {
+	return st.prefix + "orgs"
+}
+
+func (st *RedisInstanceStore) orgKey(orgID int64) string {
+	return st.prefix + "org:" + strconv.FormatInt(orgID, 10)
+}
+
+func (st *RedisInstanceStore) ListAlertInstances(ctx context.Context, cmd *models.ListAlertInstancesQuery) ([]*models.AlertInstance, error) {
+	logger := st.logger.FromContext(ctx)
+	logger.Debug("ListAlertInstances called", "rule_uid", cmd.RuleUID, "org_id", cmd.RuleOrgID)
+
+	rules := make(map[string]string)
+	if cmd.RuleUID != "" {
+		data, err := st.client.HGet(ctx, st.orgKey(cmd.RuleOrgID), cmd.RuleUID).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("failed to get alert instances of rule %s: %w", cmd.RuleUID, err)
+		}
+		if err == nil {
+			rules[cmd.RuleUID] = data
+		}
+	} else {
+		var err error
+		rules, err = st.client.HGetAll(ctx, st.orgKey(cmd.RuleOrgID)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alert instances of organization %d: %w", cmd.RuleOrgID, err)
+		}
+	}
+
+	alertInstances := make([]*models.AlertInstance, 0)
+	for ruleUID, data := range rules {
+		instances, err := decompressAlertInstances([]byte(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress alert instances for rule %s: %w", ruleUID, err)
+		}
+		for _, protoInstance := range instances {
+			modelInstance := alertInstanceProtoToModel(ruleUID, cmd.RuleOrgID, protoInstance)
+			if modelInstance == nil {
+				continue
+			}
+			alertInstances = append(alertInstances, modelInstance)
+		}
+	}
+
+	logger.Debug("ListAlertInstances completed", "instances", len(alertInstances))
+	return alertInstances, nil
+}
+
+func (st *RedisInstanceStore) SaveAlertInstance(ctx context.Context, alertInstance models.AlertInstance) error {
+	st.logger.Error("SaveAlertInstance called and not implemented")
+	return errors.New("save alert instance is not implemented for redis instance store")
+}
+
+func (st *RedisInstanceStore) DeleteAlertInstances(ctx context.Context, keys ...models.AlertInstanceKey) error {
+	st.logger.Error("DeleteAlertInstances called and not implemented")
+	return errors.New("delete alert instances is not implemented for redis instance store")
+}
+
+func (st *RedisInstanceStore) SaveAlertInstancesForRule(ctx context.Context, key models.AlertRuleKeyWithGroup, instances []models.AlertInstance) error {
+	logger := st.logger.FromContext(ctx)
+	logger.Debug("SaveAlertInstancesForRule called", "rule_uid", key.UID, "org_id", key.OrgID, "instances", len(instances))
+
+	compressedAlertInstances, err := convertAndCompressAlertInstances(instances)
+	if err != nil {
+		return fmt.Errorf("failed to compress alert instances: %w", err)
+	}
+
+	_, err = st.client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.SAdd(ctx, st.orgsKey(), key.OrgID)
+		p.HSet(ctx, st.orgKey(key.OrgID), key.UID, compressedAlertInstances)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save alert instances of rule %s: %w", key.UID, err)
+	}
+	return nil
+}
+
+func (st *RedisInstanceStore) DeleteAlertInstancesByRule(ctx context.Context, key models.AlertRuleKeyWithGroup) error {
+	logger := st.logger.FromContext(ctx)
+	logger.Debug("DeleteAlertInstancesByRule called", "rule_uid", key.UID, "org_id", key.OrgID)
+
+	if err := st.client.HDel(ctx, st.orgKey(key.OrgID), key.UID).Err(); err != nil {
+		return fmt.Errorf("failed to delete alert instances of rule %s: %w", key.UID, err)
+	}
+	return nil
+}
+
+// FullSync replaces the state of all rules in Redis with the given instances.
+func (st *RedisInstanceStore) FullSync(ctx context.Context, instances []models.AlertInstance, batchSize int, jitterFunc func(int) time.Duration) error {
+	if len(instances) == 0 {
+		return nil
+	}
+
+	logger := st.logger.FromContext(ctx)
+	logger.Debug("FullSync called", "total_instances", len(instances))
+
+	ruleInstances := make(map[models.AlertRuleKey][]models.AlertInstance)
+	for _, instance := range instances {
+		key := models.AlertRuleKey{OrgID: instance.RuleOrgID, UID: instance.RuleUID}
+		ruleInstances[key] = append(ruleInstances[key], instance)
+	}
+
+	orgRules := make(map[int64]map[string]any)
+	for key, instances := range ruleInstances {
+		compressedAlertInstances, err := convertAndCompressAlertInstances(instances)
+		if err != nil {
+			logger.Error("Failed to compress instances for rule", "rule_uid", key.UID, "error", err)
+			continue
+		}
+		if orgRules[key.OrgID] == nil {
+			orgRules[key.OrgID] = make(map[string]any)
+		}
+		orgRules[key.OrgID][key.UID] = compressedAlertInstances
+	}
+
+	orgIDs, err := st.client.SMembers(ctx, st.orgsKey()).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get organizations: %w", err)
+	}
+
+	// The state is replaced in a transaction, so that readers never see it deleted but not written yet.
+	_, err = st.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		for _, orgID := range orgIDs {
+			id, err := strconv.ParseInt(orgID, 10, 64)
+			if err != nil {
+				logger.Warn("Ignoring invalid organization ID", "org_id", orgID)
+				continue
+			}
+			p.Del(ctx, st.orgKey(id))
+		}
+		p.Del(ctx, st.orgsKey())
+		for orgID, rules := range orgRules {
+			p.SAdd(ctx, st.orgsKey(), orgID)
+			p.HSet(ctx, st.orgKey(orgID), rules)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync alert instances: %w", err)
+	}
+
+	logger.Debug("FullSync completed successfully", "rules_synced", len(ruleInstances))
+	return nil
+}
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestRedisInstanceStore(t *testing.T) {
+	ctx := context.Background()
+	ruleKey := func(orgID int64, uid string) models.AlertRuleKeyWithGroup {
+		return models.AlertRuleKeyWithGroup{AlertRuleKey: models.AlertRuleKey{OrgID: orgID, UID: uid}}
+	}
+	newStore := func(t *testing.T) (*RedisInstanceStore, *miniredis.Miniredis) {
+		mr, err := miniredis.Run()
+		require.NoError(t, err)
+		t.Cleanup(mr.Close)
+		client := redis.NewUniversalClient(&redis.UniversalOptions{Addrs: []string{mr.Addr()}})
+		t.Cleanup(func() {
+			_ = client.Close()
+		})
+		return NewRedisInstanceStore(client, "grafana:alert_rule_state", log.NewNopLogger()), mr
+	}
+
+	t.Run("should save and list instances of rules", func(t *testing.T) {
+		st, mr := newStore(t)
+
+		instance1 := createTestAlertInstance(1, "rule-1", "a")
+		instance2 := createTestAlertInstance(1, "rule-2", "b")
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-1"), []models.AlertInstance{instance1}))
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-2"), []models.AlertInstance{instance2}))
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(2, "rule-3"), []models.AlertInstance{createTestAlertInstance(2, "rule-3", "c")}))
+
+		require.ElementsMatch(t, []string{"{grafana:alert_rule_state}:orgs", "{grafana:alert_rule_state}:org:1", "{grafana:alert_rule_state}:org:2"}, mr.Keys())
+
+		instances, err := st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []*models.AlertInstance{&instance1, &instance2}, instances)
+
+		instances, err = st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1, RuleUID: "rule-2"})
+		require.NoError(t, err)
+		require.Equal(t, []*models.AlertInstance{&instance2}, instances)
+
+		instances, err = st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1, RuleUID: "unknown"})
+		require.NoError(t, err)
+		require.Empty(t, instances)
+	})
+
+	t.Run("should delete instances of rule", func(t *testing.T) {
+		st, _ := newStore(t)
+
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-1"), []models.AlertInstance{createTestAlertInstance(1, "rule-1", "a")}))
+		require.NoError(t, st.DeleteAlertInstancesByRule(ctx, ruleKey(1, "rule-1")))
+
+		instances, err := st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1})
+		require.NoError(t, err)
+		require.Empty(t, instances)
+	})
+
+	t.Run("full sync should replace the state of all rules", func(t *testing.T) {
+		st, _ := newStore(t)
+
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-1"), []models.AlertInstance{createTestAlertInstance(1, "rule-1", "a")}))
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(2, "rule-2"), []models.AlertInstance{createTestAlertInstance(2, "rule-2", "b")}))
+
+		synced := createTestAlertInstance(1, "rule-3", "c")
+		require.NoError(t, st.FullSync(ctx, []models.AlertInstance{synced}, 1, nil))
+
+		instances, err := st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1})
+		require.NoError(t, err)
+		require.Equal(t, []*models.AlertInstance{&synced}, instances)
+
+		instances, err = st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 2})
+		require.NoError(t, err)
+		require.Empty(t, instances)
+	})
+}
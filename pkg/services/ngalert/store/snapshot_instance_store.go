@@ -0,0 +1,245 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const snapshotFileExt = ".snappy"
+
+// SnapshotInstanceStore is a store for alert instances that keeps the state of every rule in a local file,
+// as a compressed protobuf message in the same format as ProtoInstanceDBStore.
+// The files are stored in a directory per organization, and are replaced atomically.
+type SnapshotInstanceStore struct {
+	path   string
+	logger log.Logger
+	// mtx prevents rules from being saved while the directory is synced.
+	mtx sync.RWMutex
+}
+
+// NewSnapshotInstanceStore creates a store that keeps the snapshots in the directory, creating it if it does not exist.
+func NewSnapshotInstanceStore(path string, logger log.Logger) (*SnapshotInstanceStore, error) {
+	if err := os.MkdirAll(path, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	return &SnapshotInstanceStore{
+		path:   path,
+		logger: logger,
+	}, nil
+}
+
+func (st *SnapshotInstanceStore) orgPath(orgID int64) string {
+	return filepath.Join(st.path, strconv.FormatInt(orgID, 10))
+}
+
+func (st *SnapshotInstanceStore) rulePath(orgID int64, ruleUID string) (string, error) {
+	if ruleUID == "" || ruleUID != filepath.Base(ruleUID) || strings.HasPrefix(ruleUID, ".") {
+		return "", fmt.Errorf("invalid rule UID %q", ruleUID)
+	}
+	return filepath.Join(st.orgPath(orgID), ruleUID+snapshotFileExt), nil
+}
+
+func (st *SnapshotInstanceStore) ListAlertInstances(ctx context.Context, cmd *models.ListAlertInstancesQuery) ([]*models.AlertInstance, error) {
+	logger := st.logger.FromContext(ctx)
+	logger.Debug("ListAlertInstances called", "rule_uid", cmd.RuleUID, "org_id", cmd.RuleOrgID)
+
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	var files []string
+	if cmd.RuleUID != "" {
+		p, err := st.rulePath(cmd.RuleOrgID, cmd.RuleUID)
+		if err != nil {
+			return nil, err
+		}
+		files = []string{p}
+	} else {
+		var err error
+		files, err = filepath.Glob(filepath.Join(st.orgPath(cmd.RuleOrgID), "*"+snapshotFileExt))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots of organization %d: %w", cmd.RuleOrgID, err)
+		}
+	}
+
+	alertInstances := make([]*models.AlertInstance, 0)
+	for _, file := range files {
+		ruleUID := strings.TrimSuffix(filepath.Base(file), snapshotFileExt)
+		data, err := os.ReadFile(file) //nolint:gosec
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			// A snapshot that cannot be read must not prevent the state of the other rules from being restored.
+			logger.Warn("Skipping snapshot that cannot be read", "rule_uid", ruleUID, "file", file, "error", err)
+			continue
+		}
+		instances, err := decompressAlertInstances(data)
+		if err != nil {
+			logger.Warn("Skipping corrupt snapshot", "rule_uid", ruleUID, "file", file, "error", err)
+			continue
+		}
+		for _, protoInstance := range instances {
+			modelInstance := alertInstanceProtoToModel(ruleUID, cmd.RuleOrgID, protoInstance)
+			if modelInstance == nil {
+				continue
+			}
+			alertInstances = append(alertInstances, modelInstance)
+		}
+	}
+
+	logger.Debug("ListAlertInstances completed", "instances", len(alertInstances))
+	return alertInstances, nil
+}
+
+func (st *SnapshotInstanceStore) SaveAlertInstance(ctx context.Context, alertInstance models.AlertInstance) error {
+	st.logger.Error("SaveAlertInstance called and not implemented")
+	return errors.New("save alert instance is not implemented for snapshot instance store")
+}
+
+func (st *SnapshotInstanceStore) DeleteAlertInstances(ctx context.Context, keys ...models.AlertInstanceKey) error {
+	st.logger.Error("DeleteAlertInstances called and not implemented")
+	return errors.New("delete alert instances is not implemented for snapshot instance store")
+}
+
+func (st *SnapshotInstanceStore) SaveAlertInstancesForRule(ctx context.Context, key models.AlertRuleKeyWithGroup, instances []models.AlertInstance) error {
+	logger := st.logger.FromContext(ctx)
+	logger.Debug("SaveAlertInstancesForRule called", "rule_uid", key.UID, "org_id", key.OrgID, "instances", len(instances))
+
+	compressedAlertInstances, err := convertAndCompressAlertInstances(instances)
+	if err != nil {
+		return fmt.Errorf("failed to compress alert instances: %w", err)
+	}
+
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+	return st.writeSnapshot(key.OrgID, key.UID, compressedAlertInstances)
+}
+
+func (st *SnapshotInstanceStore) DeleteAlertInstancesByRule(ctx context.Context, key models.AlertRuleKeyWithGroup) error {
+	logger := st.logger.FromContext(ctx)
+	logger.Debug("DeleteAlertInstancesByRule called", "rule_uid", key.UID, "org_id", key.OrgID)
+
+	p, err := st.rulePath(key.OrgID, key.UID)
+	if err != nil {
+		return err
+	}
+
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete snapshot of rule %s: %w", key.UID, err)
+	}
+	return nil
+}
+
+// FullSync replaces the snapshots of all rules with the given instances.
+func (st *SnapshotInstanceStore) FullSync(ctx context.Context, instances []models.AlertInstance, batchSize int, jitterFunc func(int) time.Duration) error {
+	if len(instances) == 0 {
+		return nil
+	}
+
+	logger := st.logger.FromContext(ctx)
+	logger.Debug("FullSync called", "total_instances", len(instances))
+
+	ruleInstances := make(map[models.AlertRuleKey][]models.AlertInstance)
+	for _, instance := range instances {
+		key := models.AlertRuleKey{OrgID: instance.RuleOrgID, UID: instance.RuleUID}
+		ruleInstances[key] = append(ruleInstances[key], instance)
+	}
+
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	written := make(map[string]struct{}, len(ruleInstances))
+	for key, instances := range ruleInstances {
+		compressedAlertInstances, err := convertAndCompressAlertInstances(instances)
+		if err != nil {
+			logger.Error("Failed to compress instances for rule", "rule_uid", key.UID, "error", err)
+			continue
+		}
+		if err := st.writeSnapshot(key.OrgID, key.UID, compressedAlertInstances); err != nil {
+			return err
+		}
+		p, _ := st.rulePath(key.OrgID, key.UID)
+		written[p] = struct{}{}
+	}
+
+	// Delete the snapshots of rules that no longer have any state.
+	files, err := filepath.Glob(filepath.Join(st.path, "*", "*"+snapshotFileExt))
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	for _, file := range files {
+		if _, ok := written[file]; ok {
+			continue
+		}
+		if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete snapshot %s: %w", file, err)
+		}
+	}
+
+	logger.Debug("FullSync completed successfully", "rules_synced", len(written))
+	return nil
+}
+
+// writeSnapshot writes the data to a temporary file and renames it, so that readers never see a partial snapshot.
+func (st *SnapshotInstanceStore) writeSnapshot(orgID int64, ruleUID string, data []byte) error {
+	p, err := st.rulePath(orgID, ruleUID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(st.orgPath(orgID), 0o750); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(st.orgPath(orgID), ruleUID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot of rule %s: %w", ruleUID, err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write snapshot of rule %s: %w", ruleUID, err)
+	}
+	// The data must be on disk before the rename, otherwise a crash can leave an empty or partial snapshot behind.
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write snapshot of rule %s: %w", ruleUID, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot of rule %s: %w", ruleUID, err)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("failed to replace snapshot of rule %s: %w", ruleUID, err)
+	}
+	return syncDir(st.orgPath(orgID))
+}
+
+// syncDir flushes the directory entries, so that a rename in the directory survives a crash.
+func syncDir(path string) error {
+	dir, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot directory: %w", err)
+	}
+	defer func() {
+		_ = dir.Close()
+	}()
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("failed to sync snapshot directory: %w", err)
+	}
+	return nil
+}
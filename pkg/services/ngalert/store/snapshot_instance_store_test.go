@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func createTestAlertInstance(orgID int64, ruleUID string, labelsHash string) models.AlertInstance {
+	now := time.Now().UTC().Truncate(time.Second)
+	return models.AlertInstance{
+		AlertInstanceKey: models.AlertInstanceKey{
+			RuleOrgID:  orgID,
+			RuleUID:    ruleUID,
+			LabelsHash: labelsHash,
+		},
+		Labels:            models.InstanceLabels{"instance": labelsHash},
+		CurrentState:      models.InstanceStateFiring,
+		CurrentStateSince: now,
+		CurrentStateEnd:   now.Add(time.Minute),
+		LastEvalTime:      now,
+	}
+}
+
+func TestSnapshotInstanceStore(t *testing.T) {
+	ctx := context.Background()
+	ruleKey := func(orgID int64, uid string) models.AlertRuleKeyWithGroup {
+		return models.AlertRuleKeyWithGroup{AlertRuleKey: models.AlertRuleKey{OrgID: orgID, UID: uid}}
+	}
+
+	t.Run("should save and list instances of rules", func(t *testing.T) {
+		st, err := NewSnapshotInstanceStore(t.TempDir(), log.NewNopLogger())
+		require.NoError(t, err)
+
+		instance1 := createTestAlertInstance(1, "rule-1", "a")
+		instance2 := createTestAlertInstance(1, "rule-2", "b")
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-1"), []models.AlertInstance{instance1}))
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-2"), []models.AlertInstance{instance2}))
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(2, "rule-3"), []models.AlertInstance{createTestAlertInstance(2, "rule-3", "c")}))
+
+		instances, err := st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []*models.AlertInstance{&instance1, &instance2}, instances)
+
+		instances, err = st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1, RuleUID: "rule-2"})
+		require.NoError(t, err)
+		require.Equal(t, []*models.AlertInstance{&instance2}, instances)
+	})
+
+	t.Run("should delete instances of rule", func(t *testing.T) {
+		st, err := NewSnapshotInstanceStore(t.TempDir(), log.NewNopLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-1"), []models.AlertInstance{createTestAlertInstance(1, "rule-1", "a")}))
+		require.NoError(t, st.DeleteAlertInstancesByRule(ctx, ruleKey(1, "rule-1")))
+		require.NoError(t, st.DeleteAlertInstancesByRule(ctx, ruleKey(1, "rule-1")))
+
+		instances, err := st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1})
+		require.NoError(t, err)
+		require.Empty(t, instances)
+	})
+
+	t.Run("full sync should replace all snapshots", func(t *testing.T) {
+		st, err := NewSnapshotInstanceStore(t.TempDir(), log.NewNopLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-1"), []models.AlertInstance{createTestAlertInstance(1, "rule-1", "a")}))
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(2, "rule-2"), []models.AlertInstance{createTestAlertInstance(2, "rule-2", "b")}))
+
+		synced := createTestAlertInstance(1, "rule-3", "c")
+		require.NoError(t, st.FullSync(ctx, []models.AlertInstance{synced}, 1, nil))
+
+		instances, err := st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1})
+		require.NoError(t, err)
+		require.Equal(t, []*models.AlertInstance{&synced}, instances)
+
+		instances, err = st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 2})
+		require.NoError(t, err)
+		require.Empty(t, instances)
+	})
+
+	t.Run("should skip corrupt snapshots", func(t *testing.T) {
+		dir := t.TempDir()
+		st, err := NewSnapshotInstanceStore(dir, log.NewNopLogger())
+		require.NoError(t, err)
+
+		instance := createTestAlertInstance(1, "rule-1", "a")
+		require.NoError(t, st.SaveAlertInstancesForRule(ctx, ruleKey(1, "rule-1"), []models.AlertInstance{instance}))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "1", "rule-2"+snapshotFileExt), []byte("corrupt"), 0o600))
+
+		instances, err := st.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: 1})
+		require.NoError(t, err)
+		require.Equal(t, []*models.AlertInstance{&instance}, instances)
+	})
+
+	t.Run("should reject rule UIDs that are not file names", func(t *testing.T) {
+		st, err := NewSnapshotInstanceStore(t.TempDir(), log.NewNopLogger())
+		require.NoError(t, err)
+
+		err = st.SaveAlertInstancesForRule(ctx, ruleKey(1, "../rule"), nil)
+		require.ErrorContains(t, err, "invalid rule UID")
+	})
+}
@@ -0,0 +1,233 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type templateLibrary struct {
+	ID          int64     `xorm:"pk autoincr 'id'"`
+	UID         string    `xorm:"uid"`
+	Name        string    `xorm:"name"`
+	Description string    `xorm:"description"`
+	Templates   string    `xorm:"templates"`
+	Version     int64     `xorm:"version"`
+	Updated     time.Time `xorm:"updated"`
+}
+
+func (l templateLibrary) TableName() string {
+	return "alert_template_library"
+}
+
+type templateLibraryVersion struct {
+	ID         int64     `xorm:"pk autoincr 'id'"`
+	LibraryUID string    `xorm:"library_uid"`
+	Version    int64     `xorm:"version"`
+	Templates  string    `xorm:"templates"`
+	Created    time.Time `xorm:"created"`
+}
+
+func (v templateLibraryVersion) TableName() string {
+	return "alert_template_library_version"
+}
+
+// ListTemplateLibraries returns all template libraries ordered by name.
+func (st DBstore) ListTemplateLibraries(ctx context.Context) ([]models.TemplateLibrary, error) {
+	var rows []templateLibrary
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Asc("name").Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.TemplateLibrary, 0, len(rows))
+	for _, row := range rows {
+		l, err := templateLibraryToModel(row)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, l)
+	}
+	return result, nil
+}
+
+// GetTemplateLibrary returns the template library with the UID.
+// Returns models.ErrTemplateLibraryNotFound if it does not exist.
+func (st DBstore) GetTemplateLibrary(ctx context.Context, uid string) (models.TemplateLibrary, error) {
+	var row templateLibrary
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		has, err := sess.Where("uid = ?", uid).Get(&row)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrTemplateLibraryNotFound.Errorf("")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.TemplateLibrary{}, err
+	}
+	return templateLibraryToModel(row)
+}
+
+// GetTemplateLibraryVersions returns all versions of the template library, the latest first.
+func (st DBstore) GetTemplateLibraryVersions(ctx context.Context, uid string) ([]models.TemplateLibraryVersion, error) {
+	var rows []templateLibraryVersion
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("library_uid = ?", uid).Desc("version").Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.TemplateLibraryVersion, 0, len(rows))
+	for _, row := range rows {
+		var templates map[string]string
+		if err := json.Unmarshal([]byte(row.Templates), &templates); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal version %d of template library %s: %w", row.Version, uid, err)
+		}
+		result = append(result, models.TemplateLibraryVersion{
+			LibraryUID: row.LibraryUID,
+			Version:    row.Version,
+			Templates:  templates,
+			Created:    row.Created,
+		})
+	}
+	return result, nil
+}
+
+// InsertTemplateLibrary stores a new template library and its first version.
+// A UID is generated if the library does not have one.
+func (st DBstore) InsertTemplateLibrary(ctx context.Context, l models.TemplateLibrary) (models.TemplateLibrary, error) {
+	if l.UID == "" {
+		l.UID = util.GenerateShortUID()
+	}
+	l.Version = 1
+	l.Updated = TimeNow()
+	row, err := templateLibraryFromModel(l)
+	if err != nil {
+		return models.TemplateLibrary{}, err
+	}
+	err = st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Insert(&row); err != nil {
+			if st.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
+				return models.ErrTemplateLibraryExists.Errorf("")
+			}
+			return fmt.Errorf("failed to insert template library: %w", err)
+		}
+		return insertTemplateLibraryVersion(sess, row)
+	})
+	if err != nil {
+		return models.TemplateLibrary{}, err
+	}
+	l.ID = row.ID
+	return l, nil
+}
+
+// UpdateTemplateLibrary updates the template library if its version matches the stored one, and returns it with
+// the incremented version. The previous content is kept as a version of the library.
+func (st DBstore) UpdateTemplateLibrary(ctx context.Context, l models.TemplateLibrary) (models.TemplateLibrary, error) {
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		var existing templateLibrary
+		has, err := sess.Where("uid = ?", l.UID).Get(&existing)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrTemplateLibraryNotFound.Errorf("")
+		}
+		if existing.Version != l.Version {
+			return models.MakeErrTemplateLibraryVersionConflict(l.UID, existing.Version, l.Version)
+		}
+		l.ID = existing.ID
+		l.Version = existing.Version + 1
+		l.Updated = TimeNow()
+		row, err := templateLibraryFromModel(l)
+		if err != nil {
+			return err
+		}
+		affected, err := sess.ID(existing.ID).Where("version = ?", existing.Version).AllCols().Update(&row)
+		if err != nil {
+			if st.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
+				return models.ErrTemplateLibraryExists.Errorf("")
+			}
+			return fmt.Errorf("failed to update template library: %w", err)
+		}
+		if affected == 0 {
+			// The version changed after it was read, report the version that is stored now.
+			var current templateLibrary
+			if _, err := sess.ID(existing.ID).Cols("version").Get(&current); err != nil {
+				return err
+			}
+			return models.MakeErrTemplateLibraryVersionConflict(l.UID, current.Version, existing.Version)
+		}
+		return insertTemplateLibraryVersion(sess, row)
+	})
+	if err != nil {
+		return models.TemplateLibrary{}, err
+	}
+	return l, nil
+}
+
+// DeleteTemplateLibrary deletes the template library and all its versions.
+// It does nothing if the library does not exist.
+func (st DBstore) DeleteTemplateLibrary(ctx context.Context, uid string) error {
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Exec("DELETE FROM alert_template_library WHERE uid = ?", uid); err != nil {
+			return err
+		}
+		_, err := sess.Exec("DELETE FROM alert_template_library_version WHERE library_uid = ?", uid)
+		return err
+	})
+}
+
+func insertTemplateLibraryVersion(sess *db.Session, row templateLibrary) error {
+	version := templateLibraryVersion{
+		LibraryUID: row.UID,
+		Version:    row.Version,
+		Templates:  row.Templates,
+		Created:    row.Updated,
+	}
+	if _, err := sess.Insert(&version); err != nil {
+		return fmt.Errorf("failed to insert version %d of template library %s: %w", row.Version, row.UID, err)
+	}
+	return nil
+}
+
+func templateLibraryFromModel(l models.TemplateLibrary) (templateLibrary, error) {
+	templates, err := json.Marshal(l.Templates)
+	if err != nil {
+		return templateLibrary{}, fmt.Errorf("failed to marshal templates of template library %s: %w", l.UID, err)
+	}
+	return templateLibrary{
+		ID:          l.ID,
+		UID:         l.UID,
+		Name:        l.Name,
+		Description: l.Description,
+		Templates:   string(templates),
+		Version:     l.Version,
+		Updated:     l.Updated,
+	}, nil
+}
+
+func templateLibraryToModel(row templateLibrary) (models.TemplateLibrary, error) {
+	var templates map[string]string
+	if err := json.Unmarshal([]byte(row.Templates), &templates); err != nil {
+		return models.TemplateLibrary{}, fmt.Errorf("failed to unmarshal templates of template library %s: %w", row.UID, err)
+	}
+	return models.TemplateLibrary{
+		ID:          row.ID,
+		UID:         row.UID,
+		Name:        row.Name,
+		Description: row.Description,
+		Templates:   templates,
+		Version:     row.Version,
+		Updated:     row.Updated,
+	}, nil
+}
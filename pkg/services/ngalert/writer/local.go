@@ -0,0 +1,76 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/tsdb/grafanads"
+)
+
+// LocalDatasourceUID is the UID of the built-in Grafana data source. Recording rules that target it are written to the Grafana database.
+const LocalDatasourceUID = grafanads.DatasourceUID
+
+// LocalStore stores the samples written by recording rules in the Grafana database.
+type LocalStore interface {
+	InsertRecordedSamples(ctx context.Context, samples []grafanads.RecordedSample) error
+}
+
+type remoteWriter interface {
+	WriteDatasource(ctx context.Context, dsUID string, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error
+}
+
+// LocalWriter writes the output of recording rules that target the built-in Grafana data source to the Grafana database.
+// The output of rules that target other data sources is passed to the remote writer.
+type LocalWriter struct {
+	store                LocalStore
+	remote               remoteWriter
+	defaultDatasourceUID string
+	l                    log.Logger
+}
+
+func NewLocalWriter(store LocalStore, remote remoteWriter, defaultDatasourceUID string, l log.Logger) *LocalWriter {
+	return &LocalWriter{
+		store:                store,
+		remote:               remote,
+		defaultDatasourceUID: defaultDatasourceUID,
+		l:                    l,
+	}
+}
+
+func (w *LocalWriter) WriteDatasource(ctx context.Context, dsUID string, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error {
+	uid := dsUID
+	if uid == "" {
+		uid = w.defaultDatasourceUID
+	}
+	if uid != LocalDatasourceUID {
+		return w.remote.WriteDatasource(ctx, dsUID, name, t, frames, orgID, extraLabels)
+	}
+
+	l := w.l.FromContext(ctx)
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return errors.Join(ErrBadFrame, err)
+	}
+
+	samples := make([]grafanads.RecordedSample, 0, len(points))
+	for _, p := range points {
+		samples = append(samples, grafanads.RecordedSample{
+			OrgID:     orgID,
+			Metric:    p.Name,
+			Labels:    p.Labels,
+			Timestamp: p.Metric.T,
+			Value:     p.Metric.V,
+		})
+	}
+
+	l.Debug("Writing recorded samples to the database", "metric", name, "samples", len(samples))
+	if err := w.store.InsertRecordedSamples(ctx, samples); err != nil {
+		l.Error("Failed to write recorded samples to the database", "error", err)
+		return err
+	}
+	return nil
+}
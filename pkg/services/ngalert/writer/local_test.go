@@ -0,0 +1,103 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/tsdb/grafanads"
+)
+
+type fakeLocalStore struct {
+	samples []grafanads.RecordedSample
+	err     error
+}
+
+func (s *fakeLocalStore) InsertRecordedSamples(_ context.Context, samples []grafanads.RecordedSample) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.samples = append(s.samples, samples...)
+	return nil
+}
+
+type fakeRemoteWriter struct {
+	calls []string
+}
+
+func (w *fakeRemoteWriter) WriteDatasource(_ context.Context, dsUID string, _ string, _ time.Time, _ data.Frames, _ int64, _ map[string]string) error {
+	w.calls = append(w.calls, dsUID)
+	return nil
+}
+
+func TestLocalWriter_WriteDatasource(t *testing.T) {
+	ts := time.Now().Truncate(time.Millisecond)
+	frames := frameGenFromLabels(t, data.FrameTypeNumericWide, []map[string]string{
+		{"job": "a"},
+		{"job": "b"},
+	})
+	extraLabels := map[string]string{"extra": "label"}
+
+	t.Run("writes to the database when the rule targets the built-in data source", func(t *testing.T) {
+		store := &fakeLocalStore{}
+		remote := &fakeRemoteWriter{}
+		w := NewLocalWriter(store, remote, "default-uid", log.NewNopLogger())
+
+		err := w.WriteDatasource(context.Background(), LocalDatasourceUID, "metric", ts, frames, 1, extraLabels)
+		require.NoError(t, err)
+
+		require.Empty(t, remote.calls)
+		require.Len(t, store.samples, 2)
+		for _, s := range store.samples {
+			require.Equal(t, int64(1), s.OrgID)
+			require.Equal(t, "metric", s.Metric)
+			require.Equal(t, ts, s.Timestamp)
+			require.Equal(t, "label", s.Labels["extra"])
+		}
+		require.ElementsMatch(t, []string{"a", "b"}, []string{store.samples[0].Labels["job"], store.samples[1].Labels["job"]})
+	})
+
+	t.Run("writes to the database when the default data source is the built-in one", func(t *testing.T) {
+		store := &fakeLocalStore{}
+		remote := &fakeRemoteWriter{}
+		w := NewLocalWriter(store, remote, LocalDatasourceUID, log.NewNopLogger())
+
+		err := w.WriteDatasource(context.Background(), "", "metric", ts, frames, 1, nil)
+		require.NoError(t, err)
+
+		require.Empty(t, remote.calls)
+		require.Len(t, store.samples, 2)
+	})
+
+	t.Run("passes other data sources to the remote writer", func(t *testing.T) {
+		store := &fakeLocalStore{}
+		remote := &fakeRemoteWriter{}
+		w := NewLocalWriter(store, remote, "default-uid", log.NewNopLogger())
+
+		require.NoError(t, w.WriteDatasource(context.Background(), "", "metric", ts, frames, 1, nil))
+		require.NoError(t, w.WriteDatasource(context.Background(), "prom-uid", "metric", ts, frames, 1, nil))
+
+		require.Equal(t, []string{"", "prom-uid"}, remote.calls)
+		require.Empty(t, store.samples)
+	})
+
+	t.Run("returns bad frame error if frames cannot be converted", func(t *testing.T) {
+		w := NewLocalWriter(&fakeLocalStore{}, &fakeRemoteWriter{}, "", log.NewNopLogger())
+
+		err := w.WriteDatasource(context.Background(), LocalDatasourceUID, "metric", ts, data.Frames{data.NewFrame("empty")}, 1, nil)
+		require.ErrorIs(t, err, ErrBadFrame)
+	})
+
+	t.Run("returns store error", func(t *testing.T) {
+		expected := errors.New("test")
+		w := NewLocalWriter(&fakeLocalStore{err: expected}, &fakeRemoteWriter{}, "", log.NewNopLogger())
+
+		err := w.WriteDatasource(context.Background(), LocalDatasourceUID, "metric", ts, frames, 1, nil)
+		require.ErrorIs(t, err, expected)
+	})
+}
@@ -161,7 +161,7 @@ func TestIntegrationPluginManager(t *testing.T) {
 	pg := postgres.ProvideService()
 	my := mysql.ProvideService()
 	ms := mssql.ProvideService()
-	graf := grafanads.ProvideService(nil, features)
+	graf := grafanads.ProvideService(nil, features, nil, nil)
 	pyroscope := pyroscope.ProvideService(hcp)
 	parca := parca.ProvideService(hcp)
 	zipkin := zipkin.ProvideService(hcp)
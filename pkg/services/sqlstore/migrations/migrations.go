@@ -174,4 +174,16 @@ func (oss *OSSMigrations) AddMigration(mg *Migrator) {
 	ualert.AddAlertRuleFolderFullpath(mg)
 
 	ualert.AddRuleAlertRoutingColumns(mg)
+
+	ualert.AddRecordedSampleTable(mg)
+
+	ualert.AddStateAcknowledgementColumn(mg)
+
+	ualert.AddAlertRuleTemplateTable(mg)
+
+	ualert.AddTemplateLibraryTables(mg)
+
+	ualert.AddRuleSeverityConditionsColumns(mg)
+
+	ualert.AddRuleFlapDetectionColumns(mg)
 }
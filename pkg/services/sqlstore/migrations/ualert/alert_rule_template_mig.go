@@ -0,0 +1,39 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddAlertRuleTemplateTable adds a table to store the templates that generate alert rule groups.
+func AddAlertRuleTemplateTable(mg *migrator.Migrator) {
+	alertRuleTemplateTable := migrator.Table{
+		Name: "alert_rule_template",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "title", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "folder_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "rule_group", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "interval_seconds", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "spec", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "uid"}, Type: migrator.UniqueIndex},
+			{Cols: []string{"org_id", "folder_uid", "rule_group"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration(
+		"add alert_rule_template table",
+		migrator.NewAddTableMigration(alertRuleTemplateTable),
+	)
+	mg.AddMigration(
+		"add unique index to alert_rule_template on org_id and uid columns",
+		migrator.NewAddIndexMigration(alertRuleTemplateTable, alertRuleTemplateTable.Indices[0]),
+	)
+	mg.AddMigration(
+		"add unique index to alert_rule_template on org_id, folder_uid and rule_group columns",
+		migrator.NewAddIndexMigration(alertRuleTemplateTable, alertRuleTemplateTable.Indices[1]),
+	)
+}
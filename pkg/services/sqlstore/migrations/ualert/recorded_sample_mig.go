@@ -0,0 +1,35 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRecordedSampleTable adds a table to store the samples written by recording rules that target the built-in Grafana data source.
+func AddRecordedSampleTable(mg *migrator.Migrator) {
+	recordedSampleTable := migrator.Table{
+		Name: "alert_rule_recorded_sample",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "metric", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "labels", Type: migrator.DB_Text, Nullable: false},
+			{Name: "epoch", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "value", Type: migrator.DB_Double, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "metric", "epoch"}, Type: migrator.IndexType},
+			{Cols: []string{"epoch"}, Type: migrator.IndexType},
+		},
+	}
+
+	mg.AddMigration(
+		"add alert_rule_recorded_sample table",
+		migrator.NewAddTableMigration(recordedSampleTable),
+	)
+	mg.AddMigration(
+		"add index to alert_rule_recorded_sample on org_id, metric and epoch columns",
+		migrator.NewAddIndexMigration(recordedSampleTable, recordedSampleTable.Indices[0]),
+	)
+	mg.AddMigration(
+		"add index to alert_rule_recorded_sample on epoch column",
+		migrator.NewAddIndexMigration(recordedSampleTable, recordedSampleTable.Indices[1]),
+	)
+}
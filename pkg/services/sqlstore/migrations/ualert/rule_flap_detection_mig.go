@@ -0,0 +1,17 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRuleFlapDetectionColumns adds flap_detection column to alert_rule and alert_rule_version tables.
+func AddRuleFlapDetectionColumns(mg *migrator.Migrator) {
+	column := &migrator.Column{Name: "flap_detection", Type: migrator.DB_Text, Nullable: true}
+
+	mg.AddMigration(
+		"add flap_detection column to alert_rule",
+		migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, column),
+	)
+	mg.AddMigration(
+		"add flap_detection column to alert_rule_version",
+		migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, column),
+	)
+}
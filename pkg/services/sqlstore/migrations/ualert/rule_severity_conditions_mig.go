@@ -0,0 +1,17 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRuleSeverityConditionsColumns adds severity_conditions column to alert_rule and alert_rule_version tables.
+func AddRuleSeverityConditionsColumns(mg *migrator.Migrator) {
+	column := &migrator.Column{Name: "severity_conditions", Type: migrator.DB_Text, Nullable: true}
+
+	mg.AddMigration(
+		"add severity_conditions column to alert_rule",
+		migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, column),
+	)
+	mg.AddMigration(
+		"add severity_conditions column to alert_rule_version",
+		migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, column),
+	)
+}
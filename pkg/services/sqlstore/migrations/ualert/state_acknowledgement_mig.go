@@ -0,0 +1,12 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddStateAcknowledgementColumn adds an acknowledgement column to alert_instance.
+func AddStateAcknowledgementColumn(mg *migrator.Migrator) {
+	mg.AddMigration("add acknowledgement column to alert_instance table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_instance"}, &migrator.Column{
+		Name:     "acknowledgement",
+		Type:     migrator.DB_Text,
+		Nullable: true,
+	}))
+}
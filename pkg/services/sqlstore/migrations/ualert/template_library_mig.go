@@ -0,0 +1,60 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddTemplateLibraryTables adds the tables to store the notification template libraries shared by all organizations
+// and their previous versions.
+func AddTemplateLibraryTables(mg *migrator.Migrator) {
+	templateLibraryTable := migrator.Table{
+		Name: "alert_template_library",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "name", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "description", Type: migrator.DB_Text, Nullable: true},
+			{Name: "templates", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"uid"}, Type: migrator.UniqueIndex},
+			{Cols: []string{"name"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration(
+		"add alert_template_library table",
+		migrator.NewAddTableMigration(templateLibraryTable),
+	)
+	mg.AddMigration(
+		"add unique index to alert_template_library on uid column",
+		migrator.NewAddIndexMigration(templateLibraryTable, templateLibraryTable.Indices[0]),
+	)
+	mg.AddMigration(
+		"add unique index to alert_template_library on name column",
+		migrator.NewAddIndexMigration(templateLibraryTable, templateLibraryTable.Indices[1]),
+	)
+
+	templateLibraryVersionTable := migrator.Table{
+		Name: "alert_template_library_version",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "library_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "templates", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "created", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"library_uid", "version"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration(
+		"add alert_template_library_version table",
+		migrator.NewAddTableMigration(templateLibraryVersionTable),
+	)
+	mg.AddMigration(
+		"add unique index to alert_template_library_version on library_uid and version columns",
+		migrator.NewAddIndexMigration(templateLibraryVersionTable, templateLibraryVersionTable.Indices[0]),
+	)
+}
@@ -501,6 +501,22 @@ type Cfg struct {
 	// LiveClientQueueMaxSize is the maximum size in bytes of the client queue
 	// for Live connections. Defaults to 4MB.
 	LiveClientQueueMaxSize int
+	// LiveManagedStreamHistoryMaxFrames is the maximum number of frames kept
+	// per managed stream channel. 0 together with LiveManagedStreamHistoryMaxAge
+	// disables history, only the latest frame is kept then.
+	LiveManagedStreamHistoryMaxFrames int
+	// LiveManagedStreamHistoryMaxAge is the maximum age of frames kept per
+	// managed stream channel.
+	LiveManagedStreamHistoryMaxAge time.Duration
+	// LiveOrgPublishMessagesPerSecond limits the number of messages per second
+	// published into Live channels of a single organization. 0 means no limit.
+	LiveOrgPublishMessagesPerSecond float64
+	// LiveOrgPublishBytesPerSecond limits the number of bytes per second
+	// published into Live channels of a single organization. 0 means no limit.
+	LiveOrgPublishBytesPerSecond float64
+	// LiveOrgPublishRateLimitPolicy defines what happens with publications
+	// exceeding organization limits: "reject" or "drop".
+	LiveOrgPublishRateLimitPolicy string
 
 	// Grafana.com URL, used for OAuth redirect.
 	GrafanaComURL string
@@ -2273,6 +2289,28 @@ func (cfg *Cfg) readLiveSettings(iniFile *ini.File) error {
 	if cfg.LiveClientQueueMaxSize <= 0 {
 		return fmt.Errorf("unexpected value %d for [live] client_queue_max_size", cfg.LiveMaxConnections)
 	}
+	cfg.LiveManagedStreamHistoryMaxFrames = section.Key("managed_stream_history_max_frames").MustInt(0)
+	if cfg.LiveManagedStreamHistoryMaxFrames < 0 {
+		return fmt.Errorf("unexpected value %d for [live] managed_stream_history_max_frames", cfg.LiveManagedStreamHistoryMaxFrames)
+	}
+	cfg.LiveManagedStreamHistoryMaxAge = section.Key("managed_stream_history_max_age").MustDuration(0)
+	if cfg.LiveManagedStreamHistoryMaxAge < 0 {
+		return fmt.Errorf("unexpected value %s for [live] managed_stream_history_max_age", cfg.LiveManagedStreamHistoryMaxAge)
+	}
+	cfg.LiveOrgPublishMessagesPerSecond = section.Key("org_publish_messages_per_second").MustFloat64(0)
+	if cfg.LiveOrgPublishMessagesPerSecond < 0 {
+		return fmt.Errorf("unexpected value %v for [live] org_publish_messages_per_second", cfg.LiveOrgPublishMessagesPerSecond)
+	}
+	cfg.LiveOrgPublishBytesPerSecond = section.Key("org_publish_bytes_per_second").MustFloat64(0)
+	if cfg.LiveOrgPublishBytesPerSecond < 0 {
+		return fmt.Errorf("unexpected value %v for [live] org_publish_bytes_per_second", cfg.LiveOrgPublishBytesPerSecond)
+	}
+	cfg.LiveOrgPublishRateLimitPolicy = section.Key("org_publish_rate_limit_policy").MustString("reject")
+	switch cfg.LiveOrgPublishRateLimitPolicy {
+	case "reject", "drop":
+	default:
+		return fmt.Errorf("unsupported value %s for [live] org_publish_rate_limit_policy", cfg.LiveOrgPublishRateLimitPolicy)
+	}
 
 	cfg.LiveHAEngine = section.Key("ha_engine").MustString("")
 	switch cfg.LiveHAEngine {
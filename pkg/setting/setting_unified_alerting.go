@@ -2,6 +2,7 @@ package setting
 
 import (
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -39,6 +40,7 @@ const (
 }
 `
 	alertingDefaultInitializationTimeout    = 30 * time.Second
+	evaluationBudgetSection                 = "unified_alerting.evaluation_budget"
 	evaluatorDefaultEvaluationTimeout       = 30 * time.Second
 	remoteAlertmanagerDefaultTimeout        = 30 * time.Second
 	schedulerDefaultAdminConfigPollInterval = time.Minute
@@ -123,6 +125,7 @@ type UnifiedAlertingSettings struct {
 	Screenshots                   UnifiedAlertingScreenshotSettings
 	ReservedLabels                UnifiedAlertingReservedLabelSettings
 	StateHistory                  UnifiedAlertingStateHistorySettings
+	StateStore                    UnifiedAlertingStateStoreSettings
 	NotificationHistory           UnifiedAlertingNotificationHistorySettings
 	RemoteAlertmanager            RemoteAlertmanagerSettings
 	RecordingRules                RecordingRuleSettings
@@ -141,6 +144,10 @@ type UnifiedAlertingSettings struct {
 	// Duration for which a resolved alert state transition will continue to be sent to the Alertmanager.
 	ResolvedAlertRetention time.Duration
 
+	// RuleTemplateReconcileInterval is how often the rule groups of alert rule templates whose source is a query
+	// are generated again. 0 disables it.
+	RuleTemplateReconcileInterval time.Duration
+
 	// RuleVersionRecordLimit defines the limit of how many alert rule versions
 	// should be stored in the database for each alert_rule in an organization including the current one.
 	// 0 value means no limit
@@ -155,6 +162,21 @@ type UnifiedAlertingSettings struct {
 	BacktestingMaxEvaluations int
 
 	IgnorePendingForNoDataAndError bool
+
+	// EvaluationBudget limits the cost of a single evaluation of an alert rule.
+	EvaluationBudget EvaluationBudgetSettings
+	// OrgEvaluationBudgets overrides EvaluationBudget for individual organizations.
+	OrgEvaluationBudgets map[int64]EvaluationBudgetSettings
+}
+
+// EvaluationBudgetSettings limits the cost of a single evaluation of an alert rule. A limit of 0 means no limit.
+type EvaluationBudgetSettings struct {
+	MaxSeries     int64
+	MaxDataPoints int64
+	MaxBytes      int64
+	MaxQueryTime  time.Duration
+	// Action is what happens to a rule that exceeds the budget, either "reject" or "throttle".
+	Action string
 }
 
 type RecordingRuleSettings struct {
@@ -162,6 +184,11 @@ type RecordingRuleSettings struct {
 	CustomHeaders        map[string]string
 	Timeout              time.Duration
 	DefaultDatasourceUID string
+
+	// LocalStorageEnabled enables storing the output of recording rules that target the built-in Grafana data source in the Grafana database.
+	LocalStorageEnabled bool
+	// LocalStorageRetention is how long the samples stored in the Grafana database are kept. 0 keeps them forever.
+	LocalStorageRetention time.Duration
 }
 
 // RemoteAlertmanagerSettings contains the configuration needed
@@ -219,6 +246,29 @@ type UnifiedAlertingStateHistorySettings struct {
 	ExternalLabels                map[string]string
 }
 
+const (
+	// StateStoreDatabase persists the state of alert rules in the Grafana database.
+	StateStoreDatabase = "database"
+	// StateStoreRedis persists the state of alert rules in the Redis server configured with the ha_redis_* settings.
+	StateStoreRedis = "redis"
+	// StateStoreSnapshot persists the state of alert rules as compressed snapshots in a local directory.
+	StateStoreSnapshot = "snapshot"
+)
+
+type UnifiedAlertingStateStoreSettings struct {
+	// Type is where the state of alert rules is persisted, one of "database", "redis" or "snapshot".
+	Type string
+	// RedisPrefix is prepended to the keys in Redis, after the ha_redis_prefix.
+	RedisPrefix string
+	// SnapshotPath is the directory of the snapshots.
+	SnapshotPath string
+}
+
+// IsExternal returns true if the state of alert rules is persisted outside of the Grafana database.
+func (s UnifiedAlertingStateStoreSettings) IsExternal() bool {
+	return s.Type != "" && s.Type != StateStoreDatabase
+}
+
 type UnifiedAlertingNotificationHistorySettings struct {
 	Enabled      bool
 	LokiSettings UnifiedAlertingLokiSettings
@@ -519,6 +569,23 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	}
 	uaCfg.StateHistory = uaCfgStateHistory
 
+	stateStore := iniFile.Section("unified_alerting.state_store")
+	uaCfgStateStore := UnifiedAlertingStateStoreSettings{
+		Type:         stateStore.Key("type").MustString(StateStoreDatabase),
+		RedisPrefix:  stateStore.Key("redis_prefix").MustString("alert_rule_state"),
+		SnapshotPath: stateStore.Key("snapshot_path").MustString(filepath.Join(cfg.DataPath, "alerting", "state")),
+	}
+	switch uaCfgStateStore.Type {
+	case StateStoreDatabase, StateStoreSnapshot:
+	case StateStoreRedis:
+		if uaCfg.HARedisAddr == "" {
+			return fmt.Errorf("setting 'ha_redis_address' in section 'unified_alerting' is required when the state store is 'redis'")
+		}
+	default:
+		return fmt.Errorf("setting 'type' in section 'unified_alerting.state_store' must be one of 'database', 'redis' or 'snapshot'")
+	}
+	uaCfg.StateStore = uaCfgStateStore
+
 	notificationHistory := iniFile.Section("unified_alerting.notification_history")
 	notificationHistoryLabels := iniFile.Section("unified_alerting.notification_history.external_labels")
 	uaCfgNotificationHistory := UnifiedAlertingNotificationHistorySettings{
@@ -550,6 +617,15 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		DefaultDatasourceUID: rr.Key("default_datasource_uid").MustString(""),
 	}
 
+	uaCfgRecordingRules.LocalStorageEnabled = rr.Key("local_storage_enabled").MustBool(false)
+	uaCfgRecordingRules.LocalStorageRetention, err = gtime.ParseDuration(valueAsString(rr, "local_storage_retention", "15d"))
+	if err != nil {
+		return fmt.Errorf("failed to parse setting 'local_storage_retention' in section 'recording_rules': %w", err)
+	}
+	if uaCfgRecordingRules.LocalStorageRetention < 0 {
+		return fmt.Errorf("setting 'local_storage_retention' in section 'recording_rules' cannot be negative")
+	}
+
 	rrHeaders := iniFile.Section("recording_rules.custom_headers")
 	rrHeadersKeys := rrHeaders.Keys()
 	uaCfgRecordingRules.CustomHeaders = make(map[string]string, len(rrHeadersKeys))
@@ -580,6 +656,14 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		return err
 	}
 
+	uaCfg.RuleTemplateReconcileInterval, err = gtime.ParseDuration(valueAsString(ua, "rule_template_reconcile_interval", (5 * time.Minute).String()))
+	if err != nil {
+		return err
+	}
+	if uaCfg.RuleTemplateReconcileInterval < 0 {
+		return fmt.Errorf("setting 'rule_template_reconcile_interval' is invalid, only 0 or a positive duration are allowed")
+	}
+
 	uaCfg.RuleVersionRecordLimit = ua.Key("rule_version_record_limit").MustInt(0)
 	if uaCfg.RuleVersionRecordLimit < 0 {
 		return fmt.Errorf("setting 'rule_version_record_limit' is invalid, only 0 or a positive integer are allowed")
@@ -600,10 +684,63 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		uaCfg.BacktestingMaxEvaluations = 100
 	}
 
+	uaCfg.EvaluationBudget, uaCfg.OrgEvaluationBudgets, err = readEvaluationBudgetSettings(iniFile)
+	if err != nil {
+		return err
+	}
+
 	cfg.UnifiedAlerting = uaCfg
 	return nil
 }
 
+// readEvaluationBudgetSettings reads the default evaluation budget from the section [unified_alerting.evaluation_budget],
+// and the budgets of organizations from the sections [unified_alerting.evaluation_budget.org_<org ID>].
+// The settings that are missing in the section of an organization are inherited from the default budget.
+func readEvaluationBudgetSettings(iniFile *ini.File) (EvaluationBudgetSettings, map[int64]EvaluationBudgetSettings, error) {
+	defaultBudget, err := readEvaluationBudgetSection(iniFile.Section(evaluationBudgetSection), EvaluationBudgetSettings{Action: "reject"})
+	if err != nil {
+		return EvaluationBudgetSettings{}, nil, err
+	}
+
+	orgBudgets := make(map[int64]EvaluationBudgetSettings)
+	prefix := evaluationBudgetSection + ".org_"
+	for _, section := range iniFile.Sections() {
+		if !strings.HasPrefix(section.Name(), prefix) {
+			continue
+		}
+		orgID, err := strconv.ParseInt(strings.TrimPrefix(section.Name(), prefix), 10, 64)
+		if err != nil {
+			return EvaluationBudgetSettings{}, nil, fmt.Errorf("invalid organization ID in section '%s': %w", section.Name(), err)
+		}
+		orgBudgets[orgID], err = readEvaluationBudgetSection(section, defaultBudget)
+		if err != nil {
+			return EvaluationBudgetSettings{}, nil, err
+		}
+	}
+	return defaultBudget, orgBudgets, nil
+}
+
+func readEvaluationBudgetSection(section *ini.Section, defaults EvaluationBudgetSettings) (EvaluationBudgetSettings, error) {
+	budget := EvaluationBudgetSettings{
+		MaxSeries:     section.Key("max_series").MustInt64(defaults.MaxSeries),
+		MaxDataPoints: section.Key("max_data_points").MustInt64(defaults.MaxDataPoints),
+		MaxBytes:      section.Key("max_bytes").MustInt64(defaults.MaxBytes),
+		Action:        section.Key("action").MustString(defaults.Action),
+	}
+	var err error
+	budget.MaxQueryTime, err = gtime.ParseDuration(valueAsString(section, "max_query_time", defaults.MaxQueryTime.String()))
+	if err != nil {
+		return EvaluationBudgetSettings{}, fmt.Errorf("failed to parse setting 'max_query_time' in section '%s': %w", section.Name(), err)
+	}
+	if budget.MaxSeries < 0 || budget.MaxDataPoints < 0 || budget.MaxBytes < 0 || budget.MaxQueryTime < 0 {
+		return EvaluationBudgetSettings{}, fmt.Errorf("limits in section '%s' cannot be negative", section.Name())
+	}
+	if budget.Action != "reject" && budget.Action != "throttle" {
+		return EvaluationBudgetSettings{}, fmt.Errorf("setting 'action' in section '%s' must be either 'reject' or 'throttle'", section.Name())
+	}
+	return budget, nil
+}
+
 func GetAlertmanagerDefaultConfiguration() string {
 	return alertmanagerDefaultConfiguration
 }
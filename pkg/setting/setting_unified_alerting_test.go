@@ -2,6 +2,7 @@ package setting
 
 import (
 	"math/rand"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -419,3 +420,84 @@ func TestHARedisSentinelModeSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluationBudgetSettings(t *testing.T) {
+	t.Run("organizations inherit the default budget", func(t *testing.T) {
+		f, err := ini.Load([]byte(`
+[unified_alerting.evaluation_budget]
+max_series = 1000
+max_query_time = 10s
+
+[unified_alerting.evaluation_budget.org_2]
+max_series = 100
+action = throttle
+`))
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.NoError(t, cfg.ReadUnifiedAlertingSettings(f))
+
+		require.Equal(t, EvaluationBudgetSettings{MaxSeries: 1000, MaxQueryTime: 10 * time.Second, Action: "reject"}, cfg.UnifiedAlerting.EvaluationBudget)
+		require.Equal(t, map[int64]EvaluationBudgetSettings{
+			2: {MaxSeries: 100, MaxQueryTime: 10 * time.Second, Action: "throttle"},
+		}, cfg.UnifiedAlerting.OrgEvaluationBudgets)
+	})
+
+	t.Run("should fail if action is unknown", func(t *testing.T) {
+		f, err := ini.Load([]byte(`
+[unified_alerting.evaluation_budget]
+action = drop
+`))
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.ErrorContains(t, cfg.ReadUnifiedAlertingSettings(f), "must be either 'reject' or 'throttle'")
+	})
+
+	t.Run("should fail if organization ID is invalid", func(t *testing.T) {
+		f, err := ini.Load([]byte(`
+[unified_alerting.evaluation_budget.org_main]
+max_series = 100
+`))
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.ErrorContains(t, cfg.ReadUnifiedAlertingSettings(f), "invalid organization ID")
+	})
+}
+
+func TestStateStoreSettings(t *testing.T) {
+	t.Run("should use database by default", func(t *testing.T) {
+		cfg := NewCfg()
+		cfg.DataPath = "/var/lib/grafana"
+		require.NoError(t, cfg.ReadUnifiedAlertingSettings(ini.Empty()))
+
+		require.Equal(t, UnifiedAlertingStateStoreSettings{
+			Type:         StateStoreDatabase,
+			RedisPrefix:  "alert_rule_state",
+			SnapshotPath: filepath.Join("/var/lib/grafana", "alerting", "state"),
+		}, cfg.UnifiedAlerting.StateStore)
+	})
+
+	t.Run("should fail if redis is not configured", func(t *testing.T) {
+		f, err := ini.Load([]byte(`
+[unified_alerting.state_store]
+type = redis
+`))
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.ErrorContains(t, cfg.ReadUnifiedAlertingSettings(f), "'ha_redis_address' in section 'unified_alerting' is required")
+	})
+
+	t.Run("should fail if type is unknown", func(t *testing.T) {
+		f, err := ini.Load([]byte(`
+[unified_alerting.state_store]
+type = etcd
+`))
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.ErrorContains(t, cfg.ReadUnifiedAlertingSettings(f), "must be one of 'database', 'redis' or 'snapshot'")
+	})
+}
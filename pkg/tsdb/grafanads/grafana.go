@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana/apps/dashboard/pkg/apis/dashboard"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/datasources"
@@ -38,15 +40,27 @@ var (
 	_ backend.CheckHealthHandler = (*Service)(nil)
 )
 
-func ProvideService(store store.StorageService, features featuremgmt.FeatureToggles) *Service {
-	return newService(store, features)
+// RecordedSamplesReader reads the samples written by recording rules to the Grafana database.
+type RecordedSamplesReader interface {
+	GetRecordedSamples(ctx context.Context, query RecordedSamplesQuery) ([]RecordedSample, error)
 }
 
-func newService(store store.StorageService, features featuremgmt.FeatureToggles) *Service {
+// LiveHistoryReader reads the frames kept in the history of managed stream Live channels.
+type LiveHistoryReader interface {
+	GetChannelHistory(ctx context.Context, user identity.Requester, channel string, since time.Time) (*data.Frame, bool, error)
+}
+
+func ProvideService(store store.StorageService, features featuremgmt.FeatureToggles, recorded RecordedSamplesReader, liveHistory LiveHistoryReader) *Service {
+	return newService(store, features, recorded, liveHistory)
+}
+
+func newService(store store.StorageService, features featuremgmt.FeatureToggles, recorded RecordedSamplesReader, liveHistory LiveHistoryReader) *Service {
 	s := &Service{
-		store:    store,
-		log:      log.New("grafanads"),
-		features: features,
+		store:       store,
+		recorded:    recorded,
+		liveHistory: liveHistory,
+		log:         log.New("grafanads"),
+		features:    features,
 	}
 
 	return s
@@ -54,9 +68,11 @@ func newService(store store.StorageService, features featuremgmt.FeatureToggles)
 
 // Service exists regardless of user settings
 type Service struct {
-	store    store.StorageService
-	log      log.Logger
-	features featuremgmt.FeatureToggles
+	store       store.StorageService
+	recorded    RecordedSamplesReader
+	liveHistory LiveHistoryReader
+	log         log.Logger
+	features    featuremgmt.FeatureToggles
 }
 
 func DataSourceModel(orgId int64) *datasources.DataSource {
@@ -82,6 +98,10 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 			response.Responses[q.RefID] = s.doListQuery(ctx, q)
 		case queryTypeRead:
 			response.Responses[q.RefID] = s.doReadQuery(ctx, q)
+		case queryTypeRecordedMetrics:
+			response.Responses[q.RefID] = s.doRecordedMetricsQuery(ctx, req.PluginContext.OrgID, q)
+		case queryTypeLiveHistory:
+			response.Responses[q.RefID] = s.doLiveHistoryQuery(ctx, q)
 		default:
 			response.Responses[q.RefID] = backend.DataResponse{
 				Error: fmt.Errorf("unknown query type"),
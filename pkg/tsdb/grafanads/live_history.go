@@ -0,0 +1,49 @@
+package grafanads
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+)
+
+// doLiveHistoryQuery returns the frames kept in the history of a managed stream
+// channel since the start of the query time range merged into a single frame.
+// The signed in user must be allowed to subscribe to the channel.
+func (s *Service) doLiveHistoryQuery(ctx context.Context, query backend.DataQuery) backend.DataResponse {
+	response := backend.DataResponse{}
+	if s.liveHistory == nil {
+		response.Error = errors.New("live channel history is not available")
+		return response
+	}
+
+	q := &liveHistoryQueryModel{}
+	if err := json.Unmarshal(query.JSON, &q); err != nil {
+		response.Error = err
+		return response
+	}
+	if q.Channel == "" {
+		response.Error = errors.New("channel is required")
+		return response
+	}
+
+	user, err := identity.GetRequester(ctx)
+	if err != nil {
+		response.Error = err
+		return response
+	}
+
+	frame, ok, err := s.liveHistory.GetChannelHistory(ctx, user, q.Channel, query.TimeRange.From)
+	if err != nil {
+		response.Error = err
+		return response
+	}
+	if ok {
+		response.Frames = data.Frames{frame}
+	}
+	return response
+}
@@ -11,6 +11,14 @@ const (
 	// currently only .csv files are supported,
 	// other file types will eventually be supported (parquet, etc)
 	queryTypeRead = "read"
+
+	// QueryTypeRecordedMetrics returns the samples written by recording rules
+	// to the Grafana database as time series
+	queryTypeRecordedMetrics = "recordedMetrics"
+
+	// QueryTypeLiveHistory returns the frames kept in the history of a
+	// managed stream Live channel within the query time range
+	queryTypeLiveHistory = "liveHistory"
 )
 
 type listQueryModel struct {
@@ -19,3 +27,11 @@ type listQueryModel struct {
 type readQueryModel struct {
 	Path string `json:"path"`
 }
+type recordedMetricsQueryModel struct {
+	// Expr is a series selector, e.g. metric_name{label="value"}
+	Expr string `json:"expr"`
+}
+type liveHistoryQueryModel struct {
+	// Channel is a stream channel, e.g. stream/telegraf/cpu
+	Channel string `json:"channel"`
+}
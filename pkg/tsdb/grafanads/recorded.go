@@ -0,0 +1,133 @@
+package grafanads
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// RecordedSample is a sample written by a recording rule to the Grafana database.
+type RecordedSample struct {
+	OrgID     int64
+	Metric    string
+	Labels    data.Labels
+	Timestamp time.Time
+	Value     float64
+}
+
+// RecordedSamplesQuery selects the samples of a metric stored in the Grafana database in the range [From, To].
+type RecordedSamplesQuery struct {
+	OrgID  int64
+	Metric string
+	From   time.Time
+	To     time.Time
+}
+
+// doRecordedMetricsQuery returns the samples written by recording rules to the Grafana database
+// that match the series selector of the query. Every series is returned as a separate frame.
+func (s *Service) doRecordedMetricsQuery(ctx context.Context, orgID int64, query backend.DataQuery) backend.DataResponse {
+	response := backend.DataResponse{}
+	if s.recorded == nil {
+		response.Error = errors.New("recorded metrics are not available")
+		return response
+	}
+
+	q := &recordedMetricsQueryModel{}
+	if err := json.Unmarshal(query.JSON, &q); err != nil {
+		response.Error = err
+		return response
+	}
+
+	matchers, err := parser.ParseMetricSelector(q.Expr)
+	if err != nil {
+		response.Error = fmt.Errorf("invalid series selector: %w", err)
+		return response
+	}
+	metric := ""
+	for _, m := range matchers {
+		if m.Name == labels.MetricName && m.Type == labels.MatchEqual {
+			metric = m.Value
+			break
+		}
+	}
+	if metric == "" {
+		response.Error = errors.New("series selector must select a metric by name")
+		return response
+	}
+
+	samples, err := s.recorded.GetRecordedSamples(ctx, RecordedSamplesQuery{
+		OrgID:  orgID,
+		Metric: metric,
+		From:   query.TimeRange.From,
+		To:     query.TimeRange.To,
+	})
+	if err != nil {
+		response.Error = err
+		return response
+	}
+
+	response.Frames = recordedSamplesToFrames(metric, matchers, samples)
+	return response
+}
+
+// recordedSamplesToFrames groups the samples that match all matchers by their labels and
+// converts every group to a time series frame. Frames are returned in the order the series first appear.
+func recordedSamplesToFrames(metric string, matchers []*labels.Matcher, samples []RecordedSample) data.Frames {
+	type series struct {
+		labels data.Labels
+		times  []time.Time
+		values []float64
+	}
+	var order []data.Fingerprint
+	bySeries := make(map[data.Fingerprint]*series)
+	for _, sample := range samples {
+		lbls := data.Labels{labels.MetricName: metric}
+		for k, v := range sample.Labels {
+			lbls[k] = v
+		}
+		if !matchLabels(matchers, lbls) {
+			continue
+		}
+		fp := lbls.Fingerprint()
+		sr, ok := bySeries[fp]
+		if !ok {
+			sr = &series{labels: lbls}
+			bySeries[fp] = sr
+			order = append(order, fp)
+		}
+		sr.times = append(sr.times, sample.Timestamp)
+		sr.values = append(sr.values, sample.Value)
+	}
+
+	if len(order) == 0 {
+		return data.Frames{data.NewFrame(metric)}
+	}
+
+	frames := make(data.Frames, 0, len(order))
+	for _, fp := range order {
+		sr := bySeries[fp]
+		frame := data.NewFrame(metric,
+			data.NewField(data.TimeSeriesTimeFieldName, nil, sr.times),
+			data.NewField(data.TimeSeriesValueFieldName, sr.labels, sr.values),
+		)
+		frame.SetMeta(&data.FrameMeta{Type: data.FrameTypeTimeSeriesMulti})
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+func matchLabels(matchers []*labels.Matcher, lbls data.Labels) bool {
+	for _, m := range matchers {
+		if !m.Matches(lbls[m.Name]) {
+			return false
+		}
+	}
+	return true
+}
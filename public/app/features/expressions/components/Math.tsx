@@ -133,6 +133,41 @@ export const Math = ({ labelWidth, onChange, query, onRunQuery }: Props) => {
                         "Rounds the number down to the nearest integer value. It's able to operate on series or escalar values."
                       )}
                     />
+                    <DocumentedFunction
+                      name="cumsum"
+                      description={t(
+                        'expression.math.description-cumsum',
+                        'Returns the cumulative sum of a series. Null and NaN values are left unchanged.'
+                      )}
+                    />
+                    <DocumentedFunction
+                      name="delta"
+                      description={t(
+                        'expression.math.description-delta',
+                        'Returns the difference between consecutive points of a series.'
+                      )}
+                    />
+                    <DocumentedFunction
+                      name="moving_avg"
+                      description={t(
+                        'expression.math.description-moving-avg',
+                        'Returns the average of the points within a window ending at each point of a series, for example moving_avg($A, 5m).'
+                      )}
+                    />
+                    <DocumentedFunction
+                      name="rate"
+                      description={t(
+                        'expression.math.description-rate',
+                        'Returns the per-second rate of increase between consecutive points of a series, handling counter resets.'
+                      )}
+                    />
+                    <DocumentedFunction
+                      name="shift"
+                      description={t(
+                        'expression.math.description-shift',
+                        'Moves a series forward in time so it can be compared with its own past, for example $A / shift($A, 1d).'
+                      )}
+                    />
                   </div>
                 </div>
               }
@@ -34,5 +34,12 @@ export function isReducerType(value: string): value is ReducerType {
     'percent_diff',
     'percent_diff_abs',
     'count_non_null',
+    'first',
+    'p90',
+    'p95',
+    'p99',
+    'stddev',
+    'variance',
+    'range',
   ].includes(value);
 }
@@ -83,6 +83,19 @@ export const reducerTypes: Array<SelectableValue<string>> = [
   { value: ReducerID.sum, label: 'Sum', description: 'Get the sum of all values' },
   { value: ReducerID.count, label: 'Count', description: 'Get the number of values' },
   { value: ReducerID.last, label: 'Last', description: 'Get the last value' },
+  { value: ReducerID.first, label: 'First', description: 'Get the first value' },
+  { value: ReducerID.p90, label: '90th percentile', description: 'Get the 90th percentile of the values' },
+  { value: ReducerID.p95, label: '95th percentile', description: 'Get the 95th percentile of the values' },
+  { value: ReducerID.p99, label: '99th percentile', description: 'Get the 99th percentile of the values' },
+  { value: 'stddev', label: 'Standard deviation', description: 'Get the standard deviation of the values' },
+  { value: ReducerID.variance, label: 'Variance', description: 'Get the variance of the values' },
+  { value: ReducerID.range, label: 'Range', description: 'Get the difference between the maximum and minimum value' },
+  { value: ReducerID.diff, label: 'Difference', description: 'Get the difference between the last and first value' },
+  {
+    value: 'percent_diff',
+    label: 'Percent difference',
+    description: 'Get the difference between the last and first value as percent of the first value',
+  },
 ];
 
 export enum ReducerMode {
@@ -198,4 +211,11 @@ export type ReducerType =
   | 'diff_abs'
   | 'percent_diff'
   | 'percent_diff_abs'
-  | 'count_non_null';
+  | 'count_non_null'
+  | 'first'
+  | 'p90'
+  | 'p95'
+  | 'p99'
+  | 'stddev'
+  | 'variance'
+  | 'range';